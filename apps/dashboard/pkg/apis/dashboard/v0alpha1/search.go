@@ -31,6 +31,9 @@ type SearchResults struct {
 
 	// Facet results
 	Facets map[string]FacetResult `json:"facets,omitempty"`
+
+	// Facet results for each namespace when searching across namespaces
+	NamespaceFacets map[string]map[string]FacetResult `json:"namespaceFacets,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -57,6 +60,8 @@ type SortableField struct {
 
 // +k8s:deepcopy-gen=true
 type DashboardHit struct {
+	// The namespace (org) of the hit when searching across namespaces
+	Namespace string `json:"namespace,omitempty"`
 	// Dashboard or folder
 	Resource string `json:"resource"` // dashboards | folders
 	// The k8s "name" (eg, grafana UID)
@@ -355,6 +355,23 @@ func (in *SearchResults) DeepCopyInto(out *SearchResults) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.NamespaceFacets != nil {
+		in, out := &in.NamespaceFacets, &out.NamespaceFacets
+		*out = make(map[string]map[string]FacetResult, len(*in))
+		for key, val := range *in {
+			var outVal map[string]FacetResult
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]FacetResult, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
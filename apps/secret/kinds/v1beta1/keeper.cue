@@ -49,7 +49,25 @@ KeeperSpec: {
 
 #HashiCorpConfig: {
 	address: string
-	token:   #CredentialValue
+	// Token used to authenticate, required unless appRole is set.
+	token: #CredentialValue
+	// AppRole used to authenticate instead of a token.
+	appRole?: #HashiCorpAppRoleConfig
+	// Vault Enterprise namespace.
+	namespace?: string
+	// Mount path of the KV v2 secrets engine, defaults to `secret`.
+	mountPath?: string
+	// Name of a transit key used to encrypt values before they are written to the KV engine.
+	transitKey?: string
+	// Mount path of the transit secrets engine, defaults to `transit`.
+	transitMountPath?: string
+}
+
+#HashiCorpAppRoleConfig: {
+	// Mount path of the AppRole auth method, defaults to `approle`.
+	mountPath?: string
+	roleID:     string
+	secretID:   #CredentialValue
 }
 
 #CredentialValue: {
@@ -64,8 +64,19 @@ func NewKeeperGCPConfig() *KeeperGCPConfig {
 
 // +k8s:openapi-gen=true
 type KeeperHashiCorpConfig struct {
-	Address string                `json:"address"`
-	Token   KeeperCredentialValue `json:"token"`
+	Address string `json:"address"`
+	// Token used to authenticate, required unless appRole is set.
+	Token KeeperCredentialValue `json:"token"`
+	// AppRole used to authenticate instead of a token.
+	AppRole *KeeperHashiCorpAppRoleConfig `json:"appRole,omitempty"`
+	// Vault Enterprise namespace.
+	Namespace *string `json:"namespace,omitempty"`
+	// Mount path of the KV v2 secrets engine, defaults to `secret`.
+	MountPath *string `json:"mountPath,omitempty"`
+	// Name of a transit key used to encrypt values before they are written to the KV engine.
+	TransitKey *string `json:"transitKey,omitempty"`
+	// Mount path of the transit secrets engine, defaults to `transit`.
+	TransitMountPath *string `json:"transitMountPath,omitempty"`
 }
 
 // NewKeeperHashiCorpConfig creates a new KeeperHashiCorpConfig object.
@@ -75,6 +86,21 @@ func NewKeeperHashiCorpConfig() *KeeperHashiCorpConfig {
 	}
 }
 
+// +k8s:openapi-gen=true
+type KeeperHashiCorpAppRoleConfig struct {
+	// Mount path of the AppRole auth method, defaults to `approle`.
+	MountPath *string               `json:"mountPath,omitempty"`
+	RoleID    string                `json:"roleID"`
+	SecretID  KeeperCredentialValue `json:"secretID"`
+}
+
+// NewKeeperHashiCorpAppRoleConfig creates a new KeeperHashiCorpAppRoleConfig object.
+func NewKeeperHashiCorpAppRoleConfig() *KeeperHashiCorpAppRoleConfig {
+	return &KeeperHashiCorpAppRoleConfig{
+		SecretID: *NewKeeperCredentialValue(),
+	}
+}
+
 // +k8s:openapi-gen=true
 type KeeperSpec struct {
 	// Short description for the Keeper.
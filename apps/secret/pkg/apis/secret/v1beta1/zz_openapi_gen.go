@@ -18,6 +18,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperAzureConfig":              schema_pkg_apis_secret_v1beta1_KeeperAzureConfig(ref),
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue":          schema_pkg_apis_secret_v1beta1_KeeperCredentialValue(ref),
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperGCPConfig":                schema_pkg_apis_secret_v1beta1_KeeperGCPConfig(ref),
+		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperHashiCorpAppRoleConfig":   schema_pkg_apis_secret_v1beta1_KeeperHashiCorpAppRoleConfig(ref),
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperHashiCorpConfig":          schema_pkg_apis_secret_v1beta1_KeeperHashiCorpConfig(ref),
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperList":                     schema_pkg_apis_secret_v1beta1_KeeperList(ref),
 		"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperSpec":                     schema_pkg_apis_secret_v1beta1_KeeperSpec(ref),
@@ -217,6 +218,41 @@ func schema_pkg_apis_secret_v1beta1_KeeperGCPConfig(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_secret_v1beta1_KeeperHashiCorpAppRoleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mount path of the AppRole auth method, defaults to `approle`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"roleID": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"secretID": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue"),
+						},
+					},
+				},
+				Required: []string{"roleID", "secretID"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue"},
+	}
+}
+
 func schema_pkg_apis_secret_v1beta1_KeeperHashiCorpConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -232,8 +268,43 @@ func schema_pkg_apis_secret_v1beta1_KeeperHashiCorpConfig(ref common.ReferenceCa
 					},
 					"token": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue"),
+							Description: "Token used to authenticate, required unless appRole is set.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue"),
+						},
+					},
+					"appRole": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AppRole used to authenticate instead of a token.",
+							Ref:         ref("github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperHashiCorpAppRoleConfig"),
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Vault Enterprise namespace.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mount path of the KV v2 secrets engine, defaults to `secret`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"transitKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of a transit key used to encrypt values before they are written to the KV engine.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"transitMountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mount path of the transit secrets engine, defaults to `transit`.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
@@ -241,7 +312,7 @@ func schema_pkg_apis_secret_v1beta1_KeeperHashiCorpConfig(ref common.ReferenceCa
 			},
 		},
 		Dependencies: []string{
-			"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue"},
+			"github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperCredentialValue", "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1.KeeperHashiCorpAppRoleConfig"},
 	}
 }
 
@@ -366,6 +366,8 @@ export interface AuthSettings {
 
   disableLogin?: boolean;
   passwordlessEnabled?: boolean;
+  webAuthnEnabled?: boolean;
+  webAuthnPasskeyLogin?: boolean;
   basicAuthStrongPasswordPolicy?: boolean;
   disableSignoutMenu?: boolean;
 }
@@ -69,6 +69,8 @@ export interface DataLink<T extends DataQuery = any> {
 export enum SupportedTransformationType {
   Regex = 'regex',
   Logfmt = 'logfmt',
+  JSONPath = 'jsonpath',
+  Template = 'template',
 }
 
 /** @internal */
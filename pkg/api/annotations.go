@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/annotations/webhooks"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
@@ -34,6 +36,54 @@ const defaultAnnotationsLimit = 100
 // 401: unauthorisedError
 // 500: internalServerError
 func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Response {
+	query, errResp := hs.annotationsQuery(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	items, errResp := hs.findAnnotations(c, query)
+	if errResp != nil {
+		return errResp
+	}
+
+	return response.JSON(http.StatusOK, items)
+}
+
+// swagger:route GET /annotations/search annotations searchAnnotations
+//
+// Search Annotations.
+//
+// Finds annotations like the Find Annotations endpoint, and returns them with the count of each tag used by the matched annotations.
+//
+// Responses:
+// 200: searchAnnotationsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 500: internalServerError
+func (hs *HTTPServer) SearchAnnotations(c *contextmodel.ReqContext) response.Response {
+	query, errResp := hs.annotationsQuery(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	// the tags are counted first, the query is changed while finding the annotations
+	tags, err := hs.annotationsRepo.FindTagFacets(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to count annotation tags", err)
+	}
+
+	items, errResp := hs.findAnnotations(c, query)
+	if errResp != nil {
+		return errResp
+	}
+
+	return response.JSON(http.StatusOK, dtos.AnnotationSearchResult{
+		Annotations: items,
+		Tags:        tags,
+	})
+}
+
+func (hs *HTTPServer) annotationsQuery(c *contextmodel.ReqContext) (*annotations.ItemQuery, response.Response) {
 	query := &annotations.ItemQuery{
 		From:         c.QueryInt64("from"),
 		To:           c.QueryInt64("to"),
@@ -48,25 +98,35 @@ func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Respon
 		Tags:         c.QueryStrings("tags"),
 		Type:         c.Query("type"),
 		MatchAny:     c.QueryBool("matchAny"),
+		Text:         c.Query("text"),
+		RegionsOnly:  c.QueryBool("regionsOnly"),
+		Sort:         c.Query("sort"),
 		SignedInUser: c.SignedInUser,
 	}
 	if query.Limit == 0 {
 		query.Limit = defaultAnnotationsLimit
 	}
+	if !annotations.IsValidSort(query.Sort) {
+		return nil, response.Error(http.StatusBadRequest, "Invalid sort in annotation request", nil)
+	}
 
 	// When dashboard ID exists without UID, find the UID from dashboards api
 	if query.DashboardID != 0 && query.DashboardUID == "" { // nolint:staticcheck
 		dq := dashboards.GetDashboardQuery{ID: query.DashboardID, OrgID: c.GetOrgID()} // nolint:staticcheck
 		dqResult, err := hs.DashboardService.GetDashboard(c.Req.Context(), &dq)
 		if err != nil {
-			return response.Error(http.StatusBadRequest, "Invalid dashboard ID in annotation request", err)
+			return nil, response.Error(http.StatusBadRequest, "Invalid dashboard ID in annotation request", err)
 		}
 		query.DashboardUID = dqResult.UID
 	}
 
+	return query, nil
+}
+
+func (hs *HTTPServer) findAnnotations(c *contextmodel.ReqContext, query *annotations.ItemQuery) ([]*annotations.ItemDTO, response.Response) {
 	items, err := hs.annotationsRepo.Find(c.Req.Context(), query)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to get annotations", err)
+		return nil, response.Error(http.StatusInternalServerError, "Failed to get annotations", err)
 	}
 
 	for _, item := range items {
@@ -75,7 +135,7 @@ func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Respon
 		}
 	}
 
-	return response.JSON(http.StatusOK, items)
+	return items, nil
 }
 
 type AnnotationError struct {
@@ -243,6 +303,59 @@ func (hs *HTTPServer) PostGraphiteAnnotation(c *contextmodel.ReqContext) respons
 	})
 }
 
+// swagger:route POST /annotations/webhooks/{source} annotations postAnnotationWebhook
+//
+// Create Annotation from a webhook.
+//
+// Creates an organization annotation from a deployment or release webhook. The source is one of `github`, `gitlab`, `argocd` or `generic`, where generic payloads are mapped with the templates of the `[annotations.webhooks]` settings. Events other than deployments and releases are accepted and ignored.
+//
+// Responses:
+// 200: postAnnotationResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) PostAnnotationWebhook(converter *webhooks.Converter) func(c *contextmodel.ReqContext) response.Response {
+	return func(c *contextmodel.ReqContext) response.Response {
+		return hs.postAnnotationWebhook(c, converter)
+	}
+}
+
+func (hs *HTTPServer) postAnnotationWebhook(c *contextmodel.ReqContext, converter *webhooks.Converter) response.Response {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Resp, c.Req.Body, webhooks.MaxPayloadSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return response.Error(http.StatusRequestEntityTooLarge, "Webhook payload is too large", err)
+		}
+		return response.Error(http.StatusBadRequest, "Failed to read webhook payload", err)
+	}
+
+	item, err := converter.Convert(web.Params(c.Req)[":source"], c.Req.Header, body)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusBadRequest, "Failed to convert webhook payload", err)
+	}
+	if item == nil {
+		return response.JSON(http.StatusOK, util.DynMap{
+			"message": "Webhook event ignored",
+		})
+	}
+
+	userID, _ := identity.UserIdentifier(c.GetID())
+	item.OrgID = c.GetOrgID()
+	item.UserID = userID
+
+	if err := hs.annotationsRepo.Save(c.Req.Context(), item); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to save webhook annotation", err)
+	}
+
+	return response.JSON(http.StatusOK, util.DynMap{
+		"message": "Webhook annotation added",
+		"id":      item.ID,
+	})
+}
+
 // swagger:route PUT /annotations/{annotation_id} annotations updateAnnotation
 //
 // Update Annotation.
@@ -729,7 +842,7 @@ type DeleteAnnotationByIDParams struct {
 	AnnotationID string `json:"annotation_id"`
 }
 
-// swagger:parameters getAnnotations
+// swagger:parameters getAnnotations searchAnnotations
 type GetAnnotationsParams struct {
 	// Find annotations created after specific epoch datetime in milliseconds.
 	// in:query
@@ -786,6 +899,20 @@ type GetAnnotationsParams struct {
 	// in:query
 	// required:false
 	MatchAny bool `json:"matchAny"`
+	// Find annotations with text containing this string, ignoring case
+	// in:query
+	// required:false
+	Text string `json:"text"`
+	// Only return region annotations, overlapping the from and to range when set
+	// in:query
+	// required:false
+	RegionsOnly bool `json:"regionsOnly"`
+	// Sort order of the annotations
+	// in:query
+	// required:false
+	// enum: time-desc,time-asc,updated-desc
+	// default: time-desc
+	Sort string `json:"sort"`
 }
 
 // swagger:parameters getAnnotationTags
@@ -822,6 +949,16 @@ type PostGraphiteAnnotationParams struct {
 	Body dtos.PostGraphiteAnnotationsCmd `json:"body"`
 }
 
+// swagger:parameters postAnnotationWebhook
+type PostAnnotationWebhookParams struct {
+	// in:path
+	// required:true
+	Source string `json:"source"`
+	// in:body
+	// required:true
+	Body any `json:"body"`
+}
+
 // swagger:parameters updateAnnotation
 type UpdateAnnotationParams struct {
 	// in:path
@@ -872,6 +1009,13 @@ type PostAnnotationResponse struct {
 	} `json:"body"`
 }
 
+// swagger:response searchAnnotationsResponse
+type SearchAnnotationsResponse struct {
+	// The response message
+	// in: body
+	Body dtos.AnnotationSearchResult `json:"body"`
+}
+
 // swagger:response getAnnotationTagsResponse
 type GetAnnotationTagsResponse struct {
 	// The response message
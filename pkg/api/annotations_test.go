@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/annotations/annotationstest"
+	"github.com/grafana/grafana/pkg/services/annotations/webhooks"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
@@ -52,6 +53,27 @@ func TestAPI_Annotations(t *testing.T) {
 			expectedCode: http.StatusForbidden,
 			permissions:  []accesscontrol.Permission{},
 		},
+		{
+			desc:         "should be able to search annotations with correct permission",
+			path:         "/api/annotations/search?text=deployed&regionsOnly=true&sort=time-asc",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead, Scope: accesscontrol.ScopeAnnotationsAll}},
+		},
+		{
+			desc:         "should not be able to search annotations without correct permission",
+			path:         "/api/annotations/search",
+			method:       http.MethodGet,
+			expectedCode: http.StatusForbidden,
+			permissions:  []accesscontrol.Permission{},
+		},
+		{
+			desc:         "should not be able to fetch annotations with invalid sort",
+			path:         "/api/annotations?sort=random",
+			method:       http.MethodGet,
+			expectedCode: http.StatusBadRequest,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead, Scope: accesscontrol.ScopeAnnotationsAll}},
+		},
 		{
 			desc:         "should be able to fetch annotation by id with correct permission",
 			path:         "/api/annotations/1",
@@ -427,6 +449,99 @@ func TestAPI_Annotations(t *testing.T) {
 	}
 }
 
+func TestAPI_AnnotationWebhooks(t *testing.T) {
+	type testCase struct {
+		desc          string
+		enabled       bool
+		path          string
+		body          string
+		expectedCode  int
+		expectedItems int
+		permissions   []accesscontrol.Permission
+	}
+
+	orgAnnotationsCreate := []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeOrganization}}
+
+	tests := []testCase{
+		{
+			desc:          "should create annotation from webhook",
+			enabled:       true,
+			path:          "/api/annotations/webhooks/argocd",
+			body:          `{"app": "shop", "revision": "a1b2c3d", "status": "Succeeded"}`,
+			expectedCode:  http.StatusOK,
+			expectedItems: 1,
+			permissions:   orgAnnotationsCreate,
+		},
+		{
+			desc:         "should ignore events other than deployments and releases",
+			enabled:      true,
+			path:         "/api/annotations/webhooks/gitlab",
+			body:         `{"object_kind": "push"}`,
+			expectedCode: http.StatusOK,
+			permissions:  orgAnnotationsCreate,
+		},
+		{
+			desc:         "should reject invalid payload",
+			enabled:      true,
+			path:         "/api/annotations/webhooks/argocd",
+			body:         `{"revision": "a1b2c3d"}`,
+			expectedCode: http.StatusBadRequest,
+			permissions:  orgAnnotationsCreate,
+		},
+		{
+			desc:         "should reject payload larger than the limit",
+			enabled:      true,
+			path:         "/api/annotations/webhooks/argocd",
+			body:         `{"app": "` + strings.Repeat("a", webhooks.MaxPayloadSize) + `"}`,
+			expectedCode: http.StatusRequestEntityTooLarge,
+			permissions:  orgAnnotationsCreate,
+		},
+		{
+			desc:         "should not find unknown source",
+			enabled:      true,
+			path:         "/api/annotations/webhooks/jenkins",
+			body:         `{}`,
+			expectedCode: http.StatusNotFound,
+			permissions:  orgAnnotationsCreate,
+		},
+		{
+			desc:         "should not be able to create annotation from webhook without correct permission",
+			enabled:      true,
+			path:         "/api/annotations/webhooks/argocd",
+			body:         `{"app": "shop"}`,
+			expectedCode: http.StatusForbidden,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeDashboard}},
+		},
+		{
+			desc:         "should not find webhooks when disabled",
+			path:         "/api/annotations/webhooks/argocd",
+			body:         `{"app": "shop"}`,
+			expectedCode: http.StatusNotFound,
+			permissions:  orgAnnotationsCreate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			repo := annotationstest.NewFakeAnnotationsRepo()
+			server := SetupAPITestServer(t, func(hs *HTTPServer) {
+				hs.Cfg = setting.NewCfg()
+				hs.Cfg.AnnotationWebhooks.Enabled = tt.enabled
+				hs.annotationsRepo = repo
+				hs.Features = featuremgmt.WithFeatures()
+				hs.AccessControl = acimpl.ProvideAccessControl(featuremgmt.WithFeatures())
+			})
+
+			req := webtest.RequestWithSignedInUser(server.NewPostRequest(tt.path, strings.NewReader(tt.body)), authedUserWithPermissions(1, 1, tt.permissions))
+			res, err := server.SendJSON(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, res.StatusCode)
+			assert.Equal(t, tt.expectedItems, repo.Len())
+			require.NoError(t, res.Body.Close())
+		})
+	}
+}
+
 func TestService_AnnotationTypeScopeResolver(t *testing.T) {
 	rootDashUID := "root-dashboard"
 	folderDashUID := "folder-dashboard"
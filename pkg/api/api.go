@@ -41,6 +41,7 @@ import (
 	"github.com/grafana/grafana/pkg/registry/apis/secret"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/ssoutils"
+	"github.com/grafana/grafana/pkg/services/annotations/webhooks"
 	"github.com/grafana/grafana/pkg/services/auth"
 	"github.com/grafana/grafana/pkg/services/cloudmigration"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
@@ -223,6 +224,11 @@ func (hs *HTTPServer) registerRoutes() {
 		r.Post("/api/login/passwordless/authenticate", requestmeta.SetOwner(requestmeta.TeamAuth), quota(string(auth.QuotaTargetSrv)), routing.Wrap(hs.LoginPasswordless))
 	}
 
+	if hs.Cfg.WebAuthnAuth.Enabled {
+		r.Post("/api/login/webauthn/start", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.StartWebAuthnLogin))
+		r.Post("/api/login/webauthn/authenticate", requestmeta.SetOwner(requestmeta.TeamAuth), quota(string(auth.QuotaTargetSrv)), routing.Wrap(hs.LoginWebAuthn))
+	}
+
 	// invited
 	r.Get("/api/user/invite/:code", routing.Wrap(hs.GetInviteInfoByCode))
 	r.Post("/api/user/invite/complete", routing.Wrap(hs.CompleteInvite))
@@ -291,6 +297,13 @@ func (hs *HTTPServer) registerRoutes() {
 
 			userRoute.Get("/auth-tokens", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.GetUserAuthTokens))
 			userRoute.Post("/revoke-auth-token", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.RevokeUserAuthToken))
+
+			if hs.Cfg.WebAuthnAuth.Enabled {
+				userRoute.Get("/webauthn/credentials", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.GetUserWebAuthnCredentials))
+				userRoute.Delete("/webauthn/credentials/:id", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.DeleteUserWebAuthnCredential))
+				userRoute.Post("/webauthn/registration/start", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.StartWebAuthnRegistration))
+				userRoute.Post("/webauthn/registration/finish", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(hs.FinishWebAuthnRegistration))
+			}
 		}, reqSignedInNoAnonymous)
 
 		apiRoute.Group("/users", func(usersRoute routing.RouteRegister) {
@@ -312,6 +325,12 @@ func (hs *HTTPServer) registerRoutes() {
 			orgRoute.Get("/quotas", authorize(ac.EvalPermission(ac.ActionOrgsQuotasRead)), routing.Wrap(hs.GetCurrentOrgQuotas))
 		})
 
+		// team quotas of the current org
+		apiRoute.Group("/teams/:teamId/quotas", func(teamQuotaRoute routing.RouteRegister) {
+			teamQuotaRoute.Get("/", authorize(ac.EvalPermission(ac.ActionOrgsQuotasRead)), routing.Wrap(hs.GetTeamQuotas))
+			teamQuotaRoute.Put("/:target", authorize(ac.EvalPermission(ac.ActionOrgsQuotasWrite)), routing.Wrap(hs.UpdateTeamQuota))
+		})
+
 		if hs.Features.IsEnabledGlobally(featuremgmt.FlagStorage) {
 			// Will eventually be replaced with the 'object' route
 			apiRoute.Group("/storage", hs.StorageService.RegisterHTTPRoutes)
@@ -497,6 +516,10 @@ func (hs *HTTPServer) registerRoutes() {
 		// Dashboard snapshots
 		apiRoute.Group("/dashboard/snapshots", func(dashboardRoute routing.RouteRegister) {
 			dashboardRoute.Get("/", authorize(ac.EvalPermission(dashboards.ActionSnapshotsRead)), routing.Wrap(hs.SearchDashboardSnapshots))
+			dashboardRoute.Post("/delete", authorize(ac.EvalPermission(dashboards.ActionSnapshotsDelete)), routing.Wrap(hs.BulkDeleteDashboardSnapshots))
+			dashboardRoute.Get("/policy", reqOrgAdmin, routing.Wrap(hs.GetDashboardSnapshotPolicy))
+			dashboardRoute.Put("/policy", reqOrgAdmin, routing.Wrap(hs.UpdateDashboardSnapshotPolicy))
+			dashboardRoute.Get("/:key/views", reqOrgAdmin, routing.Wrap(hs.GetDashboardSnapshotViews))
 		})
 
 		// Playlist
@@ -525,7 +548,13 @@ func (hs *HTTPServer) registerRoutes() {
 			annotationsRoute.Put("/:annotationId", authorize(ac.EvalPermission(ac.ActionAnnotationsWrite, ac.ScopeAnnotationsID)), routing.Wrap(hs.UpdateAnnotation))
 			annotationsRoute.Patch("/:annotationId", authorize(ac.EvalPermission(ac.ActionAnnotationsWrite, ac.ScopeAnnotationsID)), routing.Wrap(hs.PatchAnnotation))
 			annotationsRoute.Post("/graphite", authorize(ac.EvalPermission(ac.ActionAnnotationsCreate, ac.ScopeAnnotationsTypeOrganization)), routing.Wrap(hs.PostGraphiteAnnotation))
+			if hs.Cfg.AnnotationWebhooks.Enabled {
+				// the templates of generic webhooks are parsed once
+				annotationWebhooks := webhooks.NewConverter(hs.Cfg.AnnotationWebhooks)
+				annotationsRoute.Post("/webhooks/:source", authorize(ac.EvalPermission(ac.ActionAnnotationsCreate, ac.ScopeAnnotationsTypeOrganization)), routing.Wrap(hs.PostAnnotationWebhook(annotationWebhooks)))
+			}
 			annotationsRoute.Get("/tags", authorize(ac.EvalPermission(ac.ActionAnnotationsRead)), routing.Wrap(hs.GetAnnotationTags))
+			annotationsRoute.Get("/search", authorize(ac.EvalPermission(ac.ActionAnnotationsRead)), routing.Wrap(hs.SearchAnnotations))
 		})
 
 		apiRoute.Post("/frontend-metrics", routing.Wrap(hs.PostFrontendMetrics))
@@ -32,6 +32,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/org"
 	pref "github.com/grafana/grafana/pkg/services/preference"
 	publicdashboardModels "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/star"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/util"
@@ -451,7 +452,11 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 	dash := cmd.GetDashboardModel()
 	newDashboard := dash.ID == 0
 	if newDashboard {
-		limitReached, err := hs.QuotaService.QuotaReached(c, dashboards.QuotaTargetSrv)
+		limitReached, err := hs.QuotaService.CheckQuotaReached(ctx, dashboards.QuotaTargetSrv, &quota.ScopeParameters{
+			OrgID:     cmd.OrgID,
+			UserID:    userID,
+			FolderUID: cmd.FolderUID,
+		})
 		if err != nil {
 			return response.Error(http.StatusInternalServerError, "Failed to get quota", err)
 		}
@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
@@ -139,6 +141,15 @@ func (hs *HTTPServer) GetDashboardSnapshot(c *contextmodel.ReqContext) response.
 
 	metrics.MApiDashboardSnapshotGet.Inc()
 
+	if err := hs.dashboardsnapshotsService.RecordSnapshotView(c.Req.Context(), &dashboardsnapshots.RecordSnapshotViewCommand{
+		SnapshotKey: snapshot.Key,
+		OrgID:       snapshot.OrgID,
+		UserID:      c.UserID,
+		UserLogin:   c.Login,
+	}); err != nil {
+		hs.log.Warn("Failed to record snapshot view", "key", snapshot.Key, "error", err)
+	}
+
 	return response.JSON(http.StatusOK, dto).SetHeader("Cache-Control", "public, max-age=3600")
 }
 
@@ -275,6 +286,24 @@ func (hs *HTTPServer) SearchDashboardSnapshots(c *contextmodel.ReqContext) respo
 		Limit:        limit,
 		OrgID:        c.GetOrgID(),
 		SignedInUser: c.SignedInUser,
+		CreatedBy:    c.QueryInt64("createdBy"),
+		DashboardUID: c.Query("dashboardUID"),
+	}
+
+	if olderThan := c.Query("olderThan"); olderThan != "" {
+		age, err := gtime.ParseDuration(olderThan)
+		if err != nil {
+			return response.Error(http.StatusBadRequest, "Invalid olderThan duration", err)
+		}
+		searchQuery.CreatedBefore = time.Now().Add(-age)
+	}
+
+	if external := c.Query("external"); external != "" {
+		isExternal, err := strconv.ParseBool(external)
+		if err != nil {
+			return response.Error(http.StatusBadRequest, "Invalid external filter", err)
+		}
+		searchQuery.External = &isExternal
 	}
 
 	searchQueryResult, err := hs.dashboardsnapshotsService.SearchDashboardSnapshots(c.Req.Context(), &searchQuery)
@@ -285,22 +314,118 @@ func (hs *HTTPServer) SearchDashboardSnapshots(c *contextmodel.ReqContext) respo
 	dto := make([]*dashboardsnapshots.DashboardSnapshotDTO, len(searchQueryResult))
 	for i, snapshot := range searchQueryResult {
 		dto[i] = &dashboardsnapshots.DashboardSnapshotDTO{
-			ID:          snapshot.ID,
-			Name:        snapshot.Name,
-			Key:         snapshot.Key,
-			OrgID:       snapshot.OrgID,
-			UserID:      snapshot.UserID,
-			External:    snapshot.External,
-			ExternalURL: snapshot.ExternalURL,
-			Expires:     snapshot.Expires,
-			Created:     snapshot.Created,
-			Updated:     snapshot.Updated,
+			ID:           snapshot.ID,
+			Name:         snapshot.Name,
+			Key:          snapshot.Key,
+			OrgID:        snapshot.OrgID,
+			UserID:       snapshot.UserID,
+			External:     snapshot.External,
+			ExternalURL:  snapshot.ExternalURL,
+			DashboardUID: snapshot.DashboardUID,
+			Expires:      snapshot.Expires,
+			Created:      snapshot.Created,
+			Updated:      snapshot.Updated,
 		}
 	}
 
 	return response.JSON(http.StatusOK, dto)
 }
 
+// swagger:route POST /dashboard/snapshots/delete dashboards snapshots bulkDeleteDashboardSnapshots
+//
+// Delete snapshots in bulk.
+//
+// Only snapshots the user can list are deleted. External snapshots that can not be removed from the external server are kept and reported as failed.
+//
+// Responses:
+// 200: bulkDeleteDashboardSnapshotsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) BulkDeleteDashboardSnapshots(c *contextmodel.ReqContext) response.Response {
+	if !hs.Cfg.SnapshotEnabled {
+		return response.Error(http.StatusForbidden, "Dashboard Snapshots are disabled", nil)
+	}
+
+	cmd := dashboardsnapshots.BulkDeleteDashboardSnapshotsCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	cmd.OrgID = c.GetOrgID()
+	cmd.SignedInUser = c.SignedInUser
+
+	result, err := hs.dashboardsnapshotsService.BulkDeleteDashboardSnapshots(c.Req.Context(), &cmd)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to delete dashboard snapshots", err)
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /dashboard/snapshots/policy dashboards snapshots getDashboardSnapshotPolicy
+//
+// Get the snapshot retention policy of the organization.
+//
+// Responses:
+// 200: dashboardSnapshotPolicyResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardSnapshotPolicy(c *contextmodel.ReqContext) response.Response {
+	policy, err := hs.dashboardsnapshotsService.GetSnapshotPolicy(c.Req.Context(), c.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get snapshot policy", err)
+	}
+	return response.JSON(http.StatusOK, policy)
+}
+
+// swagger:route PUT /dashboard/snapshots/policy dashboards snapshots updateDashboardSnapshotPolicy
+//
+// Update the snapshot retention policy of the organization.
+//
+// Snapshots older than the max age or exceeding the max count are deleted by the periodic clean up.
+//
+// Responses:
+// 200: dashboardSnapshotPolicyResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateDashboardSnapshotPolicy(c *contextmodel.ReqContext) response.Response {
+	policy := dashboardsnapshots.SnapshotPolicy{}
+	if err := web.Bind(c.Req, &policy); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	if err := hs.dashboardsnapshotsService.SetSnapshotPolicy(c.Req.Context(), c.GetOrgID(), &policy); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update snapshot policy", err)
+	}
+	return response.JSON(http.StatusOK, policy)
+}
+
+// swagger:route GET /dashboard/snapshots/{key}/views dashboards snapshots getDashboardSnapshotViews
+//
+// List who viewed a snapshot key.
+//
+// Views are kept after the snapshot is deleted.
+//
+// Responses:
+// 200: getDashboardSnapshotViewsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) GetDashboardSnapshotViews(c *contextmodel.ReqContext) response.Response {
+	views, err := hs.dashboardsnapshotsService.GetSnapshotViews(c.Req.Context(), &dashboardsnapshots.GetSnapshotViewsQuery{
+		SnapshotKey: web.Params(c.Req)[":key"],
+		OrgID:       c.GetOrgID(),
+	})
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get snapshot views", err)
+	}
+	return response.JSON(http.StatusOK, views)
+}
+
 // swagger:parameters createDashboardSnapshot
 type CreateSnapshotParams struct {
 	// in:body
@@ -317,6 +442,38 @@ type GetSnapshotsParams struct {
 	// in:query
 	// default:1000
 	Limit int64 `json:"limit"`
+	// Only return snapshots created by this user ID
+	// in:query
+	CreatedBy int64 `json:"createdBy"`
+	// Only return snapshots of this dashboard
+	// in:query
+	DashboardUID string `json:"dashboardUID"`
+	// Only return snapshots created longer ago than this duration, for example 30d
+	// in:query
+	OlderThan string `json:"olderThan"`
+	// Only return external or local snapshots
+	// in:query
+	External *bool `json:"external"`
+}
+
+// swagger:parameters bulkDeleteDashboardSnapshots
+type BulkDeleteDashboardSnapshotsParams struct {
+	// in:body
+	// required:true
+	Body dashboardsnapshots.BulkDeleteDashboardSnapshotsCommand `json:"body"`
+}
+
+// swagger:parameters updateDashboardSnapshotPolicy
+type UpdateDashboardSnapshotPolicyParams struct {
+	// in:body
+	// required:true
+	Body dashboardsnapshots.SnapshotPolicy `json:"body"`
+}
+
+// swagger:parameters getDashboardSnapshotViews
+type GetDashboardSnapshotViewsParams struct {
+	// in:path
+	Key string `json:"key"`
 }
 
 // swagger:parameters getDashboardSnapshot
@@ -358,6 +515,24 @@ type SearchDashboardSnapshotsResponse struct {
 	Body []*dashboardsnapshots.DashboardSnapshotDTO `json:"body"`
 }
 
+// swagger:response bulkDeleteDashboardSnapshotsResponse
+type BulkDeleteDashboardSnapshotsResponse struct {
+	// in:body
+	Body dashboardsnapshots.BulkDeleteDashboardSnapshotsResult `json:"body"`
+}
+
+// swagger:response dashboardSnapshotPolicyResponse
+type DashboardSnapshotPolicyResponse struct {
+	// in:body
+	Body dashboardsnapshots.SnapshotPolicy `json:"body"`
+}
+
+// swagger:response getDashboardSnapshotViewsResponse
+type GetDashboardSnapshotViewsResponse struct {
+	// in:body
+	Body []*dashboardsnapshots.SnapshotView `json:"body"`
+}
+
 // swagger:response getDashboardSnapshotResponse
 type GetDashboardSnapshotResponse DashboardResponse
 
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -237,6 +238,86 @@ func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
 	})
 }
 
+func TestHTTPServer_SearchDashboardSnapshots(t *testing.T) {
+	setup := func(t *testing.T, svc dashboardsnapshots.Service) *webtest.Server {
+		t.Helper()
+
+		return SetupAPITestServer(t, func(hs *HTTPServer) {
+			cfg := setting.NewCfg()
+			cfg.SnapshotEnabled = true
+			hs.Cfg = cfg
+			hs.dashboardsnapshotsService = svc
+		})
+	}
+
+	reader := userWithPermissions(1, []accesscontrol.Permission{{Action: dashboards.ActionSnapshotsRead}})
+
+	t.Run("should pass filters to the search", func(t *testing.T) {
+		svc := dashboardsnapshots.NewMockService(t)
+		svc.On("SearchDashboardSnapshots", mock.Anything, mock.MatchedBy(func(query *dashboardsnapshots.GetDashboardSnapshotsQuery) bool {
+			return query.CreatedBy == 2 && query.DashboardUID == "abc" && query.External != nil && *query.External &&
+				query.CreatedBefore.Before(time.Now().Add(-29*24*time.Hour))
+		})).Return(dashboardsnapshots.DashboardSnapshotsList{{Key: "k1", DashboardUID: "abc", External: true}}, nil)
+		server := setup(t, svc)
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			server.NewGetRequest("/api/dashboard/snapshots?createdBy=2&dashboardUID=abc&external=true&olderThan=30d"), reader))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should reject invalid age filter", func(t *testing.T) {
+		server := setup(t, dashboardsnapshots.NewMockService(t))
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			server.NewGetRequest("/api/dashboard/snapshots?olderThan=old"), reader))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
+func TestHTTPServer_BulkDeleteDashboardSnapshots(t *testing.T) {
+	svc := dashboardsnapshots.NewMockService(t)
+	svc.On("BulkDeleteDashboardSnapshots", mock.Anything, mock.MatchedBy(func(cmd *dashboardsnapshots.BulkDeleteDashboardSnapshotsCommand) bool {
+		return cmd.OrgID == 1 && len(cmd.Keys) == 2
+	})).Return(&dashboardsnapshots.BulkDeleteDashboardSnapshotsResult{Deleted: []string{"k1"}, Failed: []string{"k2"}}, nil)
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		cfg := setting.NewCfg()
+		cfg.SnapshotEnabled = true
+		hs.Cfg = cfg
+		hs.dashboardsnapshotsService = svc
+	})
+
+	t.Run("should not delete without permissions", func(t *testing.T) {
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			server.NewPostRequest("/api/dashboard/snapshots/delete", strings.NewReader(`{"keys": ["k1", "k2"]}`)),
+			userWithPermissions(1, nil)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should return deleted and failed keys", func(t *testing.T) {
+		req := server.NewPostRequest("/api/dashboard/snapshots/delete", strings.NewReader(`{"keys": ["k1", "k2"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := server.Send(webtest.RequestWithSignedInUser(req,
+			userWithPermissions(1, []accesscontrol.Permission{{Action: dashboards.ActionSnapshotsDelete}})))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		body, err := simplejson.NewJson(data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"k1"}, body.Get("deleted").MustStringArray())
+		assert.Equal(t, []string{"k2"}, body.Get("failed").MustStringArray())
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 func TestGetDashboardSnapshotNotFound(t *testing.T) {
 	sqlmock := dbtest.NewFakeDB()
 
@@ -408,5 +489,6 @@ func setUpSnapshotTest(t *testing.T, userId int64, deleteUrl string) dashboardsn
 	}
 	dashSnapSvc.On("GetDashboardSnapshot", mock.Anything, mock.AnythingOfType("*dashboardsnapshots.GetDashboardSnapshotQuery")).Return(res, nil).Maybe()
 	dashSnapSvc.On("DeleteDashboardSnapshot", mock.Anything, mock.AnythingOfType("*dashboardsnapshots.DeleteDashboardSnapshotCommand")).Return(nil).Maybe()
+	dashSnapSvc.On("RecordSnapshotView", mock.Anything, mock.AnythingOfType("*dashboardsnapshots.RecordSnapshotViewCommand")).Return(nil).Maybe()
 	return dashSnapSvc
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/datasource"
 	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/pluginproxy"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -323,6 +324,7 @@ func validateURL(cmdType string, url string) response.Response {
 // validateJSONData prevents the user from adding a custom header with name that matches the auth proxy header name.
 // This is done to prevent data source proxy from being used to circumvent auth proxy.
 // For more context take a look at CVE-2022-35957
+// It also validates the data source proxy rules and team tenants.
 func validateJSONData(jsonData *simplejson.Json, cfg *setting.Cfg) error {
 	if jsonData == nil {
 		return nil
@@ -340,6 +342,16 @@ func validateJSONData(jsonData *simplejson.Json, cfg *setting.Cfg) error {
 		}
 	}
 
+	if err := pluginproxy.ValidateProxyRules(jsonData); err != nil {
+		datasourcesLogger.Error("Invalid data source proxy rules", "error", err)
+		return err
+	}
+
+	if _, err := datasources.GetTeamTenants(jsonData); err != nil {
+		datasourcesLogger.Error("Invalid data source team tenants", "error", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -1,6 +1,9 @@
 package dtos
 
-import "github.com/grafana/grafana/pkg/components/simplejson"
+import (
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/annotations"
+)
 
 type PostAnnotationsCmd struct {
 	DashboardId  int64  `json:"dashboardId"`
@@ -45,3 +48,9 @@ type PostGraphiteAnnotationsCmd struct {
 	Data string `json:"data"`
 	Tags any    `json:"tags"`
 }
+
+type AnnotationSearchResult struct {
+	Annotations []*annotations.ItemDTO `json:"annotations"`
+	// Tags counts the tags of the matched annotations, most used first
+	Tags []*annotations.TagsDTO `json:"tags"`
+}
@@ -34,6 +34,8 @@ type FrontendSettingsAuthDTO struct {
 	DisableLogin                  bool `json:"disableLogin"`
 	BasicAuthStrongPasswordPolicy bool `json:"basicAuthStrongPasswordPolicy"`
 	PasswordlessEnabled           bool `json:"passwordlessEnabled"`
+	WebAuthnEnabled               bool `json:"webAuthnEnabled"`
+	WebAuthnPasskeyLogin          bool `json:"webAuthnPasskeyLogin"`
 	DisableSignoutMenu            bool `json:"disableSignoutMenu"`
 }
 
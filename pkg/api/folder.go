@@ -34,6 +34,8 @@ func (hs *HTTPServer) registerFolderAPI(apiRoute routing.RouteRegister, authoriz
 				folderPermissionRoute.Get("/", authorize(accesscontrol.EvalPermission(dashboards.ActionFoldersPermissionsRead, uidScope)), routing.Wrap(hs.GetFolderPermissionList))
 				folderPermissionRoute.Post("/", authorize(accesscontrol.EvalPermission(dashboards.ActionFoldersPermissionsWrite, uidScope)), routing.Wrap(hs.UpdateFolderPermissions))
 			})
+			folderUidRoute.Get("/quotas", authorize(accesscontrol.EvalPermission(accesscontrol.ActionOrgsQuotasRead)), routing.Wrap(hs.GetFolderQuotas))
+			folderUidRoute.Put("/quotas/:target", authorize(accesscontrol.EvalPermission(accesscontrol.ActionOrgsQuotasWrite)), routing.Wrap(hs.UpdateFolderQuota))
 		})
 
 		folderRoute.Post("/", authorize(accesscontrol.EvalPermission(dashboards.ActionFoldersCreate)), routing.Wrap(hs.CreateFolder))
@@ -470,6 +470,7 @@ func setupServer(b testing.TB, sc benchScenario, features featuremgmt.FeatureTog
 			nil,
 			features,
 		),
+		sc.teamSvc,
 	)
 	require.NoError(b, err)
 
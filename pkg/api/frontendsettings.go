@@ -399,6 +399,8 @@ func (hs *HTTPServer) getFrontendSettings(c *contextmodel.ReqContext) (*dtos.Fro
 		OktaSkipOrgRoleSync:           parseSkipOrgRoleSyncEnabled(oauthProviders[social.OktaProviderName]),
 		DisableLogin:                  hs.Cfg.DisableLogin,
 		BasicAuthStrongPasswordPolicy: hs.Cfg.BasicAuthStrongPasswordPolicy,
+		WebAuthnEnabled:               hs.Cfg.WebAuthnAuth.Enabled,
+		WebAuthnPasskeyLogin:          hs.Cfg.WebAuthnAuth.Enabled && hs.Cfg.WebAuthnAuth.AllowPasskeyLogin,
 		DisableSignoutMenu:            hs.Cfg.DisableSignoutMenu,
 	}
 
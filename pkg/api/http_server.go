@@ -50,6 +50,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/apikey"
 	grafanaapiserver "github.com/grafana/grafana/pkg/services/apiserver"
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/auth"
 	"github.com/grafana/grafana/pkg/services/authn"
 	"github.com/grafana/grafana/pkg/services/cleanup"
@@ -110,6 +111,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/updatemanager"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/services/validations"
+	"github.com/grafana/grafana/pkg/services/webauthn"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
@@ -221,6 +223,8 @@ type HTTPServer struct {
 	namespacer           request.NamespaceMapper
 	anonService          anonymous.Service
 	userVerifier         user.Verifier
+	webAuthnService      webauthn.Service
+	auditLogService      auditlog.Service
 	tlsCerts             TLSCerts
 }
 
@@ -271,7 +275,8 @@ func ProvideHTTPServer(opts ServerOptions, cfg *setting.Cfg, routeRegister routi
 	annotationRepo annotations.Repository, tagService tag.Service, searchv2HTTPService searchV2.SearchHTTPService, oauthTokenService oauthtoken.OAuthTokenService,
 	statsService stats.Service, authnService authn.Service, pluginsCDNService *pluginscdn.Service, promGatherer prometheus.Gatherer,
 	starApi *starApi.API, promRegister prometheus.Registerer, clientConfigProvider grafanaapiserver.DirectRestConfigProvider, anonService anonymous.Service,
-	userVerifier user.Verifier, pluginPreinstall pluginchecker.Preinstall,
+	userVerifier user.Verifier, pluginPreinstall pluginchecker.Preinstall, webAuthnService webauthn.Service,
+	auditLogService auditlog.Service,
 ) (*HTTPServer, error) {
 	web.Env = cfg.Env
 	m := web.New()
@@ -376,6 +381,8 @@ func ProvideHTTPServer(opts ServerOptions, cfg *setting.Cfg, routeRegister routi
 		namespacer:                   request.GetNamespaceMapper(cfg),
 		anonService:                  anonService,
 		userVerifier:                 userVerifier,
+		webAuthnService:              webAuthnService,
+		auditLogService:              auditLogService,
 	}
 	if hs.Listener != nil {
 		hs.log.Debug("Using provided listener")
@@ -657,6 +664,11 @@ func (hs *HTTPServer) addMiddlewaresAndStaticRoutes() {
 	for _, mw := range hs.middlewares {
 		m.Use(mw)
 	}
+
+	// needs to be after context handler
+	if !hs.auditLogService.IsDisabled() {
+		m.UseMiddleware(auditlog.Middleware(hs.Cfg, hs.auditLogService))
+	}
 }
 
 func (hs *HTTPServer) metricsEndpoint(ctx *web.Context) {
@@ -922,6 +934,16 @@ func (hs *HTTPServer) configureTLS() error {
 		CipherSuites: tlsCiphers,
 	}
 
+	if hs.Cfg.MTLSAuth.Enabled && hs.Cfg.MTLSAuth.ClientCAFile != "" {
+		clientCAs, err := hs.readClientCAs()
+		if err != nil {
+			return err
+		}
+		// client certificates are optional so that other auth methods keep working
+		tlsCfg.ClientCAs = clientCAs
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
 	hs.httpSrv.TLSConfig = tlsCfg
 
 	if hs.Cfg.Protocol == setting.HTTP2Scheme {
@@ -935,6 +957,19 @@ func (hs *HTTPServer) configureTLS() error {
 	return nil
 }
 
+func (hs *HTTPServer) readClientCAs() (*x509.CertPool, error) {
+	data, err := os.ReadFile(hs.Cfg.MTLSAuth.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read client CA file %q: %w", hs.Cfg.MTLSAuth.ClientCAFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in client CA file %q", hs.Cfg.MTLSAuth.ClientCAFile)
+	}
+	return pool, nil
+}
+
 func (hs *HTTPServer) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
 	hs.tlsCerts.certLock.RLock()
 	defer hs.tlsCerts.certLock.RUnlock()
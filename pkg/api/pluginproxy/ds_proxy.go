@@ -47,6 +47,10 @@ type DataSourceProxy struct {
 	dataSourcesService datasources.DataSourceService
 	tracer             tracing.Tracer
 	features           featuremgmt.FeatureToggles
+	rules              *proxyRules
+	ruleHeaders        []ProxyRuleHeader
+	teamTenants        *datasources.TeamTenants
+	tenant             string
 }
 
 type httpClient interface {
@@ -64,6 +68,16 @@ func NewDataSourceProxy(ds *datasources.DataSource, pluginRoutes []*plugins.Rout
 		return nil, err
 	}
 
+	rules, err := parseProxyRules(ds.JsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	teamTenants, err := datasources.GetTeamTenants(ds.JsonData)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DataSourceProxy{
 		ds:                 ds,
 		pluginRoutes:       pluginRoutes,
@@ -76,6 +90,8 @@ func NewDataSourceProxy(ds *datasources.DataSource, pluginRoutes []*plugins.Rout
 		dataSourcesService: dsService,
 		tracer:             tracer,
 		features:           features,
+		rules:              rules,
+		teamTenants:        teamTenants,
 	}, nil
 }
 
@@ -92,6 +108,24 @@ func (proxy *DataSourceProxy) HandleRequest() {
 		return
 	}
 
+	if proxy.rules != nil {
+		if err := proxy.rules.limitRequestBody(proxy.ctx.Req); err != nil {
+			if errors.Is(err, errRequestBodyTooLarge) {
+				proxy.ctx.JsonApiErr(http.StatusRequestEntityTooLarge, err.Error(), nil)
+			} else {
+				proxy.ctx.JsonApiErr(http.StatusBadRequest, "Failed to read request body", err)
+			}
+			return
+		}
+
+		headers, err := proxy.rules.renderHeaders(proxy.ctx.SignedInUser)
+		if err != nil {
+			proxy.ctx.JsonApiErr(http.StatusInternalServerError, "Failed to apply data source proxy rules", err)
+			return
+		}
+		proxy.ruleHeaders = headers
+	}
+
 	proxyErrorLogger := logger.New(
 		"userId", proxy.ctx.UserID,
 		"orgId", proxy.ctx.OrgID,
@@ -129,6 +163,9 @@ func (proxy *DataSourceProxy) HandleRequest() {
 				Request:       resp.Request,
 			}
 		}
+		if proxy.rules != nil {
+			return proxy.rules.redactResponse(resp)
+		}
 		return nil
 	}
 
@@ -273,6 +310,19 @@ func (proxy *DataSourceProxy) director(req *http.Request) {
 	}
 
 	proxyutil.ApplyForwardIDHeader(req, proxy.ctx.SignedInUser)
+
+	if proxy.rules != nil {
+		applyRuleHeaders(req, proxy.ruleHeaders)
+		// the response body can only be redacted when it isn't compressed
+		if proxy.rules.redactsBody() {
+			req.Header.Del("Accept-Encoding")
+		}
+	}
+
+	// the tenants of the teams replace any tenant sent by the client
+	if proxy.teamTenants != nil {
+		req.Header.Set(proxy.teamTenants.Header, proxy.tenant)
+	}
 }
 
 func (proxy *DataSourceProxy) validateRequest() error {
@@ -280,6 +330,20 @@ func (proxy *DataSourceProxy) validateRequest() error {
 		return errors.New("target URL is not a valid target")
 	}
 
+	if proxy.rules != nil {
+		if err := proxy.rules.checkPath(proxy.proxyPath); err != nil {
+			return err
+		}
+	}
+
+	if proxy.teamTenants != nil {
+		tenant, ok := proxy.teamTenants.HeaderValue(proxy.ctx.Teams)
+		if !ok {
+			return datasources.ErrDataSourceNoTenant
+		}
+		proxy.tenant = tenant
+	}
+
 	if proxy.ds.Type == datasources.DS_ES {
 		if proxy.ctx.Req.Method == "DELETE" {
 			return errors.New("deletes not allowed on proxied Elasticsearch datasource")
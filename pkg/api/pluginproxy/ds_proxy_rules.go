@@ -0,0 +1,307 @@
+package pluginproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	proxyRulesKey = "proxyRules"
+
+	defaultRedactionReplacement = "[REDACTED]"
+)
+
+var (
+	errRequestBodyTooLarge = errors.New("request body too large")
+)
+
+// ProxyRules are the rules applied by the data source proxy, configured in jsonData.proxyRules.
+type ProxyRules struct {
+	// Headers are set on the proxied requests, the values are templates of the signed in user.
+	Headers []ProxyRuleHeader `json:"headers,omitempty"`
+	// AllowedPaths are the path prefixes that can be proxied, matched on whole path segments, all paths are allowed when empty.
+	AllowedPaths []string `json:"allowedPaths,omitempty"`
+	// DeniedPaths are the path prefixes that cannot be proxied, matched on whole path segments, they take precedence over AllowedPaths.
+	DeniedPaths []string `json:"deniedPaths,omitempty"`
+	// MaxRequestBodySize is the maximum size of the request body in bytes, no limit when 0.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize,omitempty"`
+	// RedactResponseHeaders are removed from the responses.
+	RedactResponseHeaders []string `json:"redactResponseHeaders,omitempty"`
+	// RedactResponseBody replace the matches in the textual responses.
+	RedactResponseBody []ProxyRuleRedaction `json:"redactResponseBody,omitempty"`
+}
+
+type ProxyRuleHeader struct {
+	Header string `json:"header"`
+	Value  string `json:"value"`
+}
+
+type ProxyRuleRedaction struct {
+	Pattern string `json:"pattern"`
+	// Replacement defaults to [REDACTED], it can refer to the groups of the pattern, e.g. ${1}.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// proxyRuleTemplateData is the data available to the templates of the headers, e.g. {{.Login}} or {{join .Teams "|"}}.
+type proxyRuleTemplateData struct {
+	UserID  int64
+	Login   string
+	Email   string
+	Name    string
+	OrgID   int64
+	OrgRole string
+	Teams   []int64
+}
+
+var proxyRuleTemplateFuncs = template.FuncMap{
+	"join": func(values []int64, sep string) string {
+		s := make([]string, 0, len(values))
+		for _, v := range values {
+			s = append(s, strconv.FormatInt(v, 10))
+		}
+		return strings.Join(s, sep)
+	},
+}
+
+type proxyRuleHeader struct {
+	name  string
+	value *template.Template
+}
+
+type proxyRuleRedaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// proxyRules are the parsed ProxyRules of a data source.
+type proxyRules struct {
+	headers               []proxyRuleHeader
+	allowedPaths          []string
+	deniedPaths           []string
+	maxRequestBodySize    int64
+	redactResponseHeaders []string
+	redactResponseBody    []proxyRuleRedaction
+}
+
+// ValidateProxyRules returns an error when the proxy rules of the data source json data are invalid.
+func ValidateProxyRules(jsonData *simplejson.Json) error {
+	_, err := parseProxyRules(jsonData)
+	return err
+}
+
+func parseProxyRules(jsonData *simplejson.Json) (*proxyRules, error) {
+	if jsonData == nil {
+		return nil, nil
+	}
+	if _, ok := jsonData.CheckGet(proxyRulesKey); !ok {
+		return nil, nil
+	}
+
+	raw, err := jsonData.Get(proxyRulesKey).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	cfg := ProxyRules{}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid proxy rules: %w", err)
+	}
+
+	rules := &proxyRules{
+		maxRequestBodySize:    cfg.MaxRequestBodySize,
+		redactResponseHeaders: cfg.RedactResponseHeaders,
+	}
+	if cfg.MaxRequestBodySize < 0 {
+		return nil, errors.New("invalid proxy rules: maxRequestBodySize cannot be negative")
+	}
+
+	for _, h := range cfg.Headers {
+		if h.Header == "" {
+			return nil, errors.New("invalid proxy rules: header name is missing or empty")
+		}
+		tmpl, err := template.New(h.Header).Funcs(proxyRuleTemplateFuncs).Parse(h.Value)
+		if err == nil {
+			// unknown fields are only reported on execution
+			err = tmpl.Execute(io.Discard, proxyRuleTemplateData{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy rules: header %s: %w", h.Header, err)
+		}
+		rules.headers = append(rules.headers, proxyRuleHeader{name: h.Header, value: tmpl})
+	}
+
+	if rules.allowedPaths, err = cleanProxyRulePaths(cfg.AllowedPaths); err != nil {
+		return nil, err
+	}
+	if rules.deniedPaths, err = cleanProxyRulePaths(cfg.DeniedPaths); err != nil {
+		return nil, err
+	}
+
+	for _, r := range cfg.RedactResponseBody {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil || r.Pattern == "" {
+			return nil, fmt.Errorf("invalid proxy rules: invalid redaction pattern %q", r.Pattern)
+		}
+		replacement := r.Replacement
+		if replacement == "" {
+			replacement = defaultRedactionReplacement
+		}
+		rules.redactResponseBody = append(rules.redactResponseBody, proxyRuleRedaction{pattern: pattern, replacement: replacement})
+	}
+
+	return rules, nil
+}
+
+func cleanProxyRulePaths(paths []string) ([]string, error) {
+	cleaned := make([]string, 0, len(paths))
+	for _, p := range paths {
+		c, err := util.CleanRelativePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy rules: invalid path %q", p)
+		}
+		cleaned = append(cleaned, c)
+	}
+	return cleaned, nil
+}
+
+// checkPath returns an error when the proxy path is denied, or not allowed.
+func (r *proxyRules) checkPath(proxyPath string) error {
+	if len(r.allowedPaths) == 0 && len(r.deniedPaths) == 0 {
+		return nil
+	}
+	p, err := util.CleanRelativePath(proxyPath)
+	if err != nil {
+		return err
+	}
+	for _, denied := range r.deniedPaths {
+		if matchesPathPrefix(p, denied) {
+			return errors.New("data source proxy path is denied")
+		}
+	}
+	if len(r.allowedPaths) == 0 {
+		return nil
+	}
+	for _, allowed := range r.allowedPaths {
+		if matchesPathPrefix(p, allowed) {
+			return nil
+		}
+	}
+	return errors.New("data source proxy path is not allowed")
+}
+
+// matchesPathPrefix returns true when the path is the prefix or below it, so that api/v1/query does not match api/v1/query_range.
+// Both paths are cleaned, "." being the root path.
+func matchesPathPrefix(p string, prefix string) bool {
+	return prefix == "." || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// limitRequestBody returns errRequestBodyTooLarge when the body of the request exceeds the maximum size.
+// The body is buffered, so that requests without a content length are checked as well.
+func (r *proxyRules) limitRequestBody(req *http.Request) error {
+	if r.maxRequestBodySize <= 0 || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.ContentLength > r.maxRequestBodySize {
+		return errRequestBodyTooLarge
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, r.maxRequestBodySize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(body)) > r.maxRequestBodySize {
+		return errRequestBodyTooLarge
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+// renderHeaders executes the templates of the headers for the signed in user.
+func (r *proxyRules) renderHeaders(usr *user.SignedInUser) ([]ProxyRuleHeader, error) {
+	if len(r.headers) == 0 {
+		return nil, nil
+	}
+	data := proxyRuleTemplateData{}
+	if usr != nil {
+		data = proxyRuleTemplateData{
+			UserID:  usr.UserID,
+			Login:   usr.Login,
+			Email:   usr.Email,
+			Name:    usr.Name,
+			OrgID:   usr.OrgID,
+			OrgRole: string(usr.OrgRole),
+			Teams:   usr.Teams,
+		}
+	}
+	headers := make([]ProxyRuleHeader, 0, len(r.headers))
+	for _, h := range r.headers {
+		var value bytes.Buffer
+		if err := h.value.Execute(&value, data); err != nil {
+			return nil, fmt.Errorf("failed to execute template of header %s: %w", h.name, err)
+		}
+		headers = append(headers, ProxyRuleHeader{Header: h.name, Value: value.String()})
+	}
+	return headers, nil
+}
+
+// applyRuleHeaders sets the rendered headers on the request. Headers with an empty value
+// are removed, so that they can't be set by the client.
+func applyRuleHeaders(req *http.Request, headers []ProxyRuleHeader) {
+	for _, h := range headers {
+		if h.Value == "" {
+			req.Header.Del(h.Header)
+			continue
+		}
+		req.Header.Set(h.Header, h.Value)
+	}
+}
+
+// redactsBody returns true when the response body has to be rewritten.
+func (r *proxyRules) redactsBody() bool {
+	return len(r.redactResponseBody) > 0
+}
+
+// redactResponse removes the headers and replaces the patterns of the textual response bodies.
+func (r *proxyRules) redactResponse(resp *http.Response) error {
+	for _, h := range r.redactResponseHeaders {
+		resp.Header.Del(h)
+	}
+	if !r.redactsBody() || resp.Body == nil || !isTextualContentType(resp.Header.Get("Content-Type")) {
+		return nil
+	}
+	if encoding := resp.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		return fmt.Errorf("cannot redact response with content encoding %s", encoding)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read data source response body: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	for _, redaction := range r.redactResponseBody {
+		body = redaction.pattern.ReplaceAll(body, []byte(redaction.replacement))
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+func isTextualContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "" ||
+		strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "xml")
+}
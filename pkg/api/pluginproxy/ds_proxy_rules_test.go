@@ -0,0 +1,164 @@
+package pluginproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func mustParseProxyRules(t *testing.T, rules string) *proxyRules {
+	t.Helper()
+	jsonData, err := simplejson.NewJson([]byte(`{"proxyRules": ` + rules + `}`))
+	require.NoError(t, err)
+	parsed, err := parseProxyRules(jsonData)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestParseProxyRules(t *testing.T) {
+	t.Run("no rules", func(t *testing.T) {
+		rules, err := parseProxyRules(simplejson.New())
+		require.NoError(t, err)
+		require.Nil(t, rules)
+	})
+
+	for name, rules := range map[string]string{
+		"missing header name":    `{"headers": [{"value": "tenant"}]}`,
+		"invalid template":       `{"headers": [{"header": "X-Scope-OrgID", "value": "{{.Login"}]}`,
+		"unknown template field": `{"headers": [{"header": "X-Scope-OrgID", "value": "{{.Tenant}}"}]}`,
+		"invalid redaction":      `{"redactResponseBody": [{"pattern": "("}]}`,
+		"empty redaction":        `{"redactResponseBody": [{"pattern": ""}]}`,
+		"negative body size":     `{"maxRequestBodySize": -1}`,
+		"invalid type":           `{"headers": "X-Scope-OrgID"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			jsonData, err := simplejson.NewJson([]byte(`{"proxyRules": ` + rules + `}`))
+			require.NoError(t, err)
+			require.Error(t, ValidateProxyRules(jsonData))
+		})
+	}
+}
+
+func TestProxyRules_checkPath(t *testing.T) {
+	rules := mustParseProxyRules(t, `{"allowedPaths": ["/api/v1/query", "api/v1/labels"], "deniedPaths": ["api/v1/query_exemplars"]}`)
+
+	require.NoError(t, rules.checkPath("api/v1/query"))
+	require.NoError(t, rules.checkPath("/api/v1/query/"))
+	require.NoError(t, rules.checkPath("api/v1/labels"))
+	require.NoError(t, rules.checkPath("api/v1/labels/values"))
+	require.Error(t, rules.checkPath("api/v1/query_exemplars"))
+	require.Error(t, rules.checkPath("/api/v1/query_range"))
+	require.Error(t, rules.checkPath("api/v1/labelsfoo"))
+	require.Error(t, rules.checkPath("api/v1/admin/tsdb/delete_series"))
+	require.Error(t, rules.checkPath("api/v1/labels/../../../admin"))
+
+	t.Run("denied paths only", func(t *testing.T) {
+		rules := mustParseProxyRules(t, `{"deniedPaths": ["api/v1/admin"]}`)
+		require.NoError(t, rules.checkPath("api/v1/query"))
+		require.Error(t, rules.checkPath("api/v1/admin"))
+		require.Error(t, rules.checkPath("api/v1/admin/tsdb/snapshot"))
+		require.NoError(t, rules.checkPath("api/v1/administrators"))
+	})
+
+	t.Run("root path", func(t *testing.T) {
+		rules := mustParseProxyRules(t, `{"allowedPaths": ["/"], "deniedPaths": ["api/v1/admin"]}`)
+		require.NoError(t, rules.checkPath("api/v1/query"))
+		require.Error(t, rules.checkPath("api/v1/admin/tsdb/snapshot"))
+	})
+}
+
+func TestProxyRules_limitRequestBody(t *testing.T) {
+	rules := mustParseProxyRules(t, `{"maxRequestBodySize": 5}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345"))
+	require.NoError(t, rules.limitRequestBody(req))
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, "12345", string(body))
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456"))
+	require.ErrorIs(t, rules.limitRequestBody(req), errRequestBodyTooLarge)
+
+	// without content length, e.g. chunked requests
+	req = httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("123456")))
+	req.ContentLength = -1
+	require.ErrorIs(t, rules.limitRequestBody(req), errRequestBodyTooLarge)
+}
+
+func TestProxyRules_headers(t *testing.T) {
+	rules := mustParseProxyRules(t, `{"headers": [
+		{"header": "X-Scope-OrgID", "value": "{{join .Teams \"|\"}}"},
+		{"header": "X-Grafana-Login", "value": "{{.Login}}@{{.OrgID}}"},
+		{"header": "X-Grafana-Role", "value": "{{.OrgRole}}"}
+	]}`)
+
+	headers, err := rules.renderHeaders(&user.SignedInUser{Login: "admin", OrgID: 2, OrgRole: org.RoleViewer, Teams: []int64{1, 3}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Grafana-Role", "Admin")
+	applyRuleHeaders(req, headers)
+	require.Equal(t, "1|3", req.Header.Get("X-Scope-OrgID"))
+	require.Equal(t, "admin@2", req.Header.Get("X-Grafana-Login"))
+	require.Equal(t, "Viewer", req.Header.Get("X-Grafana-Role"))
+
+	t.Run("removes the headers of the client when empty", func(t *testing.T) {
+		headers, err := rules.renderHeaders(&user.SignedInUser{Login: "admin"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Scope-OrgID", "other-tenant")
+		applyRuleHeaders(req, headers)
+		_, ok := req.Header["X-Scope-Orgid"]
+		require.False(t, ok)
+	})
+}
+
+func TestProxyRules_redactResponse(t *testing.T) {
+	rules := mustParseProxyRules(t, `{
+		"redactResponseHeaders": ["X-Internal-Host"],
+		"redactResponseBody": [
+			{"pattern": "\"password\":\"[^\"]*\""},
+			{"pattern": "token=(\\w+)", "replacement": "token=***"}
+		]
+	}`)
+
+	newResponse := func(contentType string, body string) *http.Response {
+		header := http.Header{}
+		header.Set("Content-Type", contentType)
+		header.Set("X-Internal-Host", "10.0.0.1")
+		return &http.Response{Header: header, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	resp := newResponse("application/json", `{"password":"secret","url":"/?token=abc"}`)
+	require.NoError(t, rules.redactResponse(resp))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{[REDACTED],"url":"/?token=***"}`, string(body))
+	assert.Equal(t, int64(len(body)), resp.ContentLength)
+	assert.Empty(t, resp.Header.Get("X-Internal-Host"))
+
+	t.Run("ignores binary responses", func(t *testing.T) {
+		resp := newResponse("application/x-protobuf", "token=abc")
+		require.NoError(t, rules.redactResponse(resp))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "token=abc", string(body))
+		assert.Empty(t, resp.Header.Get("X-Internal-Host"))
+	})
+
+	t.Run("fails on compressed responses", func(t *testing.T) {
+		resp := newResponse("application/json", "token=abc")
+		resp.Header.Set("Content-Encoding", "gzip")
+		require.Error(t, rules.redactResponse(resp))
+	})
+}
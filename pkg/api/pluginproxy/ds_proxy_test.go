@@ -832,6 +832,90 @@ func TestDataSourceProxy_requestHandling(t *testing.T) {
 		require.NotNil(t, req)
 		require.Equal(t, "/path/%2Ftest%2Ftest%2F?query=%2Ftest%2Ftest%2F", req.RequestURI)
 	})
+
+	t.Run("Data source proxy rules are applied", func(t *testing.T) {
+		var req *http.Request
+		ctx, ds := setUp(t, setUpCfg{
+			writeCb: func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(200)
+				_, err := w.Write([]byte(`{"secret":"value"}`))
+				require.NoError(t, err)
+			},
+		})
+		ds.JsonData = simplejson.NewFromAny(map[string]any{
+			"proxyRules": map[string]any{
+				"headers":            []any{map[string]any{"header": "X-Scope-OrgID", "value": `{{join .Teams "|"}}`}},
+				"allowedPaths":       []any{"api/v1"},
+				"redactResponseBody": []any{map[string]any{"pattern": `"secret":"\w+"`}},
+			},
+		})
+		ctx.SignedInUser.Teams = []int64{1, 2}
+		ctx.Req.Header.Set("X-Scope-OrgID", "3")
+		ctx.Req.Header.Set("Accept-Encoding", "gzip")
+		recorder := httptest.NewRecorder()
+		ctx.Resp = web.NewResponseWriter("GET", recorder)
+
+		proxy, err := setupDSProxyTest(t, ctx, ds, nil, "api/v1/query")
+		require.NoError(t, err)
+
+		proxy.HandleRequest()
+
+		require.NotNil(t, req)
+		assert.Equal(t, "1|2", req.Header.Get("X-Scope-OrgID"))
+		assert.Equal(t, `{[REDACTED]}`, recorder.Body.String())
+
+		t.Run("and deny the paths that are not allowed", func(t *testing.T) {
+			req = nil
+			ctx.Resp = web.NewResponseWriter("GET", httptest.NewRecorder())
+			proxy, err := setupDSProxyTest(t, ctx, ds, nil, "api/v2/admin")
+			require.NoError(t, err)
+
+			proxy.HandleRequest()
+
+			assert.Equal(t, http.StatusForbidden, proxy.ctx.Resp.Status())
+			assert.Nil(t, req)
+		})
+	})
+
+	t.Run("Data source team tenants are applied", func(t *testing.T) {
+		var req *http.Request
+		ctx, ds := setUp(t, setUpCfg{
+			writeCb: func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				w.WriteHeader(200)
+			},
+		})
+		ds.JsonData = simplejson.NewFromAny(map[string]any{
+			"teamTenants": map[string]any{
+				"tenants": map[string]any{"1": []any{"team-a"}, "2": []any{"team-b"}},
+			},
+		})
+		ctx.SignedInUser.Teams = []int64{1, 2}
+		ctx.Req.Header.Set("X-Scope-OrgID", "team-c")
+
+		proxy, err := setupDSProxyTest(t, ctx, ds, nil, "api/v1/query")
+		require.NoError(t, err)
+
+		proxy.HandleRequest()
+
+		require.NotNil(t, req)
+		assert.Equal(t, []string{"team-a|team-b"}, req.Header.Values("X-Scope-OrgID"))
+
+		t.Run("and deny the users without tenant", func(t *testing.T) {
+			req = nil
+			ctx.SignedInUser.Teams = []int64{3}
+			ctx.Resp = web.NewResponseWriter("GET", httptest.NewRecorder())
+			proxy, err := setupDSProxyTest(t, ctx, ds, nil, "api/v1/query")
+			require.NoError(t, err)
+
+			proxy.HandleRequest()
+
+			assert.Equal(t, http.StatusForbidden, proxy.ctx.Resp.Status())
+			assert.Nil(t, req)
+		})
+	})
 }
 
 func TestNewDataSourceProxy_InvalidURL(t *testing.T) {
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/grafana/grafana/pkg/api/apierrors"
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/services/team"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -168,6 +172,196 @@ func (hs *HTTPServer) UpdateUserQuota(c *contextmodel.ReqContext) response.Respo
 	return response.Success("Organization quota updated")
 }
 
+// swagger:route GET /teams/{team_id}/quotas quota teams getTeamQuota
+//
+// Fetch team quota.
+//
+// Team quotas limit the resources created by the members of the team.
+// If you are running Grafana Enterprise and have Fine-grained access control enabled, you need to have a permission with action `orgs.quotas:read`.
+//
+// Responses:
+// 200: getQuotaResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetTeamQuotas(c *contextmodel.ReqContext) response.Response {
+	ctx, span := hs.tracer.Start(c.Req.Context(), "api.GetTeamQuotas")
+	defer span.End()
+	teamID, errResp := hs.getQuotaTeamID(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	q, err := hs.QuotaService.GetTeamQuotas(ctx, c.GetOrgID(), teamID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get team quotas", err)
+	}
+	return response.JSON(http.StatusOK, q)
+}
+
+// swagger:route PUT /teams/{team_id}/quotas/{quota_target} quota teams updateTeamQuota
+//
+// Update team quota.
+//
+// If you are running Grafana Enterprise and have Fine-grained access control enabled, you need to have a permission with action `orgs.quotas:write`.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateTeamQuota(c *contextmodel.ReqContext) response.Response {
+	ctx, span := hs.tracer.Start(c.Req.Context(), "api.UpdateTeamQuota")
+	defer span.End()
+	cmd := quota.UpdateQuotaCmd{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Err(quota.ErrBadRequest.Errorf("bad request data: %w", err))
+	}
+	teamID, errResp := hs.getQuotaTeamID(c)
+	if errResp != nil {
+		return errResp
+	}
+	cmd.OrgID = c.GetOrgID()
+	cmd.TeamID = teamID
+	cmd.Target = web.Params(c.Req)[":target"]
+
+	if err := hs.QuotaService.Update(ctx, &cmd); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update team quotas", err)
+	}
+	return response.Success("Team quota updated")
+}
+
+// getQuotaTeamID returns the id of the team of the request, making sure it belongs to the current organization
+func (hs *HTTPServer) getQuotaTeamID(c *contextmodel.ReqContext) (int64, response.Response) {
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return 0, response.Err(quota.ErrBadRequest.Errorf("teamId is invalid: %w", err))
+	}
+	if _, err := hs.TeamService.GetTeamByID(c.Req.Context(), &team.GetTeamByIDQuery{OrgID: c.GetOrgID(), ID: teamID}); err != nil {
+		if errors.Is(err, team.ErrTeamNotFound) {
+			return 0, response.Error(http.StatusNotFound, "Team not found", err)
+		}
+		return 0, response.Error(http.StatusInternalServerError, "Failed to get team", err)
+	}
+	return teamID, nil
+}
+
+// swagger:route GET /folders/{folder_uid}/quotas quota folders getFolderQuota
+//
+// Fetch folder quota.
+//
+// Folder quotas limit the resources stored directly in the folder.
+// If you are running Grafana Enterprise and have Fine-grained access control enabled, you need to have a permission with action `orgs.quotas:read`.
+//
+// Responses:
+// 200: getQuotaResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetFolderQuotas(c *contextmodel.ReqContext) response.Response {
+	ctx, span := hs.tracer.Start(c.Req.Context(), "api.GetFolderQuotas")
+	defer span.End()
+	folderUID, errResp := hs.getQuotaFolderUID(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	q, err := hs.QuotaService.GetFolderQuotas(ctx, c.GetOrgID(), folderUID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get folder quotas", err)
+	}
+	return response.JSON(http.StatusOK, q)
+}
+
+// swagger:route PUT /folders/{folder_uid}/quotas/{quota_target} quota folders updateFolderQuota
+//
+// Update folder quota.
+//
+// If you are running Grafana Enterprise and have Fine-grained access control enabled, you need to have a permission with action `orgs.quotas:write`.
+//
+// Responses:
+// 200: okResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) UpdateFolderQuota(c *contextmodel.ReqContext) response.Response {
+	ctx, span := hs.tracer.Start(c.Req.Context(), "api.UpdateFolderQuota")
+	defer span.End()
+	cmd := quota.UpdateQuotaCmd{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Err(quota.ErrBadRequest.Errorf("bad request data: %w", err))
+	}
+	folderUID, errResp := hs.getQuotaFolderUID(c)
+	if errResp != nil {
+		return errResp
+	}
+	cmd.OrgID = c.GetOrgID()
+	cmd.FolderUID = folderUID
+	cmd.Target = web.Params(c.Req)[":target"]
+
+	if err := hs.QuotaService.Update(ctx, &cmd); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update folder quotas", err)
+	}
+	return response.Success("Folder quota updated")
+}
+
+// getQuotaFolderUID returns the uid of the folder of the request, making sure it belongs to the current organization
+func (hs *HTTPServer) getQuotaFolderUID(c *contextmodel.ReqContext) (string, response.Response) {
+	uid := web.Params(c.Req)[":uid"]
+	f, err := hs.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.GetOrgID(), UID: &uid, SignedInUser: c.SignedInUser})
+	if err != nil {
+		return "", apierrors.ToFolderErrorResponse(err)
+	}
+	return f.UID, nil
+}
+
+// swagger:parameters getTeamQuota
+type GetTeamQuotaParams struct {
+	// in:path
+	// required:true
+	TeamID int64 `json:"team_id"`
+}
+
+// swagger:parameters updateTeamQuota
+type UpdateTeamQuotaParams struct {
+	// in:body
+	// required:true
+	Body quota.UpdateQuotaCmd `json:"body"`
+	// in:path
+	// required:true
+	QuotaTarget string `json:"quota_target"`
+	// in:path
+	// required:true
+	TeamID int64 `json:"team_id"`
+}
+
+// swagger:parameters getFolderQuota
+type GetFolderQuotaParams struct {
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+}
+
+// swagger:parameters updateFolderQuota
+type UpdateFolderQuotaParams struct {
+	// in:body
+	// required:true
+	Body quota.UpdateQuotaCmd `json:"body"`
+	// in:path
+	// required:true
+	QuotaTarget string `json:"quota_target"`
+	// in:path
+	// required:true
+	FolderUID string `json:"folder_uid"`
+}
+
 // swagger:parameters updateUserQuota
 type UpdateUserQuotaParams struct {
 	// in:body
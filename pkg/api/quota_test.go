@@ -15,6 +15,8 @@ import (
 
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/services/user/usertest"
 	"github.com/grafana/grafana/pkg/setting"
@@ -25,6 +27,8 @@ var (
 	getCurrentOrgQuotasURL = "/api/org/quotas"
 	getOrgsQuotasURL       = "/api/orgs/%v/quotas"
 	putOrgsQuotasURL       = "/api/orgs/%v/quotas/%v"
+	getTeamQuotasURL       = "/api/teams/%v/quotas"
+	putTeamQuotasURL       = "/api/teams/%v/quotas/%v"
 
 	testUpdateOrgQuotaCmd = `{ "limit": 20 }`
 )
@@ -182,6 +186,54 @@ func TestAPIEndpoint_PutOrgQuotas(t *testing.T) {
 	}
 }
 
+func TestAPIEndpoint_TeamQuotas(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.Quota.Enabled = true
+	teamService := &teamtest.FakeService{ExpectedTeamDTO: &team.TeamDTO{ID: 1, OrgID: 1}}
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.Cfg = cfg
+		hs.TeamService = teamService
+	})
+
+	t.Run("AccessControl allows viewing team quotas with correct permissions", func(t *testing.T) {
+		req := webtest.RequestWithSignedInUser(server.NewGetRequest(fmt.Sprintf(getTeamQuotasURL, 1)), userWithPermissions(1, []accesscontrol.Permission{{Action: accesscontrol.ActionOrgsQuotasRead}}))
+		res, err := server.Send(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+	t.Run("AccessControl prevents viewing team quotas with incorrect permissions", func(t *testing.T) {
+		req := webtest.RequestWithSignedInUser(server.NewGetRequest(fmt.Sprintf(getTeamQuotasURL, 1)), userWithPermissions(1, []accesscontrol.Permission{{Action: "orgs:invalid"}}))
+		res, err := server.Send(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+	t.Run("AccessControl allows updating team quotas with correct permissions", func(t *testing.T) {
+		req := webtest.RequestWithSignedInUser(server.NewRequest(http.MethodPut, fmt.Sprintf(putTeamQuotasURL, 1, "dashboard"), strings.NewReader(testUpdateOrgQuotaCmd)), userWithPermissions(1, []accesscontrol.Permission{{Action: accesscontrol.ActionOrgsQuotasWrite}}))
+		res, err := server.SendJSON(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+	t.Run("Should return 404 for a team of another org", func(t *testing.T) {
+		teamService.ExpectedError = team.ErrTeamNotFound
+		t.Cleanup(func() { teamService.ExpectedError = nil })
+		req := webtest.RequestWithSignedInUser(server.NewGetRequest(fmt.Sprintf(getTeamQuotasURL, 2)), userWithPermissions(1, []accesscontrol.Permission{{Action: accesscontrol.ActionOrgsQuotasRead}}))
+		res, err := server.Send(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+	t.Run("Should return 400 for an invalid team id", func(t *testing.T) {
+		req := webtest.RequestWithSignedInUser(server.NewGetRequest(fmt.Sprintf(getTeamQuotasURL, "abc")), userWithPermissions(1, []accesscontrol.Permission{{Action: accesscontrol.ActionOrgsQuotasRead}}))
+		res, err := server.Send(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 func getFirstOrgPermissions(p map[int64][]accesscontrol.Permission) []accesscontrol.Permission {
 	for _, permissions := range p {
 		return permissions
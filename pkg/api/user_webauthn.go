@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/metrics"
+	"github.com/grafana/grafana/pkg/services/auth"
+	"github.com/grafana/grafana/pkg/services/authn"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+type startWebAuthnLoginForm struct {
+	// LoginToken is returned when a password login requires a security key.
+	// Without it any passkey can be used
+	LoginToken string `json:"loginToken"`
+}
+
+// StartWebAuthnLogin returns the options passed to navigator.credentials.get()
+func (hs *HTTPServer) StartWebAuthnLogin(c *contextmodel.ReqContext) response.Response {
+	form := startWebAuthnLoginForm{}
+	if err := web.Bind(c.Req, &form); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	assertion, err := hs.webAuthnService.BeginLogin(c.Req.Context(), form.LoginToken)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to start security key login", err)
+	}
+	return response.JSON(http.StatusOK, assertion)
+}
+
+// LoginWebAuthn verifies the result of navigator.credentials.get() and creates a session
+func (hs *HTTPServer) LoginWebAuthn(c *contextmodel.ReqContext) response.Response {
+	identity, err := hs.authnService.Login(c.Req.Context(), authn.ClientWebAuthn, &authn.Request{HTTPRequest: c.Req})
+	if err != nil {
+		tokenErr := &auth.CreateTokenErr{}
+		if errors.As(err, &tokenErr) {
+			return response.Error(tokenErr.StatusCode, tokenErr.ExternalErr, tokenErr.InternalErr)
+		}
+		return response.Err(err)
+	}
+
+	metrics.MApiLoginPost.Inc()
+	return authn.HandleLoginResponse(c.Req, c.Resp, hs.Cfg, identity, hs.ValidateRedirectTo, hs.Features)
+}
+
+// GetUserWebAuthnCredentials returns the security keys and passkeys of the signed in user
+func (hs *HTTPServer) GetUserWebAuthnCredentials(c *contextmodel.ReqContext) response.Response {
+	userID, errResp := webAuthnUserID(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	creds, err := hs.webAuthnService.GetCredentials(c.Req.Context(), userID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get credentials", err)
+	}
+	return response.JSON(http.StatusOK, creds)
+}
+
+// StartWebAuthnRegistration returns the options passed to navigator.credentials.create()
+func (hs *HTTPServer) StartWebAuthnRegistration(c *contextmodel.ReqContext) response.Response {
+	if _, errResp := webAuthnUserID(c); errResp != nil {
+		return errResp
+	}
+
+	creation, err := hs.webAuthnService.BeginRegistration(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to start registration", err)
+	}
+	return response.JSON(http.StatusOK, creation)
+}
+
+// FinishWebAuthnRegistration verifies the result of navigator.credentials.create() and stores the new credential
+func (hs *HTTPServer) FinishWebAuthnRegistration(c *contextmodel.ReqContext) response.Response {
+	if _, errResp := webAuthnUserID(c); errResp != nil {
+		return errResp
+	}
+
+	cmd := webauthn.FinishRegistrationCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	cred, err := hs.webAuthnService.FinishRegistration(c.Req.Context(), c.SignedInUser, &cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to register credential", err)
+	}
+	return response.JSON(http.StatusOK, cred)
+}
+
+// DeleteUserWebAuthnCredential removes a security key or passkey of the signed in user
+func (hs *HTTPServer) DeleteUserWebAuthnCredential(c *contextmodel.ReqContext) response.Response {
+	userID, errResp := webAuthnUserID(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	id, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "id is invalid", err)
+	}
+
+	if err := hs.webAuthnService.DeleteCredential(c.Req.Context(), userID, id); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to delete credential", err)
+	}
+	return response.Success("Credential deleted")
+}
+
+func webAuthnUserID(c *contextmodel.ReqContext) (int64, response.Response) {
+	if !c.IsIdentityType(claims.TypeUser) {
+		return 0, response.Error(http.StatusForbidden, "entity not allowed to manage security keys", nil)
+	}
+
+	userID, err := c.GetInternalID()
+	if err != nil {
+		return 0, response.Error(http.StatusInternalServerError, "failed to parse user id", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,64 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SavedSearch is a named search that is stored like any other resource.
+// It can be watched with the SearchSubscriptions service
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SavedSearch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SavedSearchSpec `json:"spec"`
+}
+
+type SavedSearchSpec struct {
+	// Display name
+	Title string `json:"title,omitempty"`
+
+	// The resource to search, eg: dashboard.grafana.app/dashboards
+	Group    string `json:"group"`
+	Resource string `json:"resource"`
+
+	// Additional resources in the same search, eg: folder.grafana.app/folders
+	Federated []SavedSearchTarget `json:"federated,omitempty"`
+
+	// The user query string
+	Query string `json:"query,omitempty"`
+
+	// The fields returned for each result
+	Fields []string `json:"fields,omitempty"`
+
+	// Field filters, eg: {"key":"tags","operator":"=","values":["payments"]}
+	Filters []SavedSearchRequirement `json:"filters,omitempty"`
+
+	// Label filters
+	Labels []SavedSearchRequirement `json:"labels,omitempty"`
+
+	// Fields to calculate term facets for
+	Facets []string `json:"facets,omitempty"`
+
+	// Sortable fields, prefixed with "-" for descending
+	Sort []string `json:"sort,omitempty"`
+}
+
+type SavedSearchTarget struct {
+	Group    string `json:"group"`
+	Resource string `json:"resource"`
+}
+
+type SavedSearchRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SavedSearchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SavedSearch `json:"items"`
+}
@@ -28,12 +28,13 @@ var _ io.ReadCloser = (*ResponseAdapter)(nil)
 // for internal clients that use the LoopbackConfig.
 // All other requests should not use this wrapper, and should be handled by the
 // Grafana HTTP server to ensure that signedInUser middleware is applied.
-func WrapHandler(handler http.Handler) func(req *http.Request) (*http.Response, error) {
+// The propagators copy values of the request context that are not carried over by default.
+func WrapHandler(handler http.Handler, propagators ...ContextPropagator) func(req *http.Request) (*http.Response, error) {
 	// ignore the lint error because the response is passed directly to the client,
 	// so the client will be responsible for closing the response body.
 	//nolint:bodyclose
 	return func(req *http.Request) (*http.Response, error) {
-		ctx, cancel, err := createLimitedContext(req)
+		ctx, cancel, err := createLimitedContext(req, propagators)
 		if err != nil {
 			return nil, err
 		}
@@ -53,9 +54,12 @@ func WrapHandler(handler http.Handler) func(req *http.Request) (*http.Response,
 	}
 }
 
+// ContextPropagator copies a value from the context of the original request to the context of the wrapped handler.
+type ContextPropagator func(from, to context.Context) context.Context
+
 // createLimitedContext creates a new context based on the the req's.
 // It contains vital information such as a logger for the driver of the request, a user for auth, tracing, and deadlines. It propagates the parent's cancellation.
-func createLimitedContext(req *http.Request) (context.Context, context.CancelFunc, error) {
+func createLimitedContext(req *http.Request, propagators []ContextPropagator) (context.Context, context.CancelFunc, error) {
 	refCtx := req.Context()
 	newCtx := context.Background()
 
@@ -98,6 +102,10 @@ func createLimitedContext(req *http.Request) (context.Context, context.CancelFun
 		newCtx = tracing.ContextWithSpan(newCtx, span)
 	}
 
+	for _, propagate := range propagators {
+		newCtx = propagate(refCtx, newCtx)
+	}
+
 	deadlineCancel := context.CancelFunc(func() {})
 	if deadline, ok := refCtx.Deadline(); ok {
 		newCtx, deadlineCancel = context.WithDeadline(newCtx, deadline)
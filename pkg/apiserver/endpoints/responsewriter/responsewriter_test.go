@@ -198,6 +198,39 @@ func TestResponseAdapter(t *testing.T) {
 		require.NoError(t, err, "request should not fail")
 		require.NoError(t, resp.Body.Close())
 	})
+
+	t.Run("should copy the values of the propagators", func(t *testing.T) {
+		t.Parallel()
+
+		type K int
+		var key K
+		baseCtx := context.WithValue(context.Background(), key, "hello, world!")
+		propagate := func(from, to context.Context) context.Context {
+			return context.WithValue(to, key, from.Value(key))
+		}
+
+		client := &http.Client{
+			Transport: &roundTripperFunc{
+				ready: make(chan struct{}),
+				// ignore the lint error because the response is passed directly to the client,
+				// so the client will be responsible for closing the response body.
+				//nolint:bodyclose
+				fn: grafanaresponsewriter.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(t, "hello, world!", r.Context().Value(key), "value was not propagated through request context")
+
+					_, err := w.Write([]byte("OK"))
+					require.NoError(t, err)
+				}), propagate),
+			},
+		}
+
+		req, err := http.NewRequestWithContext(baseCtx, http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err, "request should not fail")
+		require.NoError(t, resp.Body.Close())
+	})
 }
 
 func syncHandler(w http.ResponseWriter, r *http.Request) {
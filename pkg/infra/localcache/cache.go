@@ -1,6 +1,7 @@
 package localcache
 
 import (
+	"sync/atomic"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -9,6 +10,13 @@ import (
 // CacheService cache any object in memory on the local instance.
 type CacheService struct {
 	*gocache.Cache
+	invalidator atomic.Pointer[Invalidator]
+}
+
+// Invalidator fans out keys invalidated on the local instance to the other instances.
+// It is called from request paths, so it must not block.
+type Invalidator interface {
+	PublishInvalidation(keys []string)
 }
 
 func ProvideService() *CacheService {
@@ -21,3 +29,20 @@ func New(defaultExpiration, cleanupInterval time.Duration) *CacheService {
 		Cache: gocache.New(defaultExpiration, cleanupInterval),
 	}
 }
+
+// SetInvalidator sets the invalidator used by Invalidate.
+func (s *CacheService) SetInvalidator(invalidator Invalidator) {
+	s.invalidator.Store(&invalidator)
+}
+
+// Invalidate deletes the keys from the cache and, when an invalidator is set,
+// from the caches of the other instances shortly after.
+// Use it instead of Delete when the cached value changed, not only expired.
+func (s *CacheService) Invalidate(keys ...string) {
+	for _, key := range keys {
+		s.Delete(key)
+	}
+	if invalidator := s.invalidator.Load(); invalidator != nil && len(keys) > 0 {
+		(*invalidator).PublishInvalidation(keys)
+	}
+}
@@ -0,0 +1,192 @@
+package remotecache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/lib/pq"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	redisInvalidationType    = "redis"
+	postgresInvalidationType = "postgres"
+
+	invalidationChannel = "grafana_cache_invalidation"
+	// maxPostgresPayload is the maximum size of a NOTIFY payload, minus a margin
+	maxPostgresPayload = 7900
+)
+
+var (
+	// ErrInvalidInvalidationType is returned if the invalidation type is invalid
+	ErrInvalidInvalidationType = errors.New("invalid remote cache invalidation type")
+)
+
+// invalidationBus fans out the keys of invalidated local cache items to every Grafana instance.
+type invalidationBus interface {
+	Publish(ctx context.Context, keys []string) error
+	// Listen calls fn with the keys invalidated by any instance, including this one, until the context is done.
+	// fn is called with nil keys when invalidations may have been missed, e.g. after a reconnection.
+	Listen(ctx context.Context, fn func(keys []string)) error
+}
+
+type invalidationMessage struct {
+	Keys []string `json:"keys"`
+}
+
+func newInvalidationBus(opts *setting.RemoteCacheSettings, sqlStore db.DB) (invalidationBus, error) {
+	channel := opts.Prefix + invalidationChannel
+	switch opts.Invalidation {
+	case "":
+		return nil, nil
+	case redisInvalidationType:
+		if opts.Name != redisCacheType {
+			return nil, fmt.Errorf("redis cache invalidation requires the redis remote cache, got %q", opts.Name)
+		}
+		connOpts, err := parseRedisConnStr(opts.ConnStr)
+		if err != nil {
+			return nil, err
+		}
+		return &redisInvalidationBus{client: connOpts.newClient(), channel: channel}, nil
+	case postgresInvalidationType:
+		if sqlStore == nil || sqlStore.GetDBType() != migrator.Postgres {
+			return nil, errors.New("postgres cache invalidation requires postgres as the Grafana database")
+		}
+		return &postgresInvalidationBus{sqlStore: sqlStore, channel: channel, log: log.New("remotecache.invalidation")}, nil
+	default:
+		return nil, ErrInvalidInvalidationType
+	}
+}
+
+type redisInvalidationBus struct {
+	client  redis.UniversalClient
+	channel string
+}
+
+func (b *redisInvalidationBus) Publish(ctx context.Context, keys []string) error {
+	payload, err := json.Marshal(invalidationMessage{Keys: keys})
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Listen relies on the client to reconnect and subscribe again.
+func (b *redisInvalidationBus) Listen(ctx context.Context, fn func(keys []string)) error {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	defer func() { _ = pubsub.Close() }()
+
+	// wait for the subscription to be confirmed so that errors are returned
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return errors.New("redis subscription closed")
+			}
+			var m invalidationMessage
+			// nil keys is reserved for missed invalidations
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil || len(m.Keys) == 0 {
+				continue
+			}
+			fn(m.Keys)
+		}
+	}
+}
+
+type postgresInvalidationBus struct {
+	sqlStore db.DB
+	channel  string
+	log      log.Logger
+}
+
+func (b *postgresInvalidationBus) Publish(ctx context.Context, keys []string) error {
+	for _, batch := range batchKeys(keys, maxPostgresPayload) {
+		payload, err := json.Marshal(invalidationMessage{Keys: batch})
+		if err != nil {
+			return err
+		}
+		err = b.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Exec("SELECT pg_notify(?, ?)", b.channel, string(payload))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Listen uses a dedicated connection, since notifications are delivered to the connection that listens.
+func (b *postgresInvalidationBus) Listen(ctx context.Context, fn func(keys []string)) error {
+	listener := pq.NewListener(b.sqlStore.GetEngine().DataSourceName(), 10*time.Second, time.Minute,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				b.log.Warn("Cache invalidation listener connection event", "event", event, "error", err)
+			}
+		})
+	defer func() { _ = listener.Close() }()
+
+	if err := listener.Listen(b.channel); err != nil {
+		return err
+	}
+
+	ping := time.NewTicker(time.Minute)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ping.C:
+			go func() { _ = listener.Ping() }()
+		case n := <-listener.Notify:
+			// a nil notification is sent after the connection was re-established
+			if n == nil {
+				fn(nil)
+				continue
+			}
+			var m invalidationMessage
+			// nil keys is reserved for missed invalidations
+			if err := json.Unmarshal([]byte(n.Extra), &m); err != nil || len(m.Keys) == 0 {
+				continue
+			}
+			fn(m.Keys)
+		}
+	}
+}
+
+// batchKeys splits the keys in batches whose encoded size stays under maxSize.
+// A key larger than maxSize ends up alone in its batch.
+func batchKeys(keys []string, maxSize int) [][]string {
+	var batches [][]string
+	var batch []string
+	size := 0
+	for _, key := range keys {
+		// quotes and separator
+		keySize := len(key) + 3
+		if len(batch) > 0 && size+keySize > maxSize {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, key)
+		size += keySize
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
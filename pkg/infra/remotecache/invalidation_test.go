@@ -0,0 +1,205 @@
+package remotecache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/localcache"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+// fakeInvalidationBus delivers the published keys to every listener, like the pub/sub of a shared backend.
+type fakeInvalidationBus struct {
+	mu        sync.Mutex
+	listeners []func(keys []string)
+}
+
+func (b *fakeInvalidationBus) Publish(_ context.Context, keys []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, fn := range b.listeners {
+		fn(keys)
+	}
+	return nil
+}
+
+func (b *fakeInvalidationBus) Listen(ctx context.Context, fn func(keys []string)) error {
+	b.mu.Lock()
+	b.listeners = append(b.listeners, fn)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b *fakeInvalidationBus) listening() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.listeners)
+}
+
+func newInstanceWithInvalidation(t *testing.T, bus invalidationBus) *localcache.CacheService {
+	t.Helper()
+
+	localCache := localcache.New(time.Minute, time.Minute)
+	ds := &RemoteCache{
+		client:        NewFakeCacheStorage(),
+		Cfg:           &setting.Cfg{RemoteCacheOptions: &setting.RemoteCacheSettings{}},
+		localCache:    localCache,
+		invalidation:  bus,
+		invalidations: make(chan []string, invalidationQueueSize),
+		log:           log.NewNopLogger(),
+	}
+	localCache.SetInvalidator(ds)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = ds.Run(ctx) }()
+
+	return localCache
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	bus := &fakeInvalidationBus{}
+	first := newInstanceWithInvalidation(t, bus)
+	second := newInstanceWithInvalidation(t, bus)
+	require.Eventually(t, func() bool { return bus.listening() == 2 }, time.Second, 10*time.Millisecond)
+
+	for _, c := range []*localcache.CacheService{first, second} {
+		c.Set("permissions", "cached", time.Minute)
+		c.Set("other", "cached", time.Minute)
+	}
+
+	first.Invalidate("permissions")
+
+	// the invalidation is published in the background
+	_, found := first.Get("permissions")
+	require.False(t, found)
+	require.Eventually(t, func() bool {
+		_, found := second.Get("permissions")
+		return !found
+	}, time.Second, 10*time.Millisecond)
+	for _, c := range []*localcache.CacheService{first, second} {
+		_, found = c.Get("other")
+		require.True(t, found)
+	}
+
+	t.Run("Delete only removes the key locally", func(t *testing.T) {
+		first.Set("permissions", "cached", time.Minute)
+		second.Set("permissions", "cached", time.Minute)
+
+		first.Delete("permissions")
+
+		_, found := second.Get("permissions")
+		require.True(t, found)
+	})
+}
+
+// blockingInvalidationBus never completes a publish until the context is done, like an unreachable backend.
+type blockingInvalidationBus struct{}
+
+func (blockingInvalidationBus) Publish(ctx context.Context, _ []string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (blockingInvalidationBus) Listen(ctx context.Context, _ func(keys []string)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCacheInvalidationDoesNotWaitForTheBus(t *testing.T) {
+	localCache := newInstanceWithInvalidation(t, blockingInvalidationBus{})
+	localCache.Set("permissions", "cached", time.Minute)
+
+	// more invalidations than the queue holds are dropped instead of blocking
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < invalidationQueueSize+10; i++ {
+			localCache.Invalidate("permissions")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("invalidating waited for the bus")
+	}
+	_, found := localCache.Get("permissions")
+	require.False(t, found)
+}
+
+func TestNewInvalidationBus(t *testing.T) {
+	t.Run("is disabled by default", func(t *testing.T) {
+		bus, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: databaseCacheType}, nil)
+		require.NoError(t, err)
+		require.Nil(t, bus)
+	})
+
+	t.Run("rejects unknown types", func(t *testing.T) {
+		_, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: databaseCacheType, Invalidation: "kafka"}, nil)
+		require.ErrorIs(t, err, ErrInvalidInvalidationType)
+	})
+
+	t.Run("redis requires the redis remote cache", func(t *testing.T) {
+		_, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: databaseCacheType, Invalidation: redisInvalidationType}, nil)
+		require.Error(t, err)
+
+		bus, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: redisCacheType, ConnStr: "addr=127.0.0.1:6379", Invalidation: redisInvalidationType}, nil)
+		require.NoError(t, err)
+		require.IsType(t, &redisInvalidationBus{}, bus)
+	})
+
+	t.Run("postgres requires a postgres database", func(t *testing.T) {
+		_, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: databaseCacheType, Invalidation: postgresInvalidationType}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestBatchKeys(t *testing.T) {
+	require.Nil(t, batchKeys(nil, 10))
+	require.Equal(t, [][]string{{"a", "b"}}, batchKeys([]string{"a", "b"}, 10))
+	require.Equal(t, [][]string{{"aaa", "bbb"}, {"ccc"}}, batchKeys([]string{"aaa", "bbb", "ccc"}, 12))
+	require.Equal(t, [][]string{{"a"}, {"too-long-key"}, {"b"}}, batchKeys([]string{"a", "too-long-key", "b"}, 10))
+}
+
+func TestIntegrationPostgresInvalidationBus(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+	if !db.IsTestDbPostgres() {
+		t.Skip("Postgres cache invalidation requires a postgres database")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	bus, err := newInvalidationBus(&setting.RemoteCacheSettings{Name: databaseCacheType, Invalidation: postgresInvalidationType}, sqlStore)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	received := make(chan []string, 1)
+	go func() {
+		_ = bus.Listen(ctx, func(keys []string) {
+			if keys != nil {
+				received <- keys
+			}
+		})
+	}()
+
+	// the listener may not be subscribed yet, so publish until the keys are received
+	require.Eventually(t, func() bool {
+		require.NoError(t, bus.Publish(ctx, []string{"permissions"}))
+		select {
+		case keys := <-received:
+			return len(keys) == 1 && keys[0] == "permissions"
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+}
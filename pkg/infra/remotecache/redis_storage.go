@@ -17,13 +17,29 @@ import (
 const redisCacheType = "redis"
 
 type redisStorage struct {
-	c *redis.Client
+	c redis.UniversalClient
+}
+
+// redisConnOptions are the options parsed from the redis connection string
+type redisConnOptions struct {
+	redis.UniversalOptions
+	// Cluster forces a cluster client, which is otherwise only used for several addresses
+	Cluster bool
+}
+
+// newClient returns a client for the topology of the options: a sentinel failover client
+// when a master name is set, a cluster client for several addresses, or a single node client.
+func (o *redisConnOptions) newClient() redis.UniversalClient {
+	if o.Cluster {
+		return redis.NewClusterClient(o.UniversalOptions.Cluster())
+	}
+	return redis.NewUniversalClient(&o.UniversalOptions)
 }
 
 // parseRedisConnStr parses k=v pairs in csv and builds a redis Options object
-func parseRedisConnStr(connStr string) (*redis.Options, error) {
+func parseRedisConnStr(connStr string) (*redisConnOptions, error) {
 	keyValueCSV := strings.Split(connStr, ",")
-	options := &redis.Options{Network: "tcp"}
+	options := &redisConnOptions{}
 	setTLSIsTrue := false
 	for _, rawKeyValue := range keyValueCSV {
 		keyValueTuple := strings.SplitN(rawKeyValue, "=", 2)
@@ -32,13 +48,17 @@ func parseRedisConnStr(connStr string) (*redis.Options, error) {
 				// don't log the password
 				rawKeyValue = "password" + setting.RedactedPassword
 			}
+			if strings.HasPrefix(rawKeyValue, "sentinel_password") {
+				rawKeyValue = "sentinel_password" + setting.RedactedPassword
+			}
 			return nil, fmt.Errorf("incorrect redis connection string format detected for '%v', format is key=value,key=value", rawKeyValue)
 		}
 		connKey := keyValueTuple[0]
 		connVal := keyValueTuple[1]
 		switch connKey {
 		case "addr":
-			options.Addr = connVal
+			// addr is repeated for the several nodes of a cluster or sentinel
+			options.Addrs = append(options.Addrs, connVal)
 		case "username":
 			options.Username = connVal
 		case "password":
@@ -65,15 +85,36 @@ func parseRedisConnStr(connStr string) (*redis.Options, error) {
 			if connVal == "insecure" {
 				options.TLSConfig = &tls.Config{InsecureSkipVerify: true}
 			}
+		case "cluster":
+			b, err := strconv.ParseBool(connVal)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", "value for cluster in redis connection string must be a boolean", err)
+			}
+			options.Cluster = b
+		case "master_name":
+			options.MasterName = connVal
+		case "sentinel_username":
+			options.SentinelUsername = connVal
+		case "sentinel_password":
+			options.SentinelPassword = connVal
 		default:
 			return nil, fmt.Errorf("unrecognized option '%v' in redis connection string", connKey)
 		}
 	}
+	if len(options.Addrs) == 0 {
+		return nil, fmt.Errorf("addr is required in redis connection string")
+	}
+	if options.MasterName != "" && options.Cluster {
+		return nil, fmt.Errorf("master_name and cluster can't be both set in redis connection string")
+	}
+	if (options.Cluster || (options.MasterName == "" && len(options.Addrs) > 1)) && options.DB != 0 {
+		return nil, fmt.Errorf("db is not supported by redis cluster")
+	}
 	if setTLSIsTrue {
-		// Get hostname from the Addr property and set it on the configuration for TLS
-		sp := strings.Split(options.Addr, ":")
+		// Get hostname from the first address and set it on the configuration for TLS
+		sp := strings.Split(options.Addrs[0], ":")
 		if len(sp) < 1 {
-			return nil, fmt.Errorf("unable to get hostname from the addr field, expected host:port, got '%v'", options.Addr)
+			return nil, fmt.Errorf("unable to get hostname from the addr field, expected host:port, got '%v'", options.Addrs[0])
 		}
 		options.TLSConfig = &tls.Config{ServerName: sp[0]}
 	}
@@ -85,7 +126,7 @@ func newRedisStorage(opts *setting.RemoteCacheSettings) (*redisStorage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &redisStorage{c: redis.NewClient(opt)}, nil
+	return &redisStorage{c: opt.newClient()}, nil
 }
 
 // Set sets value to a given key
@@ -12,49 +12,89 @@ import (
 func Test_parseRedisConnStr(t *testing.T) {
 	cases := map[string]struct {
 		InputConnStr  string
-		OutputOptions *redis.Options
+		OutputOptions *redisConnOptions
 		ShouldErr     bool
 	}{
 		"all redis options should parse": {
 			"addr=127.0.0.1:6379,pool_size=100,db=1,username=grafana,password=grafanaRocks,ssl=false",
-			&redis.Options{
-				Addr:      "127.0.0.1:6379",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:     []string{"127.0.0.1:6379"},
 				PoolSize:  100,
 				DB:        1,
 				Username:  "grafana",
 				Password:  "grafanaRocks",
-				Network:   "tcp",
 				TLSConfig: nil,
-			},
+			}},
 			false,
 		},
 		"subset of redis options should parse": {
 			"addr=127.0.0.1:6379,pool_size=100",
-			&redis.Options{
-				Addr:     "127.0.0.1:6379",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:    []string{"127.0.0.1:6379"},
 				PoolSize: 100,
-				Network:  "tcp",
-			},
+			}},
 			false,
 		},
 		"ssl set to true should result in default TLS configuration with tls set to addr's host": {
 			"addr=grafana.com:6379,ssl=true",
-			&redis.Options{
-				Addr:      "grafana.com:6379",
-				Network:   "tcp",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:     []string{"grafana.com:6379"},
 				TLSConfig: &tls.Config{ServerName: "grafana.com"},
-			},
+			}},
 			false,
 		},
 		"ssl to insecure should result in TLS configuration with InsecureSkipVerify": {
 			"addr=127.0.0.1:6379,ssl=insecure",
-			&redis.Options{
-				Addr:      "127.0.0.1:6379",
-				Network:   "tcp",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:     []string{"127.0.0.1:6379"},
 				TLSConfig: &tls.Config{InsecureSkipVerify: true},
-			},
+			}},
 			false,
 		},
+		"repeated addr should parse as cluster addresses": {
+			"addr=10.0.0.1:6379,addr=10.0.0.2:6379,password=grafanaRocks",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:    []string{"10.0.0.1:6379", "10.0.0.2:6379"},
+				Password: "grafanaRocks",
+			}},
+			false,
+		},
+		"cluster with a single seed address should parse": {
+			"addr=10.0.0.1:6379,cluster=true",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{Addrs: []string{"10.0.0.1:6379"}}, Cluster: true},
+			false,
+		},
+		"sentinel options should parse": {
+			"addr=10.0.0.1:26379,addr=10.0.0.2:26379,master_name=mymaster,sentinel_username=sentinel,sentinel_password=secret,db=2",
+			&redisConnOptions{UniversalOptions: redis.UniversalOptions{
+				Addrs:            []string{"10.0.0.1:26379", "10.0.0.2:26379"},
+				MasterName:       "mymaster",
+				SentinelUsername: "sentinel",
+				SentinelPassword: "secret",
+				DB:               2,
+			}},
+			false,
+		},
+		"db with cluster should err": {
+			"addr=10.0.0.1:6379,addr=10.0.0.2:6379,db=1",
+			nil,
+			true,
+		},
+		"master_name with cluster should err": {
+			"addr=10.0.0.1:6379,cluster=true,master_name=mymaster",
+			nil,
+			true,
+		},
+		"invalid cluster value should err": {
+			"addr=10.0.0.1:6379,cluster=maybe",
+			nil,
+			true,
+		},
+		"missing addr should err": {
+			"pool_size=100",
+			nil,
+			true,
+		},
 		"invalid SSL option should err": {
 			"addr=127.0.0.1:6379,ssl=dragons",
 			nil,
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/localcache"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/registry"
 	"github.com/grafana/grafana/pkg/services/secrets"
@@ -20,6 +22,11 @@ var (
 	ErrInvalidCacheType = errors.New("invalid remote cache name")
 
 	defaultMaxCacheExpiration = time.Hour * 24
+
+	invalidationPublishTimeout = 5 * time.Second
+	invalidationRetryInterval  = 5 * time.Second
+	// invalidationQueueSize bounds the invalidations waiting to be published
+	invalidationQueueSize = 1000
 )
 
 const (
@@ -27,15 +34,26 @@ const (
 )
 
 func ProvideService(cfg *setting.Cfg, sqlStore db.DB, usageStats usagestats.Service,
-	secretsService secrets.Service) (*RemoteCache, error) {
+	secretsService secrets.Service, localCache *localcache.CacheService) (*RemoteCache, error) {
 	client, err := createClient(cfg.RemoteCacheOptions, sqlStore, secretsService)
 	if err != nil {
 		return nil, err
 	}
+	invalidation, err := newInvalidationBus(cfg.RemoteCacheOptions, sqlStore)
+	if err != nil {
+		return nil, err
+	}
 	s := &RemoteCache{
-		SQLStore: sqlStore,
-		Cfg:      cfg,
-		client:   client,
+		SQLStore:      sqlStore,
+		Cfg:           cfg,
+		client:        client,
+		localCache:    localCache,
+		invalidation:  invalidation,
+		invalidations: make(chan []string, invalidationQueueSize),
+		log:           log.New("remotecache"),
+	}
+	if invalidation != nil && localCache != nil {
+		localCache.SetInvalidator(s)
 	}
 
 	usageStats.RegisterMetricsFunc(s.getUsageStats)
@@ -52,6 +70,9 @@ func (ds *RemoteCache) getUsageStats(ctx context.Context) (map[string]any, error
 	}
 
 	stats["stats.remote_cache.encrypt_enabled.count"] = encryptVal
+	if ds.Cfg.RemoteCacheOptions.Invalidation != "" {
+		stats["stats.remote_cache.invalidation."+ds.Cfg.RemoteCacheOptions.Invalidation+".count"] = 1
+	}
 
 	return stats, nil
 }
@@ -73,9 +94,14 @@ type CacheStorage interface {
 
 // RemoteCache allows Grafana to cache data outside its own process
 type RemoteCache struct {
-	client   CacheStorage
-	SQLStore db.DB
-	Cfg      *setting.Cfg
+	client       CacheStorage
+	SQLStore     db.DB
+	Cfg          *setting.Cfg
+	localCache   *localcache.CacheService
+	invalidation invalidationBus
+	// invalidations queues the keys to publish, so invalidating does not wait for the bus
+	invalidations chan []string
+	log           log.Logger
 }
 
 // Get returns the cached value as an byte array
@@ -97,8 +123,40 @@ func (ds *RemoteCache) Delete(ctx context.Context, key string) error {
 	return ds.client.Delete(ctx, key)
 }
 
+// PublishInvalidation queues the keys invalidated in the local cache to be sent to the other instances.
+// It does not block: when the queue is full the keys are dropped, and the other instances then rely
+// on the expiration of their items, like for failures to publish.
+func (ds *RemoteCache) PublishInvalidation(keys []string) {
+	select {
+	case ds.invalidations <- keys:
+	default:
+		ds.log.Warn("Dropping cache invalidation, too many invalidations are waiting to be published", "keys", keys)
+	}
+}
+
+// publishInvalidations sends the queued invalidations to the other instances until the context is done.
+func (ds *RemoteCache) publishInvalidations(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case keys := <-ds.invalidations:
+			publishCtx, cancel := context.WithTimeout(ctx, invalidationPublishTimeout)
+			if err := ds.invalidation.Publish(publishCtx, keys); err != nil {
+				ds.log.Warn("Failed to publish cache invalidation", "keys", keys, "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
 // Run starts the backend processes for cache clients.
 func (ds *RemoteCache) Run(ctx context.Context) error {
+	if ds.invalidation != nil && ds.localCache != nil {
+		go ds.listenForInvalidations(ctx)
+		go ds.publishInvalidations(ctx)
+	}
+
 	// create new interface if more clients need GC jobs
 	backgroundjob, ok := ds.client.(registry.BackgroundService)
 	if ok {
@@ -109,6 +167,37 @@ func (ds *RemoteCache) Run(ctx context.Context) error {
 	return ctx.Err()
 }
 
+// listenForInvalidations deletes the keys invalidated by any instance from the local cache,
+// and listens again after failures until the context is done.
+func (ds *RemoteCache) listenForInvalidations(ctx context.Context) {
+	for {
+		err := ds.invalidation.Listen(ctx, ds.invalidate)
+		if ctx.Err() != nil {
+			return
+		}
+		ds.log.Error("Listening for cache invalidations failed", "error", err)
+		// invalidations may be missed until listening again
+		ds.invalidate(nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(invalidationRetryInterval):
+		}
+	}
+}
+
+// invalidate deletes the keys from the local cache, or every item when keys is nil.
+func (ds *RemoteCache) invalidate(keys []string) {
+	if keys == nil {
+		ds.localCache.Flush()
+		return
+	}
+	for _, key := range keys {
+		ds.localCache.Delete(key)
+	}
+}
+
 func createClient(opts *setting.RemoteCacheSettings, sqlstore db.DB, secretsService secrets.Service) (cache CacheStorage, err error) {
 	switch opts.Name {
 	case redisCacheType:
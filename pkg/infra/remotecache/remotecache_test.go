@@ -28,7 +28,7 @@ func createTestClient(t *testing.T, opts *setting.RemoteCacheSettings, sqlstore
 	cfg := &setting.Cfg{
 		RemoteCacheOptions: opts,
 	}
-	dc, err := ProvideService(cfg, sqlstore, &usagestats.UsageStatsMock{}, fakes.NewFakeSecretsService())
+	dc, err := ProvideService(cfg, sqlstore, &usagestats.UsageStatsMock{}, fakes.NewFakeSecretsService(), nil)
 	require.Nil(t, err, "Failed to init client for test")
 
 	return dc
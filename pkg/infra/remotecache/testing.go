@@ -24,7 +24,7 @@ func NewFakeStore(t *testing.T) *RemoteCache {
 
 	dc, err := ProvideService(&setting.Cfg{
 		RemoteCacheOptions: opts,
-	}, sqlStore, &usagestats.UsageStatsMock{}, fakes.NewFakeSecretsService())
+	}, sqlStore, &usagestats.UsageStatsMock{}, fakes.NewFakeSecretsService(), nil)
 	require.NoError(t, err, "Failed to init remote cache for test")
 
 	return dc
@@ -30,6 +30,11 @@ func CreateTransportOptions(ctx context.Context, settings backend.DataSourceInst
 
 	opts.Middlewares = middlewares(logger, httpMethod)
 
+	// The tenants are set by Grafana from the teams of the user, as a forwarded header
+	if _, ok := jsonData["teamTenants"]; ok {
+		opts.ForwardHTTPHeaders = true
+	}
+
 	return &opts, nil
 }
 
@@ -23,5 +23,15 @@ func TestCreateTransportOptions(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, http.Header{"Foo": []string{"bar"}}, opts.Header)
 		require.Equal(t, 1, len(opts.Middlewares))
+		require.False(t, opts.ForwardHTTPHeaders)
+	})
+
+	t.Run("forwards the headers with team tenants", func(t *testing.T) {
+		settings := backend.DataSourceInstanceSettings{
+			JSONData: []byte(`{"teamTenants": {"tenants": {"1": ["team-a"]}}}`),
+		}
+		opts, err := CreateTransportOptions(context.Background(), settings, backend.NewLoggerWith("logger", "test"))
+		require.NoError(t, err)
+		require.True(t, opts.ForwardHTTPHeaders)
 	})
 }
@@ -86,6 +86,11 @@ func (d *directResourceClient) Watch(ctx context.Context, in *resourcepb.WatchRe
 	return nil, fmt.Errorf("watch not supported with direct resource client")
 }
 
+// WatchSearch implements ResourceClient.
+func (d *directResourceClient) WatchSearch(ctx context.Context, in *resourcepb.WatchSearchRequest, opts ...grpc.CallOption) (resourcepb.SearchSubscriptions_WatchSearchClient, error) {
+	return nil, fmt.Errorf("watch search not supported with direct resource client")
+}
+
 // BulkProcess implements resource.ResourceClient.
 func (d *directResourceClient) BulkProcess(ctx context.Context, opts ...grpc.CallOption) (resourcepb.BulkStore_BulkProcessClient, error) {
 	return nil, fmt.Errorf("BulkProcess not supported with direct resource client")
@@ -205,6 +205,8 @@ func (c *DashboardSearchClient) Search(ctx context.Context, req *resourcepb.Reso
 			}
 
 			query.FolderUIDs = folders
+		case resource.SEARCH_FIELD_CREATED_BY:
+			query.CreatedBy = vals
 		case resource.SEARCH_FIELD_SOURCE_PATH:
 			// only one value is supported in legacy search
 			if len(vals) != 1 {
@@ -377,6 +377,7 @@ func TestDashboardSearchClient_Search(t *testing.T) {
 			DashboardUIDs: []string{"uid1", "uid2"},
 			Tags:          []string{"tag1", "tag2"},
 			FolderUIDs:    []string{"general", "folder1"},
+			CreatedBy:     []string{"user:creator"},
 			SignedInUser:  user,      // user from context should be used
 			Type:          "dash-db", // should set type based off of key
 		}).Return([]dashboards.DashboardSearchProjection{
@@ -402,6 +403,11 @@ func TestDashboardSearchClient_Search(t *testing.T) {
 						Operator: "in",
 						Values:   []string{"", "folder1"}, // empty folder should be general
 					},
+					{
+						Key:      resource.SEARCH_FIELD_CREATED_BY,
+						Operator: "in",
+						Values:   []string{"user:creator"},
+					},
 				},
 			},
 		}
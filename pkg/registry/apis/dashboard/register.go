@@ -99,6 +99,7 @@ type DashboardsAPIBuilder struct {
 	dashboardPermissionsSvc      accesscontrol.DashboardPermissionsService
 	scheme                       *runtime.Scheme
 	search                       *SearchHandler
+	restore                      *RestoreHandler
 	dashStore                    dashboards.Store
 	QuotaService                 quota.Service
 	ProvisioningService          provisioning.ProvisioningService
@@ -149,6 +150,7 @@ func RegisterAPIService(
 		unified:                      unified,
 		dashboardProvisioningService: provisioningDashboardService,
 		search:                       NewSearchHandler(tracing, dual, legacyDashboardSearcher, unified, features),
+		restore:                      NewRestoreHandler(tracing, unified),
 		dashStore:                    dashStore,
 		QuotaService:                 quotaService,
 		ProvisioningService:          provisioning,
@@ -335,6 +337,7 @@ func (b *DashboardsAPIBuilder) validateCreate(ctx context.Context, a admission.A
 	if !b.isStandalone && !a.IsDryRun() {
 		params := &quota.ScopeParameters{}
 		params.OrgID = id.GetOrgID()
+		params.FolderUID = accessor.GetFolder()
 		internalId, err := id.GetInternalID()
 		if err == nil {
 			params.UserID = internalId
@@ -398,6 +401,17 @@ func (b *DashboardsAPIBuilder) validateUpdate(ctx context.Context, a admission.A
 		if err := b.validateFolderExists(ctx, newAccessor.GetFolder(), nsInfo.OrgID); err != nil {
 			return apierrors.NewNotFound(folders.FolderResourceInfo.GroupResource(), newAccessor.GetFolder())
 		}
+
+		// Validate the quota of the folder the dashboard is moved to
+		if !b.isStandalone {
+			quotaReached, err := b.QuotaService.CheckFolderQuotaReached(ctx, dashboards.QuotaTargetSrv, nsInfo.OrgID, newAccessor.GetFolder())
+			if err != nil && !errors.Is(err, quota.ErrDisabled) {
+				return err
+			}
+			if quotaReached {
+				return apierrors.NewForbidden(dashv1.DashboardResourceInfo.GroupResource(), a.GetName(), dashboards.ErrQuotaReached)
+			}
+		}
 	}
 
 	// Validate refresh interval
@@ -649,7 +663,11 @@ func (b *DashboardsAPIBuilder) GetAPIRoutes(gv schema.GroupVersion) *builder.API
 	}
 
 	defs := b.GetOpenAPIDefinitions()(func(path string) spec.Ref { return spec.Ref{} })
-	return b.search.GetAPIRoutes(defs)
+	routes := b.search.GetAPIRoutes(defs)
+	if b.restore != nil {
+		routes.Namespace = append(routes.Namespace, b.restore.GetAPIRoutes()...)
+	}
+	return routes
 }
 
 func (b *DashboardsAPIBuilder) GetAuthorizer() authorizer.Authorizer {
@@ -0,0 +1,189 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	dashboardv0alpha1 "github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard/v0alpha1"
+	folders "github.com/grafana/grafana/apps/folder/pkg/apis/folder/v1beta1"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/apiserver/builder"
+	"github.com/grafana/grafana/pkg/storage/unified/resource"
+	"github.com/grafana/grafana/pkg/util/errhttp"
+)
+
+// RestoreHandler rolls the folders and dashboards in a namespace back to a previous point in time
+type RestoreHandler struct {
+	log    log.Logger
+	client resource.RestoreClient
+	tracer trace.Tracer
+}
+
+// RestoreResults is returned from the restore route
+type RestoreResults struct {
+	DryRun  bool                       `json:"dryRun"`
+	Results []RestoreCollectionResults `json:"results"`
+
+	// Set when some changes could not be applied. The results list the changes that were
+	// applied, and the ones that failed. Collections after a failed one are not restored
+	Error string `json:"error,omitempty"`
+}
+
+type RestoreCollectionResults struct {
+	Group    string `json:"group"`
+	Resource string `json:"resource"`
+
+	*resource.RestoreResponse
+}
+
+func NewRestoreHandler(tracer trace.Tracer, client resource.RestoreClient) *RestoreHandler {
+	return &RestoreHandler{
+		client: client,
+		log:    log.New("grafana-apiserver.dashboards.restore"),
+		tracer: tracer,
+	}
+}
+
+func (h *RestoreHandler) GetAPIRoutes() []builder.APIRouteHandler {
+	queryParam := func(name, description string, schema *spec.Schema) *spec3.Parameter {
+		return &spec3.Parameter{
+			ParameterProps: spec3.ParameterProps{
+				Name:        name,
+				In:          "query",
+				Description: description,
+				Required:    false,
+				Schema:      schema,
+			},
+		}
+	}
+	return []builder.APIRouteHandler{
+		{
+			Path: "restore",
+			Spec: &spec3.PathProps{
+				Post: &spec3.Operation{
+					OperationProps: spec3.OperationProps{
+						Tags:        []string{"Restore"},
+						Description: "Restore folders and dashboards to how they looked at a previous resource version or time",
+						Parameters: []*spec3.Parameter{
+							{
+								ParameterProps: spec3.ParameterProps{
+									Name:        "namespace",
+									In:          "path",
+									Required:    true,
+									Example:     "default",
+									Description: "workspace",
+									Schema:      spec.StringProperty(),
+								},
+							},
+							queryParam("resourceVersion", "restore to this resource version", spec.Int64Property()),
+							queryParam("time", "restore to this time (RFC3339), used when resourceVersion is not set", spec.DateTimeProperty()),
+							queryParam("folder", "only restore this folder and its subfolders", spec.StringProperty()),
+							queryParam("dryRun", "list the changes without applying them", spec.BooleanProperty()),
+						},
+						Responses: &spec3.Responses{
+							ResponsesProps: spec3.ResponsesProps{
+								StatusCodeResponses: map[int]*spec3.Response{
+									200: {
+										ResponseProps: spec3.ResponseProps{
+											Content: map[string]*spec3.MediaType{
+												"application/json": {},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Handler: h.DoRestore,
+		},
+	}
+}
+
+func (h *RestoreHandler) DoRestore(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "dashboard.restore")
+	defer span.End()
+
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		errhttp.Write(ctx, err, w)
+		return
+	}
+	if !user.GetOrgRole().Includes(identity.RoleAdmin) && !user.GetIsGrafanaAdmin() {
+		errhttp.Write(ctx, apierrors.NewForbidden(dashboardv0alpha1.DashboardResourceInfo.GroupResource(), "", nil), w)
+		return
+	}
+
+	queryParams, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errhttp.Write(ctx, apierrors.NewBadRequest(err.Error()), w)
+		return
+	}
+
+	var rv int64
+	switch {
+	case queryParams.Get("resourceVersion") != "":
+		rv, err = strconv.ParseInt(queryParams.Get("resourceVersion"), 10, 64)
+	case queryParams.Get("time") != "":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, queryParams.Get("time"))
+		rv = resource.ResourceVersionFromTime(t)
+	default:
+		err = apierrors.NewBadRequest("resourceVersion or time is required")
+	}
+	if err != nil || rv < 1 {
+		errhttp.Write(ctx, apierrors.NewBadRequest("invalid resourceVersion or time"), w)
+		return
+	}
+
+	results := RestoreResults{
+		DryRun: queryParams.Has("dryRun") && queryParams.Get("dryRun") != "false",
+	}
+
+	// Folders are restored first so dashboards are written into existing folders
+	for _, k := range []string{folders.RESOURCE, dashboardv0alpha1.DASHBOARD_RESOURCE} {
+		key, err := asResourceKey(user.GetNamespace(), k)
+		if err != nil {
+			errhttp.Write(ctx, err, w)
+			return
+		}
+		rsp, err := resource.Restore(ctx, h.client, resource.RestoreRequest{
+			Key:             key,
+			ResourceVersion: rv,
+			Folder:          queryParams.Get("folder"),
+			DryRun:          results.DryRun,
+		})
+		if rsp == nil {
+			h.log.Warn("error restoring collection", "resource", k, "err", err)
+			errhttp.Write(ctx, err, w)
+			return
+		}
+		results.Results = append(results.Results, RestoreCollectionResults{
+			Group:           key.Group,
+			Resource:        key.Resource,
+			RestoreResponse: rsp,
+		})
+		if err != nil {
+			// Dashboards are not restored into folders that failed to restore
+			h.log.Warn("partial restore", "resource", k, "failed", rsp.Failed, "err", err)
+			results.Error = err.Error()
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if results.Error != "" {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_ = json.NewEncoder(w).Encode(results)
+}
@@ -3,6 +3,7 @@ package dashboard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -94,6 +95,33 @@ func (s *SearchHandler) GetAPIRoutes(defs map[string]common.OpenAPIDefinition) *
 										Schema:      spec.StringProperty(),
 									},
 								},
+								{
+									ParameterProps: spec3.ParameterProps{
+										Name:        "metric",
+										In:          "query",
+										Description: "find dashboards with queries that use this metric name",
+										Required:    false,
+										Schema:      spec.StringProperty(),
+									},
+								},
+								{
+									ParameterProps: spec3.ParameterProps{
+										Name:        "datasource",
+										In:          "query",
+										Description: "find dashboards with queries that use this datasource uid",
+										Required:    false,
+										Schema:      spec.StringProperty(),
+									},
+								},
+								{
+									ParameterProps: spec3.ParameterProps{
+										Name:        "namespaces",
+										In:          "query",
+										Description: "also search these namespaces (orgs), or * for all namespaces, with the permissions of the user in each of them. Requires server admin",
+										Required:    false,
+										Schema:      spec.StringProperty(),
+									},
+								},
 								{
 									ParameterProps: spec3.ParameterProps{
 										Name:        "sort",
@@ -285,6 +313,15 @@ func (s *SearchHandler) DoSearch(w http.ResponseWriter, r *http.Request) {
 		searchRequest.Federated = []*resourcepb.ResourceKey{federate}
 	}
 
+	// Search across multiple namespaces (orgs)
+	if namespaces, ok := queryParams["namespaces"]; ok {
+		if !user.GetIsGrafanaAdmin() {
+			errhttp.Write(ctx, apierrors.NewForbidden(dashboardv0alpha1.DashboardResourceInfo.GroupResource(), "", errors.New("only server admins can search across namespaces")), w)
+			return
+		}
+		searchRequest.Namespaces = namespaces
+	}
+
 	// Add sorting
 	if queryParams.Has("sort") {
 		for _, sort := range queryParams["sort"] {
@@ -351,6 +388,22 @@ func (s *SearchHandler) DoSearch(w http.ResponseWriter, r *http.Request) {
 		}}
 	}
 
+	// Find dashboards with queries that use a metric or datasource
+	if metrics, ok := queryParams["metric"]; ok {
+		searchRequest.Options.Fields = append(searchRequest.Options.Fields, &resourcepb.Requirement{
+			Key:      resource.SEARCH_FIELD_PREFIX + search.DASHBOARD_QUERY_METRICS,
+			Operator: "=",
+			Values:   metrics,
+		})
+	}
+	if datasources, ok := queryParams["datasource"]; ok {
+		searchRequest.Options.Fields = append(searchRequest.Options.Fields, &resourcepb.Requirement{
+			Key:      resource.SEARCH_FIELD_PREFIX + search.DASHBOARD_DS_UIDS,
+			Operator: "=",
+			Values:   datasources,
+		})
+	}
+
 	if len(names) > 0 {
 		if searchRequest.Options.Fields == nil {
 			searchRequest.Options.Fields = []*resourcepb.Requirement{}
@@ -379,6 +432,13 @@ func (s *SearchHandler) DoSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Qualify each hit with its namespace
+	if len(searchRequest.Namespaces) > 0 && result != nil && result.Results != nil {
+		for i, row := range result.Results.Rows {
+			parsedResults.Hits[i].Namespace = row.Key.Namespace
+		}
+	}
+
 	if len(searchRequest.SortBy) == 0 {
 		// default sort by resource descending ( folders then dashboards ) then title
 		sort.Slice(parsedResults.Hits, func(i, j int) bool {
@@ -277,6 +277,35 @@ func TestSearchHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("Metric and datasource filters search the dashboard query fields", func(t *testing.T) {
+		mockClient := &MockClient{}
+
+		searchHandler := SearchHandler{
+			log:      log.New("test", "test"),
+			client:   mockClient,
+			tracer:   tracing.NewNoopTracerService(),
+			features: featuremgmt.WithFeatures(),
+		}
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/search?metric=http_requests_total&datasource=prom-uid", nil)
+		req.Header.Add("content-type", "application/json")
+		req = req.WithContext(identity.WithRequester(req.Context(), &user.SignedInUser{Namespace: "test"}))
+
+		searchHandler.DoSearch(rr, req)
+
+		require.NotNil(t, mockClient.LastSearchRequest)
+		require.Equal(t, []*resourcepb.Requirement{{
+			Key:      "fields.query_metrics",
+			Operator: "=",
+			Values:   []string{"http_requests_total"},
+		}, {
+			Key:      "fields.ds_uids",
+			Operator: "=",
+			Values:   []string{"prom-uid"},
+		}}, mockClient.LastSearchRequest.Options.Fields)
+	})
+
 	t.Run("Sort - default sort by resource", func(t *testing.T) {
 		rows := make([]*resourcepb.ResourceTableRow, len(mockResults))
 		for i, r := range mockResults {
@@ -661,6 +690,9 @@ func (m *MockClient) CountManagedObjects(ctx context.Context, in *resourcepb.Cou
 func (m *MockClient) Watch(ctx context.Context, in *resourcepb.WatchRequest, opts ...grpc.CallOption) (resourcepb.ResourceStore_WatchClient, error) {
 	return nil, nil
 }
+func (m *MockClient) WatchSearch(ctx context.Context, in *resourcepb.WatchSearchRequest, opts ...grpc.CallOption) (resourcepb.SearchSubscriptions_WatchSearchClient, error) {
+	return nil, nil
+}
 func (m *MockClient) Delete(ctx context.Context, in *resourcepb.DeleteRequest, opts ...grpc.CallOption) (*resourcepb.DeleteResponse, error) {
 	return nil, nil
 }
@@ -0,0 +1,136 @@
+package savedsearch
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/kube-openapi/pkg/common"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	searchv0alpha1 "github.com/grafana/grafana/pkg/apis/search/v0alpha1"
+	grafanaregistry "github.com/grafana/grafana/pkg/apiserver/registry/generic"
+	"github.com/grafana/grafana/pkg/services/apiserver/builder"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/storage/unified/resource"
+)
+
+var (
+	_ builder.APIGroupBuilder    = (*APIBuilder)(nil)
+	_ builder.APIGroupValidation = (*APIBuilder)(nil)
+)
+
+var resourceInfo = searchv0alpha1.SavedSearchResourceInfo
+
+// APIBuilder registers the saved searches that can be watched with the WatchSearch RPC of unified storage
+type APIBuilder struct{}
+
+func RegisterAPIService(features featuremgmt.FeatureToggles, apiregistration builder.APIRegistrar) *APIBuilder {
+	if !features.IsEnabledGlobally(featuremgmt.FlagGrafanaAPIServerWithExperimentalAPIs) {
+		return nil // skip registration unless opting into experimental apis
+	}
+	builder := &APIBuilder{}
+	apiregistration.RegisterAPI(builder)
+	return builder
+}
+
+func (b *APIBuilder) GetGroupVersion() schema.GroupVersion {
+	return resourceInfo.GroupVersion()
+}
+
+func addKnownTypes(scheme *runtime.Scheme, gv schema.GroupVersion) {
+	scheme.AddKnownTypes(gv,
+		&searchv0alpha1.SavedSearch{},
+		&searchv0alpha1.SavedSearchList{},
+	)
+}
+
+func (b *APIBuilder) InstallSchema(scheme *runtime.Scheme) error {
+	gv := resourceInfo.GroupVersion()
+	addKnownTypes(scheme, gv)
+
+	// Link this version to the internal representation.
+	// This is used for server-side-apply (PATCH), and avoids the error:
+	//   "no kind is registered for the type"
+	addKnownTypes(scheme, schema.GroupVersion{
+		Group:   gv.Group,
+		Version: runtime.APIVersionInternal,
+	})
+	metav1.AddToGroupVersion(scheme, gv)
+	return scheme.SetVersionPriority(gv)
+}
+
+func (b *APIBuilder) AllowedV0Alpha1Resources() []string {
+	return []string{builder.AllResourcesAllowed}
+}
+
+func (b *APIBuilder) UpdateAPIGroupInfo(apiGroupInfo *genericapiserver.APIGroupInfo, opts builder.APIGroupOptions) error {
+	store, err := grafanaregistry.NewRegistryStore(opts.Scheme, resourceInfo, opts.OptsGetter)
+	if err != nil {
+		return err
+	}
+
+	storage := map[string]rest.Storage{}
+	storage[resourceInfo.StoragePath()] = store
+	apiGroupInfo.VersionedResourcesStorageMap[searchv0alpha1.VERSION] = storage
+	return nil
+}
+
+func (b *APIBuilder) GetOpenAPIDefinitions() common.GetOpenAPIDefinitions {
+	return searchv0alpha1.GetOpenAPIDefinitions
+}
+
+// GetAuthorizer lets viewers read the saved searches of their org and editors manage them.
+// The results of a saved search are still filtered by the permissions of whoever watches it.
+func (b *APIBuilder) GetAuthorizer() authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(
+		func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			if !attr.IsResourceRequest() {
+				return authorizer.DecisionNoOpinion, "", nil
+			}
+
+			user, err := identity.GetRequester(ctx)
+			if err != nil {
+				return authorizer.DecisionDeny, "valid user is required", err
+			}
+
+			if user.GetIsGrafanaAdmin() {
+				return authorizer.DecisionAllow, "", nil
+			}
+			if attr.IsReadOnly() {
+				if user.GetOrgRole().Includes(identity.RoleViewer) {
+					return authorizer.DecisionAllow, "", nil
+				}
+				return authorizer.DecisionDeny, "viewer role is required", nil
+			}
+			if user.GetOrgRole().Includes(identity.RoleEditor) {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionDeny, "editor role is required", nil
+		})
+}
+
+// Validate makes sure the saved search can be converted to a search request
+func (b *APIBuilder) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	switch a.GetOperation() {
+	case admission.Create, admission.Update:
+	default:
+		return nil
+	}
+
+	obj, ok := a.GetObject().(*searchv0alpha1.SavedSearch)
+	if !ok {
+		return fmt.Errorf("obj is not search.SavedSearch")
+	}
+	if _, err := resource.SavedSearchRequest(&obj.Spec, obj.Namespace); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return nil
+}
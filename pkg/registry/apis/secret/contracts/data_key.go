@@ -37,4 +37,6 @@ type DataKeyStorage interface {
 // GlobalDataKeyStorage is an interface for namespace unbounded operations.
 type GlobalDataKeyStorage interface {
 	DisableAllDataKeys(ctx context.Context) error
+	// ReEncryptDataKeys re-encrypts all data keys, across namespaces, with the current provider.
+	ReEncryptDataKeys(ctx context.Context, providers encryption.ProviderMap, currProvider encryption.ProviderID) error
 }
@@ -1,11 +1,13 @@
 package kmsproviders
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption/cipher"
+	"github.com/grafana/grafana/pkg/services/kmsproviders/externalproviders"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -18,6 +20,7 @@ const (
 
 // ProvideOSSKMSProviders provides the ProviderConfig expected by the encryption manager in the OSS wire configuration.
 // It looks for all configured 'secret_key' sections and creates a separate provider for each, each with its own secret key, allowing users to upgrade their secret key without breaking existing secrets.
+// Sections of the external provider kinds (e.g. 'aws_kms' or 'hashicorp_vault') create a provider backed by the corresponding key management system.
 func ProvideOSSKMSProviders(cfg *setting.Cfg, cipher cipher.Cipher) (encryption.ProviderConfig, error) {
 	pCfg := encryption.ProviderConfig{
 		CurrentProvider:    encryption.ProviderID(cfg.SecretsManagement.CurrentEncryptionProvider),
@@ -33,7 +36,18 @@ func ProvideOSSKMSProviders(cfg *setting.Cfg, cipher cipher.Cipher) (encryption.
 			} else {
 				return pCfg, fmt.Errorf("missing secret_key for provider %s", providerName)
 			}
+			continue
 		}
+
+		kind, err := encryption.ProviderID(providerName).Kind()
+		if err != nil || !externalproviders.IsKind(kind) {
+			continue
+		}
+		provider, err := externalproviders.New(context.Background(), externalproviders.Kind(kind), properties)
+		if err != nil {
+			return pCfg, fmt.Errorf("failed to configure provider %s: %w", providerName, err)
+		}
+		pCfg.AvailableProviders[encryption.ProviderID(providerName)] = provider
 	}
 
 	return pCfg, nil
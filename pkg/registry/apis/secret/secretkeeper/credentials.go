@@ -0,0 +1,126 @@
+package secretkeeper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/xkube"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	// CredentialEnvPrefix is the prefix of the environment variables keepers can read their credentials from.
+	CredentialEnvPrefix = "GF_SECRETS_MANAGER_KEEPER_"
+	// CredentialConfigSection is the config section, or parent section, keepers can read their credentials from.
+	CredentialConfigSection = "secrets_manager.keepers"
+	// CredentialDecrypter must be a decrypter of the secure values keepers use as credentials.
+	CredentialDecrypter = secretv1beta1.APIGroup
+	// CredentialAddressKey is the config key, or the suffix of the environment variable, holding the address
+	// an operator credential can be sent to.
+	CredentialAddressKey = "address"
+)
+
+// credentialResolver resolves the credentials third party keepers use to authenticate.
+// Keepers are created by tenants, so only the environment variables and config values the operator
+// set aside for keepers and the secure values that allow it can be used as credentials.
+type credentialResolver struct {
+	cfg                        *setting.Cfg
+	secureValueMetadataStorage contracts.SecureValueMetadataStorage
+	systemKeeper               contracts.Keeper
+}
+
+// Resolve returns the value of the credential a keeper sends to address.
+func (r *credentialResolver) Resolve(ctx context.Context, namespace string, address string, credential secretv1beta1.KeeperCredentialValue) (string, error) {
+	switch {
+	case credential.ValueFromEnv != "":
+		if err := CheckCredentialAddress(r.cfg, credential, address); err != nil {
+			return "", err
+		}
+		value, ok := os.LookupEnv(credential.ValueFromEnv)
+		if !ok {
+			return "", fmt.Errorf("environment variable %q is not set", credential.ValueFromEnv)
+		}
+		return value, nil
+
+	case credential.ValueFromConfig != "":
+		if err := CheckCredentialAddress(r.cfg, credential, address); err != nil {
+			return "", err
+		}
+		section, key, _ := splitConfigValue(credential.ValueFromConfig)
+		value := r.cfg.Raw.Section(section).Key(key).String()
+		if value == "" {
+			return "", fmt.Errorf("config value %q not found", credential.ValueFromConfig)
+		}
+		return value, nil
+
+	case credential.SecureValueName != "":
+		sv, err := r.secureValueMetadataStorage.Read(ctx, xkube.Namespace(namespace), credential.SecureValueName, contracts.ReadOpts{})
+		if err != nil {
+			return "", fmt.Errorf("reading secure value %q: %w", credential.SecureValueName, err)
+		}
+		// credentials stored in another third party keeper could require credentials themselves
+		if sv.Spec.Keeper != nil {
+			return "", fmt.Errorf("secure value %q must be stored in the system keeper", credential.SecureValueName)
+		}
+		if !slices.Contains(sv.Spec.Decrypters, CredentialDecrypter) {
+			return "", fmt.Errorf("secure value %q must have %s as a decrypter to be used as a keeper credential", credential.SecureValueName, CredentialDecrypter)
+		}
+		exposed, err := r.systemKeeper.Expose(ctx, &secretv1beta1.SystemKeeperConfig{}, namespace, sv.Name, sv.Status.Version)
+		if err != nil {
+			return "", fmt.Errorf("exposing secure value %q: %w", credential.SecureValueName, err)
+		}
+		return exposed.DangerouslyExposeAndConsumeValue(), nil
+	}
+
+	return "", fmt.Errorf("credential value is empty")
+}
+
+// CheckCredentialAddress returns an error when credential is an operator credential that can not be sent to address.
+// Tenants choose the address of their keepers, so the operator binds each credential to the address it belongs to:
+// config values to the `address` key of their section and environment variables to the variable suffixed with `_ADDRESS`.
+// Secure values belong to the tenant and can be sent anywhere.
+func CheckCredentialAddress(cfg *setting.Cfg, credential secretv1beta1.KeeperCredentialValue, address string) error {
+	var allowed string
+	switch {
+	case credential.ValueFromEnv != "":
+		if !strings.HasPrefix(credential.ValueFromEnv, CredentialEnvPrefix) {
+			return fmt.Errorf("environment variable %q must start with %s", credential.ValueFromEnv, CredentialEnvPrefix)
+		}
+		allowed = os.Getenv(credential.ValueFromEnv + "_" + strings.ToUpper(CredentialAddressKey))
+
+	case credential.ValueFromConfig != "":
+		section, _, ok := splitConfigValue(credential.ValueFromConfig)
+		if !ok || cfg == nil || cfg.Raw == nil {
+			return fmt.Errorf("config value %q not found", credential.ValueFromConfig)
+		}
+		if section != CredentialConfigSection && !strings.HasPrefix(section, CredentialConfigSection+".") {
+			return fmt.Errorf("config value %q must be in the %s section", credential.ValueFromConfig, CredentialConfigSection)
+		}
+		allowed = cfg.Raw.Section(section).Key(CredentialAddressKey).String()
+
+	default:
+		return nil
+	}
+
+	if allowed == "" {
+		return fmt.Errorf("credential is not bound to an address by the operator")
+	}
+	if strings.TrimSuffix(allowed, "/") != strings.TrimSuffix(address, "/") {
+		return fmt.Errorf("credential can only be sent to %s", allowed)
+	}
+	return nil
+}
+
+// splitConfigValue splits the section and the key of a config value separated by the last dot, e.g. `secrets_manager.keepers.vault.token`
+func splitConfigValue(value string) (section string, key string, ok bool) {
+	idx := strings.LastIndex(value, ".")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return value[:idx], value[idx+1:], true
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"errors"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -55,17 +57,30 @@ func NewKeeperMetrics(reg prometheus.Registerer) *KeeperMetrics {
 	m := newKeeperMetrics()
 
 	if reg != nil {
-		reg.MustRegister(
-			m.StoreDuration,
-			m.UpdateDuration,
-			m.ExposeDuration,
-			m.DeleteDuration,
-		)
+		// Every keeper creates its own metrics but they are shared and labeled by keeper type,
+		// so the collectors registered by the first keeper are reused.
+		m.StoreDuration = register(reg, m.StoreDuration)
+		m.UpdateDuration = register(reg, m.UpdateDuration)
+		m.ExposeDuration = register(reg, m.ExposeDuration)
+		m.DeleteDuration = register(reg, m.DeleteDuration)
 	}
 
 	return m
 }
 
+func register(reg prometheus.Registerer, c *prometheus.HistogramVec) *prometheus.HistogramVec {
+	if err := reg.Register(c); err != nil {
+		are := prometheus.AlreadyRegisteredError{}
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
+
 func NewTestMetrics() *KeeperMetrics {
 	return newKeeperMetrics()
 }
@@ -1,35 +1,57 @@
 package secretkeeper
 
 import (
+	"net/http"
+	"time"
+
 	"go.opentelemetry.io/otel/trace"
 
 	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/sqlkeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/vaultkeeper"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const vaultRequestTimeout = 30 * time.Second
+
 // OSSKeeperService is the OSS implementation of the Service interface.
 type OSSKeeperService struct {
 	systemKeeper *sqlkeeper.SQLKeeper
+	vaultKeeper  *vaultkeeper.VaultKeeper
 }
 
 var _ contracts.KeeperService = (*OSSKeeperService)(nil)
 
 func ProvideService(
+	cfg *setting.Cfg,
 	tracer trace.Tracer,
 	store contracts.EncryptedValueStorage,
+	secureValueMetadataStorage contracts.SecureValueMetadataStorage,
 	encryptionManager contracts.EncryptionManager,
 	reg prometheus.Registerer,
 ) (*OSSKeeperService, error) {
+	// TODO: rename to system keeper or something like that
+	systemKeeper := sqlkeeper.NewSQLKeeper(tracer, encryptionManager, store, reg)
+	credentials := &credentialResolver{
+		cfg:                        cfg,
+		secureValueMetadataStorage: secureValueMetadataStorage,
+		systemKeeper:               systemKeeper,
+	}
+
 	return &OSSKeeperService{
-		// TODO: rename to system keeper or something like that
-		systemKeeper: sqlkeeper.NewSQLKeeper(tracer, encryptionManager, store, reg),
+		systemKeeper: systemKeeper,
+		vaultKeeper:  vaultkeeper.NewVaultKeeper(tracer, credentials, &http.Client{Timeout: vaultRequestTimeout}, reg),
 	}, nil
 }
 
-// Ignore the config, but we could use it to get the keeper type and then return the correct keeper.
-// Instantiation only happens on ProvideService ONCE.
-func (k *OSSKeeperService) KeeperForConfig(secretv1beta1.KeeperConfig) (contracts.Keeper, error) {
+// KeeperForConfig returns the keeper for the type of the config.
+// Keepers are only instantiated once in ProvideService and receive the config on every call.
+func (k *OSSKeeperService) KeeperForConfig(cfg secretv1beta1.KeeperConfig) (contracts.Keeper, error) {
+	switch cfg.(type) {
+	case *secretv1beta1.KeeperHashiCorpConfig:
+		return k.vaultKeeper, nil
+	}
 	return k.systemKeeper, nil
 }
@@ -1,21 +1,30 @@
 package secretkeeper
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
+	"gopkg.in/ini.v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/clock"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption/cipher/service"
 	osskmsproviders "github.com/grafana/grafana/pkg/registry/apis/secret/encryption/kmsproviders"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption/manager"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/sqlkeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/vaultkeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/xkube"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/storage/secret/database"
 	encryptionstorage "github.com/grafana/grafana/pkg/storage/secret/encryption"
+	"github.com/grafana/grafana/pkg/storage/secret/metadata"
 	"github.com/grafana/grafana/pkg/storage/secret/migrator"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
 )
@@ -41,6 +50,131 @@ func Test_OSSKeeperService(t *testing.T) {
 		assert.NotNil(t, keeper)
 		assert.IsType(t, &sqlkeeper.SQLKeeper{}, keeper)
 	})
+
+	t.Run("KeeperForConfig should return the vault keeper for hashicorp configs", func(t *testing.T) {
+		keeper, err := keeperService.KeeperForConfig(&secretv1beta1.KeeperHashiCorpConfig{Address: "http://vault:8200"})
+		require.NoError(t, err)
+
+		assert.IsType(t, &vaultkeeper.VaultKeeper{}, keeper)
+	})
+}
+
+func Test_CredentialResolver(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.Raw = ini.Empty()
+	cfg.Raw.Section("secrets_manager.keepers.vault").Key("address").SetValue("http://vault:8200/")
+	cfg.Raw.Section("secrets_manager.keepers.vault").Key("token").SetValue("config-token")
+	cfg.Raw.Section("secrets_manager.keepers.unbound").Key("token").SetValue("unbound-token")
+	cfg.Raw.Section("database").Key("password").SetValue("db-password")
+	t.Setenv("GF_SECRETS_MANAGER_KEEPER_VAULT_TOKEN", "env-token")
+	t.Setenv("GF_SECRETS_MANAGER_KEEPER_VAULT_TOKEN_ADDRESS", "http://vault:8200")
+	t.Setenv("GF_SECRETS_MANAGER_KEEPER_UNBOUND_TOKEN", "unbound-token")
+	t.Setenv("GF_TEST_SERVER_SECRET", "server-secret")
+	resolver := &credentialResolver{
+		cfg: cfg,
+		secureValueMetadataStorage: fakeSecureValues{specs: map[string]secretv1beta1.SecureValueSpec{
+			"allowed":     {Decrypters: []string{CredentialDecrypter}},
+			"not-allowed": {Decrypters: []string{"some-app"}},
+			"third-party": {Decrypters: []string{CredentialDecrypter}, Keeper: ptr("vault")},
+		}},
+		systemKeeper: fakeSystemKeeper{},
+	}
+
+	t.Run("resolves environment variables", func(t *testing.T) {
+		value, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_VAULT_TOKEN"})
+		require.NoError(t, err)
+		require.Equal(t, "env-token", value)
+
+		_, err = resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_MISSING"})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects environment variables without the keeper prefix", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromEnv: "GF_TEST_SERVER_SECRET"})
+		require.ErrorContains(t, err, CredentialEnvPrefix)
+	})
+
+	t.Run("resolves config values", func(t *testing.T) {
+		value, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromConfig: "secrets_manager.keepers.vault.token"})
+		require.NoError(t, err)
+		require.Equal(t, "config-token", value)
+
+		_, err = resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromConfig: "secrets_manager.keepers.vault.missing"})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects config values outside of the keepers section", func(t *testing.T) {
+		for _, path := range []string{"database.password", "secrets_manager.keepersfoo.token", "secrets_manager.encryption.secret_key.v1.secret_key"} {
+			_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{ValueFromConfig: path})
+			require.ErrorContains(t, err, CredentialConfigSection, path)
+		}
+	})
+
+	t.Run("rejects operator credentials sent to another address", func(t *testing.T) {
+		for _, credential := range []secretv1beta1.KeeperCredentialValue{
+			{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_VAULT_TOKEN"},
+			{ValueFromConfig: "secrets_manager.keepers.vault.token"},
+		} {
+			_, err := resolver.Resolve(context.Background(), "default", "https://attacker.example.com", credential)
+			require.ErrorContains(t, err, "http://vault:8200")
+		}
+	})
+
+	t.Run("rejects operator credentials without an address", func(t *testing.T) {
+		for _, credential := range []secretv1beta1.KeeperCredentialValue{
+			{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_UNBOUND_TOKEN"},
+			{ValueFromConfig: "secrets_manager.keepers.unbound.token"},
+		} {
+			_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", credential)
+			require.ErrorContains(t, err, "not bound to an address")
+		}
+	})
+
+	t.Run("resolves secure values that allow keepers to decrypt them", func(t *testing.T) {
+		value, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{SecureValueName: "allowed"})
+		require.NoError(t, err)
+		require.Equal(t, "exposed-allowed", value)
+	})
+
+	t.Run("rejects secure values that keepers cannot decrypt", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{SecureValueName: "not-allowed"})
+		require.ErrorContains(t, err, CredentialDecrypter)
+	})
+
+	t.Run("rejects secure values stored in third party keepers", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{SecureValueName: "third-party"})
+		require.Error(t, err)
+	})
+
+	t.Run("fails for empty credentials", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), "default", "http://vault:8200", secretv1beta1.KeeperCredentialValue{})
+		require.Error(t, err)
+	})
+}
+
+type fakeSecureValues struct {
+	contracts.SecureValueMetadataStorage
+	specs map[string]secretv1beta1.SecureValueSpec
+}
+
+func (f fakeSecureValues) Read(_ context.Context, _ xkube.Namespace, name string, _ contracts.ReadOpts) (*secretv1beta1.SecureValue, error) {
+	spec, ok := f.specs[name]
+	if !ok {
+		return nil, contracts.ErrSecureValueNotFound
+	}
+	return &secretv1beta1.SecureValue{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}, nil
+}
+
+type fakeSystemKeeper struct {
+	contracts.Keeper
+}
+
+func (fakeSystemKeeper) Expose(_ context.Context, _ secretv1beta1.KeeperConfig, _, name string, _ int64) (secretv1beta1.ExposedSecureValue, error) {
+	return secretv1beta1.NewExposedSecureValue("exposed-" + name), nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
 }
 
 func setupTestService(t *testing.T, cfg *setting.Cfg) (*OSSKeeperService, error) {
@@ -64,8 +198,11 @@ func setupTestService(t *testing.T, cfg *setting.Cfg) (*OSSKeeperService, error)
 	encryptionManager, err := manager.ProvideEncryptionManager(tracer, dataKeyStore, usageStats, enc, ossProviders)
 	require.NoError(t, err)
 
+	secureValueStorage, err := metadata.ProvideSecureValueMetadataStorage(clock.ProvideClock(), database, tracer, nil)
+	require.NoError(t, err)
+
 	// Initialize the keeper service
-	keeperService, err := ProvideService(tracer, encValueStore, encryptionManager, nil)
+	keeperService, err := ProvideService(cfg, tracer, encValueStore, secureValueStorage, encryptionManager, nil)
 
 	return keeperService, err
 }
@@ -0,0 +1,155 @@
+package vaultkeeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	errNotFound         = errors.New("not found")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// vaultClient is a minimal client for the parts of the Vault HTTP API used by the keeper.
+type vaultClient struct {
+	httpClient *http.Client
+	address    string
+	namespace  string
+	token      string
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+func (c *vaultClient) do(ctx context.Context, method, path string, body any, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.address, "/")+"/v1/"+strings.TrimPrefix(path, "/"), reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode == http.StatusForbidden:
+		return errPermissionDenied
+	case resp.StatusCode >= http.StatusBadRequest:
+		vaultErr := vaultErrorResponse{}
+		_ = json.NewDecoder(resp.Body).Decode(&vaultErr)
+		return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type kvReadResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+// kvWrite writes data to a KV v2 secrets engine
+func (c *vaultClient) kvWrite(ctx context.Context, mount, path string, data map[string]string) error {
+	return c.do(ctx, http.MethodPost, mount+"/data/"+path, map[string]any{"data": data}, nil)
+}
+
+// kvRead reads the latest version of a secret from a KV v2 secrets engine
+func (c *vaultClient) kvRead(ctx context.Context, mount, path string) (map[string]string, error) {
+	resp := kvReadResponse{}
+	if err := c.do(ctx, http.MethodGet, mount+"/data/"+path, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Data == nil {
+		// deleted versions are returned without data
+		return nil, errNotFound
+	}
+	return resp.Data.Data, nil
+}
+
+// kvDelete deletes all versions and the metadata of a secret from a KV v2 secrets engine
+func (c *vaultClient) kvDelete(ctx context.Context, mount, path string) error {
+	err := c.do(ctx, http.MethodDelete, mount+"/metadata/"+path, nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+type transitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+}
+
+// transitDataKey generates a new data key, returning it in plaintext and encrypted with the transit key
+func (c *vaultClient) transitDataKey(ctx context.Context, mount, key string) (plaintext string, ciphertext string, err error) {
+	resp := transitResponse{}
+	if err := c.do(ctx, http.MethodPost, mount+"/datakey/plaintext/"+key, map[string]any{}, &resp); err != nil {
+		return "", "", err
+	}
+	return resp.Data.Plaintext, resp.Data.Ciphertext, nil
+}
+
+// transitDecrypt decrypts a ciphertext with the transit key, the plaintext is base64 encoded
+func (c *vaultClient) transitDecrypt(ctx context.Context, mount, key, ciphertext string) (string, error) {
+	resp := transitResponse{}
+	if err := c.do(ctx, http.MethodPost, mount+"/decrypt/"+key, map[string]any{"ciphertext": ciphertext}, &resp); err != nil {
+		return "", err
+	}
+	return resp.Data.Plaintext, nil
+}
+
+type loginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// appRoleLogin exchanges an AppRole role and secret id for a token
+func (c *vaultClient) appRoleLogin(ctx context.Context, mount, roleID, secretID string) (string, time.Duration, error) {
+	resp := loginResponse{}
+	body := map[string]any{"role_id": roleID, "secret_id": secretID}
+	if err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", body, &resp); err != nil {
+		return "", 0, err
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", 0, errors.New("vault approle login did not return a token")
+	}
+	return resp.Auth.ClientToken, time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
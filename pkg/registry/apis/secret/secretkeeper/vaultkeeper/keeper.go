@@ -0,0 +1,397 @@
+package vaultkeeper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
+)
+
+const (
+	defaultKVMountPath      = "secret"
+	defaultTransitMountPath = "transit"
+	defaultAppRoleMountPath = "approle"
+
+	// pathPrefix is prepended to the path of every secure value stored in Vault
+	pathPrefix = "grafana"
+
+	// tokenExpiryMargin is how long before its expiry an AppRole token is renewed
+	tokenExpiryMargin = 30 * time.Second
+
+	valueKey            = "value"
+	encryptedValueKey   = "encrypted_value"
+	encryptedDataKeyKey = "encrypted_key"
+)
+
+// CredentialResolver returns the value of a credential referenced by a keeper config.
+// It fails when the credential can not be sent to address.
+type CredentialResolver interface {
+	Resolve(ctx context.Context, namespace string, address string, credential secretv1beta1.KeeperCredentialValue) (string, error)
+}
+
+// VaultKeeper stores secure values in the KV v2 secrets engine of HashiCorp Vault.
+// When a transit key is configured values are encrypted with a data key generated by
+// the transit engine and only the encrypted data key is stored next to them.
+type VaultKeeper struct {
+	tracer      trace.Tracer
+	credentials CredentialResolver
+	httpClient  *http.Client
+	metrics     *metrics.KeeperMetrics
+	now         func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+type cachedToken struct {
+	token   string
+	expires time.Time
+}
+
+var _ contracts.Keeper = (*VaultKeeper)(nil)
+
+func NewVaultKeeper(
+	tracer trace.Tracer,
+	credentials CredentialResolver,
+	httpClient *http.Client,
+	reg prometheus.Registerer,
+) *VaultKeeper {
+	return &VaultKeeper{
+		tracer:      tracer,
+		credentials: credentials,
+		httpClient:  httpClient,
+		metrics:     metrics.NewKeeperMetrics(reg),
+		now:         time.Now,
+		tokens:      map[string]cachedToken{},
+	}
+}
+
+func (k *VaultKeeper) Store(ctx context.Context, cfg secretv1beta1.KeeperConfig, namespace, name string, version int64, exposedValueOrRef string) (contracts.ExternalID, error) {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Store", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("name", name),
+		attribute.Int64("version", version),
+	))
+	defer span.End()
+
+	start := time.Now()
+	path := secretPath(namespace, name, version)
+	if err := k.write(ctx, cfg, namespace, path, exposedValueOrRef); err != nil {
+		return "", fmt.Errorf("unable to store value in vault: %w", err)
+	}
+
+	k.metrics.StoreDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return contracts.ExternalID(path), nil
+}
+
+func (k *VaultKeeper) Update(ctx context.Context, cfg secretv1beta1.KeeperConfig, namespace, name string, version int64, exposedValueOrRef string) error {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Update", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("name", name),
+		attribute.Int64("version", version),
+	))
+	defer span.End()
+
+	start := time.Now()
+	if err := k.write(ctx, cfg, namespace, secretPath(namespace, name, version), exposedValueOrRef); err != nil {
+		return fmt.Errorf("failed to update value in vault: %w", err)
+	}
+
+	k.metrics.UpdateDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+func (k *VaultKeeper) Expose(ctx context.Context, cfg secretv1beta1.KeeperConfig, namespace, name string, version int64) (secretv1beta1.ExposedSecureValue, error) {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Expose", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("name", name),
+		attribute.Int64("version", version),
+	))
+	defer span.End()
+
+	vaultCfg, err := hashiCorpConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	var exposed string
+	err = k.withClient(ctx, vaultCfg, namespace, func(client *vaultClient) error {
+		data, err := client.kvRead(ctx, kvMountPath(vaultCfg), secretPath(namespace, name, version))
+		if err != nil {
+			return err
+		}
+		exposed, err = k.open(ctx, client, vaultCfg, data)
+		return err
+	})
+	if errors.Is(err, errNotFound) {
+		return "", contracts.ErrSecureValueNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to read value from vault: %w", err)
+	}
+
+	k.metrics.ExposeDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return secretv1beta1.NewExposedSecureValue(exposed), nil
+}
+
+func (k *VaultKeeper) Delete(ctx context.Context, cfg secretv1beta1.KeeperConfig, namespace, name string, version int64) error {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Delete", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("name", name),
+		attribute.Int64("version", version),
+	))
+	defer span.End()
+
+	vaultCfg, err := hashiCorpConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = k.withClient(ctx, vaultCfg, namespace, func(client *vaultClient) error {
+		return client.kvDelete(ctx, kvMountPath(vaultCfg), secretPath(namespace, name, version))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete value from vault: %w", err)
+	}
+
+	k.metrics.DeleteDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+func (k *VaultKeeper) write(ctx context.Context, cfg secretv1beta1.KeeperConfig, namespace, path, value string) error {
+	vaultCfg, err := hashiCorpConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return k.withClient(ctx, vaultCfg, namespace, func(client *vaultClient) error {
+		data, err := k.seal(ctx, client, vaultCfg, value)
+		if err != nil {
+			return err
+		}
+		return client.kvWrite(ctx, kvMountPath(vaultCfg), path, data)
+	})
+}
+
+// seal returns the data written to the KV engine for a value, encrypting it when a transit key is configured
+func (k *VaultKeeper) seal(ctx context.Context, client *vaultClient, cfg *secretv1beta1.KeeperHashiCorpConfig, value string) (map[string]string, error) {
+	if cfg.TransitKey == nil || *cfg.TransitKey == "" {
+		return map[string]string{valueKey: value}, nil
+	}
+
+	plaintextKey, encryptedDataKey, err := client.transitDataKey(ctx, transitMountPath(cfg), *cfg.TransitKey)
+	if err != nil {
+		return nil, fmt.Errorf("generating data key: %w", err)
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(plaintextKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding data key: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	return map[string]string{
+		encryptedValueKey:   base64.StdEncoding.EncodeToString(sealed),
+		encryptedDataKeyKey: encryptedDataKey,
+	}, nil
+}
+
+// open returns the value from the data read from the KV engine
+func (k *VaultKeeper) open(ctx context.Context, client *vaultClient, cfg *secretv1beta1.KeeperHashiCorpConfig, data map[string]string) (string, error) {
+	if value, ok := data[valueKey]; ok {
+		return value, nil
+	}
+
+	sealed, ok := data[encryptedValueKey]
+	if !ok {
+		return "", errors.New("secret does not contain a value")
+	}
+	if cfg.TransitKey == nil || *cfg.TransitKey == "" {
+		return "", errors.New("secret is encrypted but the keeper has no transit key")
+	}
+
+	plaintextKey, err := client.transitDecrypt(ctx, transitMountPath(cfg), *cfg.TransitKey, data[encryptedDataKeyKey])
+	if err != nil {
+		return "", fmt.Errorf("decrypting data key: %w", err)
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(plaintextKey)
+	if err != nil {
+		return "", fmt.Errorf("decoding data key: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("decoding value: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	value, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting value: %w", err)
+	}
+	return string(value), nil
+}
+
+// withClient calls fn with an authenticated client. AppRole tokens can be revoked before they
+// expire, so when Vault denies a request made with a cached token it logs in again and retries once.
+func (k *VaultKeeper) withClient(ctx context.Context, cfg *secretv1beta1.KeeperHashiCorpConfig, namespace string, fn func(*vaultClient) error) error {
+	client, err := k.client(ctx, cfg, namespace)
+	if err != nil {
+		return err
+	}
+
+	err = fn(client)
+	if errors.Is(err, errPermissionDenied) && cfg.AppRole != nil {
+		k.forgetToken(cfg)
+		if client, err = k.client(ctx, cfg, namespace); err != nil {
+			return err
+		}
+		return fn(client)
+	}
+	return err
+}
+
+func (k *VaultKeeper) client(ctx context.Context, cfg *secretv1beta1.KeeperHashiCorpConfig, namespace string) (*vaultClient, error) {
+	client := &vaultClient{httpClient: k.httpClient, address: cfg.Address}
+	if cfg.Namespace != nil {
+		client.namespace = *cfg.Namespace
+	}
+
+	if cfg.AppRole == nil {
+		token, err := k.credentials.Resolve(ctx, namespace, cfg.Address, cfg.Token)
+		if err != nil {
+			return nil, fmt.Errorf("resolving vault token: %w", err)
+		}
+		client.token = token
+		return client, nil
+	}
+
+	secretID, err := k.credentials.Resolve(ctx, namespace, cfg.Address, cfg.AppRole.SecretID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving vault approle secret id: %w", err)
+	}
+
+	cacheKey := tokenCacheKey(cfg, secretID)
+	k.mu.Lock()
+	cached, ok := k.tokens[cacheKey]
+	k.mu.Unlock()
+	if ok && k.now().Before(cached.expires) {
+		client.token = cached.token
+		return client, nil
+	}
+
+	mount := defaultAppRoleMountPath
+	if cfg.AppRole.MountPath != nil && *cfg.AppRole.MountPath != "" {
+		mount = *cfg.AppRole.MountPath
+	}
+	token, ttl, err := client.appRoleLogin(ctx, mount, cfg.AppRole.RoleID, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("logging in to vault with approle: %w", err)
+	}
+
+	// tokens without a lease never expire, but are still renewed from time to time
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	k.mu.Lock()
+	k.tokens[cacheKey] = cachedToken{token: token, expires: k.now().Add(ttl - tokenExpiryMargin)}
+	k.mu.Unlock()
+
+	client.token = token
+	return client, nil
+}
+
+func (k *VaultKeeper) forgetToken(cfg *secretv1beta1.KeeperHashiCorpConfig) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for key := range k.tokens {
+		if strings.HasPrefix(key, cfg.Address+"|") {
+			delete(k.tokens, key)
+		}
+	}
+}
+
+func tokenCacheKey(cfg *secretv1beta1.KeeperHashiCorpConfig, secretID string) string {
+	hash := sha256.New()
+	if cfg.Namespace != nil {
+		hash.Write([]byte(*cfg.Namespace))
+	}
+	if cfg.AppRole.MountPath != nil {
+		hash.Write([]byte(*cfg.AppRole.MountPath))
+	}
+	hash.Write([]byte(cfg.AppRole.RoleID))
+	hash.Write([]byte(secretID))
+	return cfg.Address + "|" + hex.EncodeToString(hash.Sum(nil))
+}
+
+func hashiCorpConfig(cfg secretv1beta1.KeeperConfig) (*secretv1beta1.KeeperHashiCorpConfig, error) {
+	vaultCfg, ok := cfg.(*secretv1beta1.KeeperHashiCorpConfig)
+	if !ok || vaultCfg == nil {
+		return nil, fmt.Errorf("expected a hashicorp keeper config but got %T", cfg)
+	}
+	return vaultCfg, nil
+}
+
+func secretPath(namespace, name string, version int64) string {
+	return pathPrefix + "/" + namespace + "/" + name + "/" + strconv.FormatInt(version, 10)
+}
+
+func kvMountPath(cfg *secretv1beta1.KeeperHashiCorpConfig) string {
+	if cfg.MountPath != nil && *cfg.MountPath != "" {
+		return *cfg.MountPath
+	}
+	return defaultKVMountPath
+}
+
+func transitMountPath(cfg *secretv1beta1.KeeperHashiCorpConfig) string {
+	if cfg.TransitMountPath != nil && *cfg.TransitMountPath != "" {
+		return *cfg.TransitMountPath
+	}
+	return defaultTransitMountPath
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher from data key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
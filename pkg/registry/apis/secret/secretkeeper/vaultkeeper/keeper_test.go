@@ -0,0 +1,219 @@
+package vaultkeeper
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+)
+
+// fakeVault implements the parts of the Vault HTTP API used by the keeper
+type fakeVault struct {
+	mu       sync.Mutex
+	kv       map[string]map[string]string
+	tokens   map[string]bool
+	roleID   string
+	secretID string
+	logins   int
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	t.Helper()
+	v := &fakeVault{
+		kv:       map[string]map[string]string{},
+		tokens:   map[string]bool{"root-token": true},
+		roleID:   "role",
+		secretID: "secret",
+	}
+	server := httptest.NewServer(v)
+	t.Cleanup(server.Close)
+	return v, server
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	body := map[string]any{}
+	if r.Body != nil && r.Method != http.MethodGet {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	if path == "auth/approle/login" {
+		if body["role_id"] != v.roleID || body["secret_id"] != v.secretID {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v.logins++
+		token := "approle-token-" + strings.Repeat("x", v.logins)
+		v.tokens[token] = true
+		writeJSON(w, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": 3600}})
+		return
+	}
+
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(path, "secret/data/"):
+		key := strings.TrimPrefix(path, "secret/data/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := v.kv[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJSON(w, map[string]any{"data": map[string]any{"data": data}})
+		case http.MethodPost:
+			data := map[string]string{}
+			for k, val := range body["data"].(map[string]any) {
+				data[k] = val.(string)
+			}
+			v.kv[key] = data
+			writeJSON(w, map[string]any{"data": map[string]any{"version": 1}})
+		}
+	case strings.HasPrefix(path, "secret/metadata/") && r.Method == http.MethodDelete:
+		delete(v.kv, strings.TrimPrefix(path, "secret/metadata/"))
+		w.WriteHeader(http.StatusNoContent)
+	case path == "transit/datakey/plaintext/grafana":
+		key := make([]byte, 32)
+		_, _ = rand.Read(key)
+		encoded := base64.StdEncoding.EncodeToString(key)
+		writeJSON(w, map[string]any{"data": map[string]any{"plaintext": encoded, "ciphertext": "vault:v1:" + encoded}})
+	case path == "transit/decrypt/grafana":
+		ciphertext, _ := body["ciphertext"].(string)
+		writeJSON(w, map[string]any{"data": map[string]any{"plaintext": strings.TrimPrefix(ciphertext, "vault:v1:")}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type fakeCredentials map[string]string
+
+func (f fakeCredentials) Resolve(_ context.Context, _ string, _ string, credential secretv1beta1.KeeperCredentialValue) (string, error) {
+	return f[credential.ValueFromEnv], nil
+}
+
+func newTestKeeper() *VaultKeeper {
+	credentials := fakeCredentials{"VAULT_TOKEN": "root-token", "VAULT_SECRET_ID": "secret"}
+	return NewVaultKeeper(noop.NewTracerProvider().Tracer("test"), credentials, http.DefaultClient, nil)
+}
+
+func TestVaultKeeper(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores, exposes and deletes values with a token", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		keeper := newTestKeeper()
+		cfg := &secretv1beta1.KeeperHashiCorpConfig{
+			Address: server.URL,
+			Token:   secretv1beta1.KeeperCredentialValue{ValueFromEnv: "VAULT_TOKEN"},
+		}
+
+		externalID, err := keeper.Store(ctx, cfg, "default", "sv", 1, "s3cr3t")
+		require.NoError(t, err)
+		require.Equal(t, contracts.ExternalID("grafana/default/sv/1"), externalID)
+		require.Equal(t, map[string]string{"value": "s3cr3t"}, vault.kv["grafana/default/sv/1"])
+
+		exposed, err := keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+
+		require.NoError(t, keeper.Update(ctx, cfg, "default", "sv", 1, "updated"))
+		exposed, err = keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.NoError(t, err)
+		require.Equal(t, "updated", exposed.DangerouslyExposeAndConsumeValue())
+
+		require.NoError(t, keeper.Delete(ctx, cfg, "default", "sv", 1))
+		_, err = keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.ErrorIs(t, err, contracts.ErrSecureValueNotFound)
+	})
+
+	t.Run("encrypts values with a transit data key", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		keeper := newTestKeeper()
+		transitKey := "grafana"
+		cfg := &secretv1beta1.KeeperHashiCorpConfig{
+			Address:    server.URL,
+			Token:      secretv1beta1.KeeperCredentialValue{ValueFromEnv: "VAULT_TOKEN"},
+			TransitKey: &transitKey,
+		}
+
+		_, err := keeper.Store(ctx, cfg, "default", "sv", 1, "s3cr3t")
+		require.NoError(t, err)
+
+		stored := vault.kv["grafana/default/sv/1"]
+		require.NotContains(t, stored, "value")
+		require.NotEmpty(t, stored["encrypted_value"])
+		require.True(t, strings.HasPrefix(stored["encrypted_key"], "vault:v1:"))
+
+		exposed, err := keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+	})
+
+	t.Run("logs in with approle and reuses the token until it is revoked", func(t *testing.T) {
+		vault, server := newFakeVault(t)
+		keeper := newTestKeeper()
+		cfg := &secretv1beta1.KeeperHashiCorpConfig{
+			Address: server.URL,
+			AppRole: &secretv1beta1.KeeperHashiCorpAppRoleConfig{
+				RoleID:   "role",
+				SecretID: secretv1beta1.KeeperCredentialValue{ValueFromEnv: "VAULT_SECRET_ID"},
+			},
+		}
+
+		_, err := keeper.Store(ctx, cfg, "default", "sv", 1, "s3cr3t")
+		require.NoError(t, err)
+		_, err = keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, vault.logins)
+
+		vault.mu.Lock()
+		vault.tokens = map[string]bool{}
+		vault.mu.Unlock()
+
+		exposed, err := keeper.Expose(ctx, cfg, "default", "sv", 1)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+		require.Equal(t, 2, vault.logins)
+	})
+
+	t.Run("fails when vault denies the token", func(t *testing.T) {
+		_, server := newFakeVault(t)
+		keeper := NewVaultKeeper(noop.NewTracerProvider().Tracer("test"), fakeCredentials{"VAULT_TOKEN": "wrong"}, http.DefaultClient, nil)
+		cfg := &secretv1beta1.KeeperHashiCorpConfig{
+			Address: server.URL,
+			Token:   secretv1beta1.KeeperCredentialValue{ValueFromEnv: "VAULT_TOKEN"},
+		}
+
+		_, err := keeper.Store(ctx, cfg, "default", "sv", 1, "s3cr3t")
+		require.ErrorIs(t, err, errPermissionDenied)
+	})
+
+	t.Run("rejects other keeper configs", func(t *testing.T) {
+		keeper := newTestKeeper()
+		_, err := keeper.Expose(ctx, &secretv1beta1.SystemKeeperConfig{}, "default", "sv", 1)
+		require.Error(t, err)
+	})
+}
@@ -9,14 +9,18 @@ import (
 
 	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
-type keeperValidator struct{}
+type keeperValidator struct {
+	cfg *setting.Cfg
+}
 
 var _ contracts.KeeperValidator = &keeperValidator{}
 
-func ProvideKeeperValidator() contracts.KeeperValidator {
-	return &keeperValidator{}
+func ProvideKeeperValidator(cfg *setting.Cfg) contracts.KeeperValidator {
+	return &keeperValidator{cfg: cfg}
 }
 
 func (v *keeperValidator) Validate(keeper *secretv1beta1.Keeper, oldKeeper *secretv1beta1.Keeper, operation admission.Operation) field.ErrorList {
@@ -95,7 +99,19 @@ func (v *keeperValidator) Validate(keeper *secretv1beta1.Keeper, oldKeeper *secr
 			errs = append(errs, field.Required(field.NewPath("spec", "hashiCorpVault", "address"), "an `address` is required"))
 		}
 
-		if err := validateCredentialValue(field.NewPath("spec", "hashiCorpVault", "token"), keeper.Spec.HashiCorpVault.Token); err != nil {
+		if appRole := keeper.Spec.HashiCorpVault.AppRole; appRole != nil {
+			if keeper.Spec.HashiCorpVault.Token != (secretv1beta1.KeeperCredentialValue{}) {
+				errs = append(errs, field.Invalid(field.NewPath("spec", "hashiCorpVault", "token"), "token", "only one of `token` or `appRole` can be present at a time"))
+			}
+
+			if appRole.RoleID == "" {
+				errs = append(errs, field.Required(field.NewPath("spec", "hashiCorpVault", "appRole", "roleID"), "a `roleID` is required"))
+			}
+
+			if err := v.validateCredentialAddress(field.NewPath("spec", "hashiCorpVault", "appRole", "secretID"), appRole.SecretID, keeper.Spec.HashiCorpVault.Address); err != nil {
+				errs = append(errs, err)
+			}
+		} else if err := v.validateCredentialAddress(field.NewPath("spec", "hashiCorpVault", "token"), keeper.Spec.HashiCorpVault.Token, keeper.Spec.HashiCorpVault.Address); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -134,6 +150,21 @@ func validateKeepers(keeper *secretv1beta1.Keeper) *field.Error {
 	return nil
 }
 
+// validateCredentialAddress makes sure the credential is valid and that the operator allows sending it to address.
+func (v *keeperValidator) validateCredentialAddress(path *field.Path, credentials secretv1beta1.KeeperCredentialValue, address string) *field.Error {
+	if err := validateCredentialValue(path, credentials); err != nil {
+		return err
+	}
+	// a missing address is reported on its own
+	if address == "" {
+		return nil
+	}
+	if err := secretkeeper.CheckCredentialAddress(v.cfg, credentials, address); err != nil {
+		return field.Forbidden(path, err.Error())
+	}
+	return nil
+}
+
 func validateCredentialValue(path *field.Path, credentials secretv1beta1.KeeperCredentialValue) *field.Error {
 	availableOptions := map[string]bool{
 		"secureValueName": credentials.SecureValueName != "",
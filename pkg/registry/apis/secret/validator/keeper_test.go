@@ -5,16 +5,23 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/utils/ptr"
 
 	secretv1beta1 "github.com/grafana/grafana/apps/secret/pkg/apis/secret/v1beta1"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 func TestValidateKeeper(t *testing.T) {
 	objectMeta := metav1.ObjectMeta{Name: "test", Namespace: "test"}
-	validator := ProvideKeeperValidator()
+	cfg := setting.NewCfg()
+	cfg.Raw = ini.Empty()
+	cfg.Raw.Section("secrets_manager.keepers.vault").Key("address").SetValue("http://address")
+	cfg.Raw.Section("secrets_manager.keepers.vault").Key("token").SetValue("token")
+	t.Setenv("GF_SECRETS_MANAGER_KEEPER_VAULT_SECRET_ID_ADDRESS", "http://address")
+	validator := ProvideKeeperValidator(cfg)
 
 	t.Run("when creating a new keeper", func(t *testing.T) {
 		t.Run("the `description` must be present", func(t *testing.T) {
@@ -141,7 +148,7 @@ func TestValidateKeeper(t *testing.T) {
 					TenantID:     "tenant-id",
 					ClientID:     "client-id",
 					ClientSecret: secretv1beta1.KeeperCredentialValue{
-						ValueFromConfig: "config.path.value",
+						ValueFromConfig: "secrets_manager.keepers.vault.token",
 					},
 				},
 			},
@@ -238,7 +245,7 @@ func TestValidateKeeper(t *testing.T) {
 				HashiCorpVault: &secretv1beta1.KeeperHashiCorpConfig{
 					Address: "http://address",
 					Token: secretv1beta1.KeeperCredentialValue{
-						ValueFromConfig: "config.path.value",
+						ValueFromConfig: "secrets_manager.keepers.vault.token",
 					},
 				},
 			},
@@ -276,6 +283,54 @@ func TestValidateKeeper(t *testing.T) {
 				require.Equal(t, "spec.hashiCorpVault.token", errs[0].Field)
 			})
 		})
+
+		t.Run("operator credentials must be bound to the `address`", func(t *testing.T) {
+			keeper := validKeeperHashiCorp.DeepCopy()
+			keeper.Spec.HashiCorpVault.Address = "http://attacker"
+
+			errs := validator.Validate(keeper, nil, admission.Create)
+			require.Len(t, errs, 1)
+			require.Equal(t, "spec.hashiCorpVault.token", errs[0].Field)
+
+			keeper.Spec.HashiCorpVault.Token = secretv1beta1.KeeperCredentialValue{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_UNBOUND"}
+			errs = validator.Validate(keeper, nil, admission.Create)
+			require.Len(t, errs, 1)
+			require.Equal(t, "spec.hashiCorpVault.token", errs[0].Field)
+
+			keeper.Spec.HashiCorpVault.Token = secretv1beta1.KeeperCredentialValue{SecureValueName: "tenant-token"}
+			require.Empty(t, validator.Validate(keeper, nil, admission.Create))
+		})
+
+		t.Run("`appRole` can be used instead of `token`", func(t *testing.T) {
+			keeper := validKeeperHashiCorp.DeepCopy()
+			keeper.Spec.HashiCorpVault.Token = secretv1beta1.KeeperCredentialValue{}
+			keeper.Spec.HashiCorpVault.AppRole = &secretv1beta1.KeeperHashiCorpAppRoleConfig{
+				RoleID:   "role-id",
+				SecretID: secretv1beta1.KeeperCredentialValue{ValueFromEnv: "GF_SECRETS_MANAGER_KEEPER_VAULT_SECRET_ID"},
+			}
+
+			errs := validator.Validate(keeper, nil, admission.Create)
+			require.Empty(t, errs)
+
+			t.Run("but not together with it", func(t *testing.T) {
+				keeper := keeper.DeepCopy()
+				keeper.Spec.HashiCorpVault.Token = secretv1beta1.KeeperCredentialValue{ValueFromEnv: "VAULT_TOKEN"}
+
+				errs := validator.Validate(keeper, nil, admission.Create)
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.hashiCorpVault.token", errs[0].Field)
+			})
+
+			t.Run("`roleID` and `secretID` must be present", func(t *testing.T) {
+				keeper := keeper.DeepCopy()
+				keeper.Spec.HashiCorpVault.AppRole = &secretv1beta1.KeeperHashiCorpAppRoleConfig{}
+
+				errs := validator.Validate(keeper, nil, admission.Create)
+				require.Len(t, errs, 2)
+				require.Equal(t, "spec.hashiCorpVault.appRole.roleID", errs[0].Field)
+				require.Equal(t, "spec.hashiCorpVault.appRole.secretID", errs[1].Field)
+			})
+		})
 	})
 
 	t.Run("invalid name", func(t *testing.T) {
@@ -288,7 +343,7 @@ func TestValidateKeeper(t *testing.T) {
 				HashiCorpVault: &secretv1beta1.KeeperHashiCorpConfig{
 					Address: "http://address",
 					Token: secretv1beta1.KeeperCredentialValue{
-						ValueFromConfig: "config.path.value",
+						ValueFromConfig: "secrets_manager.keepers.vault.token",
 					},
 				},
 			},
@@ -320,7 +375,7 @@ func TestValidateKeeper(t *testing.T) {
 				HashiCorpVault: &secretv1beta1.KeeperHashiCorpConfig{
 					Address: "http://address",
 					Token: secretv1beta1.KeeperCredentialValue{
-						ValueFromConfig: "config.path.value",
+						ValueFromConfig: "secrets_manager.keepers.vault.token",
 					},
 				},
 			},
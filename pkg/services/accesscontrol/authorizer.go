@@ -149,6 +149,11 @@ func (c *LegacyAccessClient) Compile(ctx context.Context, id claims.AuthInfo, re
 		return nil, claims.NoopZookie{}, errors.New("expected identity.Requester for legacy access control")
 	}
 
+	// the permissions of the identity only apply to its own namespace
+	if req.Namespace != "" && ident.GetNamespace() != "" && !claims.NamespaceMatches(ident.GetNamespace(), req.Namespace) {
+		return nil, claims.NoopZookie{}, claims.ErrNamespaceMismatch
+	}
+
 	opts, ok := c.opts[req.Resource]
 	if !ok {
 		return nil, claims.NoopZookie{}, fmt.Errorf("unsupported resource: %s", req.Resource)
@@ -136,6 +136,36 @@ func TestLegacyAccessClient_Check(t *testing.T) {
 	})
 }
 
+func TestLegacyAccessClient_Compile(t *testing.T) {
+	ac := acimpl.ProvideAccessControl(featuremgmt.WithFeatures())
+	a := accesscontrol.NewLegacyAccessClient(ac, accesscontrol.ResourceAuthorizerOptions{
+		Resource: "dashboards",
+		Attr:     "uid",
+	})
+	ident := newIdent(accesscontrol.Permission{Action: "dashboards:read", Scope: "dashboards:uid:1"})
+	ident.Namespace = "default"
+
+	t.Run("should filter items with the permissions of the identity", func(t *testing.T) {
+		check, _, err := a.Compile(context.Background(), ident, authlib.ListRequest{
+			Namespace: "default",
+			Resource:  "dashboards",
+			Verb:      "list",
+		})
+		assert.NoError(t, err)
+		assert.True(t, check("1", ""))
+		assert.False(t, check("2", ""))
+	})
+
+	t.Run("should reject other namespaces", func(t *testing.T) {
+		_, _, err := a.Compile(context.Background(), ident, authlib.ListRequest{
+			Namespace: "org-2",
+			Resource:  "dashboards",
+			Verb:      "list",
+		})
+		assert.ErrorIs(t, err, authlib.ErrNamespaceMismatch)
+	})
+}
+
 func newIdent(permissions ...accesscontrol.Permission) *identity.StaticRequester {
 	pmap := map[string][]string{}
 	for _, p := range permissions {
@@ -21,6 +21,8 @@ type Repository interface {
 	Find(ctx context.Context, query *ItemQuery) ([]*ItemDTO, error)
 	Delete(ctx context.Context, params *DeleteParams) error
 	FindTags(ctx context.Context, query *TagsQuery) (FindTagsResult, error)
+	// FindTagFacets counts the tags of all the annotations matching the query, the limit of the query is ignored
+	FindTagFacets(ctx context.Context, query *ItemQuery) ([]*TagsDTO, error)
 }
 
 // Cleaner is responsible for cleaning up old annotations
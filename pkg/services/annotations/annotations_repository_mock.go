@@ -61,6 +61,36 @@ func (_m *FakeAnnotationsRepo) Find(ctx context.Context, query *ItemQuery) ([]*I
 	return r0, r1
 }
 
+// FindTagFacets provides a mock function with given fields: ctx, query
+func (_m *FakeAnnotationsRepo) FindTagFacets(ctx context.Context, query *ItemQuery) ([]*TagsDTO, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTagFacets")
+	}
+
+	var r0 []*TagsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ItemQuery) ([]*TagsDTO, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ItemQuery) []*TagsDTO); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TagsDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ItemQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindTags provides a mock function with given fields: ctx, query
 func (_m *FakeAnnotationsRepo) FindTags(ctx context.Context, query *TagsQuery) (FindTagsResult, error) {
 	ret := _m.Called(ctx, query)
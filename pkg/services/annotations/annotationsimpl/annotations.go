@@ -131,3 +131,37 @@ func (r *RepositoryImpl) Delete(ctx context.Context, params *annotations.DeleteP
 func (r *RepositoryImpl) FindTags(ctx context.Context, query *annotations.TagsQuery) (annotations.FindTagsResult, error) {
 	return r.reader.GetTags(ctx, *query)
 }
+
+func (r *RepositoryImpl) FindTagFacets(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.TagsDTO, error) {
+	counts := make(map[string]int64)
+
+	// the dashboards the user can access are paged, the tags are counted for each page
+	q := *query
+	for q.Page = 1; ; q.Page++ {
+		resources, err := r.authZ.Authorize(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if q.Page > 1 {
+			if len(resources.Dashboards) == 0 {
+				break
+			}
+			// the organization annotations were counted with the first page
+			resources.CanAccessOrgAnnotations = false
+		}
+
+		res, err := r.reader.GetTagFacets(ctx, q, resources)
+		if err != nil {
+			return nil, err
+		}
+		for tag, count := range res {
+			counts[tag] += count
+		}
+
+		if !resources.CanAccessDashAnnotations || len(resources.Dashboards) == 0 {
+			break
+		}
+	}
+
+	return annotations.TagFacets(counts), nil
+}
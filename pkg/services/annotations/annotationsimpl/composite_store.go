@@ -51,7 +51,7 @@ func (c *CompositeStore) Get(ctx context.Context, query annotations.ItemQuery, a
 	for items := range itemCh {
 		res = append(res, items...)
 	}
-	sort.Sort(annotations.SortedItems(res))
+	annotations.SortItems(res, query.Sort)
 
 	return res, nil
 }
@@ -81,6 +81,32 @@ func (c *CompositeStore) GetTags(ctx context.Context, query annotations.TagsQuer
 	return annotations.FindTagsResult{Tags: res}, nil
 }
 
+// GetTagFacets counts the tags of all stores, and combines the results.
+func (c *CompositeStore) GetTagFacets(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (map[string]int64, error) {
+	resCh := make(chan map[string]int64, len(c.readers))
+
+	err := concurrency.ForEachJob(ctx, len(c.readers), len(c.readers), func(ctx context.Context, i int) (err error) {
+		defer handleJobPanic(c.logger, c.readers[i].Type(), &err)
+
+		res, err := c.readers[i].GetTagFacets(ctx, query, accessResources)
+		resCh <- res
+		return err
+	})
+	if err != nil {
+		return map[string]int64{}, err
+	}
+
+	close(resCh)
+	res := make(map[string]int64)
+	for counts := range resCh {
+		for tag, count := range counts {
+			res[tag] += count
+		}
+	}
+
+	return res, nil
+}
+
 // handleJobPanic is a helper function that recovers from a panic in a concurrent job.,
 // It will log the error and set the job error if it is not nil.
 func handleJobPanic(logger log.Logger, storeType string, jobErr *error) {
@@ -98,6 +98,30 @@ func TestCompositeStore(t *testing.T) {
 		require.Equal(t, expected, items)
 	})
 
+	t.Run("should sort combined results from Get by query sort", func(t *testing.T) {
+		r1 := newFakeReader(withItems([]*annotations.ItemDTO{
+			{Time: 3, TimeEnd: 3},
+			{Time: 1, TimeEnd: 4},
+		}))
+		r2 := newFakeReader(withItems([]*annotations.ItemDTO{
+			{Time: 2, TimeEnd: 2},
+		}))
+
+		store := &CompositeStore{
+			log.NewNopLogger(),
+			[]readStore{r1, r2},
+		}
+
+		expected := []*annotations.ItemDTO{
+			{Time: 1, TimeEnd: 4},
+			{Time: 2, TimeEnd: 2},
+			{Time: 3, TimeEnd: 3},
+		}
+
+		items, _ := store.Get(context.Background(), annotations.ItemQuery{Sort: annotations.SortByTimeAsc}, nil)
+		require.Equal(t, expected, items)
+	})
+
 	t.Run("should combine and sort results from GetTags", func(t *testing.T) {
 		tags1 := []*annotations.TagsDTO{
 			{Tag: "key1:val1"},
@@ -127,6 +151,20 @@ func TestCompositeStore(t *testing.T) {
 		require.Equal(t, expected, res.Tags)
 	})
 
+	t.Run("should sum the tag facets of all stores", func(t *testing.T) {
+		r1 := newFakeReader(withTagFacets(map[string]int64{"deploy": 2, "env:prod": 1}))
+		r2 := newFakeReader(withTagFacets(map[string]int64{"deploy": 3}))
+
+		store := &CompositeStore{
+			log.NewNopLogger(),
+			[]readStore{r1, r2},
+		}
+
+		res, err := store.GetTagFacets(context.Background(), annotations.ItemQuery{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"deploy": 5, "env:prod": 1}, res)
+	})
+
 	// Check if reader is not modifying query since it might cause a race condition in case of composite store
 	t.Run("should not modify query", func(t *testing.T) {
 		getFn1 := func(ctx context.Context, query annotations.ItemQuery, resources *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error) {
@@ -155,6 +193,7 @@ func TestCompositeStore(t *testing.T) {
 type fakeReader struct {
 	items    []*annotations.ItemDTO
 	tagRes   annotations.FindTagsResult
+	facets   map[string]int64
 	getFn    func(context.Context, annotations.ItemQuery, *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error)
 	getTagFn func(context.Context, annotations.TagsQuery) (annotations.FindTagsResult, error)
 	wait     time.Duration
@@ -199,6 +238,14 @@ func (f *fakeReader) GetTags(ctx context.Context, query annotations.TagsQuery) (
 	return f.tagRes, nil
 }
 
+func (f *fakeReader) GetTagFacets(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (map[string]int64, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.facets, nil
+}
+
 func withWait(wait time.Duration) func(*fakeReader) {
 	return func(f *fakeReader) {
 		f.wait = wait
@@ -223,6 +270,12 @@ func withTags(tags []*annotations.TagsDTO) func(*fakeReader) {
 	}
 }
 
+func withTagFacets(facets map[string]int64) func(*fakeReader) {
+	return func(f *fakeReader) {
+		f.facets = facets
+	}
+}
+
 func withGetFn(fn func(context.Context, annotations.ItemQuery, *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error)) func(*fakeReader) {
 	return func(f *fakeReader) {
 		f.getFn = fn
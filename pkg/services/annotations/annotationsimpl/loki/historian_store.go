@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sort"
+	"strings"
 	"time"
 
 	"github.com/grafana/alerting/notify/historian/lokiclient"
@@ -82,7 +82,8 @@ func (r *LokiHistorianStore) Type() string {
 }
 
 func (r *LokiHistorianStore) Get(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error) {
-	if query.Type == "annotation" {
+	// state history annotations are never regions
+	if query.Type == "annotation" || query.RegionsOnly {
 		return make([]*annotations.ItemDTO, 0), nil
 	}
 
@@ -138,14 +139,14 @@ func (r *LokiHistorianStore) Get(ctx context.Context, query annotations.ItemQuer
 			return make([]*annotations.ItemDTO, 0), ErrLokiStoreInternal.Errorf("failed to query loki: %w", err)
 		}
 		for _, stream := range res.Data.Result {
-			items = append(items, r.annotationsFromStream(stream, *accessResources)...)
+			items = append(items, r.annotationsFromStream(stream, *accessResources, query.Text)...)
 		}
 	}
-	sort.Sort(annotations.SortedItems(items))
+	annotations.SortItems(items, query.Sort)
 	return items, err
 }
 
-func (r *LokiHistorianStore) annotationsFromStream(stream lokiclient.Stream, ac accesscontrol.AccessResources) []*annotations.ItemDTO {
+func (r *LokiHistorianStore) annotationsFromStream(stream lokiclient.Stream, ac accesscontrol.AccessResources, text string) []*annotations.ItemDTO {
 	items := make([]*annotations.ItemDTO, 0, len(stream.Values))
 	for _, sample := range stream.Values {
 		entry := historian.LokiEntry{}
@@ -180,6 +181,10 @@ func (r *LokiHistorianStore) annotationsFromStream(stream lokiclient.Stream, ac
 			transition.State,
 		)
 
+		if text != "" && !strings.Contains(strings.ToLower(annotationText), strings.ToLower(text)) {
+			continue
+		}
+
 		items = append(items, &annotations.ItemDTO{
 			AlertID:      entry.RuleID,
 			DashboardID:  ac.Dashboards[entry.DashboardUID], // nolint: staticcheck
@@ -200,6 +205,11 @@ func (r *LokiHistorianStore) GetTags(ctx context.Context, query annotations.Tags
 	return annotations.FindTagsResult{Tags: []*annotations.TagsDTO{}}, nil
 }
 
+// GetTagFacets returns no tags, state history annotations are not tagged
+func (r *LokiHistorianStore) GetTagFacets(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
 // util
 
 func hasAccess(entry historian.LokiEntry, resources accesscontrol.AccessResources) bool {
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -237,6 +238,29 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 			require.Empty(t, res)
 		})
 
+		t.Run("should return empty results when only regions are requested", func(t *testing.T) {
+			fakeLokiClient.rangeQueryRes = []lokiclient.Stream{
+				historian.StatesToStream(ruleMetaFromRule(t, dashboardRules[dashboard1.UID][0]), transitions, map[string]string{}, log.NewNopLogger()),
+			}
+
+			query := annotations.ItemQuery{
+				OrgID:       1,
+				RegionsOnly: true,
+			}
+			res, err := store.Get(
+				context.Background(),
+				query,
+				&annotation_ac.AccessResources{
+					Dashboards: map[string]int64{
+						dashboard1.UID: dashboard1.ID,
+					},
+					CanAccessDashAnnotations: true,
+				},
+			)
+			require.NoError(t, err)
+			require.Empty(t, res)
+		})
+
 		t.Run("should return empty results when history is outside time range", func(t *testing.T) {
 			fakeLokiClient.rangeQueryRes = []lokiclient.Stream{
 				historian.StatesToStream(ruleMetaFromRule(t, dashboardRules[dashboard1.UID][0]), transitions, map[string]string{}, log.NewNopLogger()),
@@ -362,14 +386,14 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 		store := createTestLokiStore(t, sql, fakeLokiClient)
 
 		t.Run("should return empty list when no streams", func(t *testing.T) {
-			items := store.annotationsFromStream(lokiclient.Stream{}, annotation_ac.AccessResources{})
+			items := store.annotationsFromStream(lokiclient.Stream{}, annotation_ac.AccessResources{}, "")
 			require.Empty(t, items)
 		})
 
 		t.Run("should return empty list when no entries", func(t *testing.T) {
 			items := store.annotationsFromStream(lokiclient.Stream{
 				Values: []lokiclient.Sample{},
-			}, annotation_ac.AccessResources{})
+			}, annotation_ac.AccessResources{}, "")
 			require.Empty(t, items)
 		})
 
@@ -386,7 +410,7 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 					dashboard1.UID: dashboard1.ID,
 				},
 				CanAccessDashAnnotations: true,
-			})
+			}, "")
 			require.Len(t, items, numTransitions)
 
 			for i := 0; i < numTransitions; i++ {
@@ -410,6 +434,31 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 			}
 		})
 
+		t.Run("should filter annotations by text", func(t *testing.T) {
+			rule := dashboardRules[dashboard1.UID][0]
+			transitions := genStateTransitions(t, 2, time.Now())
+
+			stream := historian.StatesToStream(ruleMetaFromRule(t, rule), transitions, map[string]string{}, log.NewNopLogger())
+			resources := annotation_ac.AccessResources{
+				Dashboards: map[string]int64{
+					dashboard1.UID: dashboard1.ID,
+				},
+				CanAccessDashAnnotations: true,
+			}
+
+			all := store.annotationsFromStream(stream, resources, "")
+			require.Len(t, all, 2)
+
+			items := store.annotationsFromStream(stream, resources, strings.ToUpper(all[0].Text))
+			require.NotEmpty(t, items)
+			for _, item := range items {
+				require.Contains(t, item.Text, all[0].Text)
+			}
+
+			items = store.annotationsFromStream(stream, resources, "deployed v2.31")
+			require.Empty(t, items)
+		})
+
 		t.Run("should filter out annotations from dashboards not in scope", func(t *testing.T) {
 			start := time.Now()
 			numTransitions := 2
@@ -431,7 +480,7 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 					dashboard1.UID: dashboard1.ID,
 				},
 				CanAccessDashAnnotations: true,
-			})
+			}, "")
 			require.Len(t, items, numTransitions)
 
 			for _, item := range items {
@@ -462,7 +511,7 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 					dashboard1.UID: dashboard1.ID,
 				},
 				CanAccessOrgAnnotations: true,
-			})
+			}, "")
 			require.Len(t, items, numTransitions)
 
 			for _, item := range items {
@@ -22,6 +22,8 @@ type readStore interface {
 	commonStore
 	Get(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error)
 	GetTags(ctx context.Context, query annotations.TagsQuery) (annotations.FindTagsResult, error)
+	// GetTagFacets counts the tags of the annotations matching the query, ignoring its limit
+	GetTagFacets(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (map[string]int64, error)
 }
 
 type writeStore interface {
@@ -282,108 +282,157 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 				SELECT a.id from annotation a
 			`)
 
-		sql.WriteString(`WHERE a.org_id = ?`)
-		params = append(params, query.OrgID)
+		filter, filterParams := r.itemFilter(query, accessResources)
+		sql.WriteString(filter)
+		params = append(params, filterParams...)
 
-		if query.AnnotationID != 0 {
-			// fmt.Println("annotation query")
-			sql.WriteString(` AND a.id = ?`)
-			params = append(params, query.AnnotationID)
+		orderBy := " ORDER BY " + itemOrderBy("a", query.Sort)
+		if query.Limit > 0 {
+			orderBy += r.db.GetDialect().Limit(query.Limit)
 		}
-
-		if query.AlertID != 0 {
-			sql.WriteString(` AND a.alert_id = ?`)
-			params = append(params, query.AlertID)
-		} else if query.AlertUID != "" {
-			sql.WriteString(` AND a.alert_id = (SELECT id FROM alert_rule WHERE uid = ? and org_id = ?)`)
-			params = append(params, query.AlertUID, query.OrgID)
+		sql.WriteString(orderBy + " ) dt on dt.id = annotation.id")
+		if query.Sort != "" && query.Sort != annotations.SortByTimeDesc {
+			// the join does not preserve the order of the subquery
+			sql.WriteString(" ORDER BY " + itemOrderBy("annotation", query.Sort))
 		}
 
-		// note: orgID is already required above
-		if query.DashboardUID != "" {
-			sql.WriteString(` AND a.dashboard_uid = ?`)
-			params = append(params, query.DashboardUID)
-		} else if query.DashboardID != 0 { // nolint: staticcheck
-			sql.WriteString(` AND a.dashboard_id = ?`)
-			params = append(params, query.DashboardID) // nolint: staticcheck
+		if err := sess.SQL(sql.String(), params...).Find(&items); err != nil {
+			items = nil
+			return err
 		}
+		return nil
+	},
+	)
 
-		if query.PanelID != 0 {
-			sql.WriteString(` AND a.panel_id = ?`)
-			params = append(params, query.PanelID)
-		}
+	return items, err
+}
 
-		if query.UserID != 0 {
-			sql.WriteString(` AND a.user_id = ?`)
-			params = append(params, query.UserID)
-		}
+// itemFilter returns the WHERE clause selecting the annotations aliased as a that match the query
+func (r *xormRepositoryImpl) itemFilter(query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (string, []any) {
+	var sql bytes.Buffer
+	params := make([]any, 0)
 
-		if query.From > 0 && query.To > 0 {
-			sql.WriteString(` AND a.epoch <= ? AND a.epoch_end >= ?`)
-			params = append(params, query.To, query.From)
-		}
+	sql.WriteString(`WHERE a.org_id = ?`)
+	params = append(params, query.OrgID)
 
-		switch query.Type {
-		case "alert":
-			sql.WriteString(` AND a.alert_id > 0`)
-		case "annotation":
-			sql.WriteString(` AND a.alert_id = 0`)
-		}
+	if query.AnnotationID != 0 {
+		// fmt.Println("annotation query")
+		sql.WriteString(` AND a.id = ?`)
+		params = append(params, query.AnnotationID)
+	}
 
-		if len(query.Tags) > 0 {
-			keyValueFilters := []string{}
+	if query.AlertID != 0 {
+		sql.WriteString(` AND a.alert_id = ?`)
+		params = append(params, query.AlertID)
+	} else if query.AlertUID != "" {
+		sql.WriteString(` AND a.alert_id = (SELECT id FROM alert_rule WHERE uid = ? and org_id = ?)`)
+		params = append(params, query.AlertUID, query.OrgID)
+	}
 
-			tags := tag.ParseTagPairs(query.Tags)
-			for _, tag := range tags {
-				if tag.Value == "" {
-					keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ?)")
-					params = append(params, tag.Key)
-				} else {
-					keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ? AND tag."+r.db.GetDialect().Quote("value")+" = ?)")
-					params = append(params, tag.Key, tag.Value)
-				}
-			}
+	// note: orgID is already required above
+	if query.DashboardUID != "" {
+		sql.WriteString(` AND a.dashboard_uid = ?`)
+		params = append(params, query.DashboardUID)
+	} else if query.DashboardID != 0 { // nolint: staticcheck
+		sql.WriteString(` AND a.dashboard_id = ?`)
+		params = append(params, query.DashboardID) // nolint: staticcheck
+	}
 
-			if len(tags) > 0 {
-				tagsSubQuery := fmt.Sprintf(`
-			SELECT SUM(1) FROM annotation_tag `+r.db.Quote("at")+`
-			INNER JOIN tag on tag.id = `+r.db.Quote("at")+`.tag_id
-			WHERE `+r.db.Quote("at")+`.annotation_id = a.id
-				AND (
-				%s
-				)
-		`, strings.Join(keyValueFilters, " OR "))
-
-				if query.MatchAny {
-					sql.WriteString(fmt.Sprintf(" AND (%s) > 0 ", tagsSubQuery))
-				} else {
-					sql.WriteString(fmt.Sprintf(" AND (%s) = %d ", tagsSubQuery, len(tags)))
-				}
+	if query.PanelID != 0 {
+		sql.WriteString(` AND a.panel_id = ?`)
+		params = append(params, query.PanelID)
+	}
+
+	if query.UserID != 0 {
+		sql.WriteString(` AND a.user_id = ?`)
+		params = append(params, query.UserID)
+	}
+
+	if query.From > 0 && query.To > 0 {
+		sql.WriteString(` AND a.epoch <= ? AND a.epoch_end >= ?`)
+		params = append(params, query.To, query.From)
+	}
+
+	if query.RegionsOnly {
+		sql.WriteString(` AND a.epoch_end > a.epoch`)
+	}
+
+	if query.Text != "" {
+		s, p := r.containsOperator("a.text", query.Text)
+		sql.WriteString(` AND ` + s)
+		params = append(params, p)
+	}
+
+	switch query.Type {
+	case "alert":
+		sql.WriteString(` AND a.alert_id > 0`)
+	case "annotation":
+		sql.WriteString(` AND a.alert_id = 0`)
+	}
+
+	if len(query.Tags) > 0 {
+		keyValueFilters := []string{}
+
+		tags := tag.ParseTagPairs(query.Tags)
+		for _, tag := range tags {
+			if tag.Value == "" {
+				keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ?)")
+				params = append(params, tag.Key)
+			} else {
+				keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ? AND tag."+r.db.GetDialect().Quote("value")+" = ?)")
+				params = append(params, tag.Key, tag.Value)
 			}
 		}
 
-		acFilter, acParams := r.getAccessControlFilter(accessResources, query.DashboardUID)
-		if acFilter != "" {
-			sql.WriteString(fmt.Sprintf(" AND (%s)", acFilter))
-		}
-		params = append(params, acParams...)
+		if len(tags) > 0 {
+			tagsSubQuery := fmt.Sprintf(`
+		SELECT SUM(1) FROM annotation_tag `+r.db.Quote("at")+`
+		INNER JOIN tag on tag.id = `+r.db.Quote("at")+`.tag_id
+		WHERE `+r.db.Quote("at")+`.annotation_id = a.id
+			AND (
+			%s
+			)
+	`, strings.Join(keyValueFilters, " OR "))
 
-		// order of ORDER BY arguments match the order of a sql index for performance
-		orderBy := " ORDER BY a.org_id, a.epoch_end DESC, a.epoch DESC"
-		if query.Limit > 0 {
-			orderBy += r.db.GetDialect().Limit(query.Limit)
+			if query.MatchAny {
+				sql.WriteString(fmt.Sprintf(" AND (%s) > 0 ", tagsSubQuery))
+			} else {
+				sql.WriteString(fmt.Sprintf(" AND (%s) = %d ", tagsSubQuery, len(tags)))
+			}
 		}
-		sql.WriteString(orderBy + " ) dt on dt.id = annotation.id")
+	}
 
-		if err := sess.SQL(sql.String(), params...).Find(&items); err != nil {
-			items = nil
-			return err
-		}
-		return nil
-	},
-	)
+	acFilter, acParams := r.getAccessControlFilter(accessResources, query.DashboardUID)
+	if acFilter != "" {
+		sql.WriteString(fmt.Sprintf(" AND (%s)", acFilter))
+	}
+	params = append(params, acParams...)
 
-	return items, err
+	return sql.String(), params
+}
+
+// likeEscaper escapes the LIKE wildcards of user input. The escape character is not a backslash,
+// MySQL would read it as an escape in the ESCAPE clause literal.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// containsOperator returns the case-insensitive condition matching the column values containing value,
+// with the LIKE wildcards of value compared literally
+func (r *xormRepositoryImpl) containsOperator(column string, value string) (string, string) {
+	s, p := r.db.GetDialect().LikeOperator(column, true, likeEscaper.Replace(value), true)
+	return s + ` ESCAPE '!'`, p
+}
+
+// itemOrderBy returns the ORDER BY arguments for an annotations.ItemQuery sort
+func itemOrderBy(table string, sortBy string) string {
+	switch sortBy {
+	case annotations.SortByTimeAsc:
+		return table + ".org_id, " + table + ".epoch ASC, " + table + ".epoch_end ASC"
+	case annotations.SortByUpdatedDesc:
+		return table + ".updated DESC"
+	default:
+		// order of ORDER BY arguments match the order of a sql index for performance
+		return table + ".org_id, " + table + ".epoch_end DESC, " + table + ".epoch DESC"
+	}
 }
 
 func (r *xormRepositoryImpl) getAccessControlFilter(accessResources *accesscontrol.AccessResources, dashboardUID string) (string, []any) {
@@ -492,12 +541,12 @@ func (r *xormRepositoryImpl) GetTags(ctx context.Context, query annotations.Tags
 		params = append(params, query.OrgID)
 
 		sql.WriteString(` AND (`)
-		s, p := r.db.GetDialect().LikeOperator(tagKey, true, query.Tag, true)
+		s, p := r.containsOperator(tagKey, query.Tag)
 		sql.WriteString(s)
 		params = append(params, p)
 		sql.WriteString(" OR ")
 
-		s, p = r.db.GetDialect().LikeOperator(tagValue, true, query.Tag, true)
+		s, p = r.containsOperator(tagValue, query.Tag)
 		sql.WriteString(s)
 		params = append(params, p)
 		sql.WriteString(")")
@@ -527,6 +576,42 @@ func (r *xormRepositoryImpl) GetTags(ctx context.Context, query annotations.Tags
 	return annotations.FindTagsResult{Tags: tags}, nil
 }
 
+func (r *xormRepositoryImpl) GetTagFacets(ctx context.Context, query annotations.ItemQuery, accessResources *accesscontrol.AccessResources) (map[string]int64, error) {
+	var items []*annotations.Tag
+	err := r.db.WithDbSession(ctx, func(sess *db.Session) error {
+		tagKey := `facet_tag.` + r.db.GetDialect().Quote("key")
+		tagValue := `facet_tag.` + r.db.GetDialect().Quote("value")
+		filter, params := r.itemFilter(query, accessResources)
+
+		// the tables are aliased so they do not collide with the tags filter of the query
+		sql := `
+		SELECT
+			` + tagKey + `,
+			` + tagValue + `,
+			count(*) as count
+		FROM annotation a
+		INNER JOIN annotation_tag facet_at ON facet_at.annotation_id = a.id
+		INNER JOIN tag facet_tag ON facet_tag.id = facet_at.tag_id
+		` + filter + `
+		GROUP BY ` + tagKey + `,` + tagValue
+
+		return sess.SQL(sql, params...).Find(&items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(items))
+	for _, item := range items {
+		tag := item.Key
+		if len(item.Value) > 0 {
+			tag = item.Key + ":" + item.Value
+		}
+		counts[tag] += item.Count
+	}
+	return counts, nil
+}
+
 func (r *xormRepositoryImpl) validateItem(item *annotations.Item) error {
 	if err := validateTimeRange(item); err != nil {
 		return err
@@ -366,6 +366,78 @@ func TestIntegrationAnnotations(t *testing.T) {
 			assert.Len(t, items, 1)
 		})
 
+		t.Run("Should find annotations by text ignoring case", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			items, err := store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				Text:         "DEPL",
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, organizationAnnotation1.ID, items[0].ID)
+		})
+
+		t.Run("Should match wildcards in the text literally", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			for _, text := range []string{"d_ploy", "de%oy"} {
+				items, err := store.Get(context.Background(), annotations.ItemQuery{
+					OrgID:        1,
+					Text:         text,
+					SignedInUser: testUser,
+				}, accRes)
+				require.NoError(t, err)
+				assert.Empty(t, items, text)
+			}
+		})
+
+		t.Run("Should find only region annotations overlapping the time range", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{
+				Dashboards:               map[string]int64{dashboard.UID: dashboard.ID, dashboard2.UID: dashboard2.ID},
+				CanAccessDashAnnotations: true,
+				CanAccessOrgAnnotations:  true,
+			}
+			items, err := store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				From:         21,
+				To:           30,
+				RegionsOnly:  true,
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, annotation2.ID, items[0].ID)
+		})
+
+		t.Run("Should sort annotations by time ascending", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			items, err := store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				Sort:         annotations.SortByTimeAsc,
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 2)
+			assert.Equal(t, organizationAnnotation1.ID, items[0].ID)
+			assert.Equal(t, organizationAnnotation2.ID, items[1].ID)
+		})
+
+		t.Run("Should count the tags of all matched annotations ignoring the limit", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{
+				Dashboards:               map[string]int64{dashboard.UID: dashboard.ID, dashboard2.UID: dashboard2.ID},
+				CanAccessDashAnnotations: true,
+				CanAccessOrgAnnotations:  true,
+			}
+			counts, err := store.GetTagFacets(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				Tags:         []string{"outage"},
+				Limit:        1,
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int64{"outage": 2, "error": 2, "type:outage": 2, "server:server-1": 2}, counts)
+		})
+
 		t.Run("Can update annotation and remove all tags", func(t *testing.T) {
 			query := annotations.ItemQuery{
 				OrgID:        1,
@@ -633,6 +705,15 @@ func TestIntegrationAnnotations(t *testing.T) {
 			require.Equal(t, int64(1), result.Tags[1].Count)
 		})
 
+		t.Run("Should match wildcards in the tag literally", func(t *testing.T) {
+			result, err := store.GetTags(context.Background(), annotations.TagsQuery{
+				OrgID: 1,
+				Tag:   "serv_r",
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Tags, 0)
+		})
+
 		t.Run("Should not find tags in other org", func(t *testing.T) {
 			result, err := store.GetTags(context.Background(), annotations.TagsQuery{
 				OrgID: 0,
@@ -83,6 +83,10 @@ func (repo *fakeAnnotationsRepo) FindTags(_ context.Context, query *annotations.
 	return result, nil
 }
 
+func (repo *fakeAnnotationsRepo) FindTagFacets(_ context.Context, query *annotations.ItemQuery) ([]*annotations.TagsDTO, error) {
+	return []*annotations.TagsDTO{}, nil
+}
+
 func (repo *fakeAnnotationsRepo) Len() int {
 	repo.mtx.Lock()
 	defer repo.mtx.Unlock()
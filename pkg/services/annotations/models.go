@@ -1,6 +1,8 @@
 package annotations
 
 import (
+	"sort"
+
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 )
@@ -20,6 +22,12 @@ type ItemQuery struct {
 	Tags         []string `json:"tags"`
 	Type         string   `json:"type"`
 	MatchAny     bool     `json:"matchAny"`
+	// Text filters annotations whose text contains the given string, ignoring case
+	Text string `json:"text"`
+	// RegionsOnly limits the result to region annotations, overlapping From and To when set
+	RegionsOnly bool `json:"regionsOnly"`
+	// Sort is one of SortByTimeDesc, SortByTimeAsc or SortByUpdatedDesc, defaulting to SortByTimeDesc
+	Sort         string `json:"sort"`
 	SignedInUser identity.Requester
 
 	Limit int64 `json:"limit"`
@@ -151,6 +159,55 @@ func (s SortedItems) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
+const (
+	SortByTimeDesc    = "time-desc"
+	SortByTimeAsc     = "time-asc"
+	SortByUpdatedDesc = "updated-desc"
+)
+
+func IsValidSort(sortBy string) bool {
+	switch sortBy {
+	case "", SortByTimeDesc, SortByTimeAsc, SortByUpdatedDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortItems sorts annotations in the order of an ItemQuery.Sort value
+func SortItems(items []*ItemDTO, sortBy string) {
+	switch sortBy {
+	case SortByTimeAsc:
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Time != items[j].Time {
+				return items[i].Time < items[j].Time
+			}
+			return items[i].TimeEnd < items[j].TimeEnd
+		})
+	case SortByUpdatedDesc:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Updated > items[j].Updated
+		})
+	default:
+		sort.Stable(SortedItems(items))
+	}
+}
+
+// TagFacets returns the tags of the given counts, most used tags first
+func TagFacets(counts map[string]int64) []*TagsDTO {
+	facets := make([]*TagsDTO, 0, len(counts))
+	for tag, count := range counts {
+		facets = append(facets, &TagsDTO{Tag: tag, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Tag < facets[j].Tag
+	})
+	return facets
+}
+
 type annotationType int
 
 const (
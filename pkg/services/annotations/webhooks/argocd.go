@@ -0,0 +1,47 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// argoCDPayload is the body Argo CD notifications are expected to send, for example with the template
+//
+//	{"app": "{{.app.metadata.name}}", "project": "{{.app.spec.project}}",
+//	 "environment": "{{.app.spec.destination.namespace}}", "revision": "{{.app.status.sync.revision}}",
+//	 "status": "{{.app.status.operationState.phase}}", "message": "{{.app.status.operationState.message}}",
+//	 "finishedAt": "{{.app.status.operationState.finishedAt}}"}
+type argoCDPayload struct {
+	App         string `json:"app"`
+	Project     string `json:"project"`
+	Environment string `json:"environment"`
+	Revision    string `json:"revision"`
+	Status      string `json:"status"`
+	Message     string `json:"message"`
+	FinishedAt  string `json:"finishedAt"`
+}
+
+// convertArgoCD handles sync notifications
+func convertArgoCD(body []byte) (*event, error) {
+	var payload argoCDPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidPayload.Errorf("failed to decode argocd payload: %w", err)
+	}
+	if payload.App == "" {
+		return nil, ErrInvalidPayload.Errorf("argocd payload without app")
+	}
+
+	text := fmt.Sprintf("Synced %s to %s", payload.App, payload.Revision)
+	if payload.Status != "" {
+		text += ": " + payload.Status
+	}
+
+	tags := appendTag([]string{SourceArgoCD, "deploy"}, "app", payload.App)
+	tags = appendTag(tags, "project", payload.Project)
+	tags = appendTag(tags, "env", payload.Environment)
+	return &event{
+		text: joinText(text, payload.Message),
+		tags: appendTag(tags, "status", payload.Status),
+		time: parseTime(payload.FinishedAt),
+	}, nil
+}
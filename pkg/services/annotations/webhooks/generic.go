@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// genericTemplates are the parsed templates of generic webhooks, a nil template renders an empty string
+type genericTemplates struct {
+	text *template.Template
+	tags *template.Template
+	time *template.Template
+}
+
+func parseGenericTemplates(cfg setting.AnnotationWebhookSettings) (*genericTemplates, error) {
+	text, err := parseTemplate("text", cfg.GenericTextTemplate)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := parseTemplate("tags", cfg.GenericTagsTemplate)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := parseTemplate("time", cfg.GenericTimeTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &genericTemplates{text: text, tags: tags, time: timestamp}, nil
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, ErrInvalidTemplate.Errorf("failed to parse generic %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// convertGeneric maps any JSON payload with the configured templates
+func convertGeneric(templates *genericTemplates, body []byte) (*event, error) {
+	// decode numbers as json.Number so epochs render without exponents
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var payload any
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, ErrInvalidPayload.Errorf("failed to decode generic payload: %w", err)
+	}
+
+	text, err := render(templates.text, payload)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, ErrInvalidPayload.Errorf("generic payload rendered an empty text")
+	}
+
+	tagList, err := render(templates.tags, payload)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	for _, tag := range strings.Split(tagList, ",") {
+		// skip key:value tags whose value is missing from the payload
+		if tag = strings.TrimSpace(tag); tag != "" && !strings.HasSuffix(tag, ":") {
+			tags = append(tags, tag)
+		}
+	}
+
+	timestamp, err := render(templates.time, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event{
+		text: text,
+		tags: tags,
+		time: parseTime(timestamp),
+	}, nil
+}
+
+func render(tmpl *template.Template, payload any) (string, error) {
+	if tmpl == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return "", ErrInvalidPayload.Errorf("failed to execute generic %s template: %w", tmpl.Name(), err)
+	}
+
+	// fields missing from the payload render as <no value>
+	return strings.TrimSpace(strings.ReplaceAll(buf.String(), "<no value>", "")), nil
+}
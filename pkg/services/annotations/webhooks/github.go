@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type githubPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Deployment *struct {
+		Environment string    `json:"environment"`
+		Ref         string    `json:"ref"`
+		SHA         string    `json:"sha"`
+		Description string    `json:"description"`
+		CreatedAt   time.Time `json:"created_at"`
+	} `json:"deployment"`
+	DeploymentStatus *struct {
+		State     string    `json:"state"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"deployment_status"`
+	Release *struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+	} `json:"release"`
+}
+
+// convertGitHub handles deployment, deployment_status and release events
+func convertGitHub(eventType string, body []byte) (*event, error) {
+	var payload githubPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidPayload.Errorf("failed to decode github payload: %w", err)
+	}
+
+	repo := payload.Repository.FullName
+	switch eventType {
+	case "deployment":
+		if payload.Deployment == nil {
+			return nil, ErrInvalidPayload.Errorf("github deployment event without deployment")
+		}
+		d := payload.Deployment
+		return &event{
+			text: joinText(fmt.Sprintf("Deploying %s %s to %s", repo, d.Ref, d.Environment), d.Description),
+			tags: appendTag(appendTag([]string{SourceGitHub, "deploy"}, "repo", repo), "env", d.Environment),
+			time: d.CreatedAt,
+		}, nil
+	case "deployment_status":
+		if payload.Deployment == nil || payload.DeploymentStatus == nil {
+			return nil, ErrInvalidPayload.Errorf("github deployment_status event without deployment status")
+		}
+		d, status := payload.Deployment, payload.DeploymentStatus
+		// only mark finished deployments
+		if status.State != "success" && status.State != "failure" && status.State != "error" {
+			return nil, nil
+		}
+		tags := appendTag(appendTag([]string{SourceGitHub, "deploy"}, "repo", repo), "env", d.Environment)
+		return &event{
+			text: joinText(fmt.Sprintf("Deployment of %s %s to %s: %s", repo, d.Ref, d.Environment, status.State), d.Description),
+			tags: appendTag(tags, "status", status.State),
+			time: status.CreatedAt,
+		}, nil
+	case "release":
+		if payload.Action != "published" {
+			return nil, nil
+		}
+		if payload.Release == nil {
+			return nil, ErrInvalidPayload.Errorf("github release event without release")
+		}
+		r := payload.Release
+		return &event{
+			text: joinText(fmt.Sprintf("Released %s %s", repo, r.TagName), r.Name, r.HTMLURL),
+			tags: appendTag(appendTag([]string{SourceGitHub, "release"}, "repo", repo), "version", r.TagName),
+			time: r.PublishedAt,
+		}, nil
+	default:
+		// ping and any other event
+		return nil, nil
+	}
+}
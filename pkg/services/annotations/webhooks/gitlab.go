@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// gitlabTimeLayout is the date format of GitLab webhook payloads
+const gitlabTimeLayout = "2006-01-02 15:04:05 -0700"
+
+type gitlabPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+
+	// deployment events
+	Status          string `json:"status"`
+	StatusChangedAt string `json:"status_changed_at"`
+	Environment     string `json:"environment"`
+	Ref             string `json:"ref"`
+	ShortSHA        string `json:"short_sha"`
+	CommitTitle     string `json:"commit_title"`
+
+	// release events
+	Action     string `json:"action"`
+	Tag        string `json:"tag"`
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	ReleasedAt string `json:"released_at"`
+}
+
+// convertGitLab handles deployment and release events
+func convertGitLab(body []byte) (*event, error) {
+	var payload gitlabPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidPayload.Errorf("failed to decode gitlab payload: %w", err)
+	}
+
+	project := payload.Project.PathWithNamespace
+	switch payload.ObjectKind {
+	case "deployment":
+		// only mark finished deployments
+		if payload.Status != "success" && payload.Status != "failed" && payload.Status != "canceled" {
+			return nil, nil
+		}
+		tags := appendTag(appendTag([]string{SourceGitLab, "deploy"}, "repo", project), "env", payload.Environment)
+		return &event{
+			text: joinText(fmt.Sprintf("Deployment of %s %s (%s) to %s: %s", project, payload.Ref, payload.ShortSHA, payload.Environment, payload.Status), payload.CommitTitle),
+			tags: appendTag(tags, "status", payload.Status),
+			time: parseTime(payload.StatusChangedAt),
+		}, nil
+	case "release":
+		if payload.Action != "create" {
+			return nil, nil
+		}
+		return &event{
+			text: joinText(fmt.Sprintf("Released %s %s", project, payload.Tag), payload.Name, payload.URL),
+			tags: appendTag(appendTag([]string{SourceGitLab, "release"}, "repo", project), "version", payload.Tag),
+			time: parseTime(payload.ReleasedAt),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
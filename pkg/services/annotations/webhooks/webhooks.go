@@ -0,0 +1,119 @@
+// Package webhooks converts deployment and release events sent by CI/CD systems into annotations.
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// MaxPayloadSize is the maximum size of a webhook payload, the limit of GitHub webhooks.
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads
+const MaxPayloadSize = 25 * 1024 * 1024
+
+const (
+	SourceGitHub  = "github"
+	SourceGitLab  = "gitlab"
+	SourceArgoCD  = "argocd"
+	SourceGeneric = "generic"
+)
+
+var (
+	ErrUnknownSource   = errutil.NotFound("annotations.webhooks.unknown-source", errutil.WithPublicMessage("Unknown annotation webhook source"))
+	ErrInvalidPayload  = errutil.BadRequest("annotations.webhooks.invalid-payload", errutil.WithPublicMessage("Invalid annotation webhook payload"))
+	ErrInvalidTemplate = errutil.Internal("annotations.webhooks.invalid-template", errutil.WithPublicMessage("Invalid annotation webhook template"))
+)
+
+// event is the part of a webhook payload that makes up an annotation
+type event struct {
+	text string
+	tags []string
+	time time.Time
+}
+
+// Converter converts webhook payloads into annotations. The templates of generic webhooks are parsed once.
+type Converter struct {
+	generic *genericTemplates
+	// templateErr is returned for generic payloads when the templates do not parse
+	templateErr error
+}
+
+func NewConverter(cfg setting.AnnotationWebhookSettings) *Converter {
+	generic, err := parseGenericTemplates(cfg)
+	return &Converter{generic: generic, templateErr: err}
+}
+
+// Convert returns the annotation for a webhook payload, or nil when the payload is not
+// a deployment or release event. OrgID and UserID are left for the caller to set.
+func (c *Converter) Convert(source string, header http.Header, body []byte) (*annotations.Item, error) {
+	var (
+		e   *event
+		err error
+	)
+
+	switch source {
+	case SourceGitHub:
+		e, err = convertGitHub(header.Get("X-GitHub-Event"), body)
+	case SourceGitLab:
+		e, err = convertGitLab(body)
+	case SourceArgoCD:
+		e, err = convertArgoCD(body)
+	case SourceGeneric:
+		if c.templateErr != nil {
+			return nil, c.templateErr
+		}
+		e, err = convertGeneric(c.generic, body)
+	default:
+		return nil, ErrUnknownSource.Errorf("unknown annotation webhook source %q", source)
+	}
+	if err != nil || e == nil {
+		return nil, err
+	}
+
+	if e.time.IsZero() {
+		e.time = time.Now()
+	}
+
+	return &annotations.Item{
+		Epoch: e.time.UnixMilli(),
+		Text:  e.text,
+		Tags:  e.tags,
+	}, nil
+}
+
+// appendTag appends a key:value tag when the value is set
+func appendTag(tags []string, key, value string) []string {
+	if value == "" {
+		return tags
+	}
+	return append(tags, key+":"+value)
+}
+
+// joinText joins the non-empty parts of an annotation text into lines
+func joinText(parts ...string) string {
+	lines := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			lines = append(lines, part)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseTime parses RFC 3339 dates, GitLab dates and epoch milliseconds, returning the zero time otherwise
+func parseTime(value string) time.Time {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms)
+	}
+	for _, layout := range []string{time.RFC3339, gitlabTimeLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
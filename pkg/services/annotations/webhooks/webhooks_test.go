@@ -0,0 +1,182 @@
+package webhooks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestConvert(t *testing.T) {
+	genericCfg := setting.AnnotationWebhookSettings{
+		GenericTextTemplate: "Deployed {{ .service }} {{ .version }}",
+		GenericTagsTemplate: "deploy, service:{{ .service }}, env:{{ .env }}",
+		GenericTimeTemplate: "{{ .timestamp }}",
+	}
+
+	type testCase struct {
+		desc        string
+		source      string
+		eventHeader string
+		body        string
+		cfg         setting.AnnotationWebhookSettings
+		expected    *annotations.Item
+		expectedErr error
+	}
+
+	tests := []testCase{
+		{
+			desc:        "should convert github deployment status",
+			source:      SourceGitHub,
+			eventHeader: "deployment_status",
+			body: `{
+				"deployment": {"environment": "production", "ref": "v2.31", "description": "Deploy v2.31", "created_at": "2024-05-01T10:00:00Z"},
+				"deployment_status": {"state": "success", "created_at": "2024-05-01T10:05:00Z"},
+				"repository": {"full_name": "acme/shop"}
+			}`,
+			expected: &annotations.Item{
+				Epoch: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC).UnixMilli(),
+				Text:  "Deployment of acme/shop v2.31 to production: success\nDeploy v2.31",
+				Tags:  []string{"github", "deploy", "repo:acme/shop", "env:production", "status:success"},
+			},
+		},
+		{
+			desc:        "should ignore pending github deployment status",
+			source:      SourceGitHub,
+			eventHeader: "deployment_status",
+			body:        `{"deployment": {"environment": "production"}, "deployment_status": {"state": "pending"}}`,
+		},
+		{
+			desc:        "should convert published github release",
+			source:      SourceGitHub,
+			eventHeader: "release",
+			body: `{
+				"action": "published",
+				"release": {"tag_name": "v2.31", "name": "Spring release", "published_at": "2024-05-01T10:00:00Z"},
+				"repository": {"full_name": "acme/shop"}
+			}`,
+			expected: &annotations.Item{
+				Epoch: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).UnixMilli(),
+				Text:  "Released acme/shop v2.31\nSpring release",
+				Tags:  []string{"github", "release", "repo:acme/shop", "version:v2.31"},
+			},
+		},
+		{
+			desc:        "should ignore github ping",
+			source:      SourceGitHub,
+			eventHeader: "ping",
+			body:        `{"zen": "Keep it logically awesome."}`,
+		},
+		{
+			desc:   "should convert gitlab deployment",
+			source: SourceGitLab,
+			body: `{
+				"object_kind": "deployment",
+				"status": "failed",
+				"status_changed_at": "2024-05-01 12:05:00 +0200",
+				"environment": "staging",
+				"ref": "main",
+				"short_sha": "a1b2c3d",
+				"project": {"path_with_namespace": "acme/shop"}
+			}`,
+			expected: &annotations.Item{
+				Epoch: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC).UnixMilli(),
+				Text:  "Deployment of acme/shop main (a1b2c3d) to staging: failed",
+				Tags:  []string{"gitlab", "deploy", "repo:acme/shop", "env:staging", "status:failed"},
+			},
+		},
+		{
+			desc:   "should convert argocd sync",
+			source: SourceArgoCD,
+			body: `{
+				"app": "shop",
+				"project": "default",
+				"environment": "prod",
+				"revision": "a1b2c3d",
+				"status": "Succeeded",
+				"finishedAt": "2024-05-01T10:00:00Z"
+			}`,
+			expected: &annotations.Item{
+				Epoch: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).UnixMilli(),
+				Text:  "Synced shop to a1b2c3d: Succeeded",
+				Tags:  []string{"argocd", "deploy", "app:shop", "project:default", "env:prod", "status:Succeeded"},
+			},
+		},
+		{
+			desc:        "should fail argocd payload without app",
+			source:      SourceArgoCD,
+			body:        `{"revision": "a1b2c3d"}`,
+			expectedErr: ErrInvalidPayload,
+		},
+		{
+			desc:   "should map generic payload with templates",
+			source: SourceGeneric,
+			body:   `{"service": "shop", "version": "v2.31", "timestamp": 1714557600000}`,
+			cfg:    genericCfg,
+			expected: &annotations.Item{
+				Epoch: 1714557600000,
+				Text:  "Deployed shop v2.31",
+				Tags:  []string{"deploy", "service:shop"},
+			},
+		},
+		{
+			desc:        "should fail generic payload with invalid template",
+			source:      SourceGeneric,
+			body:        `{}`,
+			cfg:         setting.AnnotationWebhookSettings{GenericTextTemplate: "{{ .text"},
+			expectedErr: ErrInvalidTemplate,
+		},
+		{
+			desc:        "should fail generic payload rendering empty text",
+			source:      SourceGeneric,
+			body:        `{"message": "hello"}`,
+			cfg:         setting.AnnotationWebhookSettings{GenericTextTemplate: "{{ .text }}"},
+			expectedErr: ErrInvalidPayload,
+		},
+		{
+			desc:        "should fail unknown source",
+			source:      "jenkins",
+			body:        `{}`,
+			expectedErr: ErrUnknownSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("X-GitHub-Event", tt.eventHeader)
+
+			item, err := NewConverter(tt.cfg).Convert(tt.source, header, []byte(tt.body))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, item)
+		})
+	}
+}
+
+func TestConvert_DefaultsToNow(t *testing.T) {
+	before := time.Now().UnixMilli()
+	item, err := NewConverter(setting.AnnotationWebhookSettings{}).Convert(SourceArgoCD, http.Header{}, []byte(`{"app": "shop"}`))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, item.Epoch, before)
+}
+
+func TestConverter_InvalidGenericTemplates(t *testing.T) {
+	converter := NewConverter(setting.AnnotationWebhookSettings{GenericTagsTemplate: "{{ .tags"})
+
+	_, err := converter.Convert(SourceGeneric, http.Header{}, []byte(`{"text": "deployed"}`))
+	assert.ErrorIs(t, err, ErrInvalidTemplate)
+
+	item, err := converter.Convert(SourceArgoCD, http.Header{}, []byte(`{"app": "shop"}`))
+	require.NoError(t, err)
+	assert.NotNil(t, item)
+}
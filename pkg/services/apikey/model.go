@@ -1,6 +1,7 @@
 package apikey
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -27,10 +28,17 @@ type APIKey struct {
 	Expires          *int64       `db:"expires"`
 	ServiceAccountId *int64       `db:"service_account_id"`
 	IsRevoked        *bool        `xorm:"is_revoked" db:"is_revoked"`
+	// Permissions restricts what the key can do, keys without permissions have all permissions of their service account
+	Permissions *Permissions `xorm:"permissions" db:"permissions"`
 }
 
 func (k APIKey) TableName() string { return "api_key" }
 
+// IsRestricted returns true when the key only has a subset of its service account permissions
+func (k APIKey) IsRestricted() bool {
+	return k.Permissions != nil && len(*k.Permissions) > 0
+}
+
 type AddCommand struct {
 	Name             string       `json:"name" binding:"Required"`
 	Role             org.RoleType `json:"role" binding:"Required"`
@@ -38,6 +46,37 @@ type AddCommand struct {
 	Key              string       `json:"-"`
 	SecondsToLive    int64        `json:"secondsToLive"`
 	ServiceAccountID *int64       `json:"-"`
+	Permissions      *Permissions `json:"-"`
+}
+
+// Permission is an action and optional scope a key is restricted to.
+// Keys never get permissions their service account does not have. A permission without a scope
+// only grants unscoped access to the action, use a wildcard scope such as `dashboards:*` instead.
+type Permission struct {
+	Action string `json:"action"`
+	Scope  string `json:"scope,omitempty"`
+}
+
+type Permissions []Permission
+
+func (p *Permissions) FromDB(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+func (p *Permissions) ToDB() ([]byte, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+// GroupByAction returns the scopes of the permissions grouped by action
+func (p Permissions) GroupByAction() map[string][]string {
+	grouped := make(map[string][]string, len(p))
+	for _, permission := range p {
+		grouped[permission.Action] = append(grouped[permission.Action], permission.Scope)
+	}
+	return grouped
 }
 
 type GetByNameQuery struct {
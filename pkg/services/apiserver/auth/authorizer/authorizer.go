@@ -26,14 +26,16 @@ type GrafanaAuthorizer struct {
 //  2. We allow all identities that belongs to `system:masters` group, regular grafana identities cannot
 //     be part of this group
 //  3. We check that identity is allowed to make a request for namespace.
-//  4. We check authorizer that is configured speficially for an api.
-//  5. As a last fallback we check Role, this will only happen if an api have not configured
+//  4. We deny requests that are not allowed by the restricted permissions of the identity.
+//  5. We check authorizer that is configured speficially for an api.
+//  6. As a last fallback we check Role, this will only happen if an api have not configured
 //     an authorizer or return authorizer.DecisionNoOpinion
 func NewGrafanaBuiltInSTAuthorizer(cfg *setting.Cfg) *GrafanaAuthorizer {
 	authorizers := []authorizer.Authorizer{
 		newImpersonationAuthorizer(),
 		authorizerfactory.NewPrivilegedGroups(k8suser.SystemPrivilegedGroup),
 		newNamespaceAuthorizer(),
+		newRestrictedAuthorizer(),
 	}
 
 	// Individual services may have explicit implementations
@@ -0,0 +1,61 @@
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestRestrictedAuthorizer_Authorize(t *testing.T) {
+	auth := newRestrictedAuthorizer()
+	permissions := map[int64]map[string][]string{1: {
+		"dashboards:read":  {"dashboards:uid:abc"},
+		"dashboards:write": {"dashboards:*"},
+		"folders:create":   {""},
+	}}
+	newAttributes := func(verb, resource, name string) authorizer.Attributes {
+		return authorizer.AttributesRecord{
+			Verb:            verb,
+			APIGroup:        resource + ".grafana.app",
+			Resource:        resource + "s",
+			Namespace:       "default",
+			Name:            name,
+			ResourceRequest: true,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		attrs    authorizer.Attributes
+		expected authorizer.Decision
+	}{
+		{name: "allowed name", attrs: newAttributes("get", "dashboard", "abc"), expected: authorizer.DecisionNoOpinion},
+		{name: "other name", attrs: newAttributes("get", "dashboard", "other"), expected: authorizer.DecisionDeny},
+		{name: "list without wildcard", attrs: newAttributes("list", "dashboard", ""), expected: authorizer.DecisionDeny},
+		{name: "wildcard", attrs: newAttributes("update", "dashboard", "other"), expected: authorizer.DecisionNoOpinion},
+		{name: "create", attrs: newAttributes("create", "folder", ""), expected: authorizer.DecisionNoOpinion},
+		{name: "action not in restriction", attrs: newAttributes("delete", "dashboard", "abc"), expected: authorizer.DecisionDeny},
+		{name: "unmapped resource", attrs: newAttributes("get", "playlist", "abc"), expected: authorizer.DecisionDeny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := identity.WithRequester(context.Background(), &user.SignedInUser{OrgID: 1, Permissions: permissions, PermissionsRestricted: true})
+			decision, _, err := auth.Authorize(ctx, tt.attrs)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, decision)
+		})
+	}
+
+	t.Run("identities without restricted permissions are left to the next authorizers", func(t *testing.T) {
+		ctx := identity.WithRequester(context.Background(), &user.SignedInUser{OrgID: 1, Permissions: permissions})
+		decision, _, err := auth.Authorize(ctx, newAttributes("get", "playlist", "abc"))
+		require.NoError(t, err)
+		require.Equal(t, authorizer.DecisionNoOpinion, decision)
+	})
+}
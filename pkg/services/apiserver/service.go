@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/admission"
 	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/apiserver/pkg/endpoints/responsewriter"
 	genericapiserver "k8s.io/apiserver/pkg/server"
@@ -48,6 +49,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
 	grafanaapiserveroptions "github.com/grafana/grafana/pkg/services/apiserver/options"
 	"github.com/grafana/grafana/pkg/services/apiserver/utils"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
@@ -114,6 +116,7 @@ type service struct {
 	appInstallers                     []appsdkapiserver.AppInstaller
 	builderMetrics                    *builder.BuilderMetrics
 	dualWriterMetrics                 *grafanarest.DualWriterMetrics
+	auditLog                          auditlog.Service
 }
 
 func ProvideService(
@@ -138,6 +141,7 @@ func ProvideService(
 	aggregatorRunner aggregatorrunner.AggregatorRunner,
 	appInstallers []appsdkapiserver.AppInstaller,
 	builderMetrics *builder.BuilderMetrics,
+	auditLog auditlog.Service,
 ) (*service, error) {
 	scheme := builder.ProvideScheme()
 	codecs := builder.ProvideCodecFactory(scheme)
@@ -168,6 +172,7 @@ func ProvideService(
 		aggregatorRunner:                  aggregatorRunner,
 		appInstallers:                     appInstallers,
 		builderMetrics:                    builderMetrics,
+		auditLog:                          auditLog,
 		dualWriterMetrics:                 grafanarest.NewDualWriterMetrics(reg),
 	}
 	// This will be used when running as a dskit service
@@ -372,6 +377,16 @@ func (s *service) start(ctx context.Context) error {
 		return err
 	}
 
+	// the audit log plugin is first so the requests rejected by the other plugins are recorded,
+	// the entries are recorded with the outcome of the request once the response is written
+	if !s.auditLog.IsDisabled() {
+		serverConfig.AdmissionControl = admission.NewChainHandler(auditlog.NewAdmission(s.auditLog), serverConfig.AdmissionControl)
+		buildHandlerChain := serverConfig.BuildHandlerChainFunc
+		serverConfig.BuildHandlerChainFunc = func(handler http.Handler, c *genericapiserver.Config) http.Handler {
+			return auditlog.WithRecorder(buildHandlerChain(handler, c), s.auditLog)
+		}
+	}
+
 	notFoundHandler := notfoundhandler.New(s.codecs, genericapifilters.NoMuxAndDiscoveryIncompleteKey)
 
 	if err := appinstaller.RegisterPostStartHooks(s.appInstallers, serverConfig); err != nil {
@@ -493,7 +508,7 @@ func (s *service) startCoreServer(
 	// ignore the lint error because the response is passed directly to the client,
 	// so the client will be responsible for closing the response body.
 	// nolint:bodyclose
-	transport.Fn = grafanaresponsewriter.WrapHandler(server.Handler)
+	transport.Fn = grafanaresponsewriter.WrapHandler(server.Handler, auditlog.PropagateEntry)
 	close(transport.Ready)
 
 	prepared := server.PrepareRun()
@@ -537,7 +552,7 @@ func (s *service) startDataplaneAggregator(
 	// ignore the lint error because the response is passed directly to the client,
 	// so the client will be responsible for closing the response body.
 	// nolint:bodyclose
-	transport.Fn = grafanaresponsewriter.WrapHandler(aggregatorServer.GenericAPIServer.Handler)
+	transport.Fn = grafanaresponsewriter.WrapHandler(aggregatorServer.GenericAPIServer.Handler, auditlog.PropagateEntry)
 	close(transport.Ready)
 
 	prepared, err := aggregatorServer.PrepareRun()
@@ -560,7 +575,7 @@ func (s *service) GetDirectRestConfig(c *contextmodel.ReqContext) *clientrest.Co
 					return nil, err
 				}
 				ctx := identity.WithRequester(req.Context(), c.SignedInUser)
-				wrapped := grafanaresponsewriter.WrapHandler(s.handler)
+				wrapped := grafanaresponsewriter.WrapHandler(s.handler, auditlog.PropagateEntry)
 				return wrapped(req.WithContext(ctx))
 			},
 		},
@@ -0,0 +1,145 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grafana/authlib/types"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+type admissionRecorder struct {
+	svc Service
+}
+
+var _ admission.ValidationInterface = (*admissionRecorder)(nil)
+
+// NewAdmission returns an admission plugin describing the write requests to the Kubernetes style APIs.
+// The entries are recorded by WithRecorder once the response is written, so they hold the outcome of the request.
+// It must be the first validation plugin of the chain, so the requests rejected by the other plugins are recorded too.
+func NewAdmission(svc Service) admission.ValidationInterface {
+	return &admissionRecorder{svc: svc}
+}
+
+func (a *admissionRecorder) Handles(operation admission.Operation) bool {
+	return operation == admission.Create || operation == admission.Update || operation == admission.Delete
+}
+
+func (a *admissionRecorder) Validate(ctx context.Context, attr admission.Attributes, o admission.ObjectInterfaces) error {
+	if a.svc.IsDisabled() || attr.IsDryRun() {
+		return nil
+	}
+
+	// only the requests served through WithRecorder are recorded
+	pending := pendingEntryFrom(ctx)
+	if pending == nil {
+		return nil
+	}
+
+	beforeHash := hashObject(attr.GetOldObject())
+	afterHash := hashObject(attr.GetObject())
+	if attr.GetOperation() == admission.Delete {
+		// the object of a delete request holds the delete options
+		afterHash = ""
+	}
+
+	pending.mu.Lock()
+	defer pending.mu.Unlock()
+
+	if pending.entry != nil {
+		// the request was made by a handler of the HTTP API, which records the operation,
+		// or the request went through admission twice. The hashes of the first write are kept.
+		if !pending.entry.StateTracked {
+			pending.entry.BeforeHash = beforeHash
+			pending.entry.AfterHash = afterHash
+			pending.entry.StateTracked = true
+		}
+		return nil
+	}
+
+	gvr := attr.GetResource()
+	resource := gvr.GroupResource().String()
+	if attr.GetSubresource() != "" {
+		resource += "/" + attr.GetSubresource()
+	}
+
+	entry := &Entry{
+		Source:     SourceAPIServer,
+		Action:     strings.ToLower(string(attr.GetOperation())),
+		Resource:   resource,
+		ResourceID: attr.GetName(),
+		BeforeHash:   beforeHash,
+		AfterHash:    afterHash,
+		StateTracked: true,
+		Created:      time.Now(),
+	}
+
+	if ns, err := types.ParseNamespace(attr.GetNamespace()); err == nil {
+		entry.OrgID = ns.OrgID
+	}
+
+	if requester, err := identity.GetRequester(ctx); err == nil {
+		entry.ActorUID = requester.GetUID()
+		entry.ActorLogin = requester.GetLogin()
+		entry.ActorType = string(requester.GetIdentityType())
+		if entry.OrgID == 0 {
+			entry.OrgID = requester.GetOrgID()
+		}
+	} else if u := attr.GetUserInfo(); u != nil {
+		entry.ActorUID = u.GetUID()
+		entry.ActorLogin = u.GetName()
+	}
+
+	pending.entry = entry
+	return nil
+}
+
+// WithRecorder records the write requests to the Kubernetes style APIs that were described by the admission plugin,
+// with the outcome of the response. Requests made by the handlers of the HTTP API are recorded by Middleware instead.
+func WithRecorder(handler http.Handler, svc Service) http.Handler {
+	logger := log.New("auditlog.apiserver")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if svc.IsDisabled() || requestAction(r.Method, false) == "" || pendingEntryFrom(r.Context()) != nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		pending := &pendingEntry{}
+		rw := web.Rw(w, r)
+		handler.ServeHTTP(rw, r.WithContext(withPendingEntry(r.Context(), pending)))
+
+		pending.mu.Lock()
+		entry := pending.entry
+		pending.mu.Unlock()
+		if entry == nil {
+			// the request did not reach admission, eg it was not authorized
+			return
+		}
+		entry.StatusCode = rw.Status()
+		entry.Outcome = statusOutcome(rw.Status())
+
+		// the request may have been cancelled by the client, the entry must still be stored
+		if err := svc.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+			logger.Error("Failed to record audit log entry", "resource", entry.Resource, "name", entry.ResourceID, "error", err)
+		}
+	})
+}
+
+func hashObject(obj runtime.Object) string {
+	if obj == nil {
+		return ""
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return Hash(b)
+}
@@ -0,0 +1,124 @@
+package auditlog_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/auditlog/auditlogtest"
+	grafanauser "github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestAdmission(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "dashboard.grafana.app", Version: "v1beta1", Kind: "Dashboard"}
+	gvr := schema.GroupVersionResource{Group: "dashboard.grafana.app", Version: "v1beta1", Resource: "dashboards"}
+	newObject := func(title string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName("abc")
+		obj.SetNamespace("org-2")
+		obj.Object["spec"] = map[string]any{"title": title}
+		return obj
+	}
+	ctx := identity.WithRequester(context.Background(), &grafanauser.SignedInUser{UserUID: "u1", Login: "admin", OrgID: 2})
+	// serve runs the admission plugin behind the recorder, like the apiserver handler chain does
+	serve := func(svc *auditlogtest.FakeService, ctx context.Context, attr admission.Attributes, status int) {
+		plugin := auditlog.NewAdmission(svc)
+		handler := auditlog.WithRecorder(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, plugin.Validate(r.Context(), attr, nil))
+			w.WriteHeader(status)
+		}), svc)
+		req := httptest.NewRequest(http.MethodPut, "/apis/dashboard.grafana.app/v1beta1/namespaces/org-2/dashboards/abc", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	}
+
+	t.Run("records updates with the hashes of both versions", func(t *testing.T) {
+		svc := &auditlogtest.FakeService{}
+		attr := admission.NewAttributesRecord(newObject("new"), newObject("old"), gvk, "org-2", "abc", gvr, "", admission.Update, nil, false, &user.DefaultInfo{})
+		require.True(t, auditlog.NewAdmission(svc).Handles(admission.Update))
+
+		serve(svc, ctx, attr, http.StatusOK)
+		require.Len(t, svc.RecordedEntries, 1)
+
+		entry := svc.RecordedEntries[0]
+		require.Equal(t, auditlog.SourceAPIServer, entry.Source)
+		require.Equal(t, "update", entry.Action)
+		require.Equal(t, "dashboards.dashboard.grafana.app", entry.Resource)
+		require.Equal(t, "abc", entry.ResourceID)
+		require.Equal(t, int64(2), entry.OrgID)
+		require.Equal(t, "admin", entry.ActorLogin)
+		require.Equal(t, auditlog.OutcomeSuccess, entry.Outcome)
+		require.Equal(t, http.StatusOK, entry.StatusCode)
+		require.NotEmpty(t, entry.BeforeHash)
+		require.NotEmpty(t, entry.AfterHash)
+		require.NotEqual(t, entry.BeforeHash, entry.AfterHash)
+		require.True(t, entry.StateTracked)
+	})
+
+	t.Run("records the outcome of failed writes", func(t *testing.T) {
+		svc := &auditlogtest.FakeService{}
+		attr := admission.NewAttributesRecord(newObject("new"), newObject("old"), gvk, "org-2", "abc", gvr, "", admission.Update, nil, false, &user.DefaultInfo{})
+
+		serve(svc, ctx, attr, http.StatusConflict)
+		require.Len(t, svc.RecordedEntries, 1)
+		require.Equal(t, auditlog.OutcomeFailure, svc.RecordedEntries[0].Outcome)
+		require.Equal(t, http.StatusConflict, svc.RecordedEntries[0].StatusCode)
+	})
+
+	t.Run("records deletes without an after hash", func(t *testing.T) {
+		svc := &auditlogtest.FakeService{}
+		attr := admission.NewAttributesRecord(nil, newObject("old"), gvk, "org-2", "abc", gvr, "", admission.Delete, nil, false, &user.DefaultInfo{})
+
+		serve(svc, ctx, attr, http.StatusOK)
+		require.Len(t, svc.RecordedEntries, 1)
+		require.Equal(t, "delete", svc.RecordedEntries[0].Action)
+		require.NotEmpty(t, svc.RecordedEntries[0].BeforeHash)
+		require.Empty(t, svc.RecordedEntries[0].AfterHash)
+	})
+
+	t.Run("skips dry runs and requests that did not reach admission", func(t *testing.T) {
+		svc := &auditlogtest.FakeService{}
+		attr := admission.NewAttributesRecord(newObject("new"), nil, gvk, "org-2", "abc", gvr, "", admission.Create, nil, true, &user.DefaultInfo{})
+		serve(svc, ctx, attr, http.StatusCreated)
+
+		handler := auditlog.WithRecorder(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}), svc)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/apis/dashboard.grafana.app/v1beta1/namespaces/org-2/dashboards/abc", nil))
+
+		require.Empty(t, svc.RecordedEntries)
+	})
+
+	t.Run("records the writes of the HTTP API served by the apiserver once", func(t *testing.T) {
+		svc := &auditlogtest.FakeService{}
+		cfg := setting.NewCfg()
+		cfg.AuditLog = setting.AuditLogSettings{Enabled: true}
+		attr := admission.NewAttributesRecord(newObject("new"), newObject("old"), gvk, "org-2", "abc", gvr, "", admission.Update, nil, false, &user.DefaultInfo{})
+
+		handler := auditlog.Middleware(cfg, svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the loopback transport only keeps the propagated values of the request context
+			serve(svc, auditlog.PropagateEntry(r.Context(), ctx), attr, http.StatusOK)
+			w.WriteHeader(http.StatusOK)
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/dashboards/db", nil))
+
+		require.Len(t, svc.RecordedEntries, 1)
+		entry := svc.RecordedEntries[0]
+		require.Equal(t, auditlog.SourceHTTP, entry.Source)
+		require.Equal(t, "dashboards", entry.Resource)
+		require.Equal(t, auditlog.OutcomeSuccess, entry.Outcome)
+		require.NotEmpty(t, entry.BeforeHash)
+		require.NotEmpty(t, entry.AfterHash)
+		require.True(t, entry.StateTracked)
+	})
+}
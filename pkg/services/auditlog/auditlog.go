@@ -0,0 +1,102 @@
+package auditlog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+const (
+	ActionRead = "auditlogs:read"
+)
+
+const (
+	SourceHTTP      = "http"
+	SourceAPIServer = "apiserver"
+)
+
+// Outcome of the recorded operation
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	// OutcomeDenied is used when the actor was not authenticated or not allowed to perform the operation
+	OutcomeDenied = "denied"
+)
+
+var (
+	ErrInvalidQuery = errutil.BadRequest("auditlog.invalid-query", errutil.WithPublicMessage("Invalid audit log query"))
+)
+
+type Service interface {
+	// Record stores an entry and queues it for export
+	Record(ctx context.Context, entry *Entry) error
+	// Search returns the entries matching the query, newest first
+	Search(ctx context.Context, query *SearchQuery) (*SearchResult, error)
+	// IsDisabled reports whether recording is disabled in the configuration
+	IsDisabled() bool
+}
+
+// Entry describes a single operation performed by an actor
+type Entry struct {
+	ID         int64  `xorm:"pk autoincr 'id'" json:"id"`
+	OrgID      int64  `xorm:"org_id" json:"orgId"`
+	ActorUID   string `xorm:"actor_uid" json:"actorUid"`
+	ActorLogin string `xorm:"actor_login" json:"actorLogin"`
+	ActorType  string `xorm:"actor_type" json:"actorType"`
+	Source     string `xorm:"source" json:"source"`
+	// Action is create, update, delete or read for HTTP requests and the admission operation for apiserver requests
+	Action string `xorm:"action" json:"action"`
+	// Resource is the first path segment after /api for HTTP requests and group/resource for apiserver requests
+	Resource   string `xorm:"resource" json:"resource"`
+	ResourceID string `xorm:"resource_id" json:"resourceId"`
+	Method     string `xorm:"method" json:"method"`
+	Path       string `xorm:"path" json:"path"`
+	// BeforeHash and AfterHash are sha256 hashes of the resource before and after the operation.
+	// They are only known for the resources served by the apiserver, including the HTTP requests proxied to it.
+	BeforeHash string `xorm:"before_hash" json:"beforeHash,omitempty"`
+	AfterHash  string `xorm:"after_hash" json:"afterHash,omitempty"`
+	// StateTracked reports whether BeforeHash and AfterHash describe the resource. It is false for the writes
+	// of the HTTP API handled without the apiserver, like data sources, users, access control and settings,
+	// whose state before and after the operation is not known.
+	StateTracked bool      `xorm:"state_tracked" json:"stateTracked"`
+	Outcome      string    `xorm:"outcome" json:"outcome"`
+	StatusCode   int       `xorm:"status_code" json:"statusCode,omitempty"`
+	RemoteAddr   string    `xorm:"remote_addr" json:"remoteAddr,omitempty"`
+	Created      time.Time `xorm:"'created'" json:"created"`
+}
+
+func (e Entry) TableName() string {
+	return "audit_log"
+}
+
+type SearchQuery struct {
+	OrgID      int64
+	ActorUID   string
+	Action     string
+	Resource   string
+	ResourceID string
+	Outcome    string
+	From       time.Time
+	To         time.Time
+	Page       int
+	Limit      int
+}
+
+type SearchResult struct {
+	TotalCount int64    `json:"totalCount"`
+	Entries    []*Entry `json:"entries"`
+	Page       int      `json:"page"`
+	PerPage    int      `json:"perPage"`
+}
+
+// Hash returns the hex encoded sha256 hash of b, or an empty string if b is empty
+func Hash(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package auditlogimpl
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+func (s *Service) registerAPIEndpoints(routeRegister routing.RouteRegister) {
+	authorize := ac.Middleware(s.accessControl)
+
+	routeRegister.Group("/api/audit-logs", func(entities routing.RouteRegister) {
+		entities.Get("/", authorize(ac.EvalPermission(auditlog.ActionRead)), routing.Wrap(s.searchHandler))
+	})
+}
+
+// swagger:route GET /audit-logs audit_log searchAuditLogs
+//
+// Search the audit log of the current organization.
+//
+// Returns the entries matching the filters, newest first. `from` and `to` are epoch milliseconds.
+// Use the `perpage` parameter to control the number of entries returned, the default is 100 and the maximum 1000.
+//
+// Responses:
+// 200: searchAuditLogsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (s *Service) searchHandler(c *contextmodel.ReqContext) response.Response {
+	query := &auditlog.SearchQuery{
+		OrgID:      c.GetOrgID(),
+		ActorUID:   c.Query("actorUid"),
+		Action:     c.Query("action"),
+		Resource:   c.Query("resource"),
+		ResourceID: c.Query("resourceId"),
+		Outcome:    c.Query("outcome"),
+		Page:       c.QueryInt("page"),
+		Limit:      c.QueryInt("perpage"),
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.UnixMilli(from)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.UnixMilli(to)
+	}
+
+	result, err := s.Search(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to search audit log", err)
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:parameters searchAuditLogs
+type SearchAuditLogsParams struct {
+	// in:query
+	// required:false
+	ActorUID string `json:"actorUid"`
+	// in:query
+	// required:false
+	Action string `json:"action"`
+	// in:query
+	// required:false
+	Resource string `json:"resource"`
+	// in:query
+	// required:false
+	ResourceID string `json:"resourceId"`
+	// in:query
+	// required:false
+	Outcome string `json:"outcome"`
+	// in:query
+	// required:false
+	From int64 `json:"from"`
+	// in:query
+	// required:false
+	To int64 `json:"to"`
+	// in:query
+	// required:false
+	// default:1
+	Page int `json:"page"`
+	// in:query
+	// required:false
+	// default:100
+	PerPage int `json:"perpage"`
+}
+
+// swagger:response searchAuditLogsResponse
+type SearchAuditLogsResponse struct {
+	// in:body
+	Body auditlog.SearchResult `json:"body"`
+}
@@ -0,0 +1,192 @@
+package auditlogimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/serverlock"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	cleanupInterval = time.Hour
+	flushInterval   = time.Second
+	flushBatchSize  = 100
+	recordQueueSize = 10000
+	flushTimeout    = 30 * time.Second
+
+	defaultPerPage = 100
+	maxPerPage     = 1000
+)
+
+var _ auditlog.Service = new(Service)
+
+var auditLogReaderRole = ac.RoleDTO{
+	Name:        "fixed:auditlogs:reader",
+	DisplayName: "Reader",
+	Description: "Search the audit log of the organization",
+	Group:       "Audit log",
+	Permissions: []ac.Permission{
+		{Action: auditlog.ActionRead},
+	},
+}
+
+func ProvideService(cfg *setting.Cfg, db db.DB, lock *serverlock.ServerLockService, routeRegister routing.RouteRegister,
+	accessControl ac.AccessControl, accesscontrolService ac.Service) (*Service, error) {
+	s := &Service{
+		cfg:           cfg,
+		store:         &xormStore{db: db},
+		lock:          lock,
+		accessControl: accessControl,
+		exporter:      newExporter(cfg),
+		queue:         make(chan *auditlog.Entry, recordQueueSize),
+		now:           time.Now,
+		log:           log.New("auditlog"),
+	}
+
+	if s.IsDisabled() {
+		return s, nil
+	}
+
+	if err := accesscontrolService.DeclareFixedRoles(ac.RoleRegistration{
+		Role:   auditLogReaderRole,
+		Grants: []string{string(org.RoleAdmin), ac.RoleGrafanaAdmin},
+	}); err != nil {
+		return nil, err
+	}
+
+	s.registerAPIEndpoints(routeRegister)
+
+	return s, nil
+}
+
+type Service struct {
+	cfg           *setting.Cfg
+	store         store
+	lock          *serverlock.ServerLockService
+	accessControl ac.AccessControl
+	exporter      exporter
+	queue         chan *auditlog.Entry
+	now           func() time.Time
+	log           log.Logger
+}
+
+func (s *Service) IsDisabled() bool {
+	return !s.cfg.AuditLog.Enabled
+}
+
+// Record queues the entry, it is stored and exported in batches by Run so requests do not wait for the database.
+// When the queue is full the entry is stored right away.
+func (s *Service) Record(ctx context.Context, entry *auditlog.Entry) error {
+	if s.IsDisabled() {
+		return nil
+	}
+	if entry.Created.IsZero() {
+		entry.Created = s.now()
+	}
+
+	select {
+	case s.queue <- entry:
+		return nil
+	default:
+		s.log.Warn("Audit log queue is full, entry is stored without being exported", "resource", entry.Resource)
+		return s.store.Insert(ctx, []*auditlog.Entry{entry})
+	}
+}
+
+func (s *Service) Search(ctx context.Context, query *auditlog.SearchQuery) (*auditlog.SearchResult, error) {
+	if query.OrgID == 0 {
+		return nil, auditlog.ErrInvalidQuery.Errorf("org id is required")
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, auditlog.ErrInvalidQuery.Errorf("to must be after from")
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultPerPage
+	}
+	if query.Limit > maxPerPage {
+		query.Limit = maxPerPage
+	}
+	return s.store.Search(ctx, query)
+}
+
+func (s *Service) Run(ctx context.Context) error {
+	if s.IsDisabled() {
+		return nil
+	}
+
+	cleanupTicker := time.NewTicker(cleanupInterval)
+	defer cleanupTicker.Stop()
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+
+	s.cleanup(ctx)
+
+	batch := make([]*auditlog.Entry, 0, flushBatchSize)
+	for {
+		select {
+		case entry := <-s.queue:
+			batch = append(batch, entry)
+			if len(batch) >= flushBatchSize {
+				batch = s.flush(ctx, batch)
+			}
+		case <-flushTicker.C:
+			batch = s.flush(ctx, batch)
+		case <-cleanupTicker.C:
+			s.cleanup(ctx)
+		case <-ctx.Done():
+			// store what is left before shutting down
+			for len(s.queue) > 0 {
+				batch = append(batch, <-s.queue)
+			}
+			s.flush(context.Background(), batch)
+			return ctx.Err()
+		}
+	}
+}
+
+// flush stores the batch, sends it to the exporter and returns the emptied batch
+func (s *Service) flush(ctx context.Context, batch []*auditlog.Entry) []*auditlog.Entry {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, flushTimeout)
+	defer cancel()
+	if err := s.store.Insert(ctx, batch); err != nil {
+		s.log.Error("Failed to store audit log entries", "count", len(batch), "error", err)
+	}
+	if s.exporter != nil {
+		if err := s.exporter.Export(ctx, batch); err != nil {
+			s.log.Error("Failed to export audit log entries", "count", len(batch), "error", err)
+		}
+	}
+	return batch[:0]
+}
+
+func (s *Service) cleanup(ctx context.Context) {
+	if s.cfg.AuditLog.Retention <= 0 {
+		return
+	}
+
+	err := s.lock.LockAndExecute(ctx, "delete expired audit log entries", cleanupInterval, func(ctx context.Context) {
+		deleted, err := s.store.DeleteOlderThan(ctx, s.now().Add(-s.cfg.AuditLog.Retention))
+		if err != nil {
+			s.log.Error("Problem deleting expired audit log entries", "error", err)
+			return
+		}
+		s.log.Debug("Deleted expired audit log entries", "rows affected", deleted)
+	})
+	if err != nil {
+		s.log.Error("Failed to lock and execute cleanup of expired audit log entries", "error", err)
+	}
+}
@@ -0,0 +1,71 @@
+package auditlogimpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+type fakeStore struct {
+	store
+	inserted []*auditlog.Entry
+}
+
+func (f *fakeStore) Insert(ctx context.Context, entries []*auditlog.Entry) error {
+	f.inserted = append(f.inserted, entries...)
+	return nil
+}
+
+type fakeExporter struct {
+	exported []*auditlog.Entry
+}
+
+func (f *fakeExporter) Export(ctx context.Context, entries []*auditlog.Entry) error {
+	f.exported = append(f.exported, entries...)
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.AuditLog.Enabled = true
+	newService := func(queueSize int) (*Service, *fakeStore, *fakeExporter) {
+		st, exp := &fakeStore{}, &fakeExporter{}
+		return &Service{
+			cfg:      cfg,
+			store:    st,
+			exporter: exp,
+			queue:    make(chan *auditlog.Entry, queueSize),
+			now:      time.Now,
+			log:      log.NewNopLogger(),
+		}, st, exp
+	}
+
+	t.Run("entries are stored and exported when the queue is flushed", func(t *testing.T) {
+		s, st, exp := newService(10)
+		require.NoError(t, s.Record(context.Background(), &auditlog.Entry{Action: "create"}))
+		require.NoError(t, s.Record(context.Background(), &auditlog.Entry{Action: "delete"}))
+		require.Empty(t, st.inserted)
+
+		batch := []*auditlog.Entry{<-s.queue, <-s.queue}
+		require.Empty(t, s.flush(context.Background(), batch))
+		require.Len(t, st.inserted, 2)
+		require.Len(t, exp.exported, 2)
+		require.False(t, st.inserted[0].Created.IsZero())
+	})
+
+	t.Run("entries are stored right away when the queue is full", func(t *testing.T) {
+		s, st, exp := newService(1)
+		require.NoError(t, s.Record(context.Background(), &auditlog.Entry{Action: "create"}))
+		require.NoError(t, s.Record(context.Background(), &auditlog.Entry{Action: "delete"}))
+
+		require.Len(t, st.inserted, 1)
+		require.Equal(t, "delete", st.inserted[0].Action)
+		require.Empty(t, exp.exported)
+	})
+}
@@ -0,0 +1,122 @@
+package auditlogimpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const lokiPushPath = "/loki/api/v1/push"
+
+// exporter sends entries to a destination outside of the database
+type exporter interface {
+	Export(ctx context.Context, entries []*auditlog.Entry) error
+}
+
+func newExporter(cfg *setting.Cfg) exporter {
+	switch cfg.AuditLog.Export {
+	case setting.AuditLogExportFile:
+		return &fileExporter{path: cfg.AuditLog.ExportFilePath}
+	case setting.AuditLogExportLoki:
+		return &lokiExporter{
+			url:      strings.TrimSuffix(cfg.AuditLog.ExportLokiURL, "/") + lokiPushPath,
+			tenantID: cfg.AuditLog.ExportLokiTenantID,
+			client:   &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return nil
+}
+
+// fileExporter appends entries to a file as JSON lines
+type fileExporter struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (e *fileExporter) Export(ctx context.Context, entries []*auditlog.Entry) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o750); err != nil {
+		return err
+	}
+	// nolint:gosec
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// lokiExporter pushes entries to Loki as a single stream
+type lokiExporter struct {
+	url      string
+	tenantID string
+	client   *http.Client
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (e *lokiExporter) Export(ctx context.Context, entries []*auditlog.Entry) error {
+	stream := lokiStream{
+		Stream: map[string]string{"service_name": "grafana", "source": "audit_log"},
+		Values: make([][2]string, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.Created.UnixNano(), 10), string(line)})
+	}
+
+	body, err := json.Marshal(lokiPushRequest{Streams: []lokiStream{stream}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", e.tenantID)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("loki push returned status %d", resp.StatusCode)
+	}
+	return nil
+}
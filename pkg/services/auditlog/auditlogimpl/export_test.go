@@ -0,0 +1,86 @@
+package auditlogimpl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestFileExporter(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.AuditLog.Export = setting.AuditLogExportFile
+	cfg.AuditLog.ExportFilePath = filepath.Join(t.TempDir(), "audit", "audit.log")
+	exp := newExporter(cfg)
+
+	require.NoError(t, exp.Export(context.Background(), []*auditlog.Entry{{ID: 1, Action: "create"}}))
+	require.NoError(t, exp.Export(context.Background(), []*auditlog.Entry{{ID: 2, Action: "delete"}}))
+
+	f, err := os.Open(cfg.AuditLog.ExportFilePath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var actions []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := auditlog.Entry{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		actions = append(actions, entry.Action)
+	}
+	require.Equal(t, []string{"create", "delete"}, actions)
+}
+
+func TestLokiExporter(t *testing.T) {
+	var (
+		received lokiPushRequest
+		tenantID string
+		path     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		tenantID = r.Header.Get("X-Scope-OrgID")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := setting.NewCfg()
+	cfg.AuditLog.Export = setting.AuditLogExportLoki
+	cfg.AuditLog.ExportLokiURL = server.URL + "/"
+	cfg.AuditLog.ExportLokiTenantID = "tenant-1"
+	exp := newExporter(cfg)
+
+	created := time.Unix(1700000000, 0)
+	require.NoError(t, exp.Export(context.Background(), []*auditlog.Entry{{ID: 1, Action: "update", Created: created}}))
+
+	require.Equal(t, lokiPushPath, path)
+	require.Equal(t, "tenant-1", tenantID)
+	require.Len(t, received.Streams, 1)
+	require.Equal(t, "audit_log", received.Streams[0].Stream["source"])
+	require.Len(t, received.Streams[0].Values, 1)
+	require.Equal(t, "1700000000000000000", received.Streams[0].Values[0][0])
+	require.Contains(t, received.Streams[0].Values[0][1], `"update"`)
+}
+
+func TestLokiExporterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := setting.NewCfg()
+	cfg.AuditLog.Export = setting.AuditLogExportLoki
+	cfg.AuditLog.ExportLokiURL = server.URL
+	err := newExporter(cfg).Export(context.Background(), []*auditlog.Entry{{ID: 1}})
+	require.ErrorContains(t, err, "status 400")
+}
@@ -0,0 +1,106 @@
+package auditlogimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+type store interface {
+	Insert(ctx context.Context, entries []*auditlog.Entry) error
+	Search(ctx context.Context, query *auditlog.SearchQuery) (*auditlog.SearchResult, error)
+	DeleteOlderThan(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+type xormStore struct {
+	db db.DB
+}
+
+func (s *xormStore) Insert(ctx context.Context, entries []*auditlog.Entry) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+		return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+			for _, entry := range entries {
+				if _, err := sess.Insert(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+type condition struct {
+	sql  string
+	args []any
+}
+
+func searchConditions(query *auditlog.SearchQuery) []condition {
+	conds := []condition{{sql: "org_id = ?", args: []any{query.OrgID}}}
+	if query.ActorUID != "" {
+		conds = append(conds, condition{sql: "actor_uid = ?", args: []any{query.ActorUID}})
+	}
+	if query.Action != "" {
+		conds = append(conds, condition{sql: "action = ?", args: []any{query.Action}})
+	}
+	if query.Resource != "" {
+		conds = append(conds, condition{sql: "resource = ?", args: []any{query.Resource}})
+	}
+	if query.ResourceID != "" {
+		conds = append(conds, condition{sql: "resource_id = ?", args: []any{query.ResourceID}})
+	}
+	if query.Outcome != "" {
+		conds = append(conds, condition{sql: "outcome = ?", args: []any{query.Outcome}})
+	}
+	if !query.From.IsZero() {
+		conds = append(conds, condition{sql: "created >= ?", args: []any{query.From}})
+	}
+	if !query.To.IsZero() {
+		conds = append(conds, condition{sql: "created <= ?", args: []any{query.To}})
+	}
+	return conds
+}
+
+func (s *xormStore) Search(ctx context.Context, query *auditlog.SearchQuery) (*auditlog.SearchResult, error) {
+	result := &auditlog.SearchResult{
+		Entries: make([]*auditlog.Entry, 0),
+		Page:    query.Page,
+		PerPage: query.Limit,
+	}
+	conds := searchConditions(query)
+
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		// conditions are reset after each query so they are applied twice
+		countSess := sess.Table("audit_log")
+		for _, c := range conds {
+			countSess.Where(c.sql, c.args...)
+		}
+		total, err := countSess.Count()
+		if err != nil {
+			return err
+		}
+		result.TotalCount = total
+
+		findSess := sess.Table("audit_log")
+		for _, c := range conds {
+			findSess.Where(c.sql, c.args...)
+		}
+		offset := (query.Page - 1) * query.Limit
+		return findSess.Desc("created").Desc("id").Limit(query.Limit, offset).Find(&result.Entries)
+	})
+	return result, err
+}
+
+func (s *xormStore) DeleteOlderThan(ctx context.Context, olderThan time.Time) (int64, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("DELETE FROM audit_log WHERE created < ?", olderThan)
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
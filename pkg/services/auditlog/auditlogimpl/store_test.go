@@ -0,0 +1,74 @@
+package auditlogimpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationAuditLogStore(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	ctx := context.Background()
+	store := &xormStore{db: db.InitTestDB(t)}
+	now := time.Now().Truncate(time.Second)
+
+	entries := []*auditlog.Entry{
+		{OrgID: 1, ActorUID: "u1", Action: "create", Resource: "datasources", Outcome: auditlog.OutcomeSuccess, Created: now.Add(-48 * time.Hour)},
+		{OrgID: 1, ActorUID: "u1", Action: "update", Resource: "datasources", ResourceID: "uid/abc", Outcome: auditlog.OutcomeSuccess, Created: now.Add(-time.Hour)},
+		{OrgID: 1, ActorUID: "u2", Action: "delete", Resource: "teams", ResourceID: "1", Outcome: auditlog.OutcomeDenied, Created: now},
+		{OrgID: 2, ActorUID: "u3", Action: "create", Resource: "teams", Outcome: auditlog.OutcomeSuccess, Created: now},
+	}
+	require.NoError(t, store.Insert(ctx, entries))
+	for _, entry := range entries {
+		require.NotZero(t, entry.ID)
+	}
+
+	t.Run("search is scoped to the org and ordered newest first", func(t *testing.T) {
+		result, err := store.Search(ctx, &auditlog.SearchQuery{OrgID: 1, Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.TotalCount)
+		require.Len(t, result.Entries, 3)
+		require.Equal(t, "delete", result.Entries[0].Action)
+		require.Equal(t, "create", result.Entries[2].Action)
+	})
+
+	t.Run("search filters and paginates", func(t *testing.T) {
+		result, err := store.Search(ctx, &auditlog.SearchQuery{OrgID: 1, ActorUID: "u1", Page: 2, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.TotalCount)
+		require.Len(t, result.Entries, 1)
+		require.Equal(t, "create", result.Entries[0].Action)
+
+		result, err = store.Search(ctx, &auditlog.SearchQuery{OrgID: 1, Resource: "datasources", ResourceID: "uid/abc", Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		require.Equal(t, "update", result.Entries[0].Action)
+
+		result, err = store.Search(ctx, &auditlog.SearchQuery{OrgID: 1, Outcome: auditlog.OutcomeDenied, From: now.Add(-2 * time.Hour), Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		require.Equal(t, "teams", result.Entries[0].Resource)
+	})
+
+	t.Run("deletes entries older than the retention", func(t *testing.T) {
+		deleted, err := store.DeleteOlderThan(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		result, err := store.Search(ctx, &auditlog.SearchQuery{OrgID: 1, Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.TotalCount)
+	})
+}
@@ -0,0 +1,29 @@
+package auditlogtest
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+var _ auditlog.Service = new(FakeService)
+
+type FakeService struct {
+	Disabled        bool
+	RecordedEntries []*auditlog.Entry
+	ExpectedResult  *auditlog.SearchResult
+	ExpectedErr     error
+}
+
+func (f *FakeService) Record(ctx context.Context, entry *auditlog.Entry) error {
+	f.RecordedEntries = append(f.RecordedEntries, entry)
+	return f.ExpectedErr
+}
+
+func (f *FakeService) Search(ctx context.Context, query *auditlog.SearchQuery) (*auditlog.SearchResult, error) {
+	return f.ExpectedResult, f.ExpectedErr
+}
+
+func (f *FakeService) IsDisabled() bool {
+	return f.Disabled
+}
@@ -0,0 +1,33 @@
+package auditlog
+
+import (
+	"context"
+	"sync"
+)
+
+type pendingEntryKey struct{}
+
+// pendingEntry holds the entry of the request being served, it is recorded once the response is written.
+// The entry is completed by the admission plugin, which may run in another goroutine.
+type pendingEntry struct {
+	mu    sync.Mutex
+	entry *Entry
+}
+
+func withPendingEntry(ctx context.Context, p *pendingEntry) context.Context {
+	return context.WithValue(ctx, pendingEntryKey{}, p)
+}
+
+func pendingEntryFrom(ctx context.Context) *pendingEntry {
+	p, _ := ctx.Value(pendingEntryKey{}).(*pendingEntry)
+	return p
+}
+
+// PropagateEntry carries the pending entry of a request to the apiserver requests made through the loopback transport.
+// Writes of the HTTP API that are served by the apiserver are then recorded once, with the hashes of the resource.
+func PropagateEntry(from, to context.Context) context.Context {
+	if p := pendingEntryFrom(from); p != nil {
+		return withPendingEntry(to, p)
+	}
+	return to
+}
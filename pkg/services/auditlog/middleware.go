@@ -0,0 +1,120 @@
+package auditlog
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const apiPathPrefix = "/api/"
+
+// Middleware records the requests to the HTTP API. It must be used after the context handler so the
+// signed in user is known. Requests to the Kubernetes style APIs are recorded by WithRecorder instead.
+func Middleware(cfg *setting.Cfg, svc Service) web.Middleware {
+	logger := log.New("auditlog.middleware")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			action := requestAction(r.Method, cfg.AuditLog.LogReads)
+			if svc.IsDisabled() || action == "" || !shouldRecord(r.URL.Path, cfg.AuditLog.ExcludePaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			resource, resourceID := parseAPIPath(r.URL.Path)
+			pending := &pendingEntry{entry: &Entry{
+				Source:     SourceHTTP,
+				Action:     action,
+				Resource:   resource,
+				ResourceID: resourceID,
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Created:    time.Now(),
+			}}
+
+			// the entry is passed to the apiserver requests made by the handler, so they are not recorded twice
+			ctx := withPendingEntry(r.Context(), pending)
+			r = r.WithContext(ctx)
+			c := contexthandler.FromContext(ctx)
+			if c != nil {
+				// the handlers use the request of the request context
+				*c.Req = *c.Req.WithContext(ctx)
+			}
+
+			rw := web.Rw(w, r)
+			next.ServeHTTP(rw, r)
+
+			// copy the entry, the apiserver requests of the handler may still be running
+			pending.mu.Lock()
+			entry := *pending.entry
+			pending.mu.Unlock()
+			entry.StatusCode = rw.Status()
+			entry.Outcome = statusOutcome(rw.Status())
+
+			if c != nil {
+				entry.RemoteAddr = c.RemoteAddr()
+				if c.SignedInUser != nil && !c.SignedInUser.IsNil() {
+					entry.OrgID = c.SignedInUser.GetOrgID()
+					entry.ActorUID = c.SignedInUser.GetUID()
+					entry.ActorLogin = c.SignedInUser.GetLogin()
+					entry.ActorType = string(c.SignedInUser.GetIdentityType())
+				}
+			}
+
+			// the request may have been cancelled by the client, the entry must still be stored
+			if err := svc.Record(context.WithoutCancel(r.Context()), &entry); err != nil {
+				logger.Error("Failed to record audit log entry", "path", entry.Path, "error", err)
+			}
+		})
+	}
+}
+
+func requestAction(method string, logReads bool) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	case http.MethodGet:
+		if logReads {
+			return "read"
+		}
+	}
+	return ""
+}
+
+func shouldRecord(path string, excludePaths []string) bool {
+	if !strings.HasPrefix(path, apiPathPrefix) {
+		return false
+	}
+	for _, p := range excludePaths {
+		if strings.HasPrefix(path, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAPIPath splits /api/datasources/uid/abc into the datasources resource and the uid/abc resource id
+func parseAPIPath(path string) (string, string) {
+	resource, resourceID, _ := strings.Cut(strings.Trim(strings.TrimPrefix(path, apiPathPrefix), "/"), "/")
+	return resource, resourceID
+}
+
+func statusOutcome(status int) string {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return OutcomeDenied
+	case status >= http.StatusBadRequest:
+		return OutcomeFailure
+	default:
+		return OutcomeSuccess
+	}
+}
@@ -0,0 +1,90 @@
+package auditlog_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/auditlog/auditlogtest"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestMiddleware(t *testing.T) {
+	setup := func(logReads bool, status int) (*auditlogtest.FakeService, http.Handler) {
+		cfg := setting.NewCfg()
+		cfg.AuditLog = setting.AuditLogSettings{
+			Enabled:      true,
+			LogReads:     logReads,
+			ExcludePaths: []string{"/api/ds/query"},
+		}
+		svc := &auditlogtest.FakeService{}
+		handler := auditlog.Middleware(cfg, svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.ReadAll(r.Body)
+			w.WriteHeader(status)
+		}))
+		return svc, handler
+	}
+
+	t.Run("records write requests", func(t *testing.T) {
+		svc, handler := setup(false, http.StatusOK)
+		req := httptest.NewRequest(http.MethodPut, "/api/datasources/uid/abc", strings.NewReader(`{"name":"prometheus"}`))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, svc.RecordedEntries, 1)
+		entry := svc.RecordedEntries[0]
+		require.Equal(t, auditlog.SourceHTTP, entry.Source)
+		require.Equal(t, "update", entry.Action)
+		require.Equal(t, "datasources", entry.Resource)
+		require.Equal(t, "uid/abc", entry.ResourceID)
+		require.Empty(t, entry.AfterHash)
+		require.False(t, entry.StateTracked)
+		require.Equal(t, auditlog.OutcomeSuccess, entry.Outcome)
+		require.Equal(t, http.StatusOK, entry.StatusCode)
+	})
+
+	t.Run("records the outcome of denied requests", func(t *testing.T) {
+		svc, handler := setup(false, http.StatusForbidden)
+		req := httptest.NewRequest(http.MethodDelete, "/api/teams/1", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, svc.RecordedEntries, 1)
+		require.Equal(t, "delete", svc.RecordedEntries[0].Action)
+		require.Equal(t, auditlog.OutcomeDenied, svc.RecordedEntries[0].Outcome)
+		require.Empty(t, svc.RecordedEntries[0].AfterHash)
+	})
+
+	t.Run("skips reads, excluded paths and apiserver requests", func(t *testing.T) {
+		svc, handler := setup(false, http.StatusOK)
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/api/datasources", nil),
+			httptest.NewRequest(http.MethodPost, "/api/ds/query", strings.NewReader("{}")),
+			httptest.NewRequest(http.MethodPost, "/apis/dashboard.grafana.app/v1beta1/namespaces/default/dashboards", strings.NewReader("{}")),
+			httptest.NewRequest(http.MethodPost, "/login", strings.NewReader("{}")),
+		} {
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+		require.Empty(t, svc.RecordedEntries)
+	})
+
+	t.Run("records reads when enabled", func(t *testing.T) {
+		svc, handler := setup(true, http.StatusOK)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/datasources", nil))
+
+		require.Len(t, svc.RecordedEntries, 1)
+		require.Equal(t, "read", svc.RecordedEntries[0].Action)
+		require.Equal(t, "datasources", svc.RecordedEntries[0].Resource)
+		require.Empty(t, svc.RecordedEntries[0].ResourceID)
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		svc, handler := setup(false, http.StatusOK)
+		svc.Disabled = true
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/teams", strings.NewReader("{}")))
+		require.Empty(t, svc.RecordedEntries)
+	})
+}
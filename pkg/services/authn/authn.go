@@ -30,6 +30,8 @@ const (
 	ClientProxy        = "auth.client.proxy"
 	ClientSAML         = "auth.client.saml"
 	ClientPasswordless = "auth.client.passwordless"
+	ClientWebAuthn     = "auth.client.webauthn"
+	ClientMTLS         = "auth.client.mtls"
 	ClientLDAP         = "ldap"
 	ClientProvisioning = "auth.client.apiserver.provisioning"
 )
@@ -71,6 +73,9 @@ type ClientParams struct {
 type FetchPermissionsParams struct {
 	// RestrictedActions will restrict the permissions to only these actions
 	RestrictedActions []string
+	// RestrictedPermissions will restrict the permissions to these actions and scopes, grouped by action.
+	// A scope is kept when it is covered by both the identity permissions and the restriction
+	RestrictedPermissions map[string][]string
 	// AllowedActions will be added to the identity permissions
 	AllowedActions []string
 	// Note: Kept for backwards compatibility, use K8s style instead
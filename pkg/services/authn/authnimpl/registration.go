@@ -25,8 +25,10 @@ import (
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	tempuser "github.com/grafana/grafana/pkg/services/temp_user"
 	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/webauthn"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -44,6 +46,7 @@ func ProvideRegistration(
 	socialService social.Service, cache *remotecache.RemoteCache,
 	ldapService service.LDAP, settingsProviderService setting.Provider,
 	tracer tracing.Tracer, tempUserService tempuser.Service, notificationService notifications.Service,
+	webAuthnService webauthn.Service, serviceAccountService serviceaccounts.Service,
 ) Registration {
 	logger := log.New("authn.registration")
 
@@ -70,9 +73,18 @@ func ProvideRegistration(
 		passwordClients = append(passwordClients, grafana)
 	}
 
+	var webAuthn *clients.WebAuthn
+	if cfg.WebAuthnAuth.Enabled {
+		webAuthn = clients.ProvideWebAuthn(cfg, webAuthnService, userService, orgService, tracer)
+		authnSvc.RegisterClient(webAuthn)
+	}
+
 	// if we have password clients configure check if basic auth or form auth is enabled
 	if len(passwordClients) > 0 {
-		passwordClient := clients.ProvidePassword(loginAttempts, tracer, passwordClients...)
+		var passwordClient authn.PasswordClient = clients.ProvidePassword(loginAttempts, tracer, passwordClients...)
+		if webAuthn != nil {
+			passwordClient = webAuthn.RequireSecondFactor(passwordClient)
+		}
 		if cfg.BasicAuthEnabled {
 			authnSvc.RegisterClient(clients.ProvideBasic(passwordClient))
 		}
@@ -116,6 +128,11 @@ func ProvideRegistration(
 		authnSvc.RegisterClient(clients.ProvideJWT(jwtService, orgRoleMapper, cfg, tracer))
 	}
 
+	if cfg.MTLSAuth.Enabled {
+		orgRoleMapper := connectors.ProvideOrgRoleMapper(cfg, orgService)
+		authnSvc.RegisterClient(clients.ProvideMTLS(cfg, orgRoleMapper, serviceAccountService, tracer))
+	}
+
 	if cfg.ExtJWTAuth.Enabled {
 		authnSvc.RegisterClient(clients.ProvideExtendedJWT(cfg, tracer))
 	}
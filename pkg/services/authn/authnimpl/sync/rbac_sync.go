@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -73,6 +74,10 @@ func (s *RBACSync) SyncPermissionsHook(ctx context.Context, ident *authn.Identit
 		}
 		grouped = filtered
 	}
+
+	if restricted := ident.ClientParams.FetchPermissionsParams.RestrictedPermissions; restricted != nil {
+		grouped = intersectPermissions(grouped, restricted)
+	}
 	ident.Permissions[ident.OrgID] = grouped
 
 	return nil
@@ -114,6 +119,42 @@ func (s *RBACSync) fetchPermissions(ctx context.Context, ident *authn.Identity)
 	return permissions, nil
 }
 
+// intersectPermissions returns the permissions granted by both sets, so the result is never broader than
+// either of them. For each action a scope is kept when it is covered by a scope of the other set, so a
+// restriction to one folder keeps only that folder from a wildcard grant and a narrower grant inside a
+// restricted wildcard is kept as is.
+// An empty scope only grants unscoped access to the action, so it is kept when either set has it and
+// none of the scopes are shared.
+func intersectPermissions(granted, restricted map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(restricted))
+	for action, restrictedScopes := range restricted {
+		grantedScopes, ok := granted[action]
+		if !ok {
+			continue
+		}
+
+		scopes := make([]string, 0, len(restrictedScopes))
+		for _, scope := range grantedScopes {
+			if scope != "" && accesscontrol.EvalPermission(action, scope).Evaluate(restricted) {
+				scopes = append(scopes, scope)
+			}
+		}
+		for _, scope := range restrictedScopes {
+			if scope != "" && !slices.Contains(scopes, scope) && accesscontrol.EvalPermission(action, scope).Evaluate(granted) {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		if len(scopes) == 0 && (slices.Contains(restrictedScopes, "") || slices.Contains(grantedScopes, "")) {
+			scopes = append(scopes, "")
+		}
+		if len(scopes) > 0 {
+			result[action] = scopes
+		}
+	}
+	return result
+}
+
 // addPermissionsForAction is a helper method that handles the common pattern of:
 // 1. Getting scope prefixes for an action
 // 2. Adding permissions with appropriate scopes
@@ -59,6 +59,41 @@ func TestRBACSync_SyncPermission(t *testing.T) {
 	}
 }
 
+func TestIntersectPermissions(t *testing.T) {
+	granted := map[string][]string{
+		"dashboards:read":  {"folders:uid:*", "dashboards:uid:abc"},
+		"dashboards:write": {"dashboards:uid:abc"},
+		"users:read":       {""},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"dashboards:read":  {"dashboards:uid:abc", "folders:uid:team"},
+		"dashboards:write": {"dashboards:uid:abc"},
+		"users:read":       {""},
+	}, intersectPermissions(granted, map[string][]string{
+		"dashboards:read":  {"folders:uid:team", "dashboards:uid:*"},
+		"dashboards:write": {"dashboards:*"},
+		"users:read":       {"users:id:1"},
+		"teams:read":       {""},
+	}))
+
+	assert.Empty(t, intersectPermissions(granted, map[string][]string{"dashboards:write": {"dashboards:uid:other"}}))
+
+	// an unscoped grant does not widen a scoped restriction, and an unscoped restriction does not keep scoped grants
+	assert.Equal(t, map[string][]string{"users:read": {""}}, intersectPermissions(
+		map[string][]string{"users:read": {""}},
+		map[string][]string{"users:read": {"users:id:1"}},
+	))
+	assert.Equal(t, map[string][]string{"users:read": {""}}, intersectPermissions(
+		map[string][]string{"users:read": {"users:id:1"}},
+		map[string][]string{"users:read": {""}},
+	))
+	assert.Equal(t, map[string][]string{"users:read": {"users:id:1"}}, intersectPermissions(
+		map[string][]string{"users:read": {"", "users:id:1"}},
+		map[string][]string{"users:read": {"", "users:*"}},
+	))
+}
+
 func TestRBACSync_FetchPermissions(t *testing.T) {
 	type testCase struct {
 		name                string
@@ -79,6 +114,26 @@ func TestRBACSync_FetchPermissions(t *testing.T) {
 			},
 			expectedPermissions: map[string][]string{accesscontrol.ActionUsersRead: {accesscontrol.ScopeUsersAll}},
 		},
+		{
+			name: "restrict permissions to actions and scopes",
+			identity: &authn.Identity{
+				ID: "2", Type: claims.TypeServiceAccount, OrgID: 1,
+				ClientParams: authn.ClientParams{
+					SyncPermissions: true,
+					FetchPermissionsParams: authn.FetchPermissionsParams{
+						RestrictedPermissions: map[string][]string{
+							accesscontrol.ActionUsersRead:  {"users:id:1"},
+							accesscontrol.ActionUsersWrite: {""},
+							accesscontrol.ActionTeamsRead:  {accesscontrol.ScopeTeamsAll},
+						},
+					},
+				},
+			},
+			expectedPermissions: map[string][]string{
+				accesscontrol.ActionUsersRead:  {"users:id:1"},
+				accesscontrol.ActionUsersWrite: {""},
+			},
+		},
 		{
 			name: "fetch roles permissions",
 			identity: &authn.Identity{
@@ -231,13 +231,19 @@ func validateApiKey(orgID int64, key *apikey.APIKey) error {
 }
 
 func newServiceAccountIdentity(key *apikey.APIKey) *authn.Identity {
-	return &authn.Identity{
+	id := &authn.Identity{
 		ID:              strconv.FormatInt(*key.ServiceAccountId, 10),
 		Type:            claims.TypeServiceAccount,
 		OrgID:           key.OrgID,
 		AuthenticatedBy: login.APIKeyAuthModule,
 		ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
 	}
+
+	// down-scoped tokens only get the permissions they share with their service account
+	if key.IsRestricted() {
+		id.ClientParams.FetchPermissionsParams.RestrictedPermissions = key.Permissions.GroupByAction()
+	}
+	return id
 }
 
 func shouldUpdateLastUsedAt(key *apikey.APIKey) bool {
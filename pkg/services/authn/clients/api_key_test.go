@@ -73,6 +73,41 @@ func TestAPIKey_Authenticate(t *testing.T) {
 				AuthenticatedBy: login.APIKeyAuthModule,
 			},
 		},
+		{
+			desc: "should restrict permissions for down-scoped token",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				Header: map[string][]string{
+					"Authorization": {"Bearer " + secret},
+				},
+			}},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				Permissions: &apikey.Permissions{
+					{Action: "dashboards:read", Scope: "folders:uid:ci"},
+					{Action: "dashboards:read", Scope: "folders:uid:release"},
+					{Action: "annotations:create"},
+				},
+			},
+			expectedIdentity: &authn.Identity{
+				ID:    "1",
+				Type:  claims.TypeServiceAccount,
+				OrgID: 1,
+				ClientParams: authn.ClientParams{
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					FetchPermissionsParams: authn.FetchPermissionsParams{
+						RestrictedPermissions: map[string][]string{
+							"dashboards:read":    {"folders:uid:ci", "folders:uid:release"},
+							"annotations:create": {""},
+						},
+					},
+				},
+				AuthenticatedBy: login.APIKeyAuthModule,
+			},
+		},
 		{
 			desc: "should fail for expired api key",
 			req:  &authn.Request{HTTPRequest: &http.Request{Header: map[string][]string{"Authorization": {"Bearer " + secret}}}},
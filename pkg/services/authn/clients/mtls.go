@@ -0,0 +1,246 @@
+package clients
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"strconv"
+	"strings"
+
+	claims "github.com/grafana/authlib/types"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/login/social/connectors"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// Certificate fields that can be mapped to identity attributes
+const (
+	mtlsAttributeCommonName   = "CN"
+	mtlsAttributeOrganization = "O"
+	mtlsAttributeOrgUnit      = "OU"
+	mtlsAttributeSANEmail     = "SAN_EMAIL"
+	mtlsAttributeSANDNS       = "SAN_DNS"
+	mtlsAttributeSANURI       = "SAN_URI"
+)
+
+var _ authn.ContextAwareClient = new(MTLS)
+
+var (
+	errMTLSNoCertificate = errutil.Unauthorized(
+		"mtls.no-certificate", errutil.WithPublicMessage("No verified client certificate"))
+	errMTLSMissingAttribute = errutil.Unauthorized(
+		"mtls.missing-attribute", errutil.WithPublicMessage("Missing login and email in client certificate"))
+	errMTLSInvalidRole = errutil.Forbidden(
+		"mtls.invalid-role", errutil.WithPublicMessage("Invalid role for client certificate"))
+	errMTLSServiceAccount = errutil.Unauthorized(
+		"mtls.service-account", errutil.WithPublicMessage("Service account not found for client certificate"))
+)
+
+func ProvideMTLS(cfg *setting.Cfg, orgRoleMapper *connectors.OrgRoleMapper, serviceAccountService serviceaccounts.Service, tracer trace.Tracer) *MTLS {
+	return &MTLS{
+		cfg:                   cfg,
+		log:                   log.New(authn.ClientMTLS),
+		orgRoleMapper:         orgRoleMapper,
+		orgMappingCfg:         orgRoleMapper.ParseOrgMappingSettings(context.Background(), cfg.MTLSAuth.OrgMapping, cfg.MTLSAuth.RoleAttributeStrict),
+		serviceAccountService: serviceAccountService,
+		tracer:                tracer,
+	}
+}
+
+// MTLS authenticates requests with a client certificate verified by the http server
+type MTLS struct {
+	cfg                   *setting.Cfg
+	log                   log.Logger
+	orgRoleMapper         *connectors.OrgRoleMapper
+	orgMappingCfg         connectors.MappingConfiguration
+	serviceAccountService serviceaccounts.Service
+	tracer                trace.Tracer
+}
+
+func (c *MTLS) Name() string {
+	return authn.ClientMTLS
+}
+
+func (c *MTLS) Authenticate(ctx context.Context, r *authn.Request) (*authn.Identity, error) {
+	ctx, span := c.tracer.Start(ctx, "authn.mtls.Authenticate")
+	defer span.End()
+
+	cert := verifiedCertificate(r)
+	if cert == nil {
+		return nil, errMTLSNoCertificate.Errorf("request has no verified client certificate")
+	}
+
+	var orgRoles map[int64]org.RoleType
+	if !c.cfg.MTLSAuth.SkipOrgRoleSync {
+		orgRoles = c.orgRoleMapper.MapOrgRoles(c.orgMappingCfg, certificateAttribute(cert, c.cfg.MTLSAuth.OrgAttribute), "")
+		if c.cfg.MTLSAuth.RoleAttributeStrict && len(orgRoles) == 0 {
+			return nil, errMTLSInvalidRole.Errorf("could not evaluate any valid roles for client certificate")
+		}
+	}
+
+	if name, ok := c.serviceAccountName(cert); ok {
+		return c.authenticateServiceAccount(ctx, r, name, orgRoles)
+	}
+
+	id := &authn.Identity{
+		Login:           firstAttribute(cert, c.cfg.MTLSAuth.LoginAttribute),
+		Email:           firstAttribute(cert, c.cfg.MTLSAuth.EmailAttribute),
+		Name:            firstAttribute(cert, c.cfg.MTLSAuth.NameAttribute),
+		AuthenticatedBy: login.MTLSAuthModule,
+		OrgRoles:        orgRoles,
+		ClientParams: authn.ClientParams{
+			SyncUser:        true,
+			FetchSyncedUser: true,
+			SyncPermissions: true,
+			SyncOrgRoles:    !c.cfg.MTLSAuth.SkipOrgRoleSync,
+			AllowSignUp:     c.cfg.MTLSAuth.AutoSignUp,
+		},
+	}
+
+	if id.Login == "" && id.Email == "" {
+		c.log.FromContext(ctx).Debug("Failed to get login or email from client certificate", "subject", cert.Subject.String())
+		return nil, errMTLSMissingAttribute.Errorf("missing login and email in client certificate")
+	}
+
+	if id.Login != "" {
+		id.AuthID = id.Login
+		id.ClientParams.LookUpParams.Login = &id.Login
+	} else {
+		id.AuthID = id.Email
+	}
+	if id.Email != "" {
+		id.ClientParams.LookUpParams.Email = &id.Email
+	}
+
+	return id, nil
+}
+
+// authenticateServiceAccount resolves the service account for a certificate, creating it
+// with the mapped role when auto sign up is enabled
+func (c *MTLS) authenticateServiceAccount(ctx context.Context, r *authn.Request, name string, orgRoles map[int64]org.RoleType) (*authn.Identity, error) {
+	orgID := c.cfg.MTLSAuth.ServiceAccountOrgID
+
+	saID, err := c.serviceAccountService.RetrieveServiceAccountIdByName(ctx, orgID, name)
+	if err != nil {
+		if !errors.Is(err, serviceaccounts.ErrServiceAccountNotFound) {
+			return nil, err
+		}
+		if !c.cfg.MTLSAuth.AutoSignUp {
+			return nil, errMTLSServiceAccount.Errorf("service account %q does not exist in org %d", name, orgID)
+		}
+
+		role := org.RoleViewer
+		if mapped, ok := orgRoles[orgID]; ok && mapped.IsValid() {
+			role = mapped
+		}
+
+		sa, err := c.serviceAccountService.CreateServiceAccount(ctx, orgID, &serviceaccounts.CreateServiceAccountForm{
+			Name: name,
+			Role: &role,
+		})
+		// another request may have created the service account first
+		if errors.Is(err, serviceaccounts.ErrServiceAccountAlreadyExists) {
+			saID, err = c.serviceAccountService.RetrieveServiceAccountIdByName(ctx, orgID, name)
+		} else if err == nil {
+			saID = sa.Id
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.log.FromContext(ctx).Info("Created service account for client certificate", "name", name, "orgId", orgID)
+	}
+
+	return &authn.Identity{
+		ID:              strconv.FormatInt(saID, 10),
+		Type:            claims.TypeServiceAccount,
+		OrgID:           orgID,
+		AuthenticatedBy: login.MTLSAuthModule,
+		ClientParams: authn.ClientParams{
+			FetchSyncedUser: true,
+			SyncPermissions: true,
+		},
+	}, nil
+}
+
+// serviceAccountName returns the service account name for certificates with a URI SAN
+// matching the configured prefix
+func (c *MTLS) serviceAccountName(cert *x509.Certificate) (string, bool) {
+	prefix := c.cfg.MTLSAuth.ServiceAccountURIPrefix
+	if prefix == "" {
+		return "", false
+	}
+
+	for _, uri := range cert.URIs {
+		if name, ok := strings.CutPrefix(uri.String(), prefix); ok && name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (c *MTLS) IsEnabled() bool {
+	return c.cfg.MTLSAuth.Enabled
+}
+
+func (c *MTLS) Test(ctx context.Context, r *authn.Request) bool {
+	return verifiedCertificate(r) != nil
+}
+
+func (c *MTLS) Priority() uint {
+	// explicit credentials such as tokens and basic auth take precedence over the connection certificate
+	return 45
+}
+
+// verifiedCertificate returns the leaf certificate of the first chain verified during the tls handshake
+func verifiedCertificate(r *authn.Request) *x509.Certificate {
+	if r.HTTPRequest == nil || r.HTTPRequest.TLS == nil {
+		return nil
+	}
+
+	for _, chain := range r.HTTPRequest.TLS.VerifiedChains {
+		if len(chain) > 0 {
+			return chain[0]
+		}
+	}
+	return nil
+}
+
+func firstAttribute(cert *x509.Certificate, attribute string) string {
+	if values := certificateAttribute(cert, attribute); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func certificateAttribute(cert *x509.Certificate, attribute string) []string {
+	switch strings.ToUpper(attribute) {
+	case mtlsAttributeCommonName:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case mtlsAttributeOrganization:
+		return cert.Subject.Organization
+	case mtlsAttributeOrgUnit:
+		return cert.Subject.OrganizationalUnit
+	case mtlsAttributeSANEmail:
+		return cert.EmailAddresses
+	case mtlsAttributeSANDNS:
+		return cert.DNSNames
+	case mtlsAttributeSANURI:
+		uris := make([]string, 0, len(cert.URIs))
+		for _, uri := range cert.URIs {
+			uris = append(uris, uri.String())
+		}
+		return uris
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/url"
+	"testing"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/login/social/connectors"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	satests "github.com/grafana/grafana/pkg/services/serviceaccounts/tests"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestMTLS_Authenticate(t *testing.T) {
+	userCert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:         "kiosk-1",
+			OrganizationalUnit: []string{"ops"},
+		},
+		EmailAddresses: []string{"kiosk-1@example.com"},
+	}
+
+	type testCase struct {
+		desc        string
+		cert        *x509.Certificate
+		settings    setting.AuthMTLSSettings
+		saService   *fakeMTLSServiceAccounts
+		expectedID  *authn.Identity
+		expectedErr error
+	}
+
+	tests := []testCase{
+		{
+			desc: "should map certificate fields and org roles to identity",
+			cert: userCert,
+			settings: setting.AuthMTLSSettings{
+				LoginAttribute: "CN",
+				EmailAttribute: "SAN_EMAIL",
+				NameAttribute:  "CN",
+				OrgAttribute:   "OU",
+				OrgMapping:     []string{"ops:2:Editor"},
+				AutoSignUp:     true,
+			},
+			expectedID: &authn.Identity{
+				Login:           "kiosk-1",
+				Email:           "kiosk-1@example.com",
+				Name:            "kiosk-1",
+				AuthID:          "kiosk-1",
+				AuthenticatedBy: login.MTLSAuthModule,
+				OrgRoles:        map[int64]org.RoleType{2: org.RoleEditor},
+				ClientParams: authn.ClientParams{
+					SyncUser:        true,
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					SyncOrgRoles:    true,
+					AllowSignUp:     true,
+					LookUpParams: login.UserLookupParams{
+						Login: stringPtr("kiosk-1"),
+						Email: stringPtr("kiosk-1@example.com"),
+					},
+				},
+			},
+		},
+		{
+			desc: "should use email as auth id when login attribute is missing",
+			cert: userCert,
+			settings: setting.AuthMTLSSettings{
+				LoginAttribute:  "SAN_DNS",
+				EmailAttribute:  "SAN_EMAIL",
+				SkipOrgRoleSync: true,
+			},
+			expectedID: &authn.Identity{
+				Email:           "kiosk-1@example.com",
+				AuthID:          "kiosk-1@example.com",
+				AuthenticatedBy: login.MTLSAuthModule,
+				ClientParams: authn.ClientParams{
+					SyncUser:        true,
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					LookUpParams: login.UserLookupParams{
+						Email: stringPtr("kiosk-1@example.com"),
+					},
+				},
+			},
+		},
+		{
+			desc:        "should fail without login and email",
+			cert:        &x509.Certificate{},
+			settings:    setting.AuthMTLSSettings{LoginAttribute: "CN", EmailAttribute: "SAN_EMAIL", SkipOrgRoleSync: true},
+			expectedErr: errMTLSMissingAttribute,
+		},
+		{
+			desc: "should fail when no role can be mapped in strict mode",
+			cert: userCert,
+			settings: setting.AuthMTLSSettings{
+				LoginAttribute:      "CN",
+				OrgAttribute:        "OU",
+				OrgMapping:          []string{"dev:2:Editor"},
+				RoleAttributeStrict: true,
+			},
+			expectedErr: errMTLSInvalidRole,
+		},
+		{
+			desc:        "should fail without verified certificate",
+			settings:    setting.AuthMTLSSettings{LoginAttribute: "CN"},
+			expectedErr: errMTLSNoCertificate,
+		},
+		{
+			desc: "should authenticate existing service account",
+			cert: &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/kiosk"}}},
+			settings: setting.AuthMTLSSettings{
+				ServiceAccountURIPrefix: "spiffe://example.org/",
+				ServiceAccountOrgID:     3,
+			},
+			saService: &fakeMTLSServiceAccounts{ids: map[string]int64{"kiosk": 10}},
+			expectedID: &authn.Identity{
+				ID:              "10",
+				Type:            claims.TypeServiceAccount,
+				OrgID:           3,
+				AuthenticatedBy: login.MTLSAuthModule,
+				ClientParams: authn.ClientParams{
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+				},
+			},
+		},
+		{
+			desc: "should fail for unknown service account without auto sign up",
+			cert: &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/kiosk"}}},
+			settings: setting.AuthMTLSSettings{
+				ServiceAccountURIPrefix: "spiffe://example.org/",
+				ServiceAccountOrgID:     3,
+			},
+			saService:   &fakeMTLSServiceAccounts{},
+			expectedErr: errMTLSServiceAccount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := setting.NewCfg()
+			cfg.MTLSAuth = tt.settings
+			cfg.MTLSAuth.Enabled = true
+
+			saService := tt.saService
+			if saService == nil {
+				saService = &fakeMTLSServiceAccounts{}
+			}
+
+			c := ProvideMTLS(cfg, connectors.ProvideOrgRoleMapper(cfg, &orgtest.FakeOrgService{}), saService, tracing.InitializeTracerForTest())
+			identity, err := c.Authenticate(context.Background(), &authn.Request{HTTPRequest: requestWithCertificate(tt.cert)})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, identity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.expectedID, identity)
+		})
+	}
+}
+
+func TestMTLS_AuthenticateCreatesServiceAccount(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.MTLSAuth = setting.AuthMTLSSettings{
+		Enabled:                 true,
+		OrgAttribute:            "OU",
+		OrgMapping:              []string{"ops:3:Editor"},
+		AutoSignUp:              true,
+		ServiceAccountURIPrefix: "spiffe://example.org/",
+		ServiceAccountOrgID:     3,
+	}
+
+	saService := &fakeMTLSServiceAccounts{}
+	c := ProvideMTLS(cfg, connectors.ProvideOrgRoleMapper(cfg, &orgtest.FakeOrgService{}), saService, tracing.InitializeTracerForTest())
+
+	cert := &x509.Certificate{
+		Subject: pkix.Name{OrganizationalUnit: []string{"ops"}},
+		URIs:    []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/ns/kiosk"}},
+	}
+	identity, err := c.Authenticate(context.Background(), &authn.Request{HTTPRequest: requestWithCertificate(cert)})
+	require.NoError(t, err)
+
+	require.Len(t, saService.created, 1)
+	assert.Equal(t, "ns/kiosk", saService.created[0].Name)
+	assert.Equal(t, org.RoleEditor, *saService.created[0].Role)
+	assert.Equal(t, "1", identity.ID)
+	assert.Equal(t, int64(3), identity.OrgID)
+}
+
+func TestMTLS_Test(t *testing.T) {
+	c := ProvideMTLS(setting.NewCfg(), connectors.ProvideOrgRoleMapper(setting.NewCfg(), &orgtest.FakeOrgService{}), &fakeMTLSServiceAccounts{}, tracing.InitializeTracerForTest())
+
+	assert.True(t, c.Test(context.Background(), &authn.Request{HTTPRequest: requestWithCertificate(&x509.Certificate{})}))
+	assert.False(t, c.Test(context.Background(), &authn.Request{HTTPRequest: requestWithCertificate(nil)}))
+	assert.False(t, c.Test(context.Background(), &authn.Request{HTTPRequest: &http.Request{}}))
+}
+
+func requestWithCertificate(cert *x509.Certificate) *http.Request {
+	state := &tls.ConnectionState{}
+	if cert != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return &http.Request{TLS: state}
+}
+
+type fakeMTLSServiceAccounts struct {
+	satests.FakeServiceAccountService
+	ids     map[string]int64
+	created []*serviceaccounts.CreateServiceAccountForm
+}
+
+func (f *fakeMTLSServiceAccounts) RetrieveServiceAccountIdByName(_ context.Context, _ int64, name string) (int64, error) {
+	if id, ok := f.ids[name]; ok {
+		return id, nil
+	}
+	return 0, serviceaccounts.ErrServiceAccountNotFound.Errorf("service account with name %s not found", name)
+}
+
+func (f *fakeMTLSServiceAccounts) CreateServiceAccount(_ context.Context, orgID int64, form *serviceaccounts.CreateServiceAccountForm) (*serviceaccounts.ServiceAccountDTO, error) {
+	f.created = append(f.created, form)
+	return &serviceaccounts.ServiceAccountDTO{Id: int64(len(f.created)), Name: form.Name, OrgId: orgID}, nil
+}
@@ -0,0 +1,176 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	claims "github.com/grafana/authlib/types"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+var (
+	errWebAuthnRequired = errutil.Unauthorized("webauthn.required").MustTemplate(
+		"security key required",
+		errutil.WithPublic("A security key or passkey is required to log in"),
+	)
+	errWebAuthnNotEnrolled = errutil.Unauthorized("webauthn.not-enrolled", errutil.WithPublicMessage("A security key or passkey is required to log in, but none is registered. Contact your administrator"))
+	errWebAuthnBadForm     = errutil.BadRequest("webauthn.invalid", errutil.WithPublicMessage("bad login data"))
+)
+
+var _ authn.Client = new(WebAuthn)
+
+func ProvideWebAuthn(cfg *setting.Cfg, webAuthnService webauthn.Service, userService user.Service, orgService org.Service, tracer trace.Tracer) *WebAuthn {
+	return &WebAuthn{cfg, webAuthnService, userService, orgService, log.New("authn.webauthn"), tracer}
+}
+
+type WebAuthn struct {
+	cfg             *setting.Cfg
+	webAuthnService webauthn.Service
+	userService     user.Service
+	orgService      org.Service
+	log             log.Logger
+	tracer          trace.Tracer
+}
+
+func (c *WebAuthn) Name() string {
+	return authn.ClientWebAuthn
+}
+
+func (c *WebAuthn) IsEnabled() bool {
+	return c.cfg.WebAuthnAuth.Enabled
+}
+
+// Authenticate implements authn.Client.
+func (c *WebAuthn) Authenticate(ctx context.Context, r *authn.Request) (*authn.Identity, error) {
+	ctx, span := c.tracer.Start(ctx, "authn.webauthn.Authenticate")
+	defer span.End()
+
+	form := webauthn.FinishLoginCommand{}
+	if err := web.Bind(r.HTTPRequest, &form); err != nil {
+		return nil, errWebAuthnBadForm.Errorf("failed to parse request: %w", err)
+	}
+
+	cred, err := c.webAuthnService.FinishLogin(ctx, &form)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authn.Identity{
+		ID:              strconv.FormatInt(cred.UserID, 10),
+		Type:            claims.TypeUser,
+		OrgID:           r.OrgID,
+		ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+		AuthenticatedBy: login.WebAuthnAuthModule,
+	}, nil
+}
+
+// RequireSecondFactor wraps a password client, so users who must use a security key or passkey
+// finish logging in with this client after their password was verified.
+func (c *WebAuthn) RequireSecondFactor(client authn.PasswordClient) authn.PasswordClient {
+	return &webAuthnSecondFactor{c, client}
+}
+
+type webAuthnSecondFactor struct {
+	webAuthn *WebAuthn
+	client   authn.PasswordClient
+}
+
+func (c *webAuthnSecondFactor) AuthenticatePassword(ctx context.Context, r *authn.Request, username, password string) (*authn.Identity, error) {
+	identity, err := c.client.AuthenticatePassword(ctx, r, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return c.webAuthn.secondFactor(ctx, identity, username)
+}
+
+func (c *WebAuthn) secondFactor(ctx context.Context, identity *authn.Identity, username string) (*authn.Identity, error) {
+	ctx, span := c.tracer.Start(ctx, "authn.webauthn.secondFactor")
+	defer span.End()
+
+	userID, err := c.userID(ctx, identity, username)
+	if err != nil {
+		return nil, err
+	}
+	// Users that have never logged in (eg. from LDAP) can't have registered a credential yet
+	if userID == 0 {
+		if c.cfg.WebAuthnAuth.RequireSecondFactor && !c.cfg.WebAuthnAuth.AllowEnrollment {
+			return nil, errWebAuthnNotEnrolled.Errorf("user has no credentials")
+		}
+		return identity, nil
+	}
+
+	required, err := c.isSecondFactorRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !required {
+		return identity, nil
+	}
+
+	creds, err := c.webAuthnService.GetCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) == 0 {
+		if c.cfg.WebAuthnAuth.AllowEnrollment {
+			c.log.FromContext(ctx).Warn("User logged in without a required security key to register one", "userID", userID)
+			return identity, nil
+		}
+		return nil, errWebAuthnNotEnrolled.Errorf("user has no credentials")
+	}
+
+	token, err := c.webAuthnService.CreateLoginToken(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, errWebAuthnRequired.Build(errutil.TemplateData{
+		Public: map[string]any{"loginToken": token},
+	})
+}
+
+func (c *WebAuthn) userID(ctx context.Context, identity *authn.Identity, username string) (int64, error) {
+	if identity.ID != "" {
+		return identity.GetInternalID()
+	}
+
+	usr, err := c.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: username})
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return usr.ID, nil
+}
+
+func (c *WebAuthn) isSecondFactorRequired(ctx context.Context, userID int64) (bool, error) {
+	if c.cfg.WebAuthnAuth.RequireSecondFactor {
+		return true, nil
+	}
+	if len(c.cfg.WebAuthnAuth.RequireSecondFactorOrgIDs) == 0 {
+		return false, nil
+	}
+
+	orgs, err := c.orgService.GetUserOrgList(ctx, &org.GetUserOrgListQuery{UserID: userID})
+	if err != nil {
+		return false, err
+	}
+	for _, o := range orgs {
+		if slices.Contains(c.cfg.WebAuthnAuth.RequireSecondFactorOrgIDs, o.OrgID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
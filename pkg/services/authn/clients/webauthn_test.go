@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/authn/authntest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/services/webauthn/webauthntest"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestWebAuthn_RequireSecondFactor(t *testing.T) {
+	type testCase struct {
+		desc             string
+		settings         setting.AuthWebAuthnSettings
+		identity         *authn.Identity
+		user             *user.User
+		orgs             []*org.UserOrgDTO
+		credentials      []*webauthn.Credential
+		expectIdentity   bool
+		expectLoginToken bool
+		expectedErr      error
+	}
+
+	passwordIdentity := &authn.Identity{ID: "1", Type: claims.TypeUser}
+	ldapIdentity := &authn.Identity{Login: "ldap-user", Type: claims.TypeUser}
+	credentials := []*webauthn.Credential{{ID: 1, UserID: 1}}
+
+	tests := []testCase{
+		{
+			desc:           "should return identity when second factor is not required",
+			settings:       setting.AuthWebAuthnSettings{Enabled: true},
+			identity:       passwordIdentity,
+			credentials:    credentials,
+			expectIdentity: true,
+		},
+		{
+			desc:             "should require credential when required for all users",
+			settings:         setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactor: true},
+			identity:         passwordIdentity,
+			credentials:      credentials,
+			expectLoginToken: true,
+		},
+		{
+			desc:             "should require credential for members of an org",
+			settings:         setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactorOrgIDs: []int64{2}},
+			identity:         passwordIdentity,
+			orgs:             []*org.UserOrgDTO{{OrgID: 1}, {OrgID: 2}},
+			credentials:      credentials,
+			expectLoginToken: true,
+		},
+		{
+			desc:           "should not require credential for members of other orgs",
+			settings:       setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactorOrgIDs: []int64{2}},
+			identity:       passwordIdentity,
+			orgs:           []*org.UserOrgDTO{{OrgID: 1}},
+			credentials:    credentials,
+			expectIdentity: true,
+		},
+		{
+			desc:           "should allow users without credentials to log in to register one",
+			settings:       setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactor: true, AllowEnrollment: true},
+			identity:       passwordIdentity,
+			expectIdentity: true,
+		},
+		{
+			desc:        "should fail for users without credentials when enrollment is not allowed",
+			settings:    setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactor: true},
+			identity:    passwordIdentity,
+			expectedErr: errWebAuthnNotEnrolled,
+		},
+		{
+			desc:             "should look up users that were not synced yet",
+			settings:         setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactor: true},
+			identity:         ldapIdentity,
+			user:             &user.User{ID: 1},
+			credentials:      credentials,
+			expectLoginToken: true,
+		},
+		{
+			desc:           "should allow new users to log in when enrollment is allowed",
+			settings:       setting.AuthWebAuthnSettings{Enabled: true, RequireSecondFactor: true, AllowEnrollment: true},
+			identity:       ldapIdentity,
+			expectIdentity: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := setting.NewCfg()
+			cfg.WebAuthnAuth = tt.settings
+
+			userService := &usertest.FakeUserService{ExpectedUser: tt.user}
+			if tt.user == nil {
+				userService.ExpectedError = user.ErrUserNotFound
+			}
+			webAuthnService := &webauthntest.FakeService{ExpectedCredentials: tt.credentials, ExpectedLoginToken: "token"}
+			c := ProvideWebAuthn(cfg, webAuthnService, userService, &orgtest.FakeOrgService{ExpectedUserOrgDTO: tt.orgs}, tracing.InitializeTracerForTest())
+
+			password := c.RequireSecondFactor(authntest.FakePasswordClient{ExpectedIdentity: tt.identity})
+			identity, err := password.AuthenticatePassword(context.Background(), &authn.Request{HTTPRequest: &http.Request{}}, "user", "password")
+
+			switch {
+			case tt.expectIdentity:
+				require.NoError(t, err)
+				assert.Equal(t, tt.identity, identity)
+			case tt.expectLoginToken:
+				assert.ErrorIs(t, err, errWebAuthnRequired)
+				var errutilErr errutil.Error
+				require.ErrorAs(t, err, &errutilErr)
+				assert.Equal(t, "token", errutilErr.PublicPayload["loginToken"])
+			default:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestWebAuthn_Authenticate(t *testing.T) {
+	webAuthnService := &webauthntest.FakeService{ExpectedCredential: &webauthn.Credential{ID: 1, UserID: 2}}
+	c := ProvideWebAuthn(setting.NewCfg(), webAuthnService, &usertest.FakeUserService{}, &orgtest.FakeOrgService{}, tracing.InitializeTracerForTest())
+
+	body := `{"sessionId": "session", "credential": {"id": "cred", "type": "public-key", "response": {}}}`
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/login/webauthn/authenticate", strings.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	req := &authn.Request{OrgID: 1, HTTPRequest: httpReq}
+	identity, err := c.Authenticate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "2", identity.ID)
+	assert.Equal(t, claims.TypeUser, identity.Type)
+	assert.Equal(t, int64(1), identity.OrgID)
+	assert.Equal(t, "webauthn", identity.AuthenticatedBy)
+}
@@ -308,6 +308,8 @@ func (i *Identity) SignedInUser() *user.SignedInUser {
 		FallbackType:      i.Type,
 	}
 
+	u.PermissionsRestricted = i.ClientParams.FetchPermissionsParams.RestrictedPermissions != nil
+
 	if i.IsIdentityType(claims.TypeAPIKey) {
 		id, _ := i.GetInternalID()
 		u.ApiKeyID = id
@@ -0,0 +1,180 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+const (
+	queryKeyPrefix    = "query-cache"
+	resourceKeyPrefix = "resource-cache"
+
+	// minAlignment is used for queries without an interval
+	minAlignment = time.Second
+)
+
+// identityHeaders are forwarded from the user's request, or from their OAuth session, before the
+// caching middleware runs.
+var identityHeaders = []string{"Authorization", "Cookie", "X-Id-Token"}
+
+// queryKey is what identifies a query request in the cache. The time range of every query
+// is aligned to its interval so that requests issued a few seconds apart share the same key.
+type queryKey struct {
+	OrgID             int64             `json:"orgId"`
+	DatasourceUID     string            `json:"datasourceUid"`
+	DatasourceUpdated int64             `json:"datasourceUpdated"`
+	User              string            `json:"user,omitempty"`
+	Tenant            string            `json:"tenant,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	Queries           []queryKeyEntry   `json:"queries"`
+}
+
+type queryKeyEntry struct {
+	RefID         string          `json:"refId"`
+	QueryType     string          `json:"queryType"`
+	MaxDataPoints int64           `json:"maxDataPoints"`
+	Interval      time.Duration   `json:"interval"`
+	From          int64           `json:"from"`
+	To            int64           `json:"to"`
+	JSON          json.RawMessage `json:"json"`
+}
+
+func newQueryKey(req *backend.QueryDataRequest, user string) queryKey {
+	ds := req.PluginContext.DataSourceInstanceSettings
+	key := queryKey{
+		OrgID:             req.PluginContext.OrgID,
+		DatasourceUID:     ds.UID,
+		DatasourceUpdated: ds.Updated.UnixMilli(),
+		User:              user,
+		Tenant:            teamTenant(req.PluginContext, req),
+		Headers:           forwardedHeaders(req),
+		Queries:           make([]queryKeyEntry, 0, len(req.Queries)),
+	}
+	for _, q := range req.Queries {
+		align := q.Interval
+		if align < minAlignment {
+			align = minAlignment
+		}
+		key.Queries = append(key.Queries, queryKeyEntry{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			MaxDataPoints: q.MaxDataPoints,
+			Interval:      q.Interval,
+			From:          q.TimeRange.From.Truncate(align).UnixMilli(),
+			To:            q.TimeRange.To.Truncate(align).UnixMilli(),
+			JSON:          q.JSON,
+		})
+	}
+	return key
+}
+
+type resourceKey struct {
+	OrgID             int64             `json:"orgId"`
+	PluginID          string            `json:"pluginId"`
+	DatasourceUID     string            `json:"datasourceUid"`
+	DatasourceUpdated int64             `json:"datasourceUpdated"`
+	User              string            `json:"user,omitempty"`
+	Tenant            string            `json:"tenant,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	Path              string            `json:"path"`
+	URL               string            `json:"url"`
+	Body              []byte            `json:"body,omitempty"`
+}
+
+func newResourceKey(req *backend.CallResourceRequest, user string) resourceKey {
+	ds := req.PluginContext.DataSourceInstanceSettings
+	return resourceKey{
+		OrgID:             req.PluginContext.OrgID,
+		PluginID:          req.PluginContext.PluginID,
+		DatasourceUID:     ds.UID,
+		DatasourceUpdated: ds.Updated.UnixMilli(),
+		User:              user,
+		Tenant:            teamTenant(req.PluginContext, req),
+		Headers:           forwardedHeaders(req),
+		Path:              req.Path,
+		URL:               req.URL,
+		Body:              req.Body,
+	}
+}
+
+// forwardedUser returns the login of the user when the data source receives the user's identity,
+// since the results then depend on who is asking. The headers carrying it are set after the
+// caching middleware, so they can't be part of the key themselves.
+func forwardedUser(ctx context.Context, pCtx backend.PluginContext, sendUserHeader bool) string {
+	if pCtx.User == nil {
+		return ""
+	}
+	if sendUserHeader || hasIDToken(ctx) {
+		return pCtx.User.Login
+	}
+	jsonData := struct {
+		OAuthPassThru   bool            `json:"oauthPassThru"`
+		TeamHTTPHeaders json.RawMessage `json:"teamHttpHeaders"`
+	}{}
+	if err := json.Unmarshal(pCtx.DataSourceInstanceSettings.JSONData, &jsonData); err != nil {
+		// the key can't tell users apart without the json data, so it must not be shared
+		return pCtx.User.Login
+	}
+	if jsonData.OAuthPassThru || (len(jsonData.TeamHTTPHeaders) > 0 && string(jsonData.TeamHTTPHeaders) != "null") {
+		return pCtx.User.Login
+	}
+	return ""
+}
+
+// hasIDToken reports whether the ID token of the user is forwarded to the data source
+func hasIDToken(ctx context.Context) bool {
+	if reqCtx := contexthandler.FromContext(ctx); reqCtx != nil && reqCtx.SignedInUser != nil {
+		return reqCtx.GetIDToken() != ""
+	}
+	requester, err := identity.GetRequester(ctx)
+	return err == nil && requester.GetIDToken() != ""
+}
+
+// forwardedHeaders returns the identity headers already set on the request
+func forwardedHeaders(req backend.ForwardHTTPHeaders) map[string]string {
+	var headers map[string]string
+	for _, name := range identityHeaders {
+		if value := req.GetHTTPHeader(name); value != "" {
+			if headers == nil {
+				headers = map[string]string{}
+			}
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// teamTenant returns the tenant header set from the teams of the user when the data source has
+// team tenants, since the same request then returns the data of different tenants.
+func teamTenant(pCtx backend.PluginContext, req backend.ForwardHTTPHeaders) string {
+	teamTenants, err := datasources.ParseTeamTenants(pCtx.DataSourceInstanceSettings.JSONData)
+	if err != nil || teamTenants == nil {
+		return ""
+	}
+	return req.GetHTTPHeader(teamTenants.Header)
+}
+
+// cacheableQueryResponse reports whether every response of the request succeeded
+func cacheableQueryResponse(resp *backend.QueryDataResponse) bool {
+	for _, r := range resp.Responses {
+		if r.Error != nil || r.Status >= backend.StatusBadRequest {
+			return false
+		}
+	}
+	return true
+}
+
+func setCacheStatus(ctx context.Context, status string) {
+	reqCtx := contexthandler.FromContext(ctx)
+	if reqCtx == nil || reqCtx.Resp == nil {
+		return
+	}
+	reqCtx.Resp.Header().Set(XCacheHeader, status)
+}
@@ -6,10 +6,18 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 const (
@@ -41,10 +49,6 @@ type CachedResourceDataResponse struct {
 	UpdateCacheFn CacheResourceResponseFn
 }
 
-func ProvideCachingService() *OSSCachingService {
-	return &OSSCachingService{}
-}
-
 type CachingService interface {
 	// HandleQueryRequest uses a QueryDataRequest to check the cache for any existing results for that query.
 	// If none are found, it should return false and a CachedQueryDataResponse with an UpdateCacheFn which can be used to update the results cache after the fact.
@@ -55,16 +59,166 @@ type CachingService interface {
 	HandleResourceRequest(context.Context, *backend.CallResourceRequest) (bool, CachedResourceDataResponse)
 }
 
-// Implementation of interface - does nothing
+func ProvideCachingService(cfg *setting.Cfg, cacheStorage remotecache.CacheStorage) *OSSCachingService {
+	return &OSSCachingService{
+		settings:       cfg.QueryCaching,
+		sendUserHeader: cfg.SendUserHeader,
+		cache:          cacheStorage,
+		log:            log.New("query_caching"),
+	}
+}
+
+// OSSCachingService caches query results and resource responses in the remote cache.
+// The zero value does not cache anything.
 type OSSCachingService struct {
+	settings       setting.QueryCachingSettings
+	sendUserHeader bool
+	cache          remotecache.CacheStorage
+	log            log.Logger
 }
 
 func (s *OSSCachingService) HandleQueryRequest(ctx context.Context, req *backend.QueryDataRequest) (bool, CachedQueryDataResponse) {
-	return false, CachedQueryDataResponse{}
+	if !s.settings.Enabled || s.cache == nil {
+		return false, CachedQueryDataResponse{}
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if ds == nil || len(req.Queries) == 0 {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedQueryDataResponse{}
+	}
+	ttl, enabled := s.datasourceTTL(ds, s.settings.TTL)
+	if !enabled {
+		setCacheStatus(ctx, StatusDisabled)
+		return false, CachedQueryDataResponse{}
+	}
+
+	key, err := GetKey(queryKeyPrefix, newQueryKey(req, forwardedUser(ctx, req.PluginContext, s.sendUserHeader)))
+	if err != nil {
+		s.log.Error("Failed to build query cache key", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	cached, err := s.cache.Get(ctx, key)
+	if err == nil {
+		resp := &backend.QueryDataResponse{}
+		if err := json.Unmarshal(cached, resp); err == nil {
+			setCacheStatus(ctx, StatusHit)
+			return true, CachedQueryDataResponse{Response: resp}
+		}
+		s.log.Warn("Failed to decode cached query response", "key", key, "error", err)
+	} else if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+		s.log.Error("Failed to read query cache", "key", key, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	setCacheStatus(ctx, StatusMiss)
+	return false, CachedQueryDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.QueryDataResponse) {
+			if resp == nil || !cacheableQueryResponse(resp) {
+				return
+			}
+			value, err := json.Marshal(resp)
+			if err != nil {
+				s.log.Error("Failed to encode query response for the cache", "error", err)
+				return
+			}
+			s.set(ctx, key, value, ttl)
+		},
+	}
 }
 
 func (s *OSSCachingService) HandleResourceRequest(ctx context.Context, req *backend.CallResourceRequest) (bool, CachedResourceDataResponse) {
-	return false, CachedResourceDataResponse{}
+	if !s.settings.Enabled || !s.settings.ResourcesEnabled || s.cache == nil {
+		return false, CachedResourceDataResponse{}
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if ds == nil || req.Method != http.MethodGet {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedResourceDataResponse{}
+	}
+	ttl, enabled := s.datasourceTTL(ds, s.settings.ResourcesTTL)
+	if !enabled {
+		setCacheStatus(ctx, StatusDisabled)
+		return false, CachedResourceDataResponse{}
+	}
+
+	key, err := GetKey(resourceKeyPrefix, newResourceKey(req, forwardedUser(ctx, req.PluginContext, s.sendUserHeader)))
+	if err != nil {
+		s.log.Error("Failed to build resource cache key", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	cached, err := s.cache.Get(ctx, key)
+	if err == nil {
+		resp := &backend.CallResourceResponse{}
+		if err := json.Unmarshal(cached, resp); err == nil {
+			setCacheStatus(ctx, StatusHit)
+			return true, CachedResourceDataResponse{Response: resp}
+		}
+		s.log.Warn("Failed to decode cached resource response", "key", key, "error", err)
+	} else if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+		s.log.Error("Failed to read resource cache", "key", key, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	setCacheStatus(ctx, StatusMiss)
+	// plugins can stream several responses for one request, only single responses are cached
+	var calls atomic.Int32
+	return false, CachedResourceDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.CallResourceResponse) {
+			if calls.Add(1) > 1 {
+				if err := s.cache.Delete(ctx, key); err != nil {
+					s.log.Error("Failed to delete streamed resource response from the cache", "key", key, "error", err)
+				}
+				return
+			}
+			if resp == nil || resp.Status < http.StatusOK || resp.Status >= http.StatusMultipleChoices {
+				return
+			}
+			value, err := json.Marshal(resp)
+			if err != nil {
+				s.log.Error("Failed to encode resource response for the cache", "error", err)
+				return
+			}
+			s.set(ctx, key, value, ttl)
+		},
+	}
+}
+
+func (s *OSSCachingService) set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if s.settings.MaxValueSize > 0 && len(value) > s.settings.MaxValueSize {
+		s.log.Debug("Response is too large to be cached", "key", key, "size", len(value), "limit", s.settings.MaxValueSize)
+		return
+	}
+	if err := s.cache.Set(ctx, key, value, ttl); err != nil {
+		s.log.Error("Failed to write to the query cache", "key", key, "error", err)
+	}
+}
+
+// datasourceTTL returns the TTL of the data source, or def when it does not set one.
+// A negative queryCachingTTL disables caching for the data source.
+func (s *OSSCachingService) datasourceTTL(ds *backend.DataSourceInstanceSettings, def time.Duration) (time.Duration, bool) {
+	jsonData := struct {
+		QueryCachingTTL int64 `json:"queryCachingTTL"`
+	}{}
+	if len(ds.JSONData) > 0 {
+		if err := json.Unmarshal(ds.JSONData, &jsonData); err != nil {
+			s.log.Debug("Failed to read data source caching settings", "uid", ds.UID, "error", err)
+		}
+	}
+	switch {
+	case jsonData.QueryCachingTTL < 0:
+		return 0, false
+	case jsonData.QueryCachingTTL > 0:
+		return time.Duration(jsonData.QueryCachingTTL) * time.Millisecond, true
+	}
+	return def, def > 0
 }
 
 var _ CachingService = &OSSCachingService{}
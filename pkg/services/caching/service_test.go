@@ -0,0 +1,256 @@
+package caching
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func newTestService(settings setting.QueryCachingSettings) (*OSSCachingService, remotecache.FakeCacheStorage) {
+	cache := remotecache.NewFakeCacheStorage()
+	return &OSSCachingService{settings: settings, cache: cache, log: log.NewNopLogger()}, cache
+}
+
+func newReqContext(t *testing.T) (context.Context, http.Header) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/ds/query", nil)
+	resp := web.NewResponseWriter(req.Method, httptest.NewRecorder())
+	reqCtx := &contextmodel.ReqContext{Context: &web.Context{Req: req, Resp: resp}}
+	return ctxkey.Set(context.Background(), reqCtx), resp.Header()
+}
+
+func newQueryRequest(jsonData string, from, to time.Time) *backend.QueryDataRequest {
+	return &backend.QueryDataRequest{
+		PluginContext: backend.PluginContext{
+			OrgID: 1,
+			DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+				UID:      "prom",
+				JSONData: []byte(jsonData),
+			},
+		},
+		Queries: []backend.DataQuery{{
+			RefID:     "A",
+			Interval:  time.Minute,
+			TimeRange: backend.TimeRange{From: from, To: to},
+			JSON:      []byte(`{"expr":"up"}`),
+		}},
+	}
+}
+
+func TestHandleQueryRequest(t *testing.T) {
+	settings := setting.QueryCachingSettings{Enabled: true, TTL: time.Minute, MaxValueSize: 1024 * 1024}
+	to := time.Date(2024, 1, 1, 12, 0, 10, 0, time.UTC)
+	from := to.Add(-time.Hour)
+	queryResponse := &backend.QueryDataResponse{Responses: backend.Responses{
+		"A": {Frames: data.Frames{data.NewFrame("up", data.NewField("value", nil, []float64{1}))}},
+	}}
+
+	t.Run("caches the response and serves it for requests in the same interval", func(t *testing.T) {
+		svc, _ := newTestService(settings)
+
+		ctx, header := newReqContext(t)
+		hit, cr := svc.HandleQueryRequest(ctx, newQueryRequest(`{}`, from, to))
+		require.False(t, hit)
+		require.Equal(t, StatusMiss, header.Get(XCacheHeader))
+		require.NotNil(t, cr.UpdateCacheFn)
+		cr.UpdateCacheFn(ctx, queryResponse)
+
+		ctx, header = newReqContext(t)
+		hit, cr = svc.HandleQueryRequest(ctx, newQueryRequest(`{}`, from.Add(20*time.Second), to.Add(20*time.Second)))
+		require.True(t, hit)
+		require.Equal(t, StatusHit, header.Get(XCacheHeader))
+		require.Len(t, cr.Response.Responses["A"].Frames, 1)
+		require.Equal(t, "up", cr.Response.Responses["A"].Frames[0].Name)
+
+		ctx, _ = newReqContext(t)
+		hit, _ = svc.HandleQueryRequest(ctx, newQueryRequest(`{}`, from.Add(time.Minute), to.Add(time.Minute)))
+		require.False(t, hit)
+	})
+
+	t.Run("does not share responses between the tenants of different teams", func(t *testing.T) {
+		svc, _ := newTestService(settings)
+		jsonData := `{"teamTenants":{"tenants":{"1":["team-a"],"2":["team-b"]}}}`
+		newTenantRequest := func(tenant string) *backend.QueryDataRequest {
+			req := newQueryRequest(jsonData, from, to)
+			req.SetHTTPHeader(datasources.DefaultTenantHeader, tenant)
+			return req
+		}
+
+		ctx, _ := newReqContext(t)
+		hit, cr := svc.HandleQueryRequest(ctx, newTenantRequest("team-a"))
+		require.False(t, hit)
+		cr.UpdateCacheFn(ctx, queryResponse)
+
+		ctx, _ = newReqContext(t)
+		hit, _ = svc.HandleQueryRequest(ctx, newTenantRequest("team-b"))
+		require.False(t, hit)
+
+		ctx, _ = newReqContext(t)
+		hit, _ = svc.HandleQueryRequest(ctx, newTenantRequest("team-a"))
+		require.True(t, hit)
+	})
+
+	t.Run("does not share responses between users when their identity is forwarded", func(t *testing.T) {
+		newUserRequest := func(jsonData, login string) *backend.QueryDataRequest {
+			req := newQueryRequest(jsonData, from, to)
+			req.PluginContext.User = &backend.User{Login: login}
+			return req
+		}
+
+		for name, tc := range map[string]struct {
+			jsonData       string
+			sendUserHeader bool
+		}{
+			"oauth pass-through": {jsonData: `{"oauthPassThru":true}`},
+			"team http headers":  {jsonData: `{"teamHttpHeaders":{"headers":{"1":[{"header":"X-Prom-Label-Policy","value":"1:{job=\"a\"}"}]}}}`},
+			"user header":        {jsonData: `{}`, sendUserHeader: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				svc, _ := newTestService(settings)
+				svc.sendUserHeader = tc.sendUserHeader
+
+				ctx, _ := newReqContext(t)
+				_, cr := svc.HandleQueryRequest(ctx, newUserRequest(tc.jsonData, "alice"))
+				cr.UpdateCacheFn(ctx, queryResponse)
+
+				ctx, _ = newReqContext(t)
+				hit, _ := svc.HandleQueryRequest(ctx, newUserRequest(tc.jsonData, "bob"))
+				require.False(t, hit)
+
+				ctx, _ = newReqContext(t)
+				hit, _ = svc.HandleQueryRequest(ctx, newUserRequest(tc.jsonData, "alice"))
+				require.True(t, hit)
+			})
+		}
+
+		t.Run("id token", func(t *testing.T) {
+			svc, _ := newTestService(settings)
+			newIDTokenContext := func() context.Context {
+				ctx, _ := newReqContext(t)
+				contexthandler.FromContext(ctx).SignedInUser = &user.SignedInUser{IDToken: "token"}
+				return ctx
+			}
+
+			ctx := newIDTokenContext()
+			_, cr := svc.HandleQueryRequest(ctx, newUserRequest(`{}`, "alice"))
+			cr.UpdateCacheFn(ctx, queryResponse)
+
+			hit, _ := svc.HandleQueryRequest(newIDTokenContext(), newUserRequest(`{}`, "bob"))
+			require.False(t, hit)
+		})
+
+		t.Run("forwarded headers", func(t *testing.T) {
+			svc, _ := newTestService(settings)
+			newTokenRequest := func(token string) *backend.QueryDataRequest {
+				req := newQueryRequest(`{}`, from, to)
+				req.SetHTTPHeader("Authorization", "Bearer "+token)
+				return req
+			}
+
+			ctx, _ := newReqContext(t)
+			_, cr := svc.HandleQueryRequest(ctx, newTokenRequest("alice"))
+			cr.UpdateCacheFn(ctx, queryResponse)
+
+			ctx, _ = newReqContext(t)
+			hit, _ := svc.HandleQueryRequest(ctx, newTokenRequest("bob"))
+			require.False(t, hit)
+		})
+	})
+
+	t.Run("does not cache failed or oversized responses", func(t *testing.T) {
+		svc, cache := newTestService(setting.QueryCachingSettings{Enabled: true, TTL: time.Minute, MaxValueSize: 10})
+
+		ctx, _ := newReqContext(t)
+		_, cr := svc.HandleQueryRequest(ctx, newQueryRequest(`{}`, from, to))
+		cr.UpdateCacheFn(ctx, queryResponse)
+		cr.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{"A": {Status: backend.StatusBadRequest}}})
+		require.Empty(t, cache.Storage)
+	})
+
+	t.Run("uses the data source ttl and can be disabled per data source", func(t *testing.T) {
+		svc, _ := newTestService(settings)
+
+		ttl, enabled := svc.datasourceTTL(&backend.DataSourceInstanceSettings{JSONData: []byte(`{"queryCachingTTL":30000}`)}, settings.TTL)
+		require.True(t, enabled)
+		require.Equal(t, 30*time.Second, ttl)
+
+		ctx, header := newReqContext(t)
+		hit, cr := svc.HandleQueryRequest(ctx, newQueryRequest(`{"queryCachingTTL":-1}`, from, to))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Equal(t, StatusDisabled, header.Get(XCacheHeader))
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		svc := &OSSCachingService{}
+		ctx, header := newReqContext(t)
+		hit, cr := svc.HandleQueryRequest(ctx, newQueryRequest(`{}`, from, to))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Empty(t, header.Get(XCacheHeader))
+	})
+}
+
+func TestHandleResourceRequest(t *testing.T) {
+	svc, cache := newTestService(setting.QueryCachingSettings{Enabled: true, ResourcesEnabled: true, ResourcesTTL: time.Minute})
+	newRequest := func(method string) *backend.CallResourceRequest {
+		return &backend.CallResourceRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				PluginID:                   "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "prom"},
+			},
+			Path:   "api/v1/labels",
+			URL:    "api/v1/labels",
+			Method: method,
+		}
+	}
+
+	t.Run("caches single GET responses", func(t *testing.T) {
+		ctx, _ := newReqContext(t)
+		hit, cr := svc.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.False(t, hit)
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`["job"]`)})
+
+		ctx, header := newReqContext(t)
+		hit, cr = svc.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.True(t, hit)
+		require.Equal(t, StatusHit, header.Get(XCacheHeader))
+		require.Equal(t, []byte(`["job"]`), cr.Response.Body)
+	})
+
+	t.Run("does not cache streamed responses", func(t *testing.T) {
+		for k := range cache.Storage {
+			delete(cache.Storage, k)
+		}
+		ctx, _ := newReqContext(t)
+		_, cr := svc.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte("a")})
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Body: []byte("b")})
+		require.Empty(t, cache.Storage)
+	})
+
+	t.Run("bypasses other methods", func(t *testing.T) {
+		ctx, header := newReqContext(t)
+		hit, cr := svc.HandleResourceRequest(ctx, newRequest(http.MethodPost))
+		require.False(t, hit)
+		require.Nil(t, cr.UpdateCacheFn)
+		require.Equal(t, StatusBypass, header.Get(XCacheHeader))
+	})
+}
@@ -119,6 +119,7 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 	cleanupJobs := []cleanUpJob{
 		{"clean up temporary files", srv.cleanUpTmpFiles},
 		{"delete expired snapshots", srv.deleteExpiredSnapshots},
+		{"enforce snapshot policies", srv.enforceSnapshotPolicies},
 		{"delete expired dashboard versions", srv.deleteExpiredDashboardVersions},
 		{"delete expired images", srv.deleteExpiredImages},
 		{"cleanup old annotations", srv.cleanUpOldAnnotations},
@@ -232,6 +233,16 @@ func (srv *CleanUpService) deleteExpiredSnapshots(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) enforceSnapshotPolicies(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	cmd := dashboardsnapshots.EnforceSnapshotPoliciesCommand{}
+	if err := srv.dashboardSnapshotService.EnforceSnapshotPolicies(ctx, &cmd); err != nil {
+		logger.Error("Failed to enforce snapshot policies", "error", err.Error())
+	} else {
+		logger.Debug("Enforced snapshot policies", "rows affected", cmd.DeletedRows)
+	}
+}
+
 func (srv *CleanUpService) deleteExpiredDashboardVersions(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	cmd := dashver.DeleteExpiredVersionsCommand{}
@@ -381,8 +392,7 @@ func (srv *CleanUpService) deleteStaleKubernetesShortURLs(ctx context.Context) {
 func (srv *CleanUpService) deleteStaleQueryHistory(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	// Delete query history from 14+ days ago with exception of starred queries
-	maxQueryHistoryLifetime := time.Hour * 24 * 14
-	olderThan := time.Now().Add(-maxQueryHistoryLifetime).Unix()
+	olderThan := time.Now().Add(-queryhistory.MaxLifetime).Unix()
 	rowsCount, err := srv.QueryHistoryService.DeleteStaleQueriesInQueryHistory(ctx, olderThan)
 	if err != nil {
 		logger.Error("Problem deleting stale query history", "error", err.Error())
@@ -390,6 +400,14 @@ func (srv *CleanUpService) deleteStaleQueryHistory(ctx context.Context) {
 		logger.Debug("Deleted stale query history", "rows affected", rowsCount)
 	}
 
+	// Enforce the max age and max count of the org retention policies
+	rowsCount, err = srv.QueryHistoryService.EnforceRetentionPoliciesInQueryHistory(ctx)
+	if err != nil {
+		logger.Error("Problem enforcing query history retention policies", "error", err.Error())
+	} else {
+		logger.Debug("Enforced query history retention policies", "rows affected", rowsCount)
+	}
+
 	// Enforce 200k limit for query_history table
 	queryHistoryLimit := 200000
 	rowsCount, err = srv.QueryHistoryService.EnforceRowLimitInQueryHistory(ctx, queryHistoryLimit, false)
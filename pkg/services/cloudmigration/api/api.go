@@ -67,6 +67,10 @@ func (cma *CloudMigrationAPI) registerEndpoints(acHandler accesscontrol.AccessCo
 		cloudMigrationRoute.Post("/migration/:uid/snapshot/:snapshotUid/upload", routing.Wrap(cma.UploadSnapshot))
 		cloudMigrationRoute.Post("/migration/:uid/snapshot/:snapshotUid/cancel", routing.Wrap(cma.CancelSnapshot))
 
+		// local snapshot archives, to migrate between self-hosted instances
+		cloudMigrationRoute.Post("/snapshot/export", routing.Wrap(cma.ExportSnapshot))
+		cloudMigrationRoute.Post("/snapshot/import", routing.Wrap(cma.ImportSnapshot))
+
 		// resource dependency list
 		cloudMigrationRoute.Get("/resources/dependencies", routing.Wrap(cma.GetResourceDependencies))
 	}, authorize(cloudmigration.MigrationAssistantAccess))
@@ -640,6 +644,133 @@ func (cma *CloudMigrationAPI) CancelSnapshot(c *contextmodel.ReqContext) respons
 	return response.JSON(http.StatusOK, nil)
 }
 
+// swagger:route POST /cloudmigration/snapshot/export migrations exportSnapshot
+//
+// Export a snapshot of the instance into an archive encrypted with the provided passphrase.
+// The archive can be imported into another instance with the import endpoint.
+//
+// Produces:
+// - application/octet-stream
+//
+// Responses:
+// 200: exportSnapshotResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (cma *CloudMigrationAPI) ExportSnapshot(c *contextmodel.ReqContext) response.Response {
+	ctx, span := cma.tracer.Start(c.Req.Context(), "MigrationAPI.ExportSnapshot")
+	defer span.End()
+
+	var cmd ExportSnapshotRequestDTO
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		span.SetStatus(codes.Error, "invalid request body")
+		span.RecordError(err)
+
+		return response.ErrOrFallback(http.StatusBadRequest, "invalid request body", err)
+	}
+
+	if len(cmd.ResourceTypes) == 0 {
+		return response.ErrOrFallback(http.StatusBadRequest, "at least one resource type is required", cloudmigration.ErrEmptyResourceTypes)
+	}
+	if cmd.Passphrase == "" {
+		return response.ErrOrFallback(http.StatusBadRequest, "a passphrase is required", cloudmigration.ErrEmptyPassphrase)
+	}
+
+	rawResourceTypes := make([]cloudmigration.MigrateDataType, 0, len(cmd.ResourceTypes))
+	for _, t := range cmd.ResourceTypes {
+		rawResourceTypes = append(rawResourceTypes, cloudmigration.MigrateDataType(t))
+	}
+
+	resourceTypes, err := cma.resourceDependencyMap.Parse(rawResourceTypes)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid resource types")
+		span.RecordError(err)
+
+		return response.ErrOrFallback(http.StatusBadRequest, "invalid resource types", err)
+	}
+
+	archive, err := cma.cloudMigrationService.ExportSnapshot(ctx, c.SignedInUser, cloudmigration.ExportSnapshotCommand{
+		ResourceTypes: resourceTypes,
+		Passphrase:    cmd.Passphrase,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "error exporting snapshot")
+		span.RecordError(err)
+
+		return response.ErrOrFallback(http.StatusInternalServerError, "error exporting snapshot", err)
+	}
+
+	return response.Respond(http.StatusOK, archive).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetHeader("Content-Disposition", `attachment; filename="grafana-snapshot.bin"`)
+}
+
+// swagger:route POST /cloudmigration/snapshot/import migrations importSnapshot
+//
+// Import an archive created by the export endpoint into the current organization.
+// Resources that already exist are handled according to the conflict policy, and the outcome
+// of every resource is returned.
+//
+// Responses:
+// 200: importSnapshotResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (cma *CloudMigrationAPI) ImportSnapshot(c *contextmodel.ReqContext) response.Response {
+	ctx, span := cma.tracer.Start(c.Req.Context(), "MigrationAPI.ImportSnapshot")
+	defer span.End()
+
+	var cmd ImportSnapshotRequestDTO
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		span.SetStatus(codes.Error, "invalid request body")
+		span.RecordError(err)
+
+		return response.ErrOrFallback(http.StatusBadRequest, "invalid request body", err)
+	}
+
+	if cmd.Passphrase == "" {
+		return response.ErrOrFallback(http.StatusBadRequest, "a passphrase is required", cloudmigration.ErrEmptyPassphrase)
+	}
+
+	conflictPolicy := cloudmigration.ConflictPolicySkip
+	if cmd.ConflictPolicy != "" {
+		conflictPolicy = cloudmigration.ConflictPolicy(strings.ToLower(cmd.ConflictPolicy))
+	}
+	if !conflictPolicy.IsValid() {
+		return response.ErrOrFallback(http.StatusBadRequest, "invalid conflict policy", cloudmigration.ErrInvalidConflictPolicy)
+	}
+
+	report, err := cma.cloudMigrationService.ImportSnapshot(ctx, c.SignedInUser, cloudmigration.ImportSnapshotCommand{
+		Archive:        cmd.Archive,
+		Passphrase:     cmd.Passphrase,
+		ConflictPolicy: conflictPolicy,
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "error importing snapshot")
+		span.RecordError(err)
+
+		return response.ErrOrFallback(http.StatusInternalServerError, "error importing snapshot", err)
+	}
+
+	results := make([]ImportResultDTO, 0, len(report.Results))
+	for _, r := range report.Results {
+		results = append(results, ImportResultDTO{
+			Type:     MigrateDataType(r.Type),
+			RefID:    r.RefID,
+			Name:     r.Name,
+			NewRefID: r.NewRefID,
+			Status:   ImportStatus(r.Status),
+			Message:  r.Message,
+		})
+	}
+
+	return response.JSON(http.StatusOK, ImportSnapshotResponseDTO{
+		Results: results,
+	})
+}
+
 // swagger:route GET /cloudmigration/resources/dependencies migrations getResourceDependencies
 //
 // Get the resource dependencies graph for the current set of migratable resources.
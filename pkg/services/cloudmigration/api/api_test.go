@@ -623,6 +623,108 @@ func TestCloudMigrationAPI_GetResourceDependencies(t *testing.T) {
 	}
 }
 
+func TestCloudMigrationAPI_ExportSnapshot(t *testing.T) {
+	tests := []TestCase{
+		{
+			desc:               "returns 200 and the archive if everything is fine",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/export",
+			requestBody:        `{"resourceTypes":["PLUGIN"],"passphrase":"secret"}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusOK,
+			expectedBody:       "fake archive",
+		},
+		{
+			desc:               "returns 400 if the passphrase is missing",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/export",
+			requestBody:        `{"resourceTypes":["PLUGIN"]}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusBadRequest,
+		},
+		{
+			desc:               "returns 400 if resource types are invalid",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/export",
+			requestBody:        `{"resourceTypes":["INVALID"],"passphrase":"secret"}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusBadRequest,
+		},
+		{
+			desc:               "returns 403 if the user does not have the right permissions",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/export",
+			requestBody:        `{"resourceTypes":["PLUGIN"],"passphrase":"secret"}`,
+			user:               userWithoutPermissions,
+			expectedHttpResult: http.StatusForbidden,
+		},
+		{
+			desc:               "returns 500 if service returns an error",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/export",
+			requestBody:        `{"resourceTypes":["PLUGIN"],"passphrase":"secret"}`,
+			user:               userWithPermissions,
+			serviceReturnError: true,
+			expectedHttpResult: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, runSimpleApiTest(tt))
+	}
+}
+
+func TestCloudMigrationAPI_ImportSnapshot(t *testing.T) {
+	tests := []TestCase{
+		{
+			desc:               "returns 200 and the report if everything is fine",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/import",
+			requestBody:        `{"archive":"ZmFrZQ==","passphrase":"secret","conflictPolicy":"rename"}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusOK,
+			expectedBody:       `{"results":[{"type":"FOLDER","refId":"folder_uid","name":"Folder","status":"CREATED"},{"type":"DASHBOARD","refId":"dash_uid","name":"Dashboard","status":"SKIPPED"}]}`,
+		},
+		{
+			desc:               "returns 400 if the conflict policy is invalid",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/import",
+			requestBody:        `{"archive":"ZmFrZQ==","passphrase":"secret","conflictPolicy":"merge"}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusBadRequest,
+		},
+		{
+			desc:               "returns 400 if the passphrase is missing",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/import",
+			requestBody:        `{"archive":"ZmFrZQ=="}`,
+			user:               userWithPermissions,
+			expectedHttpResult: http.StatusBadRequest,
+		},
+		{
+			desc:               "returns 403 if the user does not have the right permissions",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/import",
+			requestBody:        `{"archive":"ZmFrZQ==","passphrase":"secret"}`,
+			user:               userWithoutPermissions,
+			expectedHttpResult: http.StatusForbidden,
+		},
+		{
+			desc:               "returns 500 if service returns an error",
+			requestHttpMethod:  http.MethodPost,
+			requestUrl:         "/api/cloudmigration/snapshot/import",
+			requestBody:        `{"archive":"ZmFrZQ==","passphrase":"secret"}`,
+			user:               userWithPermissions,
+			serviceReturnError: true,
+			expectedHttpResult: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, runSimpleApiTest(tt))
+	}
+}
+
 func runSimpleApiTest(tt TestCase) func(t *testing.T) {
 	return func(t *testing.T) {
 		// setup server
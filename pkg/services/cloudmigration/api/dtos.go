@@ -406,6 +406,74 @@ type CancelSnapshotParams struct {
 	SnapshotUID string `json:"snapshotUid"`
 }
 
+// swagger:parameters exportSnapshot
+type ExportSnapshotRequest struct {
+	// in:body
+	// required:true
+	Body ExportSnapshotRequestDTO `json:"body"`
+}
+
+type ExportSnapshotRequestDTO struct {
+	ResourceTypes []MigrateDataType `json:"resourceTypes"`
+	// Passphrase the archive is encrypted with, it must be provided again to import it.
+	Passphrase string `json:"passphrase"`
+}
+
+// swagger:response exportSnapshotResponse
+type ExportSnapshotResponse struct {
+	// in: body
+	Body []byte
+}
+
+// swagger:parameters importSnapshot
+type ImportSnapshotRequest struct {
+	// in:body
+	// required:true
+	Body ImportSnapshotRequestDTO `json:"body"`
+}
+
+type ImportSnapshotRequestDTO struct {
+	// Base64 encoded archive, as returned by the export endpoint.
+	Archive    []byte `json:"archive"`
+	Passphrase string `json:"passphrase"`
+	// What to do with resources that already exist: skip, overwrite or rename. Defaults to skip.
+	ConflictPolicy string `json:"conflictPolicy"`
+}
+
+// swagger:response importSnapshotResponse
+type ImportSnapshotResponse struct {
+	// in: body
+	Body ImportSnapshotResponseDTO
+}
+
+type ImportSnapshotResponseDTO struct {
+	Results []ImportResultDTO `json:"results"`
+}
+
+type ImportResultDTO struct {
+	// required:true
+	Type MigrateDataType `json:"type"`
+	// required:true
+	RefID string `json:"refId"`
+	Name  string `json:"name"`
+	// Set when the resource was imported under a different uid.
+	NewRefID string `json:"newRefId,omitempty"`
+	// required:true
+	Status  ImportStatus `json:"status"`
+	Message string       `json:"message,omitempty"`
+}
+
+// swagger:enum ImportStatus
+type ImportStatus string
+
+const (
+	ImportStatusCreated     ImportStatus = "CREATED"
+	ImportStatusOverwritten ImportStatus = "OVERWRITTEN"
+	ImportStatusRenamed     ImportStatus = "RENAMED"
+	ImportStatusSkipped     ImportStatus = "SKIPPED"
+	ImportStatusError       ImportStatus = "ERROR"
+)
+
 // swagger:response resourceDependenciesResponse
 type ResourceDependenciesResponse struct {
 	// in: body
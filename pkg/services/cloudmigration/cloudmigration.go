@@ -31,4 +31,9 @@ type Service interface {
 	GetSnapshotList(ctx context.Context, query ListSnapshotsQuery) ([]CloudMigrationSnapshot, error)
 	UploadSnapshot(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, sessionUid string, snapshotUid string) error
 	CancelSnapshot(ctx context.Context, sessionUid string, snapshotUid string) error
+
+	// ExportSnapshot Builds a snapshot of the instance into a passphrase-encrypted archive that can be imported on another instance.
+	ExportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd ExportSnapshotCommand) ([]byte, error)
+	// ImportSnapshot Creates the resources of an exported archive in the organization of the signed in user.
+	ImportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd ImportSnapshotCommand) (*ImportReport, error)
 }
@@ -64,3 +64,11 @@ func (s *NoopServiceImpl) UploadSnapshot(ctx context.Context, orgID int64, signe
 func (s *NoopServiceImpl) CancelSnapshot(ctx context.Context, sessionUid string, snapshotUid string) error {
 	return cloudmigration.ErrFeatureDisabledError
 }
+
+func (s *NoopServiceImpl) ExportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd cloudmigration.ExportSnapshotCommand) ([]byte, error) {
+	return nil, cloudmigration.ErrFeatureDisabledError
+}
+
+func (s *NoopServiceImpl) ImportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd cloudmigration.ImportSnapshotCommand) (*cloudmigration.ImportReport, error) {
+	return nil, cloudmigration.ErrFeatureDisabledError
+}
@@ -167,3 +167,22 @@ func (m FakeServiceImpl) CancelSnapshot(ctx context.Context, sessionUid string,
 	}
 	return nil
 }
+
+func (m FakeServiceImpl) ExportSnapshot(ctx context.Context, _ *user.SignedInUser, _ cloudmigration.ExportSnapshotCommand) ([]byte, error) {
+	if m.ReturnError {
+		return nil, fmt.Errorf("mock error")
+	}
+	return []byte("fake archive"), nil
+}
+
+func (m FakeServiceImpl) ImportSnapshot(ctx context.Context, _ *user.SignedInUser, cmd cloudmigration.ImportSnapshotCommand) (*cloudmigration.ImportReport, error) {
+	if m.ReturnError {
+		return nil, fmt.Errorf("mock error")
+	}
+	return &cloudmigration.ImportReport{
+		Results: []cloudmigration.ImportResult{
+			{Type: cloudmigration.FolderDataType, RefID: "folder_uid", Name: "Folder", Status: cloudmigration.ImportStatusCreated},
+			{Type: cloudmigration.DashboardDataType, RefID: "dash_uid", Name: "Dashboard", Status: cloudmigration.ImportStatusSkipped},
+		},
+	}, nil
+}
@@ -0,0 +1,126 @@
+package cloudmigrationimpl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/cloudmigration"
+	"github.com/grafana/grafana/pkg/services/encryption"
+)
+
+const (
+	// snapshotArchiveMagic prefixes every archive so that other files are rejected early.
+	snapshotArchiveMagic   = "GRAFANA-SNAPSHOT"
+	snapshotArchiveVersion = 1
+	snapshotArchiveSaltLen = 16
+	// maxSnapshotArchiveSize bounds the decompressed size of an archive.
+	maxSnapshotArchiveSize = 1 << 30
+)
+
+// snapshotArchive is the content of a local snapshot archive, before compression and encryption.
+type snapshotArchive struct {
+	Version        int                   `json:"version"`
+	GrafanaVersion string                `json:"grafanaVersion"`
+	Created        time.Time             `json:"created"`
+	Items          []snapshotArchiveItem `json:"items"`
+}
+
+type snapshotArchiveItem struct {
+	Type  cloudmigration.MigrateDataType `json:"type"`
+	RefID string                         `json:"refId"`
+	Name  string                         `json:"name"`
+	Data  json.RawMessage                `json:"data"`
+}
+
+// sealSnapshotArchive compresses the archive and encrypts it with AES-256-GCM,
+// using a key derived from the passphrase. The output is laid out as magic | salt | nonce | ciphertext.
+func sealSnapshotArchive(archive *snapshotArchive, passphrase string) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if err := json.NewEncoder(zw).Encode(archive); err != nil {
+		return nil, fmt.Errorf("encoding snapshot archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compressing snapshot archive: %w", err)
+	}
+
+	salt := make([]byte, snapshotArchiveSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := snapshotArchiveCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(snapshotArchiveMagic)+len(salt)+len(nonce)+compressed.Len()+aead.Overhead())
+	out = append(out, snapshotArchiveMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, compressed.Bytes(), []byte(snapshotArchiveMagic)), nil
+}
+
+// openSnapshotArchive reverses sealSnapshotArchive. A wrong passphrase and a tampered archive
+// both fail the authentication of the cipher and are reported as an invalid archive.
+func openSnapshotArchive(data []byte, passphrase string) (*snapshotArchive, error) {
+	if !bytes.HasPrefix(data, []byte(snapshotArchiveMagic)) {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+	data = data[len(snapshotArchiveMagic):]
+	if len(data) < snapshotArchiveSaltLen {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+
+	salt := data[:snapshotArchiveSaltLen]
+	aead, err := snapshotArchiveCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	data = data[snapshotArchiveSaltLen:]
+	if len(data) < aead.NonceSize() {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+
+	compressed, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(snapshotArchiveMagic))
+	if err != nil {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+	defer func() { _ = zr.Close() }()
+
+	archive := &snapshotArchive{}
+	if err := json.NewDecoder(io.LimitReader(zr, maxSnapshotArchiveSize)).Decode(archive); err != nil {
+		return nil, cloudmigration.ErrInvalidSnapshotArchive
+	}
+	if archive.Version != snapshotArchiveVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", cloudmigration.ErrInvalidSnapshotArchive, archive.Version)
+	}
+	return archive, nil
+}
+
+func snapshotArchiveCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := encryption.KeyToBytes(passphrase, string(salt))
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
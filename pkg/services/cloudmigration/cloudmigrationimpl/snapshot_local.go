@@ -0,0 +1,704 @@
+package cloudmigrationimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/grafana/pkg/services/cloudmigration"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/folder"
+	libraryelements "github.com/grafana/grafana/pkg/services/libraryelements/model"
+	ngalertapi "github.com/grafana/grafana/pkg/services/ngalert/api/compat"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginsettings"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// renamedSuffix is appended to the name of resources imported next to an existing one.
+	renamedSuffix = " (imported)"
+
+	notRenamableMessage = "resource is referenced by name and cannot be renamed, it was left untouched"
+)
+
+// ExportSnapshot builds the same snapshot data as CreateSnapshot, but writes it into
+// a passphrase-encrypted archive instead of uploading it to GMS.
+func (s *Service) ExportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd cloudmigration.ExportSnapshotCommand) ([]byte, error) {
+	ctx, span := s.tracer.Start(ctx, "CloudMigrationService.ExportSnapshot")
+	defer span.End()
+
+	if cmd.Passphrase == "" {
+		return nil, cloudmigration.ErrEmptyPassphrase
+	}
+	if len(cmd.ResourceTypes) == 0 {
+		return nil, cloudmigration.ErrEmptyResourceTypes
+	}
+
+	migrationData, err := s.getMigrationDataJSON(ctx, signedInUser, cmd.ResourceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("fetching migration data: %w", err)
+	}
+
+	archive := &snapshotArchive{
+		Version:        snapshotArchiveVersion,
+		GrafanaVersion: s.grafanaVersion,
+		Created:        time.Now().UTC(),
+		Items:          make([]snapshotArchiveItem, 0, len(migrationData.Items)),
+	}
+	for _, item := range migrationData.Items {
+		data, err := json.Marshal(item.Data)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %w", item.Type, item.RefID, err)
+		}
+		archive.Items = append(archive.Items, snapshotArchiveItem{
+			Type:  item.Type,
+			RefID: item.RefID,
+			Name:  item.Name,
+			Data:  data,
+		})
+	}
+
+	span.SetAttributes(attribute.Int("items", len(archive.Items)))
+
+	return sealSnapshotArchive(archive, cmd.Passphrase)
+}
+
+// ImportSnapshot creates the resources of an exported archive in the organization of the signed in user.
+// Resource types are imported after the types they depend on, and a failure to import one resource
+// is reported in its result instead of stopping the import.
+func (s *Service) ImportSnapshot(ctx context.Context, signedInUser *user.SignedInUser, cmd cloudmigration.ImportSnapshotCommand) (*cloudmigration.ImportReport, error) {
+	ctx, span := s.tracer.Start(ctx, "CloudMigrationService.ImportSnapshot")
+	defer span.End()
+
+	if cmd.Passphrase == "" {
+		return nil, cloudmigration.ErrEmptyPassphrase
+	}
+	if !cmd.ConflictPolicy.IsValid() {
+		return nil, cloudmigration.ErrInvalidConflictPolicy
+	}
+
+	archive, err := openSnapshotArchive(cmd.Archive, cmd.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &cloudmigration.ImportReport{Results: make([]cloudmigration.ImportResult, 0, len(archive.Items))}
+
+	itemsByType := make(map[cloudmigration.MigrateDataType][]snapshotArchiveItem)
+	resourceTypes := make(cloudmigration.ResourceTypes)
+	for _, item := range archive.Items {
+		if _, ok := cloudmigration.ResourceDependency[item.Type]; !ok {
+			report.Results = append(report.Results, cloudmigration.ImportResult{
+				Type:    item.Type,
+				RefID:   item.RefID,
+				Name:    item.Name,
+				Status:  cloudmigration.ImportStatusError,
+				Message: "unsupported resource type",
+			})
+			continue
+		}
+		itemsByType[item.Type] = append(itemsByType[item.Type], item)
+		resourceTypes[item.Type] = struct{}{}
+	}
+
+	order, err := cloudmigration.ResourceDependency.Order(resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &snapshotImporter{
+		s:              s,
+		user:           signedInUser,
+		orgID:          signedInUser.GetOrgID(),
+		policy:         cmd.ConflictPolicy,
+		folderUIDs:     make(map[string]string),
+		dataSourceUIDs: make(map[string]string),
+		libraryUIDs:    make(map[string]string),
+		ruleGroups:     make(map[string]cloudmigration.ImportStatus),
+	}
+
+	for _, resourceType := range order {
+		for _, item := range itemsByType[resourceType] {
+			report.Results = append(report.Results, importer.importItem(ctx, item))
+		}
+	}
+
+	span.SetAttributes(attribute.Int("items", len(report.Results)))
+
+	return report, nil
+}
+
+// snapshotImporter holds the state shared between the items of one import.
+type snapshotImporter struct {
+	s      *Service
+	user   *user.SignedInUser
+	orgID  int64
+	policy cloudmigration.ConflictPolicy
+
+	// uids of renamed resources, by their uid in the archive, used to rewrite the references to them.
+	folderUIDs     map[string]string
+	dataSourceUIDs map[string]string
+	libraryUIDs    map[string]string
+	// ruleGroups keeps the status of the rule groups that had rules imported, by folder uid and title.
+	ruleGroups map[string]cloudmigration.ImportStatus
+}
+
+type importOutcome struct {
+	status   cloudmigration.ImportStatus
+	newRefID string
+	message  string
+}
+
+func (imp *snapshotImporter) importItem(ctx context.Context, item snapshotArchiveItem) cloudmigration.ImportResult {
+	var outcome importOutcome
+	var err error
+
+	switch item.Type {
+	case cloudmigration.PluginDataType:
+		outcome, err = imp.importPlugin(ctx, item)
+	case cloudmigration.FolderDataType:
+		outcome, err = imp.importFolder(ctx, item)
+	case cloudmigration.DatasourceDataType:
+		outcome, err = imp.importDataSource(ctx, item)
+	case cloudmigration.LibraryElementDataType:
+		outcome, err = imp.importLibraryElement(ctx, item)
+	case cloudmigration.DashboardDataType:
+		outcome, err = imp.importDashboard(ctx, item)
+	case cloudmigration.MuteTimingType:
+		outcome, err = imp.importMuteTiming(ctx, item)
+	case cloudmigration.NotificationTemplateType:
+		outcome, err = imp.importNotificationTemplate(ctx, item)
+	case cloudmigration.ContactPointType:
+		outcome, err = imp.importContactPoint(ctx, item)
+	case cloudmigration.NotificationPolicyType:
+		outcome, err = imp.importNotificationPolicy(ctx, item)
+	case cloudmigration.AlertRuleType:
+		outcome, err = imp.importAlertRule(ctx, item)
+	case cloudmigration.AlertRuleGroupType:
+		outcome, err = imp.importAlertRuleGroup(ctx, item)
+	default:
+		err = fmt.Errorf("unsupported resource type")
+	}
+
+	result := cloudmigration.ImportResult{
+		Type:     item.Type,
+		RefID:    item.RefID,
+		Name:     item.Name,
+		NewRefID: outcome.newRefID,
+		Status:   outcome.status,
+		Message:  outcome.message,
+	}
+	if err != nil {
+		imp.s.log.Warn("Failed to import resource", "type", item.Type, "refId", item.RefID, "err", err)
+		result.Status = cloudmigration.ImportStatusError
+		result.Message = err.Error()
+	}
+	return result
+}
+
+// onConflict returns the outcome for an existing resource that is skipped, or nil when the resource must be written.
+func (imp *snapshotImporter) onConflict(renamable bool) *importOutcome {
+	switch imp.policy {
+	case cloudmigration.ConflictPolicySkip:
+		return &importOutcome{status: cloudmigration.ImportStatusSkipped, message: "resource already exists"}
+	case cloudmigration.ConflictPolicyRename:
+		if !renamable {
+			return &importOutcome{status: cloudmigration.ImportStatusSkipped, message: notRenamableMessage}
+		}
+	}
+	return nil
+}
+
+// exists interprets the error of a lookup, treating the given not found errors as a missing resource.
+func exists(err error, notFound ...error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	for _, target := range notFound {
+		if errors.Is(err, target) {
+			return false, nil
+		}
+	}
+	return false, err
+}
+
+func remapUID(uids map[string]string, uid string) string {
+	if newUID, ok := uids[uid]; ok {
+		return newUID
+	}
+	return uid
+}
+
+// remapReferences rewrites the uid of every object found under key, e.g. the datasource references of
+// the panels and targets of a dashboard.
+func remapReferences(v any, key string, uids map[string]string) {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			if ref, ok := child.(map[string]any); ok && k == key {
+				if uid, ok := ref["uid"].(string); ok {
+					ref["uid"] = remapUID(uids, uid)
+				}
+			}
+			remapReferences(child, key, uids)
+		}
+	case []any:
+		for _, child := range value {
+			remapReferences(child, key, uids)
+		}
+	}
+}
+
+// remapModel rewrites the datasource and library panel references of a dashboard or library element model.
+func (imp *snapshotImporter) remapModel(model any) {
+	if len(imp.dataSourceUIDs) > 0 {
+		remapReferences(model, "datasource", imp.dataSourceUIDs)
+	}
+	if len(imp.libraryUIDs) > 0 {
+		remapReferences(model, "libraryPanel", imp.libraryUIDs)
+	}
+}
+
+func (imp *snapshotImporter) importPlugin(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var cmd pluginsettings.UpdatePluginSettingCmd
+	if err := json.Unmarshal(item.Data, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+
+	if _, ok := imp.s.pluginStore.Plugin(ctx, item.RefID); !ok {
+		return importOutcome{}, fmt.Errorf("plugin %s is not installed on this instance", item.RefID)
+	}
+
+	_, err := imp.s.pluginSettingsService.GetPluginSettingByPluginID(ctx, &pluginsettings.GetByPluginIDArgs{PluginID: item.RefID, OrgID: imp.orgID})
+	found, err := exists(err, pluginsettings.ErrPluginSettingNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	status := cloudmigration.ImportStatusCreated
+	if found {
+		if outcome := imp.onConflict(false); outcome != nil {
+			return *outcome, nil
+		}
+		status = cloudmigration.ImportStatusOverwritten
+	}
+
+	err = imp.s.pluginSettingsService.UpdatePluginSetting(ctx, &pluginsettings.UpdateArgs{
+		Enabled:        cmd.Enabled,
+		Pinned:         cmd.Pinned,
+		JSONData:       cmd.JsonData,
+		SecureJSONData: cmd.SecureJsonData,
+		PluginVersion:  cmd.PluginVersion,
+		PluginID:       item.RefID,
+		OrgID:          imp.orgID,
+	})
+	return importOutcome{status: status}, err
+}
+
+func (imp *snapshotImporter) importFolder(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var cmd folder.CreateFolderCommand
+	if err := json.Unmarshal(item.Data, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+	cmd.OrgID = imp.orgID
+	cmd.SignedInUser = imp.user
+	cmd.ParentUID = remapUID(imp.folderUIDs, cmd.ParentUID)
+
+	existing, err := imp.s.folderService.Get(ctx, &folder.GetFolderQuery{UID: &cmd.UID, OrgID: imp.orgID, SignedInUser: imp.user})
+	found, err := exists(err, folder.ErrFolderNotFound, dashboards.ErrFolderNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	if !found || existing == nil {
+		_, err := imp.s.folderService.Create(ctx, &cmd)
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(true); outcome != nil {
+		return *outcome, nil
+	}
+
+	if imp.policy == cloudmigration.ConflictPolicyOverwrite {
+		_, err := imp.s.folderService.Update(ctx, &folder.UpdateFolderCommand{
+			UID:            cmd.UID,
+			OrgID:          imp.orgID,
+			NewTitle:       &cmd.Title,
+			NewDescription: &cmd.Description,
+			Overwrite:      true,
+			SignedInUser:   imp.user,
+		})
+		return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+	}
+
+	oldUID := cmd.UID
+	cmd.UID = util.GenerateShortUID()
+	cmd.Title += renamedSuffix
+	if _, err := imp.s.folderService.Create(ctx, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+	imp.folderUIDs[oldUID] = cmd.UID
+	return importOutcome{status: cloudmigration.ImportStatusRenamed, newRefID: cmd.UID}, nil
+}
+
+func (imp *snapshotImporter) importDataSource(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var cmd datasources.AddDataSourceCommand
+	if err := json.Unmarshal(item.Data, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+	cmd.OrgID = imp.orgID
+	cmd.UserID = imp.user.UserID
+
+	existing, err := imp.s.dsService.GetDataSource(ctx, &datasources.GetDataSourceQuery{UID: cmd.UID, OrgID: imp.orgID})
+	found, err := exists(err, datasources.ErrDataSourceNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	if !found {
+		_, err := imp.s.dsService.AddDataSource(ctx, &cmd)
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(true); outcome != nil {
+		return *outcome, nil
+	}
+
+	if imp.policy == cloudmigration.ConflictPolicyOverwrite {
+		_, err := imp.s.dsService.UpdateDataSource(ctx, &datasources.UpdateDataSourceCommand{
+			Name:            cmd.Name,
+			Type:            cmd.Type,
+			Access:          cmd.Access,
+			URL:             cmd.URL,
+			User:            cmd.User,
+			Database:        cmd.Database,
+			BasicAuth:       cmd.BasicAuth,
+			BasicAuthUser:   cmd.BasicAuthUser,
+			WithCredentials: cmd.WithCredentials,
+			IsDefault:       cmd.IsDefault,
+			JsonData:        cmd.JsonData,
+			SecureJsonData:  cmd.SecureJsonData,
+			UID:             existing.UID,
+			Version:         existing.Version,
+			OrgID:           imp.orgID,
+			ID:              existing.ID,
+		})
+		return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+	}
+
+	oldUID := cmd.UID
+	cmd.UID = util.GenerateShortUID()
+	cmd.Name += renamedSuffix
+	// The existing data source keeps being the default one.
+	cmd.IsDefault = false
+	if _, err := imp.s.dsService.AddDataSource(ctx, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+	imp.dataSourceUIDs[oldUID] = cmd.UID
+	return importOutcome{status: cloudmigration.ImportStatusRenamed, newRefID: cmd.UID}, nil
+}
+
+func (imp *snapshotImporter) importLibraryElement(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var element libraryElement
+	if err := json.Unmarshal(item.Data, &element); err != nil {
+		return importOutcome{}, err
+	}
+	if element.FolderUID != nil {
+		folderUID := remapUID(imp.folderUIDs, *element.FolderUID)
+		element.FolderUID = &folderUID
+	}
+	if len(imp.dataSourceUIDs) > 0 && len(element.Model) > 0 {
+		var model any
+		if err := json.Unmarshal(element.Model, &model); err != nil {
+			return importOutcome{}, err
+		}
+		imp.remapModel(model)
+		remapped, err := json.Marshal(model)
+		if err != nil {
+			return importOutcome{}, err
+		}
+		element.Model = remapped
+	}
+
+	existing, err := imp.s.libraryElementsService.GetElement(ctx, imp.user, libraryelements.GetLibraryElementCommand{UID: element.UID})
+	found, err := exists(err, libraryelements.ErrLibraryElementNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+
+	create := libraryelements.CreateLibraryElementCommand{
+		FolderUID: element.FolderUID,
+		Name:      element.Name,
+		Model:     element.Model,
+		Kind:      element.Kind,
+		UID:       element.UID,
+	}
+	if !found {
+		_, err := imp.s.libraryElementsService.CreateElement(ctx, imp.user, create)
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(true); outcome != nil {
+		return *outcome, nil
+	}
+
+	if imp.policy == cloudmigration.ConflictPolicyOverwrite {
+		_, err := imp.s.libraryElementsService.PatchElement(ctx, imp.user, libraryelements.PatchLibraryElementCommand{
+			FolderUID: element.FolderUID,
+			Name:      element.Name,
+			Model:     element.Model,
+			Kind:      element.Kind,
+			Version:   existing.Version,
+			UID:       element.UID,
+		}, element.UID)
+		return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+	}
+
+	create.UID = util.GenerateShortUID()
+	create.Name += renamedSuffix
+	if _, err := imp.s.libraryElementsService.CreateElement(ctx, imp.user, create); err != nil {
+		return importOutcome{}, err
+	}
+	imp.libraryUIDs[element.UID] = create.UID
+	return importOutcome{status: cloudmigration.ImportStatusRenamed, newRefID: create.UID}, nil
+}
+
+func (imp *snapshotImporter) importDashboard(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var cmd dashboards.SaveDashboardCommand
+	if err := json.Unmarshal(item.Data, &cmd); err != nil {
+		return importOutcome{}, err
+	}
+	if cmd.Dashboard == nil {
+		return importOutcome{}, fmt.Errorf("dashboard has no model")
+	}
+	cmd.OrgID = imp.orgID
+	cmd.UserID = imp.user.UserID
+	cmd.FolderUID = remapUID(imp.folderUIDs, cmd.FolderUID)
+	imp.remapModel(cmd.Dashboard.Interface())
+
+	_, err := imp.s.dashboardService.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: item.RefID, OrgID: imp.orgID})
+	found, err := exists(err, dashboards.ErrDashboardNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+
+	outcome := importOutcome{status: cloudmigration.ImportStatusCreated}
+	overwrite := false
+	if found {
+		if skipped := imp.onConflict(true); skipped != nil {
+			return *skipped, nil
+		}
+
+		if imp.policy == cloudmigration.ConflictPolicyOverwrite {
+			outcome.status = cloudmigration.ImportStatusOverwritten
+			overwrite = true
+		} else {
+			outcome.status = cloudmigration.ImportStatusRenamed
+			outcome.newRefID = util.GenerateShortUID()
+			cmd.Dashboard.Set("uid", outcome.newRefID)
+			cmd.Dashboard.Set("title", cmd.Dashboard.Get("title").MustString()+renamedSuffix)
+		}
+	}
+
+	_, err = imp.s.dashboardService.SaveDashboard(ctx, &dashboards.SaveDashboardDTO{
+		OrgID:     imp.orgID,
+		User:      imp.user,
+		Message:   fmt.Sprintf("Imported from a snapshot archive by user \"%s\"", imp.user.Login),
+		Overwrite: overwrite,
+		Dashboard: cmd.GetDashboardModel(),
+	}, false)
+	return outcome, err
+}
+
+func (imp *snapshotImporter) importMuteTiming(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var muteTiming muteTimeInterval
+	if err := json.Unmarshal(item.Data, &muteTiming); err != nil {
+		return importOutcome{}, err
+	}
+
+	existing, err := imp.s.ngAlert.Api.MuteTimings.GetMuteTiming(ctx, muteTiming.Name, imp.orgID)
+	found, err := exists(err, provisioning.ErrTimeIntervalNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	if !found {
+		_, err := imp.s.ngAlert.Api.MuteTimings.CreateMuteTiming(ctx, definitions.MuteTimeInterval{MuteTimeInterval: muteTiming.MuteTimeInterval}, imp.orgID)
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(false); outcome != nil {
+		return *outcome, nil
+	}
+
+	_, err = imp.s.ngAlert.Api.MuteTimings.UpdateMuteTiming(ctx, definitions.MuteTimeInterval{
+		UID:              existing.UID,
+		MuteTimeInterval: muteTiming.MuteTimeInterval,
+		Version:          existing.Version,
+		Provenance:       existing.Provenance,
+	}, imp.orgID)
+	return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+}
+
+func (imp *snapshotImporter) importNotificationTemplate(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var template notificationTemplate
+	if err := json.Unmarshal(item.Data, &template); err != nil {
+		return importOutcome{}, err
+	}
+
+	existing, err := imp.s.ngAlert.Api.Templates.GetTemplate(ctx, imp.orgID, template.Name)
+	found, err := exists(err, provisioning.ErrTemplateNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	if !found {
+		_, err := imp.s.ngAlert.Api.Templates.CreateTemplate(ctx, imp.orgID, definitions.NotificationTemplate{Name: template.Name, Template: template.Template})
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(false); outcome != nil {
+		return *outcome, nil
+	}
+
+	existing.Template = template.Template
+	_, err = imp.s.ngAlert.Api.Templates.UpdateTemplate(ctx, imp.orgID, existing)
+	return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+}
+
+func (imp *snapshotImporter) importContactPoint(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var cp contactPoint
+	if err := json.Unmarshal(item.Data, &cp); err != nil {
+		return importOutcome{}, err
+	}
+
+	existing, err := imp.s.ngAlert.Api.ContactPointService.GetContactPoints(ctx, provisioning.ContactPointQuery{Name: cp.Name, OrgID: imp.orgID}, imp.user)
+	if err != nil {
+		return importOutcome{}, err
+	}
+	found := false
+	for _, e := range existing {
+		if e.UID == cp.UID {
+			found = true
+			break
+		}
+	}
+
+	embedded := definitions.EmbeddedContactPoint{
+		UID:                   cp.UID,
+		Name:                  cp.Name,
+		Type:                  cp.Type,
+		Settings:              cp.Settings,
+		DisableResolveMessage: cp.DisableResolveMessage,
+	}
+	if !found {
+		_, err := imp.s.ngAlert.Api.ContactPointService.CreateContactPoint(ctx, imp.orgID, imp.user, embedded, ngmodels.ProvenanceNone)
+		return importOutcome{status: cloudmigration.ImportStatusCreated}, err
+	}
+
+	if outcome := imp.onConflict(false); outcome != nil {
+		return *outcome, nil
+	}
+
+	err = imp.s.ngAlert.Api.ContactPointService.UpdateContactPoint(ctx, imp.orgID, embedded, ngmodels.ProvenanceNone)
+	return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+}
+
+// importNotificationPolicy replaces the policy tree of the organization. As every organization has a tree,
+// it is always a conflict and the tree is only imported with the overwrite policy.
+func (imp *snapshotImporter) importNotificationPolicy(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var tree definitions.Route
+	if err := json.Unmarshal(item.Data, &tree); err != nil {
+		return importOutcome{}, err
+	}
+
+	if outcome := imp.onConflict(false); outcome != nil {
+		return *outcome, nil
+	}
+
+	_, _, err := imp.s.ngAlert.Api.Policies.UpdatePolicyTree(ctx, imp.orgID, tree, ngmodels.ProvenanceNone, "")
+	return importOutcome{status: cloudmigration.ImportStatusOverwritten}, err
+}
+
+func (imp *snapshotImporter) importAlertRule(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var provisioned definitions.ProvisionedAlertRule
+	if err := json.Unmarshal(item.Data, &provisioned); err != nil {
+		return importOutcome{}, err
+	}
+	provisioned.ID = 0
+	provisioned.OrgID = imp.orgID
+	provisioned.FolderUID = remapUID(imp.folderUIDs, provisioned.FolderUID)
+	for i := range provisioned.Data {
+		provisioned.Data[i].DatasourceUID = remapUID(imp.dataSourceUIDs, provisioned.Data[i].DatasourceUID)
+	}
+
+	rule, err := ngalertapi.AlertRuleFromProvisionedAlertRule(provisioned)
+	if err != nil {
+		return importOutcome{}, err
+	}
+
+	_, _, err = imp.s.ngAlert.Api.AlertRules.GetAlertRule(ctx, imp.user, rule.UID)
+	found, err := exists(err, ngmodels.ErrAlertRuleNotFound)
+	if err != nil {
+		return importOutcome{}, err
+	}
+
+	outcome := importOutcome{status: cloudmigration.ImportStatusCreated}
+	if found {
+		if skipped := imp.onConflict(true); skipped != nil {
+			return *skipped, nil
+		}
+
+		if imp.policy == cloudmigration.ConflictPolicyOverwrite {
+			if _, err := imp.s.ngAlert.Api.AlertRules.UpdateAlertRule(ctx, imp.user, rule, ngmodels.ProvenanceNone); err != nil {
+				return importOutcome{}, err
+			}
+			imp.trackRuleGroup(rule, cloudmigration.ImportStatusOverwritten)
+			return importOutcome{status: cloudmigration.ImportStatusOverwritten}, nil
+		}
+
+		outcome = importOutcome{status: cloudmigration.ImportStatusRenamed, newRefID: util.GenerateShortUID()}
+		rule.UID = outcome.newRefID
+		rule.Title += renamedSuffix
+	}
+
+	if _, err := imp.s.ngAlert.Api.AlertRules.CreateAlertRule(ctx, imp.user, rule, ngmodels.ProvenanceNone); err != nil {
+		return importOutcome{}, err
+	}
+	imp.trackRuleGroup(rule, cloudmigration.ImportStatusCreated)
+	return outcome, nil
+}
+
+func ruleGroupKey(folderUID, title string) string {
+	return folderUID + "/" + title
+}
+
+// trackRuleGroup records that a rule of the group was imported, so the settings of the group are imported too.
+func (imp *snapshotImporter) trackRuleGroup(rule ngmodels.AlertRule, status cloudmigration.ImportStatus) {
+	key := ruleGroupKey(rule.NamespaceUID, rule.RuleGroup)
+	if imp.ruleGroups[key] != cloudmigration.ImportStatusOverwritten {
+		imp.ruleGroups[key] = status
+	}
+}
+
+// importAlertRuleGroup sets the evaluation interval of a group. The group itself is created with its rules,
+// so it is only updated when at least one of them was imported.
+func (imp *snapshotImporter) importAlertRuleGroup(ctx context.Context, item snapshotArchiveItem) (importOutcome, error) {
+	var group alertRuleGroup
+	if err := json.Unmarshal(item.Data, &group); err != nil {
+		return importOutcome{}, err
+	}
+	folderUID := remapUID(imp.folderUIDs, group.FolderUID)
+
+	status, ok := imp.ruleGroups[ruleGroupKey(folderUID, group.Title)]
+	if !ok {
+		return importOutcome{status: cloudmigration.ImportStatusSkipped, message: "none of the rules of the group were imported"}, nil
+	}
+
+	err := imp.s.ngAlert.Api.AlertRules.UpdateRuleGroup(ctx, imp.user, folderUID, group.Title, group.Interval)
+	return importOutcome{status: status}, err
+}
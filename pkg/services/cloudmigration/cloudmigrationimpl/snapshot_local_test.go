@@ -0,0 +1,250 @@
+package cloudmigrationimpl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/cloudmigration"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	datafakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
+	"github.com/grafana/grafana/pkg/services/folder"
+	libraryelements "github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestSnapshotArchive(t *testing.T) {
+	archive := &snapshotArchive{
+		Version: snapshotArchiveVersion,
+		Items: []snapshotArchiveItem{
+			{Type: cloudmigration.FolderDataType, RefID: "folder", Name: "Folder", Data: json.RawMessage(`{"uid":"folder"}`)},
+		},
+	}
+
+	sealed, err := sealSnapshotArchive(archive, "passphrase")
+	require.NoError(t, err)
+	require.NotContains(t, string(sealed), "Folder")
+
+	t.Run("opens with the same passphrase", func(t *testing.T) {
+		opened, err := openSnapshotArchive(sealed, "passphrase")
+		require.NoError(t, err)
+		require.Equal(t, archive.Items, opened.Items)
+	})
+
+	t.Run("rejects a wrong passphrase", func(t *testing.T) {
+		_, err := openSnapshotArchive(sealed, "wrong")
+		require.ErrorIs(t, err, cloudmigration.ErrInvalidSnapshotArchive)
+	})
+
+	t.Run("rejects a tampered archive", func(t *testing.T) {
+		tampered := append([]byte{}, sealed...)
+		tampered[len(tampered)-1] ^= 0xff
+		_, err := openSnapshotArchive(tampered, "passphrase")
+		require.ErrorIs(t, err, cloudmigration.ErrInvalidSnapshotArchive)
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		_, err := openSnapshotArchive([]byte("not an archive"), "passphrase")
+		require.ErrorIs(t, err, cloudmigration.ErrInvalidSnapshotArchive)
+	})
+}
+
+func TestExportImportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	signedInUser := &user.SignedInUser{OrgID: 1, Login: "admin"}
+
+	newArchive := func(t *testing.T, items ...cloudmigration.MigrateDataRequestItem) []byte {
+		t.Helper()
+		archive := &snapshotArchive{Version: snapshotArchiveVersion}
+		for _, item := range items {
+			data, err := json.Marshal(item.Data)
+			require.NoError(t, err)
+			archive.Items = append(archive.Items, snapshotArchiveItem{Type: item.Type, RefID: item.RefID, Name: item.Name, Data: data})
+		}
+		sealed, err := sealSnapshotArchive(archive, "passphrase")
+		require.NoError(t, err)
+		return sealed
+	}
+
+	statuses := func(report *cloudmigration.ImportReport) map[string]cloudmigration.ImportStatus {
+		out := make(map[string]cloudmigration.ImportStatus, len(report.Results))
+		for _, r := range report.Results {
+			out[r.RefID] = r.Status
+		}
+		return out
+	}
+
+	t.Run("rejects invalid commands", func(t *testing.T) {
+		s := setUpServiceTest(t, false).(*Service)
+
+		_, err := s.ExportSnapshot(ctx, signedInUser, cloudmigration.ExportSnapshotCommand{
+			ResourceTypes: cloudmigration.ResourceTypes{cloudmigration.LibraryElementDataType: {}},
+		})
+		require.ErrorIs(t, err, cloudmigration.ErrEmptyPassphrase)
+
+		_, err = s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{Passphrase: "passphrase", ConflictPolicy: "merge"})
+		require.ErrorIs(t, err, cloudmigration.ErrInvalidConflictPolicy)
+
+		_, err = s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        newArchive(t),
+			Passphrase:     "wrong",
+			ConflictPolicy: cloudmigration.ConflictPolicySkip,
+		})
+		require.ErrorIs(t, err, cloudmigration.ErrInvalidSnapshotArchive)
+	})
+
+	t.Run("exported archives can be imported back", func(t *testing.T) {
+		s := setUpServiceTest(t, false).(*Service)
+
+		_, err := s.libraryElementsService.CreateElement(ctx, signedInUser, libraryelements.CreateLibraryElementCommand{
+			Name:  "panel",
+			Model: []byte(`{"type":"graph"}`),
+			Kind:  int64(libraryelements.PanelElement),
+			UID:   "panel-uid",
+		})
+		require.NoError(t, err)
+
+		archive, err := s.ExportSnapshot(ctx, signedInUser, cloudmigration.ExportSnapshotCommand{
+			ResourceTypes: cloudmigration.ResourceTypes{cloudmigration.LibraryElementDataType: {}},
+			Passphrase:    "passphrase",
+		})
+		require.NoError(t, err)
+
+		report, err := s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        archive,
+			Passphrase:     "passphrase",
+			ConflictPolicy: cloudmigration.ConflictPolicyOverwrite,
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]cloudmigration.ImportStatus{"panel-uid": cloudmigration.ImportStatusOverwritten}, statuses(report))
+	})
+
+	t.Run("applies the conflict policy to existing resources", func(t *testing.T) {
+		s := setUpServiceTest(t, false).(*Service)
+		s.dsService = &datafakes.FakeDataSourceService{
+			DataSources: []*datasources.DataSource{{UID: "existing-ds", Name: "Existing", Type: "prometheus", OrgID: 1}},
+		}
+
+		archive := newArchive(t,
+			cloudmigration.MigrateDataRequestItem{
+				Type: cloudmigration.DatasourceDataType, RefID: "existing-ds", Name: "Existing",
+				Data: datasources.AddDataSourceCommand{UID: "existing-ds", Name: "Existing", Type: "prometheus"},
+			},
+			cloudmigration.MigrateDataRequestItem{
+				Type: cloudmigration.DatasourceDataType, RefID: "new-ds", Name: "New",
+				Data: datasources.AddDataSourceCommand{UID: "new-ds", Name: "New", Type: "loki"},
+			},
+		)
+
+		report, err := s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        archive,
+			Passphrase:     "passphrase",
+			ConflictPolicy: cloudmigration.ConflictPolicySkip,
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]cloudmigration.ImportStatus{
+			"existing-ds": cloudmigration.ImportStatusSkipped,
+			"new-ds":      cloudmigration.ImportStatusCreated,
+		}, statuses(report))
+
+		report, err = s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        archive,
+			Passphrase:     "passphrase",
+			ConflictPolicy: cloudmigration.ConflictPolicyOverwrite,
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]cloudmigration.ImportStatus{
+			"existing-ds": cloudmigration.ImportStatusOverwritten,
+			"new-ds":      cloudmigration.ImportStatusOverwritten,
+		}, statuses(report))
+	})
+
+	t.Run("renames conflicting resources and rewrites the references to them", func(t *testing.T) {
+		s := setUpServiceTest(t, false).(*Service)
+		s.dsService = &datafakes.FakeDataSourceService{
+			DataSources: []*datasources.DataSource{{UID: "ds", Name: "Prometheus", Type: "prometheus", OrgID: 1}},
+		}
+
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.Anything).Return(nil, dashboards.ErrDashboardNotFound)
+		var saved *dashboards.Dashboard
+		dashboardService.On("SaveDashboard", mock.Anything, mock.Anything, false).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*dashboards.SaveDashboardDTO).Dashboard
+		}).Return(&dashboards.Dashboard{}, nil)
+		s.dashboardService = dashboardService
+
+		dashboard := simplejson.NewFromAny(map[string]any{
+			"uid":   "dash",
+			"title": "Dashboard",
+			"panels": []any{
+				map[string]any{"datasource": map[string]any{"type": "prometheus", "uid": "ds"}},
+			},
+		})
+
+		archive := newArchive(t,
+			// "folderUID" exists in the test setup
+			cloudmigration.MigrateDataRequestItem{
+				Type: cloudmigration.FolderDataType, RefID: "folderUID", Name: "Folder",
+				Data: folder.CreateFolderCommand{UID: "folderUID", Title: "Folder"},
+			},
+			cloudmigration.MigrateDataRequestItem{
+				Type: cloudmigration.DashboardDataType, RefID: "dash", Name: "Dashboard",
+				Data: dashboards.SaveDashboardCommand{Dashboard: dashboard, FolderUID: "folderUID"},
+			},
+			cloudmigration.MigrateDataRequestItem{
+				Type: cloudmigration.DatasourceDataType, RefID: "ds", Name: "Prometheus",
+				Data: datasources.AddDataSourceCommand{UID: "ds", Name: "Prometheus", Type: "prometheus"},
+			},
+		)
+
+		report, err := s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        archive,
+			Passphrase:     "passphrase",
+			ConflictPolicy: cloudmigration.ConflictPolicyRename,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Results, 3)
+
+		newRefIDs := make(map[string]string)
+		for _, r := range report.Results {
+			newRefIDs[r.RefID] = r.NewRefID
+		}
+		// Dependencies are imported first, regardless of their order in the archive.
+		require.Equal(t, cloudmigration.DashboardDataType, report.Results[2].Type)
+		require.Equal(t, cloudmigration.ImportStatusCreated, report.Results[2].Status)
+		require.NotEmpty(t, newRefIDs["folderUID"])
+		require.NotEmpty(t, newRefIDs["ds"])
+
+		require.NotNil(t, saved)
+		require.Equal(t, newRefIDs["folderUID"], saved.FolderUID)
+		require.Equal(t, newRefIDs["ds"], saved.Data.Get("panels").GetIndex(0).GetPath("datasource", "uid").MustString())
+
+		renamed, err := s.dsService.GetDataSource(ctx, &datasources.GetDataSourceQuery{UID: newRefIDs["ds"], OrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, "Prometheus (imported)", renamed.Name)
+	})
+
+	t.Run("skips the notification policy unless it is overwritten", func(t *testing.T) {
+		s := setUpServiceTest(t, false).(*Service)
+
+		archive := newArchive(t, cloudmigration.MigrateDataRequestItem{
+			Type: cloudmigration.NotificationPolicyType, RefID: "Notification Policy Tree", Name: "Notification Policy Tree",
+			Data: map[string]any{"receiver": "grafana-default-email"},
+		})
+
+		report, err := s.ImportSnapshot(ctx, signedInUser, cloudmigration.ImportSnapshotCommand{
+			Archive:        archive,
+			Passphrase:     "passphrase",
+			ConflictPolicy: cloudmigration.ConflictPolicyRename,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Results, 1)
+		require.Equal(t, cloudmigration.ImportStatusSkipped, report.Results[0].Status)
+		require.Equal(t, notRenamableMessage, report.Results[0].Message)
+	})
+}
@@ -15,6 +15,9 @@ var (
 	ErrTokenNotFound               = errutil.NotFound("cloudmigrations.tokenNotFound").Errorf("Token not found")
 	ErrSnapshotNotFound            = errutil.NotFound("cloudmigrations.snapshotNotFound").Errorf("Snapshot not found")
 	ErrEmptyResourceTypes          = errutil.BadRequest("cloudmigrations.emptyResourceTypes").Errorf("Resource types cannot be empty")
+	ErrEmptyPassphrase             = errutil.BadRequest("cloudmigrations.emptyPassphrase").Errorf("Passphrase cannot be empty")
+	ErrInvalidConflictPolicy       = errutil.BadRequest("cloudmigrations.invalidConflictPolicy").Errorf("Conflict policy must be one of skip, overwrite or rename")
+	ErrInvalidSnapshotArchive      = errutil.BadRequest("cloudmigrations.invalidSnapshotArchive").Errorf("Snapshot archive is invalid or the passphrase is wrong")
 )
 
 // CloudMigration domain structs
@@ -225,6 +228,65 @@ type CreateSnapshotCommand struct {
 	ResourceTypes ResourceTypes
 }
 
+// Local snapshot archives, used to migrate between self-hosted instances
+
+// ConflictPolicy decides what happens when an imported resource already exists on the target instance.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicySkip leaves the existing resource untouched.
+	ConflictPolicySkip ConflictPolicy = "skip"
+	// ConflictPolicyOverwrite replaces the existing resource with the imported one.
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+	// ConflictPolicyRename imports the resource next to the existing one with a new uid and name.
+	ConflictPolicyRename ConflictPolicy = "rename"
+)
+
+func (p ConflictPolicy) IsValid() bool {
+	switch p {
+	case ConflictPolicySkip, ConflictPolicyOverwrite, ConflictPolicyRename:
+		return true
+	}
+	return false
+}
+
+type ExportSnapshotCommand struct {
+	ResourceTypes ResourceTypes
+	// Passphrase the archive is encrypted with, it is needed again to import it.
+	Passphrase string
+}
+
+type ImportSnapshotCommand struct {
+	Archive        []byte
+	Passphrase     string
+	ConflictPolicy ConflictPolicy
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated     ImportStatus = "CREATED"
+	ImportStatusOverwritten ImportStatus = "OVERWRITTEN"
+	ImportStatusRenamed     ImportStatus = "RENAMED"
+	ImportStatusSkipped     ImportStatus = "SKIPPED"
+	ImportStatusError       ImportStatus = "ERROR"
+)
+
+// ImportResult is the outcome of importing a single resource.
+type ImportResult struct {
+	Type  MigrateDataType
+	RefID string
+	Name  string
+	// NewRefID is set when the resource was imported under a different uid.
+	NewRefID string
+	Status   ImportStatus
+	Message  string
+}
+
+type ImportReport struct {
+	Results []ImportResult
+}
+
 type GetSnapshotsQuery struct {
 	SnapshotUID string
 	OrgID       int64
@@ -1,6 +1,8 @@
 package cloudmigration
 
 import (
+	"sort"
+
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 )
 
@@ -50,6 +52,48 @@ func (depMap DependencyMap) Parse(rawInput []MigrateDataType) (ResourceTypes, er
 	return input, nil
 }
 
+// Order returns the given resource types sorted so that every type comes after its dependencies,
+// which is the order resources have to be created in when importing a snapshot.
+// Types without a dependency between them are sorted by name to keep the order stable.
+func (depMap DependencyMap) Order(resourceTypes ResourceTypes) ([]MigrateDataType, error) {
+	pending := make([]MigrateDataType, 0, len(resourceTypes))
+	for resourceType := range resourceTypes {
+		if _, ok := depMap[resourceType]; !ok {
+			return nil, ErrUnknownResourceType.Errorf("unknown resource type: %v", resourceType)
+		}
+		pending = append(pending, resourceType)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i] < pending[j] })
+
+	ordered := make([]MigrateDataType, 0, len(pending))
+	done := make(ResourceTypes, len(pending))
+	for len(pending) > 0 {
+		next := pending[:0]
+		for _, resourceType := range pending {
+			ready := true
+			for _, dep := range depMap[resourceType] {
+				if resourceTypes.Has(dep) && !done.Has(dep) {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, resourceType)
+				done[resourceType] = struct{}{}
+			} else {
+				next = append(next, resourceType)
+			}
+		}
+
+		if len(next) == len(pending) {
+			return nil, ErrMissingDependency.Errorf("circular dependency between resource types: %v", next)
+		}
+		pending = next
+	}
+
+	return ordered, nil
+}
+
 // validateDependencies recursively checks if all dependencies for a resource type are present in the input set.
 func (depMap DependencyMap) validateDependencies(resourceType MigrateDataType, input ResourceTypes) error {
 	// Get the direct dependencies for this resource type.
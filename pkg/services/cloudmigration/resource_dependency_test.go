@@ -266,3 +266,44 @@ func TestResourceDependencyParse(t *testing.T) {
 		})
 	})
 }
+
+func TestResourceDependencyOrder(t *testing.T) {
+	t.Run("all resource types come after their dependencies", func(t *testing.T) {
+		all := make(ResourceTypes, len(ResourceDependency))
+		for resourceType := range ResourceDependency {
+			all[resourceType] = struct{}{}
+		}
+
+		ordered, err := ResourceDependency.Order(all)
+		require.NoError(t, err)
+		require.Len(t, ordered, len(all))
+
+		position := make(map[MigrateDataType]int, len(ordered))
+		for i, resourceType := range ordered {
+			position[resourceType] = i
+		}
+		for resourceType, dependencies := range ResourceDependency {
+			for _, dep := range dependencies {
+				require.Less(t, position[dep], position[resourceType], "%v must come before %v", dep, resourceType)
+			}
+		}
+	})
+
+	t.Run("the order is stable", func(t *testing.T) {
+		input := ResourceTypes{DashboardDataType: {}, FolderDataType: {}, PluginDataType: {}, DatasourceDataType: {}, LibraryElementDataType: {}}
+		ordered, err := ResourceDependency.Order(input)
+		require.NoError(t, err)
+		require.Equal(t, []MigrateDataType{FolderDataType, LibraryElementDataType, PluginDataType, DatasourceDataType, DashboardDataType}, ordered)
+	})
+
+	t.Run("unknown resource type returns an error", func(t *testing.T) {
+		_, err := ResourceDependency.Order(ResourceTypes{"does not exist": {}})
+		require.ErrorIs(t, err, ErrUnknownResourceType)
+	})
+
+	t.Run("circular dependencies return an error", func(t *testing.T) {
+		depMap := DependencyMap{FolderDataType: {DashboardDataType}, DashboardDataType: {FolderDataType}}
+		_, err := depMap.Order(ResourceTypes{FolderDataType: {}, DashboardDataType: {}})
+		require.ErrorIs(t, err, ErrMissingDependency)
+	})
+}
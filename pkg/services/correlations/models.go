@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/grafana/grafana/pkg/services/quota"
 )
@@ -18,6 +19,11 @@ var (
 	ErrInvalidTransformationType     = errors.New("invalid transformation type")
 	ErrTransformationNotNested       = errors.New("transformations must be nested under config")
 	ErrTransformationRegexReqExp     = errors.New("regex transformations require expression")
+	ErrTransformationJSONPathReqExp  = errors.New("jsonpath transformations require expression")
+	ErrTransformationJSONPathInvalid = errors.New("invalid jsonpath expression")
+	ErrTransformationTemplateReqExp  = errors.New("template transformations require expression")
+	ErrTransformationTemplateReqMap  = errors.New("template transformations require mapValue")
+	ErrTransformationTemplateUnknown = errors.New("template transformation uses a variable that is not extracted by a previous transformation")
 	ErrCorrelationsQuotaFailed       = errors.New("error getting correlations quota")
 	ErrCorrelationsQuotaReached      = errors.New("correlations quota reached")
 	ErrConfigTypeDeprecated          = errors.New("config.type is deprecated; please move type to be sibling of config")
@@ -38,13 +44,33 @@ const (
 )
 
 type Transformation struct {
-	//Enum: regex,logfmt
+	//Enum: regex,logfmt,jsonpath,template
 	Type       string `json:"type"`
 	Expression string `json:"expression,omitempty"`
 	Field      string `json:"field,omitempty"`
 	MapValue   string `json:"mapValue,omitempty"`
 }
 
+const (
+	// TransformationRegex extracts the capture groups of a regular expression.
+	TransformationRegex = "regex"
+	// TransformationLogfmt extracts every key of a logfmt formatted field.
+	TransformationLogfmt = "logfmt"
+	// TransformationJSONPath extracts the value at a JSONPath, e.g. $.trace.id, of a JSON formatted field.
+	TransformationJSONPath = "jsonpath"
+	// TransformationTemplate composes a new variable from the variables extracted before it, e.g. ${service}-${env}.
+	TransformationTemplate = "template"
+)
+
+var (
+	// jsonPathRegex matches the dot and bracket notations of JSONPath, starting at the root.
+	jsonPathRegex = regexp.MustCompile(`^\$(\.\.?([A-Za-z_$@][\w$@-]*|\*)|\[(-?\d+|\*|'[^']*'|"[^"]*")\])*$`)
+	// templateVariableRegex matches the ${name} references of a template expression.
+	templateVariableRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+	// namedGroupRegex matches the named capture groups of a regular expression, in both Go and JavaScript syntax.
+	namedGroupRegex = regexp.MustCompile(`\(\?P?<([A-Za-z_]\w*)>`)
+)
+
 func (t CorrelationType) Validate() error {
 	if t != query && t != external {
 		return fmt.Errorf("%s: \"%s\"", ErrInvalidType, t)
@@ -52,12 +78,80 @@ func (t CorrelationType) Validate() error {
 	return nil
 }
 
+// variables returns the names of the variables a regex or jsonpath transformation extracts.
+// Without a mapValue or a field, the value is named after the field of the correlation, given as sourceField.
+func (t Transformation) variables(sourceField string) []string {
+	if t.Type == TransformationRegex {
+		if groups := namedGroupRegex.FindAllStringSubmatch(t.Expression, -1); len(groups) > 0 {
+			names := make([]string, 0, len(groups))
+			for _, group := range groups {
+				names = append(names, group[1])
+			}
+			return names
+		}
+	}
+	switch {
+	case t.MapValue != "":
+		return []string{t.MapValue}
+	case t.Field != "":
+		return []string{t.Field}
+	default:
+		return []string{sourceField}
+	}
+}
+
 func (t Transformations) Validate() error {
+	return t.validate("")
+}
+
+// validate checks the transformations in order, so that template transformations only use
+// variables extracted before them. sourceField is the field of the correlation, empty when unknown.
+func (t Transformations) validate(sourceField string) error {
+	extracted := map[string]bool{}
+	// set once a transformation extracts names only known when the correlation is used,
+	// like the keys of logfmt or a value named after an unknown source field
+	anyVariable := false
+	extract := func(v Transformation) {
+		for _, name := range v.variables(sourceField) {
+			anyVariable = anyVariable || name == ""
+			extracted[name] = true
+		}
+	}
+
 	for _, v := range t {
-		if v.Type != "regex" && v.Type != "logfmt" {
+		switch v.Type {
+		case TransformationRegex:
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationRegexReqExp, t)
+			}
+			extract(v)
+		case TransformationJSONPath:
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationJSONPathReqExp, t)
+			}
+			if !jsonPathRegex.MatchString(v.Expression) {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationJSONPathInvalid, v.Expression)
+			}
+			extract(v)
+		case TransformationLogfmt:
+			anyVariable = true
+		case TransformationTemplate:
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationTemplateReqExp, t)
+			}
+			if len(v.MapValue) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationTemplateReqMap, t)
+			}
+			if !anyVariable {
+				for _, match := range templateVariableRegex.FindAllStringSubmatch(v.Expression, -1) {
+					if !extracted[match[1]] {
+						return fmt.Errorf("%s: \"%s\"", ErrTransformationTemplateUnknown, match[1])
+					}
+				}
+			}
+			extracted[v.MapValue] = true
+		default:
 			return fmt.Errorf("%s: \"%s\"", ErrInvalidTransformationType, t)
-		} else if v.Type == "regex" && len(v.Expression) == 0 {
-			return fmt.Errorf("%s: \"%s\"", ErrTransformationRegexReqExp, t)
 		}
 	}
 	return nil
@@ -85,6 +179,11 @@ type CorrelationConfig struct {
 	Transformations Transformations `json:"transformations,omitempty"`
 }
 
+// Validate checks the transformations against the field of the correlation.
+func (c CorrelationConfig) Validate() error {
+	return c.Transformations.validate(c.Field)
+}
+
 func (c CorrelationConfig) MarshalJSON() ([]byte, error) {
 	target := c.Target
 	transformations := c.Transformations
@@ -177,7 +276,7 @@ func (c CreateCorrelationCommand) Validate() error {
 		return fmt.Errorf("correlations of type \"%s\" must have a targetUID", query)
 	}
 
-	if err := c.Config.Transformations.Validate(); err != nil {
+	if err := c.Config.Validate(); err != nil {
 		return err
 	}
 	return nil
@@ -242,6 +341,16 @@ func (c UpdateCorrelationCommand) Validate() error {
 		return ErrUpdateCorrelationEmptyParams
 	}
 
+	if c.Config != nil && c.Config.Transformations != nil {
+		sourceField := ""
+		if c.Config.Field != nil {
+			sourceField = *c.Config.Field
+		}
+		if err := Transformations(c.Config.Transformations).validate(sourceField); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
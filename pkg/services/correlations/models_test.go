@@ -87,4 +87,104 @@ func TestCorrelationModels(t *testing.T) {
 			require.Equal(t, `{"field":"field","target":{}}`, string(data))
 		})
 	})
+
+	t.Run("Transformations Validate", func(t *testing.T) {
+		tests := []struct {
+			name            string
+			field           string
+			transformations Transformations
+			err             error
+		}{
+			{
+				name:            "regex and logfmt",
+				transformations: Transformations{{Type: "regex", Expression: "id=(\\w+)"}, {Type: "logfmt"}},
+			},
+			{
+				name:            "unknown type",
+				transformations: Transformations{{Type: "xml"}},
+				err:             ErrInvalidTransformationType,
+			},
+			{
+				name:            "jsonpath",
+				transformations: Transformations{{Type: "jsonpath", Expression: "$.spans[0]['trace.id']", MapValue: "traceId"}},
+			},
+			{
+				name:            "jsonpath without expression",
+				transformations: Transformations{{Type: "jsonpath", MapValue: "traceId"}},
+				err:             ErrTransformationJSONPathReqExp,
+			},
+			{
+				name:            "jsonpath not starting at the root",
+				transformations: Transformations{{Type: "jsonpath", Expression: "spans.traceId"}},
+				err:             ErrTransformationJSONPathInvalid,
+			},
+			{
+				name:            "jsonpath with an unclosed bracket",
+				transformations: Transformations{{Type: "jsonpath", Expression: "$.spans[0"}},
+				err:             ErrTransformationJSONPathInvalid,
+			},
+			{
+				name:  "template using extracted variables",
+				field: "message",
+				transformations: Transformations{
+					{Type: "jsonpath", Expression: "$.service", MapValue: "service"},
+					{Type: "regex", Expression: "env=(?P<env>\\w+)"},
+					{Type: "regex", Expression: "id=(\\w+)"},
+					{Type: "template", Expression: "${service}-${env}/${message}", MapValue: "target"},
+					{Type: "template", Expression: "${target}", MapValue: "link"},
+				},
+			},
+			{
+				name:            "template without mapValue",
+				transformations: Transformations{{Type: "jsonpath", Expression: "$.a", MapValue: "a"}, {Type: "template", Expression: "${a}"}},
+				err:             ErrTransformationTemplateReqMap,
+			},
+			{
+				name:            "template without expression",
+				transformations: Transformations{{Type: "template", MapValue: "a"}},
+				err:             ErrTransformationTemplateReqExp,
+			},
+			{
+				name:  "template using a variable extracted after it",
+				field: "message",
+				transformations: Transformations{
+					{Type: "template", Expression: "${service}", MapValue: "target"},
+					{Type: "jsonpath", Expression: "$.service", MapValue: "service"},
+				},
+				err: ErrTransformationTemplateUnknown,
+			},
+			{
+				name:  "template after logfmt can use any variable",
+				field: "message",
+				transformations: Transformations{
+					{Type: "logfmt"},
+					{Type: "template", Expression: "${service}", MapValue: "target"},
+				},
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				config := CorrelationConfig{Field: tc.field, Transformations: tc.transformations}
+				err := config.Validate()
+				if tc.err == nil {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorContains(t, err, tc.err.Error())
+			})
+		}
+	})
+
+	t.Run("UpdateCorrelationCommand validates transformations", func(t *testing.T) {
+		cmd := UpdateCorrelationCommand{
+			Config: &CorrelationConfigUpdateDTO{
+				Transformations: []Transformation{{Type: "template", Expression: "${unknown}", MapValue: "target"}},
+			},
+		}
+		label := "label"
+		cmd.Label = &label
+
+		require.ErrorContains(t, cmd.Validate(), ErrTransformationTemplateUnknown.Error())
+	})
 }
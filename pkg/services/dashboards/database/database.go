@@ -823,6 +823,22 @@ func (d *dashboardStore) FindDashboards(ctx context.Context, query *dashboards.F
 		filters = append(filters, searchstore.DashboardIDFilter{IDs: query.DashboardIds})
 	}
 
+	if len(query.CreatedBy) > 0 {
+		// dashboards created by service accounts are also stored with the id of the user row
+		userUIDs := make([]string, 0, len(query.CreatedBy))
+		for _, createdBy := range query.CreatedBy {
+			typ, uid, err := claims.ParseTypeID(createdBy)
+			if err != nil || !claims.IsIdentityType(typ, claims.TypeUser, claims.TypeServiceAccount) {
+				continue
+			}
+			userUIDs = append(userUIDs, uid)
+		}
+		if len(userUIDs) == 0 {
+			return []dashboards.DashboardSearchProjection{}, nil
+		}
+		filters = append(filters, searchstore.CreatedByFilter{Dialect: d.store.GetDialect(), UserUIDs: userUIDs})
+	}
+
 	if len(query.Title) > 0 {
 		filters = append(filters, searchstore.TitleFilter{Dialect: d.store.GetDialect(), Title: query.Title, TitleExactMatch: query.TitleExactMatch})
 	}
@@ -445,6 +445,8 @@ type FindPersistedDashboardsQuery struct {
 	FolderIds  []int64
 	FolderUIDs []string
 	Tags       []string
+	// CreatedBy filters on the identities that created the dashboards, eg user:<uid>
+	CreatedBy  []string
 	Limit      int64
 	Page       int64
 	Permission dashboardaccess.PermissionType
@@ -52,6 +52,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/search/model"
 	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
 	"github.com/grafana/grafana/pkg/services/store/entity"
+	"github.com/grafana/grafana/pkg/services/team"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
@@ -96,6 +97,7 @@ type DashboardServiceImpl struct {
 	serverLockService      *serverlock.ServerLockService
 	kvstore                kvstore.KVStore
 	dual                   dualwrite.Service
+	teamService            team.Service
 
 	dashboardPermissionsReady chan struct{}
 }
@@ -385,6 +387,7 @@ func ProvideDashboardServiceImpl(
 	serverLockService *serverlock.ServerLockService,
 	kvstore kvstore.KVStore,
 	k8sClient dashboardclient.K8sHandlerWithFallback,
+	teamService team.Service,
 ) (*DashboardServiceImpl, error) {
 	dashSvc := &DashboardServiceImpl{
 		cfg:                       cfg,
@@ -403,6 +406,7 @@ func ProvideDashboardServiceImpl(
 		serverLockService:         serverLockService,
 		kvstore:                   kvstore,
 		dual:                      dual,
+		teamService:               teamService,
 	}
 
 	defaultLimits, err := readQuotaConfig(cfg)
@@ -471,9 +475,46 @@ func (dr *DashboardServiceImpl) Count(ctx context.Context, scopeParams *quota.Sc
 	}
 	u.Set(tag, total)
 
+	if scopeParams != nil && scopeParams.OrgID != 0 {
+		if err := dr.countInTeamAndFolder(ctx, scopeParams, u); err != nil {
+			return nil, err
+		}
+	}
+
 	return u, nil
 }
 
+// countInTeamAndFolder sets the team and folder usage of the scope parameters
+func (dr *DashboardServiceImpl) countInTeamAndFolder(ctx context.Context, scopeParams *quota.ScopeParameters, u *quota.Map) error {
+	ctx, ident := identity.WithServiceIdentity(ctx, scopeParams.OrgID)
+
+	if scopeParams.TeamID != 0 {
+		count, err := dr.CountInTeam(ctx, scopeParams.OrgID, scopeParams.TeamID, ident)
+		if err != nil {
+			return err
+		}
+		tag, err := quota.NewTag(dashboards.QuotaTargetSrv, dashboards.QuotaTarget, quota.TeamScope)
+		if err != nil {
+			return err
+		}
+		u.Set(tag, count)
+	}
+
+	if scopeParams.FolderUID != "" {
+		count, err := dr.CountInFolders(ctx, scopeParams.OrgID, []string{scopeParams.FolderUID}, ident)
+		if err != nil {
+			return err
+		}
+		tag, err := quota.NewTag(dashboards.QuotaTargetSrv, dashboards.QuotaTarget, quota.FolderScope)
+		if err != nil {
+			return err
+		}
+		u.Set(tag, count)
+	}
+
+	return nil
+}
+
 func (dr *DashboardServiceImpl) GetDashboardsByLibraryPanelUID(ctx context.Context, libraryPanelUID string, orgID int64) ([]*dashboards.DashboardRef, error) {
 	res, err := dr.k8sclient.Search(ctx, orgID, &resourcepb.ResourceSearchRequest{
 		Options: &resourcepb.ListOptions{
@@ -536,8 +577,20 @@ func readQuotaConfig(cfg *setting.Cfg) (*quota.Map, error) {
 		return &quota.Map{}, err
 	}
 
+	teamQuotaTag, err := quota.NewTag(dashboards.QuotaTargetSrv, dashboards.QuotaTarget, quota.TeamScope)
+	if err != nil {
+		return &quota.Map{}, err
+	}
+	folderQuotaTag, err := quota.NewTag(dashboards.QuotaTargetSrv, dashboards.QuotaTarget, quota.FolderScope)
+	if err != nil {
+		return &quota.Map{}, err
+	}
+
 	limits.Set(globalQuotaTag, cfg.Quota.Global.Dashboard)
 	limits.Set(orgQuotaTag, cfg.Quota.Org.Dashboard)
+	// team and folder quotas are unlimited unless set through the API
+	limits.Set(teamQuotaTag, -1)
+	limits.Set(folderQuotaTag, -1)
 	return limits, nil
 }
 
@@ -1638,6 +1691,36 @@ func (dr DashboardServiceImpl) CountInFolders(ctx context.Context, orgID int64,
 	return int64(len(dashs)), nil
 }
 
+// CountInTeam counts the dashboards of the organization created by the members of the team
+func (dr DashboardServiceImpl) CountInTeam(ctx context.Context, orgID int64, teamID int64, u identity.Requester) (int64, error) {
+	members, err := dr.teamService.GetTeamMembers(ctx, &team.GetTeamMembersQuery{
+		OrgID:        orgID,
+		TeamID:       teamID,
+		SignedInUser: u,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	createdBy := make([]string, 0, len(members))
+	for _, m := range members {
+		createdBy = append(createdBy, claims.NewTypeID(claims.TypeUser, m.UserUID))
+	}
+
+	dashs, err := dr.searchDashboardsThroughK8s(ctx, &dashboards.FindPersistedDashboardsQuery{
+		OrgId:     orgID,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(dashs)), nil
+}
+
 func (dr *DashboardServiceImpl) DeleteInFolders(ctx context.Context, orgID int64, folderUIDs []string, u identity.Requester) error {
 	ctx, span := tracer.Start(ctx, "dashboards.service.DeleteInFolders")
 	defer span.End()
@@ -1904,6 +1987,14 @@ func (dr *DashboardServiceImpl) searchDashboardsThroughK8sRaw(ctx context.Contex
 		})
 	}
 
+	if len(query.CreatedBy) > 0 {
+		request.Options.Fields = append(request.Options.Fields, &resourcepb.Requirement{
+			Key:      resource.SEARCH_FIELD_CREATED_BY,
+			Operator: string(selection.In),
+			Values:   query.CreatedBy,
+		})
+	}
+
 	if query.ManagedBy != "" {
 		request.Options.Fields = append(request.Options.Fields, &resourcepb.Requirement{
 			Key:      resource.SEARCH_FIELD_MANAGER_KIND,
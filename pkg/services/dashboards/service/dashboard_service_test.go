@@ -41,6 +41,8 @@ import (
 	"github.com/grafana/grafana/pkg/services/search/model"
 	"github.com/grafana/grafana/pkg/services/search/sort"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
@@ -1873,6 +1875,57 @@ func TestCountInFolders(t *testing.T) {
 	require.Equal(t, result, int64(2))
 }
 
+func TestCountInTeam(t *testing.T) {
+	service := &DashboardServiceImpl{
+		cfg: setting.NewCfg(),
+		teamService: &teamtest.FakeService{ExpectedMembers: []*team.TeamMemberDTO{
+			{UserID: 1, UserUID: "user-1"},
+			{UserID: 2, UserUID: "user-2"},
+		}},
+	}
+	ctx, k8sCliMock := setupK8sDashboardTests(service)
+	dashs := &resourcepb.ResourceSearchResponse{
+		Results: &resourcepb.ResourceTable{
+			Columns: []*resourcepb.ResourceTableColumnDefinition{
+				{
+					Name: "title",
+					Type: resourcepb.ResourceTableColumnDefinition_STRING,
+				},
+			},
+			Rows: []*resourcepb.ResourceTableRow{
+				{
+					Key: &resourcepb.ResourceKey{
+						Name:     "uid",
+						Resource: "dashboard",
+					},
+					Cells: [][]byte{
+						[]byte("Dashboard 1"),
+					},
+				},
+			},
+		},
+		TotalHits: 1,
+	}
+
+	k8sCliMock.On("GetNamespace", mock.Anything, mock.Anything).Return("default")
+	k8sCliMock.On("Search", mock.Anything, int64(1), mock.MatchedBy(func(req *resourcepb.ResourceSearchRequest) bool {
+		return len(req.Options.Fields) == 1 &&
+			req.Options.Fields[0].Key == resource.SEARCH_FIELD_CREATED_BY &&
+			slices.Equal(req.Options.Fields[0].Values, []string{"user:user-1", "user:user-2"})
+	})).Return(dashs, nil).Once()
+	result, err := service.CountInTeam(ctx, 1, 3, &user.SignedInUser{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result)
+	k8sCliMock.AssertExpectations(t)
+
+	t.Run("does not search without team members", func(t *testing.T) {
+		service.teamService = &teamtest.FakeService{}
+		result, err := service.CountInTeam(ctx, 1, 3, &user.SignedInUser{})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), result)
+	})
+}
+
 func TestSearchDashboardsThroughK8sRaw(t *testing.T) {
 	t.Run("can search dashboards", func(t *testing.T) {
 		ctx := context.Background()
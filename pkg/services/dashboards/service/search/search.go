@@ -137,22 +137,33 @@ func ParseResults(result *resourcepb.ResourceSearchResponse, offset int64) (v0al
 
 	// Add facet results
 	if result.Facet != nil {
-		sr.Facets = make(map[string]v0alpha1.FacetResult)
-		for k, v := range result.Facet {
-			sr.Facets[k] = v0alpha1.FacetResult{
-				Field:   v.Field,
-				Total:   v.Total,
-				Missing: v.Missing,
-				Terms:   make([]v0alpha1.TermFacet, len(v.Terms)),
-			}
-			for j, t := range v.Terms {
-				sr.Facets[k].Terms[j] = v0alpha1.TermFacet{
-					Term:  t.Term,
-					Count: t.Count,
-				}
-			}
+		sr.Facets = parseFacets(result.Facet)
+	}
+	if result.NamespaceFacet != nil {
+		sr.NamespaceFacets = make(map[string]map[string]v0alpha1.FacetResult, len(result.NamespaceFacet))
+		for ns, v := range result.NamespaceFacet {
+			sr.NamespaceFacets[ns] = parseFacets(v.Facet)
 		}
 	}
 
 	return sr, nil
 }
+
+func parseFacets(facets map[string]*resourcepb.ResourceSearchResponse_Facet) map[string]v0alpha1.FacetResult {
+	res := make(map[string]v0alpha1.FacetResult, len(facets))
+	for k, v := range facets {
+		res[k] = v0alpha1.FacetResult{
+			Field:   v.Field,
+			Total:   v.Total,
+			Missing: v.Missing,
+			Terms:   make([]v0alpha1.TermFacet, len(v.Terms)),
+		}
+		for j, t := range v.Terms {
+			res[k].Terms[j] = v0alpha1.TermFacet{
+				Term:  t.Term,
+				Count: t.Count,
+			}
+		}
+	}
+	return res
+}
@@ -61,6 +61,7 @@ func (d *DashboardSnapshotStore) CreateDashboardSnapshot(ctx context.Context, cm
 			External:           cmd.External,
 			ExternalURL:        cmd.ExternalURL,
 			ExternalDeleteURL:  cmd.ExternalDeleteURL,
+			DashboardUID:       cmd.DashboardUID,
 			Dashboard:          simplejson.New(),
 			DashboardEncrypted: cmd.DashboardEncrypted,
 			Expires:            expires,
@@ -86,6 +87,17 @@ func (d *DashboardSnapshotStore) DeleteDashboardSnapshot(ctx context.Context, cm
 	})
 }
 
+// DeleteDashboardSnapshots removes the snapshots with the given keys from an org
+func (d *DashboardSnapshotStore) DeleteDashboardSnapshots(ctx context.Context, cmd *dashboardsnapshots.DeleteDashboardSnapshotsCommand) error {
+	if len(cmd.Keys) == 0 {
+		return nil
+	}
+	return d.store.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Table("dashboard_snapshot").Where("org_id = ?", cmd.OrgID).In(d.store.Quote("key"), cmd.Keys).Delete(&dashboardsnapshots.DashboardSnapshot{})
+		return err
+	})
+}
+
 func (d *DashboardSnapshotStore) GetDashboardSnapshot(ctx context.Context, query *dashboardsnapshots.GetDashboardSnapshotQuery) (*dashboardsnapshots.DashboardSnapshot, error) {
 	var queryResult *dashboardsnapshots.DashboardSnapshot
 	err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
@@ -114,13 +126,29 @@ func (d *DashboardSnapshotStore) SearchDashboardSnapshots(ctx context.Context, q
 	err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
 		var snapshots = make(dashboardsnapshots.DashboardSnapshotsList, 0)
 		if query.Limit > 0 {
-			sess.Limit(query.Limit)
+			sess.Limit(query.Limit, query.Offset)
 		}
 		sess.Table("dashboard_snapshot")
 
 		if query.Name != "" {
 			sess.Where("name LIKE ?", query.Name)
 		}
+		if len(query.Keys) > 0 {
+			sess.In(d.store.Quote("key"), query.Keys)
+		}
+		if query.CreatedBy != 0 {
+			sess.Where("user_id = ?", query.CreatedBy)
+		}
+		if query.DashboardUID != "" {
+			sess.Where("dashboard_uid = ?", query.DashboardUID)
+		}
+		if !query.CreatedBefore.IsZero() {
+			sess.Where("created < ?", query.CreatedBefore)
+		}
+		if query.External != nil {
+			sess.Where("external = ?", *query.External)
+		}
+		sess.OrderBy("created DESC, id DESC")
 
 		var userID int64
 		if query.SignedInUser.IsIdentityType(claims.TypeUser, claims.TypeServiceAccount) {
@@ -151,3 +179,40 @@ func (d *DashboardSnapshotStore) SearchDashboardSnapshots(ctx context.Context, q
 	}
 	return queryResult, nil
 }
+
+// RecordSnapshotView counts a view of a snapshot key by a user, anonymous views are counted together
+func (d *DashboardSnapshotStore) RecordSnapshotView(ctx context.Context, cmd *dashboardsnapshots.RecordSnapshotViewCommand) error {
+	return d.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		now := time.Now()
+		res, err := sess.Exec("UPDATE dashboard_snapshot_view SET view_count = view_count + 1, last_viewed = ? WHERE snapshot_key = ? AND user_id = ? AND user_login = ?",
+			now, cmd.SnapshotKey, cmd.UserID, cmd.UserLogin)
+		if err != nil {
+			return err
+		}
+		if updated, err := res.RowsAffected(); err != nil || updated > 0 {
+			return err
+		}
+
+		_, err = sess.Insert(&dashboardsnapshots.SnapshotView{
+			SnapshotKey: cmd.SnapshotKey,
+			OrgID:       cmd.OrgID,
+			UserID:      cmd.UserID,
+			UserLogin:   cmd.UserLogin,
+			ViewCount:   1,
+			FirstViewed: now,
+			LastViewed:  now,
+		})
+		return err
+	})
+}
+
+func (d *DashboardSnapshotStore) GetSnapshotViews(ctx context.Context, query *dashboardsnapshots.GetSnapshotViewsQuery) ([]*dashboardsnapshots.SnapshotView, error) {
+	views := make([]*dashboardsnapshots.SnapshotView, 0)
+	err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table("dashboard_snapshot_view").
+			Where("org_id = ? AND snapshot_key = ?", query.OrgID, query.SnapshotKey).
+			OrderBy("last_viewed DESC").
+			Find(&views)
+	})
+	return views, err
+}
@@ -198,6 +198,94 @@ func TestIntegrationDeleteExpiredSnapshots(t *testing.T) {
 	})
 }
 
+func TestIntegrationSearchDashboardSnapshotFilters(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	sqlstore := db.InitTestDB(t)
+	dashStore := NewStore(sqlstore)
+	ctx := context.Background()
+
+	for _, cmd := range []dashboardsnapshots.CreateDashboardSnapshotCommand{
+		{Key: "local", DeleteKey: "delete-local", OrgID: 1, UserID: 1, DashboardUID: "dash-a"},
+		{Key: "external", DeleteKey: "delete-external", OrgID: 1, UserID: 2, DashboardUID: "dash-b",
+			DashboardCreateCommand: dashboardsnapshot.DashboardCreateCommand{External: true}},
+		{Key: "other-org", DeleteKey: "delete-other-org", OrgID: 2, UserID: 1, DashboardUID: "dash-a"},
+	} {
+		_, err := dashStore.CreateDashboardSnapshot(ctx, &cmd)
+		require.NoError(t, err)
+	}
+
+	admin := &user.SignedInUser{OrgRole: org.RoleAdmin, UserID: 1, OrgID: 1}
+	external := true
+	testCases := []struct {
+		desc     string
+		query    dashboardsnapshots.GetDashboardSnapshotsQuery
+		expected []string
+	}{
+		{desc: "by creator", query: dashboardsnapshots.GetDashboardSnapshotsQuery{CreatedBy: 2}, expected: []string{"external"}},
+		{desc: "by dashboard", query: dashboardsnapshots.GetDashboardSnapshotsQuery{DashboardUID: "dash-a"}, expected: []string{"local"}},
+		{desc: "by external", query: dashboardsnapshots.GetDashboardSnapshotsQuery{External: &external}, expected: []string{"external"}},
+		{desc: "by keys", query: dashboardsnapshots.GetDashboardSnapshotsQuery{Keys: []string{"local", "other-org"}}, expected: []string{"local"}},
+		{desc: "by age", query: dashboardsnapshots.GetDashboardSnapshotsQuery{CreatedBefore: time.Now().Add(-time.Hour)}, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.query.OrgID = 1
+			tc.query.SignedInUser = admin
+			result, err := dashStore.SearchDashboardSnapshots(ctx, &tc.query)
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(result))
+			for _, snapshot := range result {
+				keys = append(keys, snapshot.Key)
+			}
+			assert.Equal(t, tc.expected, keys)
+		})
+	}
+
+	t.Run("should only delete snapshots of the org", func(t *testing.T) {
+		err := dashStore.DeleteDashboardSnapshots(ctx, &dashboardsnapshots.DeleteDashboardSnapshotsCommand{OrgID: 1, Keys: []string{"local", "other-org"}})
+		require.NoError(t, err)
+
+		_, err = dashStore.GetDashboardSnapshot(ctx, &dashboardsnapshots.GetDashboardSnapshotQuery{Key: "local"})
+		require.ErrorIs(t, err, dashboardsnapshots.ErrBaseNotFound)
+		_, err = dashStore.GetDashboardSnapshot(ctx, &dashboardsnapshots.GetDashboardSnapshotQuery{Key: "other-org"})
+		require.NoError(t, err)
+	})
+}
+
+func TestIntegrationSnapshotViews(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	sqlstore := db.InitTestDB(t)
+	dashStore := NewStore(sqlstore)
+	ctx := context.Background()
+
+	for _, cmd := range []dashboardsnapshots.RecordSnapshotViewCommand{
+		{SnapshotKey: "key1", OrgID: 1, UserID: 1, UserLogin: "admin"},
+		{SnapshotKey: "key1", OrgID: 1, UserID: 1, UserLogin: "admin"},
+		{SnapshotKey: "key1", OrgID: 1},
+		{SnapshotKey: "key2", OrgID: 1, UserID: 1, UserLogin: "admin"},
+	} {
+		require.NoError(t, dashStore.RecordSnapshotView(ctx, &cmd))
+	}
+
+	views, err := dashStore.GetSnapshotViews(ctx, &dashboardsnapshots.GetSnapshotViewsQuery{SnapshotKey: "key1", OrgID: 1})
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+
+	counts := map[string]int64{}
+	for _, view := range views {
+		counts[view.UserLogin] = view.ViewCount
+	}
+	assert.Equal(t, map[string]int64{"admin": 2, "": 1}, counts)
+
+	views, err = dashStore.GetSnapshotViews(ctx, &dashboardsnapshots.GetSnapshotViewsQuery{SnapshotKey: "key1", OrgID: 2})
+	require.NoError(t, err)
+	assert.Empty(t, views)
+}
+
 func createTestSnapshot(t *testing.T, dashStore *DashboardSnapshotStore, key string, expires int64) *dashboardsnapshots.DashboardSnapshot {
 	cmd := dashboardsnapshots.CreateDashboardSnapshotCommand{
 		Key:       key,
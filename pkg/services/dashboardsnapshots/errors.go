@@ -4,4 +4,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 )
 
-var ErrBaseNotFound = errutil.NotFound("dashboardsnapshots.not-found", errutil.WithPublicMessage("Snapshot not found"))
+var (
+	ErrBaseNotFound          = errutil.NotFound("dashboardsnapshots.not-found", errutil.WithPublicMessage("Snapshot not found"))
+	ErrInvalidSnapshotPolicy = errutil.BadRequest("dashboardsnapshots.invalid-policy", errutil.WithPublicMessage("Invalid snapshot policy"))
+)
@@ -19,6 +19,7 @@ type DashboardSnapshot struct {
 	External          bool
 	ExternalURL       string `xorm:"external_url"`
 	ExternalDeleteURL string `xorm:"external_delete_url"`
+	DashboardUID      string `xorm:"dashboard_uid"`
 
 	Expires time.Time
 	Created time.Time
@@ -37,6 +38,9 @@ type DashboardSnapshotDTO struct {
 	UserID      int64  `json:"-" xorm:"user_id"`
 	External    bool   `json:"external"`
 	ExternalURL string `json:"externalUrl" xorm:"external_url"`
+	// DashboardUID is empty for snapshots created before it was recorded
+	DashboardUID      string `json:"dashboardUid,omitempty" xorm:"dashboard_uid"`
+	ExternalDeleteURL string `json:"-" xorm:"external_delete_url"`
 
 	Expires time.Time `json:"expires"`
 	Created time.Time `json:"created"`
@@ -63,8 +67,9 @@ type CreateDashboardSnapshotCommand struct {
 	// required:false
 	DeleteKey string `json:"deleteKey"`
 
-	OrgID  int64 `json:"-"`
-	UserID int64 `json:"-"`
+	OrgID        int64  `json:"-"`
+	UserID       int64  `json:"-"`
+	DashboardUID string `json:"-"`
 
 	DashboardEncrypted []byte `json:"-"`
 }
@@ -77,6 +82,65 @@ type DeleteExpiredSnapshotsCommand struct {
 	DeletedRows int64
 }
 
+type DeleteDashboardSnapshotsCommand struct {
+	OrgID int64
+	Keys  []string
+}
+
+// BulkDeleteDashboardSnapshotsCommand deletes the snapshots with the given keys the user is allowed to list
+type BulkDeleteDashboardSnapshotsCommand struct {
+	Keys         []string           `json:"keys"`
+	OrgID        int64              `json:"-"`
+	SignedInUser identity.Requester `json:"-"`
+}
+
+type BulkDeleteDashboardSnapshotsResult struct {
+	Deleted []string `json:"deleted"`
+	// Failed holds the keys of external snapshots that could not be removed from the external server
+	Failed []string `json:"failed"`
+}
+
+type EnforceSnapshotPoliciesCommand struct {
+	DeletedRows int64
+}
+
+// SnapshotPolicy limits the snapshots kept by an organization, zero values disable a limit
+type SnapshotPolicy struct {
+	// Snapshots created longer ago than MaxAgeSeconds are deleted
+	MaxAgeSeconds int64 `json:"maxAgeSeconds"`
+	// Only the MaxCount most recent snapshots are kept
+	MaxCount int64 `json:"maxCount"`
+}
+
+// SnapshotView records who viewed a snapshot key, views are kept after the snapshot is deleted
+type SnapshotView struct {
+	ID          int64     `json:"-" xorm:"pk autoincr 'id'"`
+	SnapshotKey string    `json:"snapshotKey" xorm:"snapshot_key"`
+	OrgID       int64     `json:"orgId" xorm:"org_id"`
+	UserID      int64     `json:"userId" xorm:"user_id"`
+	UserLogin   string    `json:"userLogin" xorm:"user_login"`
+	ViewCount   int64     `json:"viewCount" xorm:"view_count"`
+	FirstViewed time.Time `json:"firstViewed" xorm:"first_viewed"`
+	LastViewed  time.Time `json:"lastViewed" xorm:"last_viewed"`
+}
+
+func (SnapshotView) TableName() string {
+	return "dashboard_snapshot_view"
+}
+
+type RecordSnapshotViewCommand struct {
+	SnapshotKey string
+	OrgID       int64
+	// UserID and UserLogin are empty for anonymous viewers
+	UserID    int64
+	UserLogin string
+}
+
+type GetSnapshotViewsQuery struct {
+	SnapshotKey string
+	OrgID       int64
+}
+
 type GetDashboardSnapshotQuery struct {
 	Key       string
 	DeleteKey string
@@ -87,8 +151,15 @@ type DashboardSnapshotsList []*DashboardSnapshotDTO
 type GetDashboardSnapshotsQuery struct {
 	Name         string
 	Limit        int
+	Offset       int
 	OrgID        int64
 	SignedInUser identity.Requester
+
+	Keys          []string
+	CreatedBy     int64
+	DashboardUID  string
+	CreatedBefore time.Time
+	External      *bool
 }
 
 type CreateExternalSnapshotResponse struct {
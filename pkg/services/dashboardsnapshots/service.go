@@ -23,11 +23,17 @@ import (
 
 //go:generate mockery --name Service --structname MockService --inpackage --filename service_mock.go
 type Service interface {
+	BulkDeleteDashboardSnapshots(context.Context, *BulkDeleteDashboardSnapshotsCommand) (*BulkDeleteDashboardSnapshotsResult, error)
 	CreateDashboardSnapshot(context.Context, *CreateDashboardSnapshotCommand) (*DashboardSnapshot, error)
 	DeleteDashboardSnapshot(context.Context, *DeleteDashboardSnapshotCommand) error
 	DeleteExpiredSnapshots(context.Context, *DeleteExpiredSnapshotsCommand) error
+	EnforceSnapshotPolicies(context.Context, *EnforceSnapshotPoliciesCommand) error
 	GetDashboardSnapshot(context.Context, *GetDashboardSnapshotQuery) (*DashboardSnapshot, error)
+	GetSnapshotPolicy(ctx context.Context, orgID int64) (*SnapshotPolicy, error)
+	GetSnapshotViews(context.Context, *GetSnapshotViewsQuery) ([]*SnapshotView, error)
+	RecordSnapshotView(context.Context, *RecordSnapshotViewCommand) error
 	SearchDashboardSnapshots(context.Context, *GetDashboardSnapshotsQuery) (DashboardSnapshotsList, error)
+	SetSnapshotPolicy(ctx context.Context, orgID int64, policy *SnapshotPolicy) error
 	ValidateDashboardExists(context.Context, int64, string) error
 }
 
@@ -67,6 +73,7 @@ func CreateDashboardSnapshot(c *contextmodel.ReqContext, cfg dashboardsnapshot.S
 	cmd.ExternalURL = ""
 	cmd.OrgID = user.GetOrgID()
 	cmd.UserID, _ = identity.UserIdentifier(user.GetID())
+	cmd.DashboardUID = uid
 	originalDashboardURL, err := createOriginalDashboardURL(&cmd)
 	if err != nil {
 		c.JsonApiErr(http.StatusInternalServerError, "Invalid app URL", err)
@@ -2,27 +2,45 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	"github.com/grafana/grafana/pkg/services/secrets"
 )
 
+const (
+	snapshotPolicyNamespace = "dashboardsnapshots"
+	snapshotPolicyKey       = "policy"
+
+	// policyBatchSize limits how many snapshots of an org are deleted by a single policy run
+	policyBatchSize = 1000
+)
+
 type ServiceImpl struct {
 	store            dashboardsnapshots.Store
 	secretsService   secrets.Service
 	dashboardService dashboards.DashboardService
+	kvStore          kvstore.KVStore
+	log              log.Logger
 }
 
 // ServiceImpl implements the dashboardsnapshots Service interface
 var _ dashboardsnapshots.Service = (*ServiceImpl)(nil)
 
-func ProvideService(store dashboardsnapshots.Store, secretsService secrets.Service, dashboardService dashboards.DashboardService) *ServiceImpl {
+func ProvideService(store dashboardsnapshots.Store, secretsService secrets.Service, dashboardService dashboards.DashboardService, kvStore kvstore.KVStore) *ServiceImpl {
 	s := &ServiceImpl{
 		store:            store,
 		secretsService:   secretsService,
 		dashboardService: dashboardService,
+		kvStore:          kvStore,
+		log:              log.New("dashboardsnapshots"),
 	}
 
 	return s
@@ -83,3 +101,132 @@ func (s *ServiceImpl) SearchDashboardSnapshots(ctx context.Context, query *dashb
 func (s *ServiceImpl) DeleteExpiredSnapshots(ctx context.Context, cmd *dashboardsnapshots.DeleteExpiredSnapshotsCommand) error {
 	return s.store.DeleteExpiredSnapshots(ctx, cmd)
 }
+
+func (s *ServiceImpl) BulkDeleteDashboardSnapshots(ctx context.Context, cmd *dashboardsnapshots.BulkDeleteDashboardSnapshotsCommand) (*dashboardsnapshots.BulkDeleteDashboardSnapshotsResult, error) {
+	result := &dashboardsnapshots.BulkDeleteDashboardSnapshotsResult{Deleted: []string{}, Failed: []string{}}
+	if len(cmd.Keys) == 0 {
+		return result, nil
+	}
+
+	// only snapshots the user can list are deleted
+	snapshots, err := s.store.SearchDashboardSnapshots(ctx, &dashboardsnapshots.GetDashboardSnapshotsQuery{
+		Keys:         cmd.Keys,
+		OrgID:        cmd.OrgID,
+		SignedInUser: cmd.SignedInUser,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Deleted, result.Failed, err = s.deleteSnapshots(ctx, cmd.OrgID, snapshots)
+	return result, err
+}
+
+// EnforceSnapshotPolicies deletes the snapshots exceeding the max age or max count of their org policy
+func (s *ServiceImpl) EnforceSnapshotPolicies(ctx context.Context, cmd *dashboardsnapshots.EnforceSnapshotPoliciesCommand) error {
+	policies, err := s.kvStore.GetAll(ctx, kvstore.AllOrganizations, snapshotPolicyNamespace)
+	if err != nil {
+		return err
+	}
+
+	for orgID, values := range policies {
+		value, ok := values[snapshotPolicyKey]
+		if !ok {
+			continue
+		}
+		policy := &dashboardsnapshots.SnapshotPolicy{}
+		if err := json.Unmarshal([]byte(value), policy); err != nil {
+			s.log.Warn("Failed to decode snapshot policy", "orgId", orgID, "error", err)
+			continue
+		}
+
+		orgCtx, requester := identity.WithServiceIdentity(ctx, orgID)
+		queries := []*dashboardsnapshots.GetDashboardSnapshotsQuery{}
+		if policy.MaxAgeSeconds > 0 {
+			queries = append(queries, &dashboardsnapshots.GetDashboardSnapshotsQuery{
+				CreatedBefore: time.Now().Add(-time.Duration(policy.MaxAgeSeconds) * time.Second),
+				Limit:         policyBatchSize,
+				OrgID:         orgID,
+				SignedInUser:  requester,
+			})
+		}
+		if policy.MaxCount > 0 {
+			// snapshots are sorted newest first, everything after the first MaxCount is deleted
+			queries = append(queries, &dashboardsnapshots.GetDashboardSnapshotsQuery{
+				Limit:        policyBatchSize,
+				Offset:       int(policy.MaxCount),
+				OrgID:        orgID,
+				SignedInUser: requester,
+			})
+		}
+
+		for _, query := range queries {
+			snapshots, err := s.store.SearchDashboardSnapshots(orgCtx, query)
+			if err != nil {
+				return err
+			}
+			deleted, _, err := s.deleteSnapshots(orgCtx, orgID, snapshots)
+			if err != nil {
+				return err
+			}
+			cmd.DeletedRows += int64(len(deleted))
+		}
+	}
+	return nil
+}
+
+// deleteSnapshots removes external snapshots from their server before deleting them, snapshots that
+// could not be removed externally are kept so they can be retried
+func (s *ServiceImpl) deleteSnapshots(ctx context.Context, orgID int64, snapshots dashboardsnapshots.DashboardSnapshotsList) ([]string, []string, error) {
+	deleted := make([]string, 0, len(snapshots))
+	failed := []string{}
+	for _, snapshot := range snapshots {
+		if snapshot.External && snapshot.ExternalDeleteURL != "" {
+			if err := dashboardsnapshots.DeleteExternalDashboardSnapshot(snapshot.ExternalDeleteURL); err != nil {
+				s.log.Warn("Failed to delete external snapshot", "key", snapshot.Key, "orgId", orgID, "error", err)
+				failed = append(failed, snapshot.Key)
+				continue
+			}
+		}
+		deleted = append(deleted, snapshot.Key)
+	}
+
+	if err := s.store.DeleteDashboardSnapshots(ctx, &dashboardsnapshots.DeleteDashboardSnapshotsCommand{OrgID: orgID, Keys: deleted}); err != nil {
+		return nil, nil, err
+	}
+	return deleted, failed, nil
+}
+
+// GetSnapshotPolicy returns the snapshot policy of an org, orgs without a policy get an empty one
+func (s *ServiceImpl) GetSnapshotPolicy(ctx context.Context, orgID int64) (*dashboardsnapshots.SnapshotPolicy, error) {
+	policy := &dashboardsnapshots.SnapshotPolicy{}
+	value, ok, err := s.kvStore.Get(ctx, orgID, snapshotPolicyNamespace, snapshotPolicyKey)
+	if err != nil || !ok {
+		return policy, err
+	}
+
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (s *ServiceImpl) SetSnapshotPolicy(ctx context.Context, orgID int64, policy *dashboardsnapshots.SnapshotPolicy) error {
+	if policy.MaxAgeSeconds < 0 || policy.MaxCount < 0 {
+		return dashboardsnapshots.ErrInvalidSnapshotPolicy.Errorf("max age and max count can not be negative")
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return s.kvStore.Set(ctx, orgID, snapshotPolicyNamespace, snapshotPolicyKey, string(value))
+}
+
+func (s *ServiceImpl) RecordSnapshotView(ctx context.Context, cmd *dashboardsnapshots.RecordSnapshotViewCommand) error {
+	return s.store.RecordSnapshotView(ctx, cmd)
+}
+
+func (s *ServiceImpl) GetSnapshotViews(ctx context.Context, query *dashboardsnapshots.GetSnapshotViewsQuery) ([]*dashboardsnapshots.SnapshotView, error) {
+	return s.store.GetSnapshotViews(ctx, query)
+}
@@ -4,17 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	common "github.com/grafana/grafana/pkg/apimachinery/apis/common/v0alpha1"
 	dashboardsnapshot "github.com/grafana/grafana/pkg/apis/dashboardsnapshot/v0alpha1"
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	dashsnapdb "github.com/grafana/grafana/pkg/services/dashboardsnapshots/database"
+	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/secrets/database"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
 	"github.com/grafana/grafana/pkg/util/testutil"
@@ -32,7 +37,7 @@ func TestIntegrationDashboardSnapshotsService(t *testing.T) {
 	dsStore := dashsnapdb.ProvideStore(sqlStore, cfg)
 	fakeDashboardService := &dashboards.FakeDashboardService{}
 	secretsService := secretsManager.SetupTestService(t, database.ProvideSecretsStore(sqlStore))
-	s := ProvideService(dsStore, secretsService, fakeDashboardService)
+	s := ProvideService(dsStore, secretsService, fakeDashboardService, kvstore.ProvideService(sqlStore))
 
 	origSecret := cfg.SecretKey
 	cfg.SecretKey = "dashboard_snapshot_service_test"
@@ -83,3 +88,91 @@ func TestIntegrationDashboardSnapshotsService(t *testing.T) {
 		require.Equal(t, rawDashboard, decrypted)
 	})
 }
+
+func TestIntegrationSnapshotPolicies(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	sqlStore := db.InitTestDB(t)
+	cfg := setting.NewCfg()
+	dsStore := dashsnapdb.ProvideStore(sqlStore, cfg)
+	secretsService := secretsManager.SetupTestService(t, database.ProvideSecretsStore(sqlStore))
+	s := ProvideService(dsStore, secretsService, &dashboards.FakeDashboardService{}, kvstore.ProvideService(sqlStore))
+	ctx := context.Background()
+
+	createSnapshot := func(t *testing.T, orgID int64, key string, age time.Duration) {
+		t.Helper()
+		_, err := s.CreateDashboardSnapshot(ctx, &dashboardsnapshots.CreateDashboardSnapshotCommand{
+			Key:       key,
+			DeleteKey: "delete-" + key,
+			OrgID:     orgID,
+			DashboardCreateCommand: dashboardsnapshot.DashboardCreateCommand{
+				Dashboard: &common.Unstructured{Object: map[string]any{"uid": "dash"}},
+			},
+		})
+		require.NoError(t, err)
+		err = sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Exec("UPDATE dashboard_snapshot SET created = ? WHERE delete_key = ?", time.Now().Add(-age), "delete-"+key)
+			return err
+		})
+		require.NoError(t, err)
+	}
+
+	listKeys := func(t *testing.T, orgID int64) []string {
+		t.Helper()
+		snapshots, err := s.SearchDashboardSnapshots(ctx, &dashboardsnapshots.GetDashboardSnapshotsQuery{
+			OrgID:        orgID,
+			SignedInUser: &user.SignedInUser{OrgID: orgID, OrgRole: org.RoleAdmin},
+		})
+		require.NoError(t, err)
+		keys := make([]string, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			keys = append(keys, snapshot.Key)
+		}
+		return keys
+	}
+
+	createSnapshot(t, 1, "age-new", time.Hour)
+	createSnapshot(t, 1, "age-old", 48*time.Hour)
+	createSnapshot(t, 2, "count-1", time.Hour)
+	createSnapshot(t, 2, "count-2", 2*time.Hour)
+	createSnapshot(t, 2, "count-3", 3*time.Hour)
+	createSnapshot(t, 3, "no-policy", 72*time.Hour)
+
+	t.Run("should reject negative limits", func(t *testing.T) {
+		err := s.SetSnapshotPolicy(ctx, 1, &dashboardsnapshots.SnapshotPolicy{MaxCount: -1})
+		require.ErrorIs(t, err, dashboardsnapshots.ErrInvalidSnapshotPolicy)
+	})
+
+	t.Run("should return an empty policy for orgs without one", func(t *testing.T) {
+		policy, err := s.GetSnapshotPolicy(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, &dashboardsnapshots.SnapshotPolicy{}, policy)
+	})
+
+	t.Run("should delete snapshots exceeding the org policies", func(t *testing.T) {
+		require.NoError(t, s.SetSnapshotPolicy(ctx, 1, &dashboardsnapshots.SnapshotPolicy{MaxAgeSeconds: 24 * 60 * 60}))
+		require.NoError(t, s.SetSnapshotPolicy(ctx, 2, &dashboardsnapshots.SnapshotPolicy{MaxCount: 2}))
+
+		cmd := &dashboardsnapshots.EnforceSnapshotPoliciesCommand{}
+		require.NoError(t, s.EnforceSnapshotPolicies(ctx, cmd))
+		assert.Equal(t, int64(2), cmd.DeletedRows)
+
+		assert.Equal(t, []string{"age-new"}, listKeys(t, 1))
+		assert.Equal(t, []string{"count-1", "count-2"}, listKeys(t, 2))
+		assert.Equal(t, []string{"no-policy"}, listKeys(t, 3))
+	})
+
+	t.Run("should bulk delete only snapshots the user can list", func(t *testing.T) {
+		result, err := s.BulkDeleteDashboardSnapshots(ctx, &dashboardsnapshots.BulkDeleteDashboardSnapshotsCommand{
+			Keys:         []string{"count-1", "no-policy"},
+			OrgID:        2,
+			SignedInUser: &user.SignedInUser{OrgID: 2, OrgRole: org.RoleAdmin},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"count-1"}, result.Deleted)
+		assert.Empty(t, result.Failed)
+
+		assert.Equal(t, []string{"count-2"}, listKeys(t, 2))
+		assert.Equal(t, []string{"no-policy"}, listKeys(t, 3))
+	})
+}
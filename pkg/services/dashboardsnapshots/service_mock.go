@@ -13,6 +13,36 @@ type MockService struct {
 	mock.Mock
 }
 
+// BulkDeleteDashboardSnapshots provides a mock function with given fields: _a0, _a1
+func (_m *MockService) BulkDeleteDashboardSnapshots(_a0 context.Context, _a1 *BulkDeleteDashboardSnapshotsCommand) (*BulkDeleteDashboardSnapshotsResult, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteDashboardSnapshots")
+	}
+
+	var r0 *BulkDeleteDashboardSnapshotsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BulkDeleteDashboardSnapshotsCommand) (*BulkDeleteDashboardSnapshotsResult, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *BulkDeleteDashboardSnapshotsCommand) *BulkDeleteDashboardSnapshotsResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BulkDeleteDashboardSnapshotsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *BulkDeleteDashboardSnapshotsCommand) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDashboardSnapshot provides a mock function with given fields: _a0, _a1
 func (_m *MockService) CreateDashboardSnapshot(_a0 context.Context, _a1 *CreateDashboardSnapshotCommand) (*DashboardSnapshot, error) {
 	ret := _m.Called(_a0, _a1)
@@ -79,6 +109,24 @@ func (_m *MockService) DeleteExpiredSnapshots(_a0 context.Context, _a1 *DeleteEx
 	return r0
 }
 
+// EnforceSnapshotPolicies provides a mock function with given fields: _a0, _a1
+func (_m *MockService) EnforceSnapshotPolicies(_a0 context.Context, _a1 *EnforceSnapshotPoliciesCommand) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnforceSnapshotPolicies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *EnforceSnapshotPoliciesCommand) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDashboardSnapshot provides a mock function with given fields: _a0, _a1
 func (_m *MockService) GetDashboardSnapshot(_a0 context.Context, _a1 *GetDashboardSnapshotQuery) (*DashboardSnapshot, error) {
 	ret := _m.Called(_a0, _a1)
@@ -109,6 +157,84 @@ func (_m *MockService) GetDashboardSnapshot(_a0 context.Context, _a1 *GetDashboa
 	return r0, r1
 }
 
+// GetSnapshotPolicy provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetSnapshotPolicy(ctx context.Context, orgID int64) (*SnapshotPolicy, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshotPolicy")
+	}
+
+	var r0 *SnapshotPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*SnapshotPolicy, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *SnapshotPolicy); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*SnapshotPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotViews provides a mock function with given fields: _a0, _a1
+func (_m *MockService) GetSnapshotViews(_a0 context.Context, _a1 *GetSnapshotViewsQuery) ([]*SnapshotView, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshotViews")
+	}
+
+	var r0 []*SnapshotView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GetSnapshotViewsQuery) ([]*SnapshotView, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GetSnapshotViewsQuery) []*SnapshotView); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*SnapshotView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GetSnapshotViewsQuery) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordSnapshotView provides a mock function with given fields: _a0, _a1
+func (_m *MockService) RecordSnapshotView(_a0 context.Context, _a1 *RecordSnapshotViewCommand) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordSnapshotView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *RecordSnapshotViewCommand) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SearchDashboardSnapshots provides a mock function with given fields: _a0, _a1
 func (_m *MockService) SearchDashboardSnapshots(_a0 context.Context, _a1 *GetDashboardSnapshotsQuery) (DashboardSnapshotsList, error) {
 	ret := _m.Called(_a0, _a1)
@@ -139,6 +265,24 @@ func (_m *MockService) SearchDashboardSnapshots(_a0 context.Context, _a1 *GetDas
 	return r0, r1
 }
 
+// SetSnapshotPolicy provides a mock function with given fields: ctx, orgID, policy
+func (_m *MockService) SetSnapshotPolicy(ctx context.Context, orgID int64, policy *SnapshotPolicy) error {
+	ret := _m.Called(ctx, orgID, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSnapshotPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *SnapshotPolicy) error); ok {
+		r0 = rf(ctx, orgID, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ValidateDashboardExists provides a mock function with given fields: _a0, _a1, _a2
 func (_m *MockService) ValidateDashboardExists(_a0 context.Context, _a1 int64, _a2 string) error {
 	ret := _m.Called(_a0, _a1, _a2)
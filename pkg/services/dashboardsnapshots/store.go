@@ -7,7 +7,10 @@ import (
 type Store interface {
 	CreateDashboardSnapshot(context.Context, *CreateDashboardSnapshotCommand) (*DashboardSnapshot, error)
 	DeleteDashboardSnapshot(context.Context, *DeleteDashboardSnapshotCommand) error
+	DeleteDashboardSnapshots(context.Context, *DeleteDashboardSnapshotsCommand) error
 	DeleteExpiredSnapshots(context.Context, *DeleteExpiredSnapshotsCommand) error
 	GetDashboardSnapshot(context.Context, *GetDashboardSnapshotQuery) (*DashboardSnapshot, error)
 	SearchDashboardSnapshots(context.Context, *GetDashboardSnapshotsQuery) (DashboardSnapshotsList, error)
+	RecordSnapshotView(context.Context, *RecordSnapshotViewCommand) error
+	GetSnapshotViews(context.Context, *GetSnapshotViewsQuery) ([]*SnapshotView, error)
 }
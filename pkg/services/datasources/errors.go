@@ -19,4 +19,5 @@ var (
 	ErrDataSourceURLInvalid              = errutil.ValidationFailed("datasource.urlInvalid", errutil.WithPublicMessage("Invalid datasource url."))
 	ErrDataSourceAPIVersionInvalid       = errutil.ValidationFailed("datasource.apiVersionInvalid", errutil.WithPublicMessage("Invalid datasource apiVersion."))
 	ErrDataSourceUIDInvalid              = errutil.ValidationFailed("datasource.uidInvalid", errutil.WithPublicMessage("Invalid datasource UID."))
+	ErrDataSourceNoTenant                = errutil.Forbidden("datasource.noTenant", errutil.WithPublicMessage("None of the teams of the user has a tenant in the data source."))
 )
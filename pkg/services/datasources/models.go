@@ -3,6 +3,11 @@ package datasources
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -135,6 +140,118 @@ func GetTeamHTTPHeaders(jsonData *simplejson.Json) (*TeamHTTPHeaders, error) {
 	return teamHTTPHeaders, nil
 }
 
+const (
+	// DefaultTenantHeader is the tenant header of Mimir, Loki and Tempo
+	DefaultTenantHeader = "X-Scope-OrgID"
+	// TenantSeparator separates the tenants of a multi-tenant query
+	TenantSeparator = "|"
+)
+
+type TeamTenantsJSONData struct {
+	TeamTenants *TeamTenants `json:"teamTenants"`
+}
+
+// TeamTenants maps the teams of the users to the tenants of a multi-tenant data source,
+// such as Mimir, Loki or Tempo, so that one data source can serve many teams.
+type TeamTenants struct {
+	// Header is the tenant header, X-Scope-OrgID by default.
+	Header string `json:"header,omitempty"`
+	// Tenants are the tenants of each team, by team ID.
+	Tenants map[string][]string `json:"tenants"`
+	// DefaultTenant is used for the users without a mapped team. Without it, their requests are rejected.
+	DefaultTenant string `json:"defaultTenant,omitempty"`
+}
+
+// GetTeamTenants returns the team tenants of the json data, or nil when they aren't configured.
+func GetTeamTenants(jsonData *simplejson.Json) (*TeamTenants, error) {
+	if jsonData == nil {
+		return nil, nil
+	}
+	raw, err := jsonData.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return ParseTeamTenants(raw)
+}
+
+// ParseTeamTenants returns the team tenants of the raw json data, or nil when they aren't configured.
+func ParseTeamTenants(jsonData []byte) (*TeamTenants, error) {
+	if len(jsonData) == 0 {
+		return nil, nil
+	}
+	data := TeamTenantsJSONData{}
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return nil, err
+	}
+	t := data.TeamTenants
+	if t == nil {
+		return nil, nil
+	}
+
+	if t.Header == "" {
+		t.Header = DefaultTenantHeader
+	}
+	for teamID, tenants := range t.Tenants {
+		if _, err := strconv.ParseInt(teamID, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid team ID %q in teamTenants", teamID)
+		}
+		for _, tenant := range tenants {
+			if !validTenant(tenant) {
+				return nil, fmt.Errorf("invalid tenant %q in teamTenants", tenant)
+			}
+		}
+	}
+	if t.DefaultTenant != "" && !validTenant(t.DefaultTenant) {
+		return nil, fmt.Errorf("invalid default tenant %q in teamTenants", t.DefaultTenant)
+	}
+
+	// a custom header would replace the tenants of the teams
+	custom := map[string]any{}
+	if err := json.Unmarshal(jsonData, &custom); err != nil {
+		return nil, err
+	}
+	for key, value := range custom {
+		if strings.HasPrefix(key, CustomHeaderName) && http.CanonicalHeaderKey(fmt.Sprint(value)) == http.CanonicalHeaderKey(t.Header) {
+			return nil, fmt.Errorf("the %s header of teamTenants cannot be a custom header", t.Header)
+		}
+	}
+
+	return t, nil
+}
+
+func validTenant(tenant string) bool {
+	return tenant != "" && !strings.Contains(tenant, TenantSeparator)
+}
+
+// TenantsOf returns the sorted tenants of the teams, or the default tenant when none of the teams is mapped.
+func (t *TeamTenants) TenantsOf(teams []int64) []string {
+	seen := map[string]bool{}
+	tenants := []string{}
+	for _, team := range teams {
+		for _, tenant := range t.Tenants[strconv.FormatInt(team, 10)] {
+			if !seen[tenant] {
+				seen[tenant] = true
+				tenants = append(tenants, tenant)
+			}
+		}
+	}
+	if len(tenants) == 0 && t.DefaultTenant != "" {
+		tenants = append(tenants, t.DefaultTenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// HeaderValue returns the value of the tenant header for the teams, and false when
+// the teams have no tenant, in which case the request must be rejected.
+func (t *TeamTenants) HeaderValue(teams []int64) (string, bool) {
+	tenants := t.TenantsOf(teams)
+	if len(tenants) == 0 {
+		return "", false
+	}
+	return strings.Join(tenants, TenantSeparator), true
+}
+
 // AllowedCookies parses the jsondata.keepCookies and returns a list of
 // allowed cookies, otherwise an empty list.
 func (ds DataSource) AllowedCookies() []string {
@@ -103,6 +103,75 @@ func TestTeamHTTPHeaders(t *testing.T) {
 	}
 }
 
+func TestTeamTenants(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		given   string
+		want    *TeamTenants
+		wantErr bool
+	}{
+		{
+			desc:  "Usual json data with teamTenants",
+			given: `{"teamTenants": {"tenants": {"1": ["team-a"], "2": ["team-b", "shared"]}}}`,
+			want: &TeamTenants{
+				Header:  DefaultTenantHeader,
+				Tenants: map[string][]string{"1": {"team-a"}, "2": {"team-b", "shared"}},
+			},
+		},
+		{
+			desc:  "Json data without teamTenants",
+			given: `{"foo": "bar"}`,
+			want:  nil,
+		},
+		{
+			desc:    "Invalid team ID",
+			given:   `{"teamTenants": {"tenants": {"team-a": ["team-a"]}}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "Tenant with the separator",
+			given:   `{"teamTenants": {"tenants": {"1": ["team-a|team-b"]}}}`,
+			wantErr: true,
+		},
+		{
+			desc:    "Tenant header set as a custom header",
+			given:   `{"httpHeaderName1": "x-scope-orgid", "teamTenants": {"tenants": {"1": ["team-a"]}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			jsonData, err := simplejson.NewJson([]byte(test.given))
+			require.NoError(t, err)
+
+			actual, err := GetTeamTenants(jsonData)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, actual)
+		})
+	}
+
+	t.Run("Header value", func(t *testing.T) {
+		tenants := &TeamTenants{Tenants: map[string][]string{"1": {"team-a"}, "2": {"team-b", "team-a"}}}
+
+		value, ok := tenants.HeaderValue([]int64{2, 1, 3})
+		require.True(t, ok)
+		require.Equal(t, "team-a|team-b", value)
+
+		_, ok = tenants.HeaderValue([]int64{3})
+		require.False(t, ok)
+
+		tenants.DefaultTenant = "anonymous"
+		value, ok = tenants.HeaderValue(nil)
+		require.True(t, ok)
+		require.Equal(t, "anonymous", value)
+	})
+}
+
 func TestIsSecureSocksDSProxyEnabled(t *testing.T) {
 	testCases := []struct {
 		desc string
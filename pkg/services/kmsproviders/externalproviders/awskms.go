@@ -0,0 +1,76 @@
+package externalproviders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+type awsKMSClient interface {
+	Encrypt(ctx context.Context, params *kms.EncryptInput, optFns ...func(*kms.Options)) (*kms.EncryptOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// awsKMSProvider encrypts data keys with a symmetric AWS KMS key.
+//
+// Supported properties: key_id (required), region, access_key_id, secret_access_key and endpoint.
+// Without static credentials the default AWS credential chain is used.
+type awsKMSProvider struct {
+	client awsKMSClient
+	keyID  string
+}
+
+func newAWSKMSProvider(ctx context.Context, props map[string]string) (*awsKMSProvider, error) {
+	keyID, err := requiredProperty(KindAWSKMS, props, "key_id")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []func(*config.LoadOptions) error{}
+	if region := props["region"]; region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	if accessKey := props["access_key_id"]; accessKey != "" {
+		provider := credentials.NewStaticCredentialsProvider(accessKey, props["secret_access_key"], "")
+		opts = append(opts, config.WithCredentialsProvider(provider))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+
+	client := kms.NewFromConfig(awsCfg, func(o *kms.Options) {
+		if endpoint := props["endpoint"]; endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	return &awsKMSProvider{client: client, keyID: keyID}, nil
+}
+
+func (p *awsKMSProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	out, err := p.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(p.keyID),
+		Plaintext: blob,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aws kms encrypt: %w", err)
+	}
+	return out.CiphertextBlob, nil
+}
+
+func (p *awsKMSProvider) Decrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(p.keyID),
+		CiphertextBlob: blob,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aws kms decrypt: %w", err)
+	}
+	return out.Plaintext, nil
+}
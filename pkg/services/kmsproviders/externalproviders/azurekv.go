@@ -0,0 +1,108 @@
+package externalproviders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/keyvault/azkeys"
+)
+
+type azureKeyVaultClient interface {
+	WrapKey(ctx context.Context, name string, version string, parameters azkeys.KeyOperationsParameters, options *azkeys.WrapKeyOptions) (azkeys.WrapKeyResponse, error)
+	UnwrapKey(ctx context.Context, name string, version string, parameters azkeys.KeyOperationsParameters, options *azkeys.UnwrapKeyOptions) (azkeys.UnwrapKeyResponse, error)
+}
+
+// azureKeyVaultProvider wraps data keys with an RSA key stored in Azure Key Vault.
+//
+// Supported properties: vault_uri and key_id (required), key_version, algorithm (RSA-OAEP-256 by default),
+// tenant_id, client_id and client_secret. Without a client secret the default Azure credential chain is used.
+type azureKeyVaultProvider struct {
+	client    azureKeyVaultClient
+	keyName   string
+	version   string
+	algorithm azkeys.JSONWebKeyEncryptionAlgorithm
+}
+
+// azureWrappedKey is stored as the encrypted data key, the key version is kept
+// so data keys can still be unwrapped after the key has been rotated in the vault.
+type azureWrappedKey struct {
+	Version string `json:"version"`
+	Value   []byte `json:"value"`
+}
+
+func newAzureKeyVaultProvider(props map[string]string) (*azureKeyVaultProvider, error) {
+	vaultURI, err := requiredProperty(KindAzureKeyVault, props, "vault_uri")
+	if err != nil {
+		return nil, err
+	}
+	keyName, err := requiredProperty(KindAzureKeyVault, props, "key_id")
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := azkeys.JSONWebKeyEncryptionAlgorithmRSAOAEP256
+	if alg := props["algorithm"]; alg != "" {
+		algorithm = azkeys.JSONWebKeyEncryptionAlgorithm(alg)
+	}
+
+	var credential azcore.TokenCredential
+	if props["client_secret"] != "" {
+		credential, err = azidentity.NewClientSecretCredential(props["tenant_id"], props["client_id"], props["client_secret"], nil)
+	} else {
+		credential, err = azidentity.NewDefaultAzureCredential(nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure credential: %w", err)
+	}
+
+	client, err := azkeys.NewClient(vaultURI, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure key vault client: %w", err)
+	}
+
+	return &azureKeyVaultProvider{
+		client:    client,
+		keyName:   keyName,
+		version:   props["key_version"],
+		algorithm: algorithm,
+	}, nil
+}
+
+func (p *azureKeyVaultProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	resp, err := p.client.WrapKey(ctx, p.keyName, p.version, azkeys.KeyOperationsParameters{
+		Algorithm: &p.algorithm,
+		Value:     blob,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault wrap key: %w", err)
+	}
+
+	wrapped := azureWrappedKey{Version: p.version, Value: resp.Result}
+	if resp.KID != nil {
+		wrapped.Version = resp.KID.Version()
+	}
+	return json.Marshal(wrapped)
+}
+
+func (p *azureKeyVaultProvider) Decrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	wrapped := azureWrappedKey{}
+	if err := json.Unmarshal(blob, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to read wrapped key: %w", err)
+	}
+	if len(wrapped.Value) == 0 {
+		return nil, errors.New("wrapped key is empty")
+	}
+
+	resp, err := p.client.UnwrapKey(ctx, p.keyName, wrapped.Version, azkeys.KeyOperationsParameters{
+		Algorithm: &p.algorithm,
+		Value:     wrapped.Value,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault unwrap key: %w", err)
+	}
+	return resp.Result, nil
+}
@@ -0,0 +1,71 @@
+// Package externalproviders contains key encryption key providers backed by external key management systems.
+// The providers are shared by the legacy secrets service and the secrets management encryption manager,
+// both of which use them to wrap the data keys used for envelope encryption.
+package externalproviders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Kind is the type of external provider, used as the first part of a provider identifier.
+type Kind string
+
+const (
+	KindAWSKMS         Kind = "aws_kms"
+	KindAzureKeyVault  Kind = "azure_keyvault"
+	KindGoogleKMS      Kind = "google_kms"
+	KindHashiCorpVault Kind = "hashicorp_vault"
+	KindLocalKey       Kind = "local_key"
+	KindPKCS11         Kind = "pkcs11"
+)
+
+// Kinds lists the supported external provider kinds.
+var Kinds = []Kind{KindAWSKMS, KindAzureKeyVault, KindGoogleKMS, KindHashiCorpVault, KindLocalKey, KindPKCS11}
+
+// Provider wraps and unwraps data keys with a key held by an external system.
+// It matches both secrets.Provider and encryption.Provider.
+type Provider interface {
+	Encrypt(ctx context.Context, blob []byte) ([]byte, error)
+	Decrypt(ctx context.Context, blob []byte) ([]byte, error)
+}
+
+// IsKind returns whether kind is a supported external provider kind.
+func IsKind(kind string) bool {
+	for _, k := range Kinds {
+		if string(k) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// New creates a provider of the given kind from the properties of its configuration section.
+func New(ctx context.Context, kind Kind, props map[string]string) (Provider, error) {
+	switch kind {
+	case KindAWSKMS:
+		return newAWSKMSProvider(ctx, props)
+	case KindAzureKeyVault:
+		return newAzureKeyVaultProvider(props)
+	case KindGoogleKMS:
+		return newGoogleKMSProvider(ctx, props)
+	case KindHashiCorpVault:
+		return newVaultTransitProvider(props, &http.Client{Timeout: 30 * time.Second})
+	case KindLocalKey:
+		return newLocalKeyProvider(props)
+	case KindPKCS11:
+		return newPKCS11Provider(props)
+	default:
+		return nil, fmt.Errorf("unsupported kms provider kind %q", kind)
+	}
+}
+
+func requiredProperty(kind Kind, props map[string]string, key string) (string, error) {
+	value := props[key]
+	if value == "" {
+		return "", fmt.Errorf("missing %s for %s provider", key, kind)
+	}
+	return value, nil
+}
@@ -0,0 +1,215 @@
+package externalproviders
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/Azure/azure-sdk-for-go/sdk/keyvault/azkeys"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects unknown kinds", func(t *testing.T) {
+		_, err := New(ctx, "unknown", map[string]string{})
+		require.Error(t, err)
+	})
+
+	t.Run("requires the key of each kind", func(t *testing.T) {
+		for _, kind := range Kinds {
+			_, err := New(ctx, kind, map[string]string{})
+			require.Error(t, err, kind)
+		}
+	})
+
+	t.Run("knows the supported kinds", func(t *testing.T) {
+		require.True(t, IsKind("hashicorp_vault"))
+		require.False(t, IsKind("secret_key"))
+	})
+}
+
+func TestLocalKeyProvider(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	for name, content := range map[string][]byte{
+		"raw":    key,
+		"hex":    []byte(hex.EncodeToString(key) + "\n"),
+		"base64": []byte(base64.StdEncoding.EncodeToString(key)),
+	} {
+		t.Run("encrypts and decrypts with a "+name+" key", func(t *testing.T) {
+			keyFile := filepath.Join(t.TempDir(), "key")
+			require.NoError(t, os.WriteFile(keyFile, content, 0600))
+
+			provider, err := New(ctx, KindLocalKey, map[string]string{"key_file": keyFile})
+			require.NoError(t, err)
+
+			encrypted, err := provider.Encrypt(ctx, []byte("data key"))
+			require.NoError(t, err)
+			require.NotContains(t, string(encrypted), "data key")
+
+			decrypted, err := provider.Decrypt(ctx, encrypted)
+			require.NoError(t, err)
+			require.Equal(t, "data key", string(decrypted))
+		})
+	}
+
+	t.Run("rejects keys of the wrong size", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(keyFile, []byte("short"), 0600))
+
+		_, err := New(ctx, KindLocalKey, map[string]string{"key_file": keyFile})
+		require.Error(t, err)
+	})
+}
+
+func TestVaultTransitProvider(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		data := map[string]string{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/grafana":
+			data["ciphertext"] = "vault:v1:" + body["plaintext"]
+		case "/v1/transit/decrypt/grafana":
+			data["plaintext"] = strings.TrimPrefix(body["ciphertext"], "vault:v1:")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("encrypts and decrypts with the transit key", func(t *testing.T) {
+		provider, err := New(ctx, KindHashiCorpVault, map[string]string{"url": server.URL, "token": "token", "key_name": "grafana"})
+		require.NoError(t, err)
+
+		encrypted, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(encrypted), "vault:v1:"))
+
+		decrypted, err := provider.Decrypt(ctx, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "data key", string(decrypted))
+	})
+
+	t.Run("reads the token from a file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0600))
+
+		provider, err := New(ctx, KindHashiCorpVault, map[string]string{"url": server.URL, "token_file": tokenFile, "key_name": "grafana"})
+		require.NoError(t, err)
+
+		_, err = provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+	})
+
+	t.Run("returns the errors of vault", func(t *testing.T) {
+		provider, err := New(ctx, KindHashiCorpVault, map[string]string{"url": server.URL, "token": "wrong", "key_name": "grafana"})
+		require.NoError(t, err)
+
+		_, err = provider.Encrypt(ctx, []byte("data key"))
+		require.ErrorContains(t, err, "permission denied")
+	})
+}
+
+// reversingClient "encrypts" by reversing the input and records the key that was used
+type reversingClient struct {
+	keys []string
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
+
+func (c *reversingClient) Encrypt(_ context.Context, in *kms.EncryptInput, _ ...func(*kms.Options)) (*kms.EncryptOutput, error) {
+	c.keys = append(c.keys, *in.KeyId)
+	return &kms.EncryptOutput{CiphertextBlob: reverse(in.Plaintext)}, nil
+}
+
+func (c *reversingClient) Decrypt(_ context.Context, in *kms.DecryptInput, _ ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	c.keys = append(c.keys, *in.KeyId)
+	return &kms.DecryptOutput{Plaintext: reverse(in.CiphertextBlob)}, nil
+}
+
+func (c *reversingClient) WrapKey(_ context.Context, name string, _ string, params azkeys.KeyOperationsParameters, _ *azkeys.WrapKeyOptions) (azkeys.WrapKeyResponse, error) {
+	c.keys = append(c.keys, name)
+	kid := azkeys.ID("https://vault.example.com/keys/" + name + "/v2")
+	return azkeys.WrapKeyResponse{KeyOperationResult: azkeys.KeyOperationResult{KID: &kid, Result: reverse(params.Value)}}, nil
+}
+
+func (c *reversingClient) UnwrapKey(_ context.Context, name string, version string, params azkeys.KeyOperationsParameters, _ *azkeys.UnwrapKeyOptions) (azkeys.UnwrapKeyResponse, error) {
+	c.keys = append(c.keys, name+"/"+version)
+	return azkeys.UnwrapKeyResponse{KeyOperationResult: azkeys.KeyOperationResult{Result: reverse(params.Value)}}, nil
+}
+
+type reversingGoogleClient struct {
+	reversingClient
+}
+
+func (c *reversingGoogleClient) Encrypt(_ context.Context, req *kmspb.EncryptRequest, _ ...gax.CallOption) (*kmspb.EncryptResponse, error) {
+	c.keys = append(c.keys, req.Name)
+	return &kmspb.EncryptResponse{Ciphertext: reverse(req.Plaintext)}, nil
+}
+
+func (c *reversingGoogleClient) Decrypt(_ context.Context, req *kmspb.DecryptRequest, _ ...gax.CallOption) (*kmspb.DecryptResponse, error) {
+	c.keys = append(c.keys, req.Name)
+	return &kmspb.DecryptResponse{Plaintext: reverse(req.Ciphertext)}, nil
+}
+
+func TestCloudProviders(t *testing.T) {
+	ctx := context.Background()
+
+	roundTrip := func(t *testing.T, provider Provider) {
+		t.Helper()
+		encrypted, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+		decrypted, err := provider.Decrypt(ctx, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "data key", string(decrypted))
+	}
+
+	t.Run("aws kms", func(t *testing.T) {
+		client := &reversingClient{}
+		roundTrip(t, &awsKMSProvider{client: client, keyID: "alias/grafana"})
+		require.Equal(t, []string{"alias/grafana", "alias/grafana"}, client.keys)
+	})
+
+	t.Run("azure key vault unwraps with the version used to wrap", func(t *testing.T) {
+		client := &reversingClient{}
+		roundTrip(t, &azureKeyVaultProvider{client: client, keyName: "grafana", algorithm: azkeys.JSONWebKeyEncryptionAlgorithmRSAOAEP256})
+		require.Equal(t, []string{"grafana", "grafana/v2"}, client.keys)
+	})
+
+	t.Run("google kms", func(t *testing.T) {
+		client := &reversingGoogleClient{}
+		keyName := "projects/p/locations/global/keyRings/r/cryptoKeys/grafana"
+		roundTrip(t, &googleKMSProvider{client: client, keyName: keyName})
+		require.Equal(t, []string{keyName, keyName}, client.keys)
+	})
+}
@@ -0,0 +1,60 @@
+package externalproviders
+
+import (
+	"context"
+	"fmt"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+)
+
+type googleKMSClient interface {
+	Encrypt(ctx context.Context, req *kmspb.EncryptRequest, opts ...gax.CallOption) (*kmspb.EncryptResponse, error)
+	Decrypt(ctx context.Context, req *kmspb.DecryptRequest, opts ...gax.CallOption) (*kmspb.DecryptResponse, error)
+}
+
+// googleKMSProvider encrypts data keys with a Google Cloud KMS symmetric key.
+//
+// Supported properties: key_id (required, the full crypto key resource name) and credentials_file.
+// Without a credentials file the application default credentials are used.
+type googleKMSProvider struct {
+	client  googleKMSClient
+	keyName string
+}
+
+func newGoogleKMSProvider(ctx context.Context, props map[string]string) (*googleKMSProvider, error) {
+	keyName, err := requiredProperty(KindGoogleKMS, props, "key_id")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []option.ClientOption{}
+	if credentialsFile := props["credentials_file"]; credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	client, err := kms.NewKeyManagementClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create google kms client: %w", err)
+	}
+
+	return &googleKMSProvider{client: client, keyName: keyName}, nil
+}
+
+func (p *googleKMSProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	resp, err := p.client.Encrypt(ctx, &kmspb.EncryptRequest{Name: p.keyName, Plaintext: blob})
+	if err != nil {
+		return nil, fmt.Errorf("google kms encrypt: %w", err)
+	}
+	return resp.Ciphertext, nil
+}
+
+func (p *googleKMSProvider) Decrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	resp, err := p.client.Decrypt(ctx, &kmspb.DecryptRequest{Name: p.keyName, Ciphertext: blob})
+	if err != nil {
+		return nil, fmt.Errorf("google kms decrypt: %w", err)
+	}
+	return resp.Plaintext, nil
+}
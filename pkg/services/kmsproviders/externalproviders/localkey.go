@@ -0,0 +1,85 @@
+package externalproviders
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const localKeySize = 32
+
+// localKeyProvider encrypts data keys with AES-256-GCM using a key read from a local file,
+// for air-gapped setups where no key management system is reachable.
+// The key file must contain 32 bytes, either raw or encoded as hex or base64.
+//
+// Supported properties: key_file (required).
+type localKeyProvider struct {
+	aead cipher.AEAD
+}
+
+func newLocalKeyProvider(props map[string]string) (*localKeyProvider, error) {
+	keyFile, err := requiredProperty(KindLocalKey, props, "key_file")
+	if err != nil {
+		return nil, err
+	}
+
+	// We can ignore the gosec G304 warning as `keyFile` originates from grafana configuration file
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := parseLocalKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", keyFile, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localKeyProvider{aead: aead}, nil
+}
+
+func parseLocalKey(content []byte) ([]byte, error) {
+	if len(content) == localKeySize {
+		return content, nil
+	}
+
+	encoded := strings.TrimSpace(string(content))
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == localKeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == localKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("expected a %d byte key", localKeySize)
+}
+
+func (p *localKeyProvider) Encrypt(_ context.Context, blob []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return p.aead.Seal(nonce, nonce, blob, nil), nil
+}
+
+func (p *localKeyProvider) Decrypt(_ context.Context, blob []byte) ([]byte, error) {
+	nonceSize := p.aead.NonceSize()
+	if len(blob) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+	return p.aead.Open(nil, blob[:nonceSize], blob[nonceSize:], nil)
+}
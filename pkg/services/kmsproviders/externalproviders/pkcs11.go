@@ -0,0 +1,192 @@
+//go:build cgo
+
+package externalproviders
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	pkcs11NonceSize = 12
+	pkcs11TagBits   = 128
+)
+
+// pkcs11Provider encrypts data keys with AES-256-GCM using a secret key held by a PKCS#11 token,
+// like a hardware security module, so the key never leaves the token.
+// The token is the first one present unless token_label or slot is set.
+//
+// Supported properties: module (required), pin (required), key_label (required), token_label and slot.
+type pkcs11Provider struct {
+	// sessions can not be used concurrently
+	mu      sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+}
+
+func newPKCS11Provider(props map[string]string) (*pkcs11Provider, error) {
+	module, err := requiredProperty(KindPKCS11, props, "module")
+	if err != nil {
+		return nil, err
+	}
+	pin, err := requiredProperty(KindPKCS11, props, "pin")
+	if err != nil {
+		return nil, err
+	}
+	keyLabel, err := requiredProperty(KindPKCS11, props, "key_label")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load pkcs11 module %s", module)
+	}
+	if err := ctx.Initialize(); err != nil && !isPKCS11Error(err, pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize pkcs11 module: %w", err)
+	}
+
+	p := &pkcs11Provider{ctx: ctx}
+	if err := p.open(props["token_label"], props["slot"], pin, keyLabel); err != nil {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *pkcs11Provider) open(tokenLabel, slot, pin, keyLabel string) error {
+	slotID, err := p.findSlot(tokenLabel, slot)
+	if err != nil {
+		return err
+	}
+
+	p.session, err = p.ctx.OpenSession(slotID, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open pkcs11 session: %w", err)
+	}
+	// the login is shared by the sessions of the module
+	if err := p.ctx.Login(p.session, pkcs11.CKU_USER, pin); err != nil && !isPKCS11Error(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return fmt.Errorf("failed to log in to pkcs11 token: %w", err)
+	}
+
+	p.key, err = p.findKey(keyLabel)
+	return err
+}
+
+func (p *pkcs11Provider) findSlot(tokenLabel, slot string) (uint, error) {
+	if slot != "" {
+		slotID, err := strconv.ParseUint(slot, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid pkcs11 slot %q: %w", slot, err)
+		}
+		return uint(slotID), nil
+	}
+
+	slots, err := p.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pkcs11 slots: %w", err)
+	}
+	for _, slotID := range slots {
+		if tokenLabel == "" {
+			return slotID, nil
+		}
+		info, err := p.ctx.GetTokenInfo(slotID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read pkcs11 token info: %w", err)
+		}
+		// labels are padded with spaces
+		if strings.TrimRight(info.Label, " \x00") == tokenLabel {
+			return slotID, nil
+		}
+	}
+
+	if tokenLabel == "" {
+		return 0, errors.New("no pkcs11 token found")
+	}
+	return 0, fmt.Errorf("pkcs11 token %q not found", tokenLabel)
+}
+
+func (p *pkcs11Provider) findKey(keyLabel string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	if err := p.ctx.FindObjectsInit(p.session, template); err != nil {
+		return 0, fmt.Errorf("failed to search pkcs11 keys: %w", err)
+	}
+	objects, _, err := p.ctx.FindObjects(p.session, 2)
+	if finalErr := p.ctx.FindObjectsFinal(p.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to search pkcs11 keys: %w", err)
+	}
+
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("pkcs11 key %q not found", keyLabel)
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("pkcs11 key label %q is not unique", keyLabel)
+	}
+}
+
+func (p *pkcs11Provider) Encrypt(_ context.Context, blob []byte) ([]byte, error) {
+	nonce := make([]byte, pkcs11NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	params := pkcs11.NewGCMParams(nonce, nil, pkcs11TagBits)
+	defer params.Free()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ctx.EncryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, p.key); err != nil {
+		return nil, fmt.Errorf("failed to encrypt with pkcs11 key: %w", err)
+	}
+	ciphertext, err := p.ctx.Encrypt(p.session, blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt with pkcs11 key: %w", err)
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+func (p *pkcs11Provider) Decrypt(_ context.Context, blob []byte) ([]byte, error) {
+	if len(blob) < pkcs11NonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	params := pkcs11.NewGCMParams(blob[:pkcs11NonceSize], nil, pkcs11TagBits)
+	defer params.Free()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ctx.DecryptInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}, p.key); err != nil {
+		return nil, fmt.Errorf("failed to decrypt with pkcs11 key: %w", err)
+	}
+	plaintext, err := p.ctx.Decrypt(p.session, blob[pkcs11NonceSize:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with pkcs11 key: %w", err)
+	}
+	return plaintext, nil
+}
+
+func isPKCS11Error(err error, code uint) bool {
+	var pkcs11Err pkcs11.Error
+	return errors.As(err, &pkcs11Err) && uint(pkcs11Err) == code
+}
@@ -0,0 +1,10 @@
+//go:build !cgo
+
+package externalproviders
+
+import "errors"
+
+// newPKCS11Provider fails in builds without cgo, the PKCS#11 modules are C libraries.
+func newPKCS11Provider(_ map[string]string) (Provider, error) {
+	return nil, errors.New("HSM provider requires a cgo build")
+}
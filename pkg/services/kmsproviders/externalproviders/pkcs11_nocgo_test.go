@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package externalproviders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPKCS11Provider(t *testing.T) {
+	_, err := New(context.Background(), KindPKCS11, map[string]string{"module": "/usr/lib/softhsm/libsofthsm2.so", "pin": "1234", "key_label": "grafana"})
+	require.ErrorContains(t, err, "requires a cgo build")
+}
@@ -0,0 +1,34 @@
+//go:build cgo
+
+package externalproviders
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPKCS11Provider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires the pin and the key label", func(t *testing.T) {
+		_, err := New(ctx, KindPKCS11, map[string]string{"module": "/usr/lib/softhsm/libsofthsm2.so", "key_label": "grafana"})
+		require.ErrorContains(t, err, "missing pin")
+
+		_, err = New(ctx, KindPKCS11, map[string]string{"module": "/usr/lib/softhsm/libsofthsm2.so", "pin": "1234"})
+		require.ErrorContains(t, err, "missing key_label")
+	})
+
+	t.Run("fails when the module can not be loaded", func(t *testing.T) {
+		module := filepath.Join(t.TempDir(), "missing.so")
+		_, err := New(ctx, KindPKCS11, map[string]string{"module": module, "pin": "1234", "key_label": "grafana"})
+		require.ErrorContains(t, err, "failed to load pkcs11 module")
+	})
+
+	t.Run("rejects ciphertexts without a nonce", func(t *testing.T) {
+		_, err := (&pkcs11Provider{}).Decrypt(ctx, []byte("short"))
+		require.ErrorContains(t, err, "ciphertext too short")
+	})
+}
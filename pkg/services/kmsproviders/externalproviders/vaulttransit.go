@@ -0,0 +1,131 @@
+package externalproviders
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// vaultTransitProvider encrypts data keys with the transit secrets engine of HashiCorp Vault.
+//
+// Supported properties: url and key_name (required), token (or token_file), namespace and
+// transit_mount (transit by default). The token is re-read from token_file on each request
+// so it can be renewed by a Vault agent.
+type vaultTransitProvider struct {
+	httpClient *http.Client
+	address    string
+	token      string
+	tokenFile  string
+	namespace  string
+	mount      string
+	keyName    string
+}
+
+func newVaultTransitProvider(props map[string]string, httpClient *http.Client) (*vaultTransitProvider, error) {
+	address, err := requiredProperty(KindHashiCorpVault, props, "url")
+	if err != nil {
+		return nil, err
+	}
+	keyName, err := requiredProperty(KindHashiCorpVault, props, "key_name")
+	if err != nil {
+		return nil, err
+	}
+	if props["token"] == "" && props["token_file"] == "" {
+		return nil, fmt.Errorf("missing token or token_file for %s provider", KindHashiCorpVault)
+	}
+
+	mount := props["transit_mount"]
+	if mount == "" {
+		mount = "transit"
+	}
+
+	return &vaultTransitProvider{
+		httpClient: httpClient,
+		address:    strings.TrimSuffix(address, "/"),
+		token:      props["token"],
+		tokenFile:  props["token_file"],
+		namespace:  props["namespace"],
+		mount:      strings.Trim(mount, "/"),
+		keyName:    keyName,
+	}, nil
+}
+
+type vaultTransitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *vaultTransitProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	resp, err := p.do(ctx, "encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(blob)})
+	if err != nil {
+		return nil, fmt.Errorf("vault transit encrypt: %w", err)
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+func (p *vaultTransitProvider) Decrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	resp, err := p.do(ctx, "decrypt", map[string]string{"ciphertext": string(blob)})
+	if err != nil {
+		return nil, fmt.Errorf("vault transit decrypt: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
+
+func (p *vaultTransitProvider) do(ctx context.Context, operation string, body map[string]string) (*vaultTransitResponse, error) {
+	token, err := p.currentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", p.address, p.mount, operation, p.keyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out := &vaultTransitResponse{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil && resp.StatusCode < http.StatusBadRequest {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(out.Errors, ", "))
+	}
+	return out, nil
+}
+
+func (p *vaultTransitProvider) currentToken() (string, error) {
+	if p.tokenFile == "" {
+		return p.token, nil
+	}
+	// We can ignore the gosec G304 warning as `tokenFile` originates from grafana configuration file
+	token, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault token file: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
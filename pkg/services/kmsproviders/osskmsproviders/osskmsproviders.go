@@ -1,14 +1,33 @@
 package osskmsproviders
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/grafana/grafana/pkg/services/encryption"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/kmsproviders"
 	grafana "github.com/grafana/grafana/pkg/services/kmsproviders/defaultprovider"
+	"github.com/grafana/grafana/pkg/services/kmsproviders/externalproviders"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
+// providerSectionPrefix is the prefix of the configuration sections of external providers,
+// e.g. [security.encryption.awskms.v1] configures the provider with identifier awskms.v1.
+const providerSectionPrefix = "security.encryption."
+
+// kinds maps the provider kinds used in identifiers to the external provider implementations.
+var kinds = map[string]externalproviders.Kind{
+	"awskms":         externalproviders.KindAWSKMS,
+	"azurekv":        externalproviders.KindAzureKeyVault,
+	"googlekms":      externalproviders.KindGoogleKMS,
+	"hashicorpvault": externalproviders.KindHashiCorpVault,
+	"localkey":       externalproviders.KindLocalKey,
+	"pkcs11":         externalproviders.KindPKCS11,
+}
+
 type Service struct {
 	enc      encryption.Internal
 	cfg      *setting.Cfg
@@ -24,7 +43,32 @@ func ProvideService(enc encryption.Internal, cfg *setting.Cfg, features featurem
 }
 
 func (s Service) Provide() (map[secrets.ProviderID]secrets.Provider, error) {
-	return map[secrets.ProviderID]secrets.Provider{
+	providers := map[secrets.ProviderID]secrets.Provider{
 		kmsproviders.Default: grafana.New(s.cfg, s.enc),
-	}, nil
+	}
+
+	for _, section := range s.cfg.Raw.Sections() {
+		if !strings.HasPrefix(section.Name(), providerSectionPrefix) {
+			continue
+		}
+
+		// Sections of other kinds are left to the providers registered elsewhere
+		providerID := secrets.ProviderID(strings.TrimPrefix(section.Name(), providerSectionPrefix))
+		kind, err := providerID.Kind()
+		if err != nil {
+			continue
+		}
+		externalKind, ok := kinds[kind]
+		if !ok {
+			continue
+		}
+
+		provider, err := externalproviders.New(context.Background(), externalKind, section.KeysHash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure encryption provider %s: %w", providerID, err)
+		}
+		providers[providerID] = provider
+	}
+
+	return providers, nil
 }
@@ -0,0 +1,45 @@
+package osskmsproviders
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/kmsproviders"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestProvide(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("0123456789abcdef0123456789abcdef"), 0600))
+
+	raw, err := ini.Load([]byte(`
+[security]
+secret_key = sdDkslslld
+
+[security.encryption.localkey.v1]
+key_file = ` + keyFile + `
+
+[security.encryption.fakeProvider.v1]
+`))
+	require.NoError(t, err)
+
+	providers, err := ProvideService(nil, &setting.Cfg{Raw: raw}, featuremgmt.WithFeatures()).Provide()
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	require.Contains(t, providers, secrets.ProviderID(kmsproviders.Default))
+
+	provider := providers["localkey.v1"]
+	require.NotNil(t, provider)
+	encrypted, err := provider.Encrypt(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+	decrypted, err := provider.Decrypt(context.Background(), encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "data key", string(decrypted))
+}
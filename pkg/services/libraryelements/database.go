@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/search/sort"
 	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
 	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
@@ -110,6 +111,45 @@ func (l *LibraryElementService) GetLibraryElement(c context.Context, signedInUse
 	return elements[0], nil
 }
 
+// checkQuotaReached checks the quota of library elements created by the user in the folder.
+func (l *LibraryElementService) checkQuotaReached(c context.Context, signedInUser identity.Requester, folderUID *string) error {
+	if l.quotaService == nil {
+		return nil
+	}
+
+	params := &quota.ScopeParameters{OrgID: signedInUser.GetOrgID()}
+	if id, err := identity.UserIdentifier(signedInUser.GetID()); err == nil {
+		params.UserID = id
+	}
+	if folderUID != nil {
+		params.FolderUID = *folderUID
+	}
+	limitReached, err := l.quotaService.CheckQuotaReached(c, model.QuotaTargetSrv, params)
+	if err != nil {
+		return fmt.Errorf("failed to get quota: %w", err)
+	}
+	if limitReached {
+		return model.ErrLibraryElementQuotaReached
+	}
+	return nil
+}
+
+// checkFolderQuotaReached checks the quota of library elements in the folder a library element is moved to.
+func (l *LibraryElementService) checkFolderQuotaReached(c context.Context, orgID int64, folderUID string) error {
+	if l.quotaService == nil {
+		return nil
+	}
+
+	limitReached, err := l.quotaService.CheckFolderQuotaReached(c, model.QuotaTargetSrv, orgID, folderUID)
+	if err != nil {
+		return fmt.Errorf("failed to get quota: %w", err)
+	}
+	if limitReached {
+		return model.ErrLibraryElementQuotaReached
+	}
+	return nil
+}
+
 // createLibraryElement adds a library element.
 func (l *LibraryElementService) createLibraryElement(c context.Context, signedInUser identity.Requester, cmd model.CreateLibraryElementCommand) (model.LibraryElementDTO, error) {
 	if err := l.requireSupportedElementKind(cmd.Kind); err != nil {
@@ -126,6 +166,10 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 		}
 	}
 
+	if err := l.checkQuotaReached(c, signedInUser, cmd.FolderUID); err != nil {
+		return model.LibraryElementDTO{}, err
+	}
+
 	updatedModel := cmd.Model
 	var err error
 	if cmd.Kind == int64(model.PanelElement) {
@@ -592,6 +636,11 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		if elementInDB.Version != cmd.Version {
 			return model.ErrLibraryElementVersionMismatch
 		}
+		if cmd.FolderUID != nil && *cmd.FolderUID != elementInDB.FolderUID {
+			if err := l.checkFolderQuotaReached(c, signedInUser.GetOrgID(), *cmd.FolderUID); err != nil {
+				return err
+			}
+		}
 		updateUID := cmd.UID
 		if len(updateUID) == 0 {
 			updateUID = uid
@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/kinds/librarypanel"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/testutil"
 )
@@ -69,6 +70,19 @@ func TestIntegration_CreateLibraryElement(t *testing.T) {
 			}
 		})
 
+	scenarioWithPanel(t, "When an admin tries to create a library panel and the quota is reached, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.service.quotaService = quotatest.New(true, nil)
+			// nolint:staticcheck
+			command := getCreatePanelCommand(sc.folder.ID, sc.folder.UID, "Quota reached")
+			sc.reqContext.Req.Body = mockRequestBody(command)
+			resp := sc.service.createHandler(sc.reqContext)
+			require.Equal(t, 403, resp.Status())
+
+			_, err := sc.service.CreateElement(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, command)
+			require.ErrorIs(t, err, model.ErrLibraryElementQuotaReached)
+		})
+
 	testScenario(t, "When an admin tries to create a library panel that does not exists using an nonexistent UID, it should succeed",
 		func(t *testing.T, sc scenarioContext) {
 			// nolint:staticcheck
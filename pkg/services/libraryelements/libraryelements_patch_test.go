@@ -6,6 +6,7 @@ import (
 	"github.com/grafana/grafana/pkg/kinds/librarypanel"
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
 	"github.com/grafana/grafana/pkg/util"
 
 	"github.com/google/go-cmp/cmp"
@@ -27,6 +28,28 @@ func TestIntegration_PatchLibraryElement(t *testing.T) {
 			require.Equal(t, 404, resp.Status())
 		})
 
+	scenarioWithPanel(t, "When an admin tries to move a library panel to a folder whose quota is reached, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			newFolder := &folder.Folder{
+				ID:    2,
+				OrgID: 1,
+				UID:   "uid_for_FullFolder",
+				Title: "FullFolder",
+			}
+			sc.folderSvc.ExpectedFolder = newFolder
+			sc.service.quotaService = quotatest.New(true, nil)
+			cmd := model.PatchLibraryElementCommand{
+				FolderID:  newFolder.ID, // nolint:staticcheck
+				FolderUID: &newFolder.UID,
+				Kind:      int64(model.PanelElement),
+				Version:   1,
+			}
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(cmd)
+			resp := sc.service.patchHandler(sc.reqContext)
+			require.Equal(t, 403, resp.Status())
+		})
+
 	scenarioWithPanel(t, "When an admin tries to patch a library panel that exists, it should succeed",
 		func(t *testing.T, sc scenarioContext) {
 			newFolder := &folder.Folder{
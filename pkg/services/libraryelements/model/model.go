@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/kinds/librarypanel"
+	"github.com/grafana/grafana/pkg/services/quota"
 )
 
 type LibraryConnectionKind int
@@ -157,6 +158,8 @@ var (
 	ErrLibraryElementInvalidUID = errors.New("uid contains illegal characters")
 	// errLibraryElementUIDTooLong is an error for when the uid of a library element is invalid
 	ErrLibraryElementUIDTooLong = errors.New("uid too long, max 40 characters")
+	// ErrLibraryElementQuotaReached is an error for when the quota of library elements has been reached.
+	ErrLibraryElementQuotaReached = errors.New("quota reached")
 )
 
 // Commands
@@ -264,5 +267,10 @@ const (
 	PanelElement LibraryElementKind = iota + 1
 )
 
+const (
+	QuotaTargetSrv quota.TargetSrv = "library_panel"
+	QuotaTarget    quota.Target    = "library_panel"
+)
+
 const LibraryElementTableName = "library_element"
 const LibraryElementConnectionTableName = "library_element_connection"
@@ -17,12 +17,13 @@ import (
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/libraryelements"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/store/entity"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
 func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister,
-	libraryElementService libraryelements.Service, folderService folder.Service) (*LibraryPanelService, error) {
+	libraryElementService libraryelements.Service, folderService folder.Service, quotaService quota.Service) (*LibraryPanelService, error) {
 	lps := LibraryPanelService{
 		Cfg:                   cfg,
 		SQLStore:              sqlStore,
@@ -36,6 +37,18 @@ func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.Rout
 		return nil, err
 	}
 
+	defaultLimits, err := readQuotaConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := quotaService.RegisterQuotaReporter(&quota.NewUsageReporter{
+		TargetSrv:     model.QuotaTargetSrv,
+		DefaultLimits: defaultLimits,
+		Reporter:      lps.Count,
+	}); err != nil {
+		return nil, err
+	}
+
 	return &lps, nil
 }
 
@@ -215,6 +228,72 @@ func (lps LibraryPanelService) CountInFolders(ctx context.Context, orgID int64,
 	})
 }
 
+// CountInTeam returns the number of library panels created by the members of the team.
+func (lps LibraryPanelService) CountInTeam(ctx context.Context, orgID, teamID int64) (int64, error) {
+	var count int64
+	return count, lps.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.SQL(`SELECT COUNT(*) FROM library_element
+			INNER JOIN team_member ON team_member.user_id = library_element.created_by AND team_member.org_id = library_element.org_id
+			WHERE library_element.org_id = ? AND team_member.team_id = ? AND library_element.kind = ?`,
+			orgID, teamID, int64(model.PanelElement)).Get(&count)
+		return err
+	})
+}
+
+// Count reports the team and folder usage of library panels for the quota service.
+// Library panels have no global or organization quota.
+func (lps LibraryPanelService) Count(ctx context.Context, scopeParams *quota.ScopeParameters) (*quota.Map, error) {
+	u := &quota.Map{}
+	if scopeParams == nil || scopeParams.OrgID == 0 {
+		return u, nil
+	}
+
+	if scopeParams.TeamID != 0 {
+		count, err := lps.CountInTeam(ctx, scopeParams.OrgID, scopeParams.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := quota.NewTag(model.QuotaTargetSrv, model.QuotaTarget, quota.TeamScope)
+		if err != nil {
+			return nil, err
+		}
+		u.Set(tag, count)
+	}
+
+	if scopeParams.FolderUID != "" {
+		ctx, ident := identity.WithServiceIdentity(ctx, scopeParams.OrgID)
+		count, err := lps.CountInFolders(ctx, scopeParams.OrgID, []string{scopeParams.FolderUID}, ident)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := quota.NewTag(model.QuotaTargetSrv, model.QuotaTarget, quota.FolderScope)
+		if err != nil {
+			return nil, err
+		}
+		u.Set(tag, count)
+	}
+
+	return u, nil
+}
+
+func readQuotaConfig() (*quota.Map, error) {
+	limits := &quota.Map{}
+
+	teamQuotaTag, err := quota.NewTag(model.QuotaTargetSrv, model.QuotaTarget, quota.TeamScope)
+	if err != nil {
+		return limits, err
+	}
+	folderQuotaTag, err := quota.NewTag(model.QuotaTargetSrv, model.QuotaTarget, quota.FolderScope)
+	if err != nil {
+		return limits, err
+	}
+
+	// team and folder quotas are unlimited unless set through the API
+	limits.Set(teamQuotaTag, -1)
+	limits.Set(folderQuotaTag, -1)
+	return limits, nil
+}
+
 // DeleteInFolder deletes the library panels contained in a given folder.
 func (lps LibraryPanelService) DeleteInFolders(ctx context.Context, orgID int64, folderUIDs []string, user identity.Requester) error {
 	for _, folderUID := range folderUIDs {
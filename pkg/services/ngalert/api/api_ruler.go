@@ -586,8 +586,9 @@ func (srv RulerSrv) performUpdateAlertRules(ctx context.Context, c *contextmodel
 		if len(finalChanges.New) > 0 {
 			userID, _ := identity.UserIdentifier(c.GetID())
 			limitReached, err := srv.QuotaService.CheckQuotaReached(tranCtx, ngmodels.QuotaTargetSrv, &quota.ScopeParameters{
-				OrgID:  c.GetOrgID(),
-				UserID: userID,
+				OrgID:     c.GetOrgID(),
+				UserID:    userID,
+				FolderUID: groupKey.NamespaceUID,
 			}) // alert rule is table name
 			if err != nil {
 				return fmt.Errorf("failed to get alert rules quota: %w", err)
@@ -595,6 +596,15 @@ func (srv RulerSrv) performUpdateAlertRules(ctx context.Context, c *contextmodel
 			if limitReached {
 				return ngmodels.ErrQuotaReached
 			}
+		} else if finalChanges.HasMovedRules() {
+			// moving rules only changes the usage of the folder they are moved to
+			limitReached, err := srv.QuotaService.CheckFolderQuotaReached(tranCtx, ngmodels.QuotaTargetSrv, c.GetOrgID(), groupKey.NamespaceUID)
+			if err != nil {
+				return fmt.Errorf("failed to get alert rules quota: %w", err)
+			}
+			if limitReached {
+				return ngmodels.ErrQuotaReached
+			}
 		}
 		return nil
 	})
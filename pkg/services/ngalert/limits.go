@@ -3,6 +3,7 @@ package ngalert
 import (
 	"context"
 
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/setting"
@@ -10,6 +11,8 @@ import (
 
 type RuleUsageReader interface {
 	Count(ctx context.Context, orgID int64) (int64, error)
+	CountInTeam(ctx context.Context, orgID int64, teamID int64) (int64, error)
+	CountInFolders(ctx context.Context, orgID int64, folderUIDs []string, user identity.Requester) (int64, error)
 }
 
 func RegisterQuotas(cfg *setting.Cfg, qs quota.Service, rules RuleUsageReader) error {
@@ -54,6 +57,30 @@ func UsageReporter(rules RuleUsageReader) quota.UsageReporterFunc {
 			u.Set(tag, globalUsage)
 		}
 
+		if scopeParams != nil && scopeParams.OrgID != 0 && scopeParams.TeamID != 0 {
+			teamUsage, err := rules.CountInTeam(ctx, scopeParams.OrgID, scopeParams.TeamID)
+			if err != nil {
+				return u, err
+			}
+			tag, err := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.TeamScope)
+			if err != nil {
+				return u, err
+			}
+			u.Set(tag, teamUsage)
+		}
+
+		if scopeParams != nil && scopeParams.OrgID != 0 && scopeParams.FolderUID != "" {
+			folderUsage, err := rules.CountInFolders(ctx, scopeParams.OrgID, []string{scopeParams.FolderUID}, nil)
+			if err != nil {
+				return u, err
+			}
+			tag, err := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.FolderScope)
+			if err != nil {
+				return u, err
+			}
+			u.Set(tag, folderUsage)
+		}
+
 		return u, nil
 	}
 }
@@ -74,7 +101,19 @@ func readQuotaConfig(cfg *setting.Cfg) (*quota.Map, error) {
 		return limits, err
 	}
 
+	teamQuotaTag, err := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.TeamScope)
+	if err != nil {
+		return limits, err
+	}
+	folderQuotaTag, err := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.FolderScope)
+	if err != nil {
+		return limits, err
+	}
+
 	limits.Set(globalQuotaTag, cfg.Quota.Global.AlertRule)
 	limits.Set(orgQuotaTag, cfg.Quota.Org.AlertRule)
+	// team and folder quotas are unlimited unless set through the API
+	limits.Set(teamQuotaTag, -1)
+	limits.Set(folderQuotaTag, -1)
 	return limits, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/setting"
@@ -52,6 +53,41 @@ func TestUsageReporter(t *testing.T) {
 		require.True(t, ok, "reporter did not report on global rules usage")
 		require.Equal(t, int64(30), val)
 	})
+
+	t.Run("reports team and folder usage", func(t *testing.T) {
+		rules := newFakeUsageReader(map[int64]int64{1: 10, 2: 20})
+		rules.team = map[int64]int64{5: 3}
+		rules.folder = map[string]int64{"folder-1": 4}
+		params := quota.ScopeParameters{
+			OrgID:     1,
+			TeamID:    5,
+			FolderUID: "folder-1",
+		}
+
+		res, err := UsageReporter(rules)(context.Background(), &params)
+
+		require.NoError(t, err)
+		rulesTeam, _ := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.TeamScope)
+		val, ok := res.Get(rulesTeam)
+		require.True(t, ok, "reporter did not report on team rules usage")
+		require.Equal(t, int64(3), val)
+
+		rulesFolder, _ := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.FolderScope)
+		val, ok = res.Get(rulesFolder)
+		require.True(t, ok, "reporter did not report on folder rules usage")
+		require.Equal(t, int64(4), val)
+	})
+
+	t.Run("does not report team and folder usage when not requested", func(t *testing.T) {
+		rules := newFakeUsageReader(map[int64]int64{1: 10})
+
+		res, err := UsageReporter(rules)(context.Background(), &quota.ScopeParameters{OrgID: 1})
+
+		require.NoError(t, err)
+		rulesTeam, _ := quota.NewTag(models.QuotaTargetSrv, models.QuotaTarget, quota.TeamScope)
+		_, ok := res.Get(rulesTeam)
+		require.False(t, ok)
+	})
 }
 
 func TestReadQuotaConfig(t *testing.T) {
@@ -88,7 +124,9 @@ func TestReadQuotaConfig(t *testing.T) {
 }
 
 type fakeUsageReader struct {
-	usage map[int64]int64 // orgID -> count
+	usage  map[int64]int64  // orgID -> count
+	team   map[int64]int64  // teamID -> count
+	folder map[string]int64 // folderUID -> count
 }
 
 func newFakeUsageReader(usage map[int64]int64) fakeUsageReader {
@@ -111,3 +149,15 @@ func (f fakeUsageReader) Count(_ context.Context, orgID int64) (int64, error) {
 	}
 	return 0, nil
 }
+
+func (f fakeUsageReader) CountInTeam(_ context.Context, _ int64, teamID int64) (int64, error) {
+	return f.team[teamID], nil
+}
+
+func (f fakeUsageReader) CountInFolders(_ context.Context, _ int64, folderUIDs []string, _ identity.Requester) (int64, error) {
+	total := int64(0)
+	for _, uid := range folderUIDs {
+		total += f.folder[uid]
+	}
+	return total, nil
+}
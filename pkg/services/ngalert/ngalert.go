@@ -55,6 +55,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/rendering"
 	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/services/supportbundles"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 )
@@ -87,6 +88,7 @@ func ProvideService(
 	pluginContextProvider *plugincontext.Provider,
 	resourcePermissions accesscontrol.ReceiverPermissionsService,
 	userService user.Service,
+	bundleRegistry supportbundles.Service,
 ) (*AlertNG, error) {
 	ng := &AlertNG{
 		Cfg:                   cfg,
@@ -127,6 +129,8 @@ func ProvideService(
 		return nil, err
 	}
 
+	bundleRegistry.RegisterSupportItemCollector(ng.supportBundleCollector())
+
 	return ng, nil
 }
 
@@ -322,7 +322,7 @@ func (service *AlertRuleService) CreateAlertRule(ctx context.Context, user ident
 			return errors.New("couldn't find newly created id")
 		}
 
-		if err = service.checkLimitsTransactionCtx(ctx, user); err != nil {
+		if err = service.checkLimitsTransactionCtx(ctx, user, rule.NamespaceUID); err != nil {
 			return err
 		}
 
@@ -693,7 +693,7 @@ func (service *AlertRuleService) persistDelta(ctx context.Context, user identity
 			}
 		}
 
-		if err := service.checkLimitsTransactionCtx(ctx, user); err != nil {
+		if err := service.checkLimitsTransactionCtx(ctx, user, delta.GroupKey.NamespaceUID); err != nil {
 			return err
 		}
 
@@ -791,6 +791,11 @@ func (service *AlertRuleService) UpdateAlertRule(ctx context.Context, user ident
 		if err != nil {
 			return err
 		}
+		if storedRule.NamespaceUID != rule.NamespaceUID {
+			if err := service.checkFolderLimitTransactionCtx(ctx, rule.OrgID, rule.NamespaceUID); err != nil {
+				return err
+			}
+		}
 		return service.provenanceStore.SetProvenance(ctx, &rule, rule.OrgID, provenance)
 	})
 	if err != nil {
@@ -842,7 +847,7 @@ func (service *AlertRuleService) DeleteAlertRule(ctx context.Context, user ident
 }
 
 // checkLimitsTransactionCtx checks whether the current transaction (as identified by the ctx) breaches configured alert rule limits.
-func (service *AlertRuleService) checkLimitsTransactionCtx(ctx context.Context, user identity.Requester) error {
+func (service *AlertRuleService) checkLimitsTransactionCtx(ctx context.Context, user identity.Requester, folderUID string) error {
 	// default to 0 if there is no user
 	var userID int64
 	if id, err := identity.UserIdentifier(user.GetID()); err == nil {
@@ -850,8 +855,9 @@ func (service *AlertRuleService) checkLimitsTransactionCtx(ctx context.Context,
 	}
 
 	limitReached, err := service.quotas.CheckQuotaReached(ctx, models.QuotaTargetSrv, &quota.ScopeParameters{
-		OrgID:  user.GetOrgID(),
-		UserID: userID,
+		OrgID:     user.GetOrgID(),
+		UserID:    userID,
+		FolderUID: folderUID,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to check alert rule quota: %w", err)
@@ -862,6 +868,18 @@ func (service *AlertRuleService) checkLimitsTransactionCtx(ctx context.Context,
 	return nil
 }
 
+// checkFolderLimitTransactionCtx checks whether the current transaction (as identified by the ctx) breaches the alert rule limit of the folder rules are moved to.
+func (service *AlertRuleService) checkFolderLimitTransactionCtx(ctx context.Context, orgID int64, folderUID string) error {
+	limitReached, err := service.quotas.CheckFolderQuotaReached(ctx, models.QuotaTargetSrv, orgID, folderUID)
+	if err != nil {
+		return fmt.Errorf("failed to check alert rule quota: %w", err)
+	}
+	if limitReached {
+		return models.ErrQuotaReached
+	}
+	return nil
+}
+
 // deleteRules deletes a set of target rules and associated data, while checking for database consistency.
 func (service *AlertRuleService) deleteRules(ctx context.Context, user identity.Requester, targets ...*models.AlertRule) error {
 	uids := make([]string, 0, len(targets))
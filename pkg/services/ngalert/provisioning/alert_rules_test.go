@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
@@ -750,6 +751,22 @@ func TestIntegrationAlertRuleService(t *testing.T) {
 		require.ErrorIs(t, err, models.ErrQuotaReached)
 	})
 
+	t.Run("folder quota met causes moving a rule to be rejected", func(t *testing.T) {
+		ruleService := createAlertRuleService(t, nil)
+		rule, err := ruleService.CreateAlertRule(context.Background(), u, dummyRule("test#move", orgID), models.ProvenanceNone)
+		require.NoError(t, err)
+
+		checker := &MockQuotaChecker{}
+		checker.EXPECT().CheckFolderQuotaReached(mock.Anything, models.QuotaTargetSrv, orgID, "full-folder").Return(true, nil)
+		ruleService.quotas = checker
+
+		rule.NamespaceUID = "full-folder"
+		_, err = ruleService.UpdateAlertRule(context.Background(), u, rule, models.ProvenanceNone)
+
+		require.ErrorIs(t, err, models.ErrQuotaReached)
+		checker.AssertExpectations(t)
+	})
+
 	t.Run("alert rules created without a group should be considered NoGroup rules", func(t *testing.T) {
 		rule := createNoGroupRule("test-no-group-rule", orgID, "my-namespace")
 		// This is the way legacy storage creates rules without a group
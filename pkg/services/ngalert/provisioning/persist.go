@@ -45,4 +45,5 @@ type RuleStore interface {
 //go:generate mockery --name QuotaChecker --structname MockQuotaChecker --inpackage --filename quota_checker_mock.go --with-expecter
 type QuotaChecker interface {
 	CheckQuotaReached(ctx context.Context, target quota.TargetSrv, scopeParams *quota.ScopeParameters) (bool, error)
+	CheckFolderQuotaReached(ctx context.Context, target quota.TargetSrv, orgID int64, folderUID string) (bool, error)
 }
@@ -22,6 +22,65 @@ func (_m *MockQuotaChecker) EXPECT() *MockQuotaChecker_Expecter {
 	return &MockQuotaChecker_Expecter{mock: &_m.Mock}
 }
 
+// CheckFolderQuotaReached provides a mock function with given fields: ctx, target, orgID, folderUID
+func (_m *MockQuotaChecker) CheckFolderQuotaReached(ctx context.Context, target quota.TargetSrv, orgID int64, folderUID string) (bool, error) {
+	ret := _m.Called(ctx, target, orgID, folderUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckFolderQuotaReached")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, quota.TargetSrv, int64, string) (bool, error)); ok {
+		return rf(ctx, target, orgID, folderUID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, quota.TargetSrv, int64, string) bool); ok {
+		r0 = rf(ctx, target, orgID, folderUID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, quota.TargetSrv, int64, string) error); ok {
+		r1 = rf(ctx, target, orgID, folderUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuotaChecker_CheckFolderQuotaReached_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckFolderQuotaReached'
+type MockQuotaChecker_CheckFolderQuotaReached_Call struct {
+	*mock.Call
+}
+
+// CheckFolderQuotaReached is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target quota.TargetSrv
+//   - orgID int64
+//   - folderUID string
+func (_e *MockQuotaChecker_Expecter) CheckFolderQuotaReached(ctx interface{}, target interface{}, orgID interface{}, folderUID interface{}) *MockQuotaChecker_CheckFolderQuotaReached_Call {
+	return &MockQuotaChecker_CheckFolderQuotaReached_Call{Call: _e.mock.On("CheckFolderQuotaReached", ctx, target, orgID, folderUID)}
+}
+
+func (_c *MockQuotaChecker_CheckFolderQuotaReached_Call) Run(run func(ctx context.Context, target quota.TargetSrv, orgID int64, folderUID string)) *MockQuotaChecker_CheckFolderQuotaReached_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(quota.TargetSrv), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuotaChecker_CheckFolderQuotaReached_Call) Return(_a0 bool, _a1 error) *MockQuotaChecker_CheckFolderQuotaReached_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuotaChecker_CheckFolderQuotaReached_Call) RunAndReturn(run func(context.Context, quota.TargetSrv, int64, string) (bool, error)) *MockQuotaChecker_CheckFolderQuotaReached_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckQuotaReached provides a mock function with given fields: ctx, target, scopeParams
 func (_m *MockQuotaChecker) CheckQuotaReached(ctx context.Context, target quota.TargetSrv, scopeParams *quota.ScopeParameters) (bool, error) {
 	ret := _m.Called(ctx, target, scopeParams)
@@ -974,6 +974,28 @@ func (st DBstore) Count(ctx context.Context, orgID int64) (int64, error) {
 	return r.Count, err
 }
 
+// CountInTeam returns the number of alert rules of the organization last updated by the members of the team.
+// Rules do not keep track of their creator, so the last editor is used instead.
+func (st DBstore) CountInTeam(ctx context.Context, orgID int64, teamID int64) (int64, error) {
+	type result struct {
+		Count int64
+	}
+
+	r := result{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		userTable := st.SQLStore.GetDialect().Quote("user")
+		rawSQL := fmt.Sprintf(`SELECT COUNT(*) AS count FROM alert_rule
+			INNER JOIN %[1]s ON %[1]s.uid = alert_rule.updated_by
+			INNER JOIN team_member ON team_member.user_id = %[1]s.id
+			WHERE alert_rule.org_id = ? AND team_member.team_id = ?`, userTable)
+		if _, err := sess.SQL(rawSQL, orgID, teamID).Get(&r); err != nil {
+			return err
+		}
+		return nil
+	})
+	return r.Count, err
+}
+
 func (st DBstore) GetRuleGroupInterval(ctx context.Context, orgID int64, namespaceUID string, ruleGroup string) (int64, error) {
 	var interval int64 = 0
 	return interval, st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
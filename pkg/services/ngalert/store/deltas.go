@@ -49,6 +49,16 @@ func (c *GroupDelta) IsEmpty() bool {
 	return len(c.Update)+len(c.New)+len(c.Delete) == 0
 }
 
+// HasMovedRules returns true if some of the updated rules are moved to the group from another folder.
+func (c *GroupDelta) HasMovedRules() bool {
+	for _, update := range c.Update {
+		if update.Existing.NamespaceUID != update.New.NamespaceUID {
+			return true
+		}
+	}
+	return false
+}
+
 // NewOrUpdatedNotificationSettings returns a list of notification settings that are either new or updated in the group.
 func (c *GroupDelta) NewOrUpdatedNotificationSettings() []models.NotificationSettings {
 	var settings []models.NotificationSettings
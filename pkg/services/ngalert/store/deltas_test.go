@@ -623,6 +623,7 @@ func TestCalculateRuleUpdate(t *testing.T) {
 
 		require.Contains(t, delta.AffectedGroups, delta.GroupKey)
 		assert.Equal(t, models.RulesGroup(groupRules), delta.AffectedGroups[delta.GroupKey])
+		assert.False(t, delta.HasMovedRules())
 	})
 
 	t.Run("when a rule is moved between groups", func(t *testing.T) {
@@ -652,6 +653,7 @@ func TestCalculateRuleUpdate(t *testing.T) {
 		assert.Equal(t, models.RulesGroup(groupRules), delta.AffectedGroups[sourceGroupKey])
 		require.Contains(t, delta.AffectedGroups, targetGroupKey)
 		assert.Equal(t, models.RulesGroup(targetGroup), delta.AffectedGroups[targetGroupKey])
+		assert.Equal(t, sourceGroupKey.NamespaceUID != targetGroupKey.NamespaceUID, delta.HasMovedRules())
 	})
 
 	t.Run("when an alert rule query is updated", func(t *testing.T) {
@@ -0,0 +1,158 @@
+package ngalert
+
+import (
+	"context"
+	"encoding/json"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/supportbundles"
+)
+
+type alertingSupportInfo struct {
+	Rules                  alertRulesSupportInfo     `json:"rules"`
+	SchedulerBehindSeconds float64                   `json:"scheduler_behind_seconds"`
+	Alertmanagers          []alertmanagerSupportInfo `json:"alertmanagers"`
+}
+
+type alertRulesSupportInfo struct {
+	Total  int           `json:"total"`
+	Paused int           `json:"paused"`
+	ByOrg  map[int64]int `json:"by_org"`
+	// ByState counts rules by the most severe state of their instances
+	ByState          map[string]int `json:"by_state"`
+	InstancesByState map[string]int `json:"instances_by_state"`
+}
+
+type alertmanagerSupportInfo struct {
+	OrgID                int64                 `json:"org_id"`
+	ConfigurationHash    string                `json:"configuration_hash"`
+	ConfigurationVersion string                `json:"configuration_version"`
+	Default              bool                  `json:"default"`
+	CreatedAt            int64                 `json:"created_at"`
+	Receivers            []receiverSupportInfo `json:"receivers,omitempty"`
+	Error                string                `json:"error,omitempty"`
+}
+
+// receiverSupportInfo only holds the integration types of a receiver, its settings may contain secrets
+type receiverSupportInfo struct {
+	Name         string   `json:"name"`
+	Integrations []string `json:"integrations"`
+}
+
+// stateSeverity orders instance states to pick the state a rule is counted in
+var stateSeverity = map[eval.State]int{
+	eval.Normal:     0,
+	eval.NoData:     1,
+	eval.Error:      2,
+	eval.Recovering: 3,
+	eval.Pending:    4,
+	eval.Alerting:   5,
+}
+
+func (ng *AlertNG) supportBundleCollector() supportbundles.Collector {
+	return supportbundles.Collector{
+		UID:               "alerting",
+		DisplayName:       "Alerting information",
+		Description:       "Alert rule states, scheduler lag and Alertmanager configurations with redacted receivers",
+		IncludedByDefault: false,
+		Default:           true,
+		Fn: func(ctx context.Context) (*supportbundles.SupportItem, error) {
+			info := alertingSupportInfo{}
+
+			rules, err := ng.collectRuleSupportInfo(ctx)
+			if err != nil {
+				return nil, err
+			}
+			info.Rules = rules
+
+			behind := &dto.Metric{}
+			if err := ng.Metrics.GetSchedulerMetrics().BehindSeconds.Write(behind); err == nil {
+				info.SchedulerBehindSeconds = behind.GetGauge().GetValue()
+			}
+
+			configs, err := ng.store.GetAllLatestAlertmanagerConfiguration(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, cfg := range configs {
+				info.Alertmanagers = append(info.Alertmanagers, alertmanagerSupportInfoFromConfig(cfg))
+			}
+
+			data, err := json.MarshalIndent(info, "", " ")
+			if err != nil {
+				return nil, err
+			}
+
+			return &supportbundles.SupportItem{
+				Filename:  "alerting.json",
+				FileBytes: data,
+			}, nil
+		},
+	}
+}
+
+func (ng *AlertNG) collectRuleSupportInfo(ctx context.Context) (alertRulesSupportInfo, error) {
+	info := alertRulesSupportInfo{
+		ByOrg:            map[int64]int{},
+		ByState:          map[string]int{},
+		InstancesByState: map[string]int{},
+	}
+
+	// a negative org ID lists the rules of all organizations
+	rules, err := ng.store.ListAlertRules(ctx, &ngmodels.ListAlertRulesQuery{OrgID: -1})
+	if err != nil {
+		return info, err
+	}
+
+	for _, rule := range rules {
+		info.Total++
+		info.ByOrg[rule.OrgID]++
+		if rule.IsPaused {
+			info.Paused++
+		}
+
+		ruleState := eval.Normal
+		for _, s := range ng.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID) {
+			info.InstancesByState[s.State.String()]++
+			if stateSeverity[s.State] > stateSeverity[ruleState] {
+				ruleState = s.State
+			}
+		}
+		info.ByState[ruleState.String()]++
+	}
+
+	return info, nil
+}
+
+func alertmanagerSupportInfoFromConfig(cfg *ngmodels.AlertConfiguration) alertmanagerSupportInfo {
+	info := alertmanagerSupportInfo{
+		OrgID:                cfg.OrgID,
+		ConfigurationHash:    cfg.ConfigurationHash,
+		ConfigurationVersion: cfg.ConfigurationVersion,
+		Default:              cfg.Default,
+		CreatedAt:            cfg.CreatedAt,
+	}
+
+	amConfig, err := notifier.Load([]byte(cfg.AlertmanagerConfiguration))
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+
+	for _, receiver := range amConfig.AlertmanagerConfig.Receivers {
+		integrations := make([]string, 0, len(receiver.GrafanaManagedReceivers))
+		for _, integration := range receiver.GrafanaManagedReceivers {
+			integrations = append(integrations, integration.Type)
+		}
+		info.Receivers = append(info.Receivers, receiverSupportInfo{
+			Name:         receiver.Name,
+			Integrations: integrations,
+		})
+	}
+
+	return info
+}
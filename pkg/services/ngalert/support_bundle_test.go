@@ -0,0 +1,43 @@
+package ngalert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestAlertmanagerSupportInfoFromConfig(t *testing.T) {
+	t.Run("should list receiver integrations without settings", func(t *testing.T) {
+		cfg := &ngmodels.AlertConfiguration{
+			OrgID:             2,
+			ConfigurationHash: "abc",
+			AlertmanagerConfiguration: `{
+				"alertmanager_config": {
+					"route": {"receiver": "ops"},
+					"receivers": [{
+						"name": "ops",
+						"grafana_managed_receiver_configs": [
+							{"uid": "a", "name": "ops", "type": "slack", "settings": {"recipient": "#ops"}, "secureSettings": {"token": "secret-token"}},
+							{"uid": "b", "name": "ops", "type": "email", "settings": {"addresses": "ops@example.com"}}
+						]
+					}]
+				}
+			}`,
+		}
+
+		info := alertmanagerSupportInfoFromConfig(cfg)
+		require.Empty(t, info.Error)
+		assert.Equal(t, int64(2), info.OrgID)
+		assert.Equal(t, "abc", info.ConfigurationHash)
+		assert.Equal(t, []receiverSupportInfo{{Name: "ops", Integrations: []string{"slack", "email"}}}, info.Receivers)
+	})
+
+	t.Run("should report configurations that fail to load", func(t *testing.T) {
+		info := alertmanagerSupportInfoFromConfig(&ngmodels.AlertConfiguration{OrgID: 1, AlertmanagerConfiguration: "{"})
+		assert.NotEmpty(t, info.Error)
+		assert.Empty(t, info.Receivers)
+	})
+}
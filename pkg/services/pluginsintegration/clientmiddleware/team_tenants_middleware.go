@@ -0,0 +1,140 @@
+package clientmiddleware
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+// NewTeamTenantsMiddleware creates a new backend.HandlerMiddleware that will
+// set the tenant header of the data sources with team tenants, from the teams
+// of the signed in user, on outgoing backend.Handler requests.
+func NewTeamTenantsMiddleware() backend.HandlerMiddleware {
+	return backend.HandlerMiddlewareFunc(func(next backend.Handler) backend.Handler {
+		return &TeamTenantsMiddleware{
+			log:         log.New("team_tenants_middleware"),
+			BaseHandler: backend.NewBaseHandler(next),
+		}
+	})
+}
+
+type TeamTenantsMiddleware struct {
+	log log.Logger
+
+	backend.BaseHandler
+}
+
+func (m *TeamTenantsMiddleware) applyTenant(ctx context.Context, pCtx backend.PluginContext, req backend.ForwardHTTPHeaders) error {
+	if req == nil || pCtx.DataSourceInstanceSettings == nil {
+		return nil
+	}
+
+	teamTenants, err := datasources.ParseTeamTenants(pCtx.DataSourceInstanceSettings.JSONData)
+	if err != nil {
+		m.log.Error("Failed to parse team tenants", "datasource", pCtx.DataSourceInstanceSettings.UID, "error", err)
+		return err
+	}
+	if teamTenants == nil {
+		return nil
+	}
+
+	var teams []int64
+	if reqCtx := contexthandler.FromContext(ctx); reqCtx != nil && reqCtx.SignedInUser != nil {
+		teams = reqCtx.Teams
+	} else if requester, err := identity.GetRequester(ctx); err == nil {
+		teams = requester.GetTeams()
+	}
+
+	tenant, ok := teamTenants.HeaderValue(teams)
+	if !ok {
+		return datasources.ErrDataSourceNoTenant
+	}
+
+	// the tenants of the teams replace any tenant sent by the client
+	req.DeleteHTTPHeader(teamTenants.Header)
+	req.SetHTTPHeader(teamTenants.Header, tenant)
+	return nil
+}
+
+func (m *TeamTenantsMiddleware) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	if req == nil {
+		return m.BaseHandler.QueryData(ctx, req)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.BaseHandler.QueryData(ctx, req)
+}
+
+func (m *TeamTenantsMiddleware) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	if req == nil {
+		return m.BaseHandler.CallResource(ctx, req, sender)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return err
+	}
+
+	return m.BaseHandler.CallResource(ctx, req, sender)
+}
+
+func (m *TeamTenantsMiddleware) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	if req == nil {
+		return m.BaseHandler.CheckHealth(ctx, req)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.BaseHandler.CheckHealth(ctx, req)
+}
+
+func (m *TeamTenantsMiddleware) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	if req == nil {
+		return m.BaseHandler.SubscribeStream(ctx, req)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.BaseHandler.SubscribeStream(ctx, req)
+}
+
+func (m *TeamTenantsMiddleware) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	if req == nil {
+		return m.BaseHandler.PublishStream(ctx, req)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.BaseHandler.PublishStream(ctx, req)
+}
+
+func (m *TeamTenantsMiddleware) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	if req == nil {
+		return m.BaseHandler.RunStream(ctx, req, sender)
+	}
+
+	err := m.applyTenant(ctx, req.PluginContext, req)
+	if err != nil {
+		return err
+	}
+
+	return m.BaseHandler.RunStream(ctx, req, sender)
+}
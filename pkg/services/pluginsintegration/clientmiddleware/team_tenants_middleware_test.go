@@ -0,0 +1,91 @@
+package clientmiddleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/handlertest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestTeamTenantsMiddleware(t *testing.T) {
+	pluginContext := backend.PluginContext{
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+			JSONData: []byte(`{"teamTenants": {"tenants": {"1": ["team-a"], "2": ["team-b"]}}}`),
+		},
+	}
+	signedIn := func(teams ...int64) context.Context {
+		return context.WithValue(context.Background(), ctxkey.Key{}, &contextmodel.ReqContext{
+			Context:      &web.Context{Req: &http.Request{}},
+			SignedInUser: &user.SignedInUser{Teams: teams},
+		})
+	}
+
+	t.Run("Should set the tenants of the teams for QueryData", func(t *testing.T) {
+		cdt := handlertest.NewHandlerMiddlewareTest(t, handlertest.WithMiddlewares(NewTeamTenantsMiddleware()))
+		req := &backend.QueryDataRequest{PluginContext: pluginContext}
+		// a tenant sent by the client is replaced
+		req.SetHTTPHeader(datasources.DefaultTenantHeader, "team-c")
+
+		_, err := cdt.MiddlewareHandler.QueryData(signedIn(2, 1), req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"team-a|team-b"}, cdt.QueryDataReq.GetHTTPHeaders().Values(datasources.DefaultTenantHeader))
+	})
+
+	t.Run("Should set the tenants of the teams for CallResource", func(t *testing.T) {
+		cdt := handlertest.NewHandlerMiddlewareTest(t, handlertest.WithMiddlewares(NewTeamTenantsMiddleware()))
+
+		err := cdt.MiddlewareHandler.CallResource(signedIn(1), &backend.CallResourceRequest{
+			PluginContext: pluginContext,
+		}, nopCallResourceSender)
+		require.NoError(t, err)
+		require.Equal(t, "team-a", cdt.CallResourceReq.GetHTTPHeader(datasources.DefaultTenantHeader))
+	})
+
+	t.Run("Should set the tenants of the requester for RunStream", func(t *testing.T) {
+		cdt := handlertest.NewHandlerMiddlewareTest(t, handlertest.WithMiddlewares(NewTeamTenantsMiddleware()))
+		ctx := identity.WithRequester(context.Background(), &user.SignedInUser{Teams: []int64{2}})
+
+		err := cdt.MiddlewareHandler.RunStream(ctx, &backend.RunStreamRequest{
+			PluginContext: pluginContext,
+		}, &backend.StreamSender{})
+		require.NoError(t, err)
+		require.Equal(t, "team-b", cdt.RunStreamReq.GetHTTPHeader(datasources.DefaultTenantHeader))
+	})
+
+	t.Run("Should reject the requests of users without tenant", func(t *testing.T) {
+		cdt := handlertest.NewHandlerMiddlewareTest(t, handlertest.WithMiddlewares(NewTeamTenantsMiddleware()))
+
+		_, err := cdt.MiddlewareHandler.QueryData(signedIn(3), &backend.QueryDataRequest{
+			PluginContext: pluginContext,
+		})
+		require.ErrorIs(t, err, datasources.ErrDataSourceNoTenant)
+		require.Nil(t, cdt.QueryDataReq)
+
+		_, err = cdt.MiddlewareHandler.QueryData(context.Background(), &backend.QueryDataRequest{
+			PluginContext: pluginContext,
+		})
+		require.ErrorIs(t, err, datasources.ErrDataSourceNoTenant)
+	})
+
+	t.Run("Should not set the tenant for data sources without team tenants", func(t *testing.T) {
+		cdt := handlertest.NewHandlerMiddlewareTest(t, handlertest.WithMiddlewares(NewTeamTenantsMiddleware()))
+
+		_, err := cdt.MiddlewareHandler.QueryData(signedIn(1), &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{JSONData: []byte(`{}`)},
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, cdt.QueryDataReq.GetHTTPHeaders())
+	})
+}
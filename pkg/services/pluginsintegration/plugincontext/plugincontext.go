@@ -153,7 +153,7 @@ func (p *Provider) appInstanceSettings(ctx context.Context, pluginID string, org
 }
 
 func (p *Provider) InvalidateSettingsCache(_ context.Context, pluginID string) {
-	p.cacheService.Delete(getCacheKey(pluginID))
+	p.cacheService.Invalidate(getCacheKey(pluginID))
 }
 
 func (p *Provider) getCachedPluginSettings(ctx context.Context, pluginID string, orgID int64) (*pluginsettings.DTO, error) {
@@ -33,6 +33,8 @@ var (
 
 	oneDatasourceWithTwoCorrelations   = "testdata/one-datasource-two-correlations"
 	correlationsDifferentOrganizations = "testdata/correlations-different-organizations"
+	correlationsTransformations        = "testdata/correlations-transformations"
+	correlationsInvalidTransformations = "testdata/correlations-invalid-transformations"
 )
 
 func TestDatasourceAsConfig(t *testing.T) {
@@ -367,6 +369,34 @@ func TestDatasourceAsConfig(t *testing.T) {
 			require.Equal(t, int64(2), correlationsStore.deletedBySourceUID[1].OrgId)
 			require.Equal(t, int64(3), correlationsStore.deletedBySourceUID[2].OrgId)
 		})
+
+		t.Run("Creates correlations with jsonpath and template transformations", func(t *testing.T) {
+			store := &spyStore{}
+			orgFake := &orgtest.FakeOrgService{}
+			correlationsStore := &mockCorrelationsStore{}
+			dc := newDatasourceProvisioner(logger, store, correlationsStore, orgFake)
+			err := dc.applyChanges(context.Background(), correlationsTransformations)
+			if err != nil {
+				t.Fatalf("applyChanges return an error %v", err)
+			}
+
+			require.Equal(t, 1, len(correlationsStore.created))
+			require.Equal(t, correlations.Transformations{
+				{Type: "jsonpath", Expression: "$.resource['service.name']", MapValue: "service"},
+				{Type: "jsonpath", Expression: "$.resource.namespace", MapValue: "namespace"},
+				{Type: "template", Expression: "${namespace}/${service}", MapValue: "target"},
+			}, correlationsStore.created[0].Config.Transformations)
+		})
+
+		t.Run("Rejects templates using variables that are not extracted", func(t *testing.T) {
+			store := &spyStore{}
+			orgFake := &orgtest.FakeOrgService{}
+			correlationsStore := &mockCorrelationsStore{}
+			dc := newDatasourceProvisioner(logger, store, correlationsStore, orgFake)
+			err := dc.applyChanges(context.Background(), correlationsInvalidTransformations)
+			require.ErrorContains(t, err, correlations.ErrTransformationTemplateUnknown.Error())
+			require.Equal(t, 0, len(correlationsStore.created))
+		})
 	})
 }
 
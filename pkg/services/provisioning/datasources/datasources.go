@@ -113,7 +113,7 @@ func (dc *DatasourceProvisioner) provisionCorrelations(ctx context.Context, cfg
 		for _, correlation := range ds.Correlations {
 			createCorrelationCmd, err := makeCreateCorrelationCommand(correlation, dataSource.UID, dataSource.OrgID)
 			if err != nil {
-				dc.log.Error("failed to parse correlation", "correlation", correlation)
+				dc.log.Error("failed to parse correlation", "correlation", correlation, "error", err)
 				return err
 			}
 			// "Provisioned" column was introduced in #71110. Any records that were created before this change
@@ -99,6 +99,11 @@ func (api *Api) RegisterAPIEndpoints() {
 	api.routeRegister.Delete("/api/dashboards/uid/:dashboardUid/public-dashboards/:uid",
 		auth(accesscontrol.EvalPermission(dashboards.ActionDashboardsPublicWrite, uidScope)),
 		routing.Wrap(api.DeletePublicDashboard))
+
+	// Get public dashboard access analytics
+	api.routeRegister.Get("/api/dashboards/uid/:dashboardUid/public-dashboards/:uid/analytics",
+		auth(accesscontrol.EvalPermission(dashboards.ActionDashboardsRead, uidScope)),
+		routing.Wrap(api.GetPublicDashboardAnalytics))
 }
 
 // swagger:route GET /dashboards/public-dashboards dashboards dashboard_public listPublicDashboards
@@ -296,6 +301,36 @@ func (api *Api) DeletePublicDashboard(c *contextmodel.ReqContext) response.Respo
 	return response.Empty(http.StatusOK)
 }
 
+// swagger:route GET /dashboards/uid/{dashboardUid}/public-dashboards/{uid}/analytics dashboards dashboard_public getPublicDashboardAnalytics
+//
+//	Get request counters and query costs of a public dashboard access token
+//
+// Responses:
+// 200: getPublicDashboardAnalyticsResponse
+// 400: badRequestPublicError
+// 401: unauthorisedPublicError
+// 403: forbiddenPublicError
+// 404: notFoundPublicError
+// 500: internalServerPublicError
+func (api *Api) GetPublicDashboardAnalytics(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	if !validation.IsValidShortUID(uid) {
+		return response.Err(ErrInvalidUid.Errorf("GetPublicDashboardAnalytics: invalid Uid %s", uid))
+	}
+
+	dashboardUid := web.Params(c.Req)[":dashboardUid"]
+	if !validation.IsValidShortUID(dashboardUid) {
+		return response.Err(ErrInvalidUid.Errorf("GetPublicDashboardAnalytics: invalid dashboard Uid %s", dashboardUid))
+	}
+
+	stats, err := api.PublicDashboardService.FindAccessStats(c.Req.Context(), uid, dashboardUid)
+	if err != nil {
+		return response.Err(err)
+	}
+
+	return response.JSON(http.StatusOK, stats)
+}
+
 // Copied from pkg/api/metrics.go
 func toJsonStreamingResponse(ctx context.Context, features featuremgmt.FeatureToggles, qdr *backend.QueryDataResponse) response.Response {
 	statusCode := http.StatusOK
@@ -371,3 +406,19 @@ type DeletePublicDashboardParams struct {
 	// required:true
 	Uid string `json:"uid"`
 }
+
+// swagger:parameters getPublicDashboardAnalytics
+type GetPublicDashboardAnalyticsParams struct {
+	// in:path
+	// required:true
+	DashboardUid string `json:"dashboardUid"`
+	// in:path
+	// required:true
+	Uid string `json:"uid"`
+}
+
+// swagger:response getPublicDashboardAnalyticsResponse
+type GetPublicDashboardAnalyticsResponse struct {
+	// in: body
+	Body AccessTokenStats `json:"body"`
+}
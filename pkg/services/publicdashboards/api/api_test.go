@@ -615,3 +615,80 @@ func TestAPIUpdatePublicDashboard(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIGetPublicDashboardAnalytics(t *testing.T) {
+	dashboardUid := "abc1234"
+	publicDashboardUid := "1234asdfasdf"
+	userViewerAnotherDashboard := &user.SignedInUser{UserID: 4, OrgID: 1, OrgRole: org.RoleViewer, Login: "testViewerUser", Permissions: map[int64]map[string][]string{1: {dashboards.ActionDashboardsRead: {"dashboards:uid:another-uid"}}}}
+	userViewerDashboard := &user.SignedInUser{UserID: 4, OrgID: 1, OrgRole: org.RoleViewer, Login: "testViewerUser", Permissions: map[int64]map[string][]string{1: {dashboards.ActionDashboardsRead: {fmt.Sprintf("dashboards:uid:%s", dashboardUid)}}}}
+	stats := &AccessTokenStats{AccessToken: "an-access-token", Views: 3, Queries: 10, CachedQueries: 4, RateLimitedQueries: 1}
+
+	testCases := []struct {
+		Name                 string
+		User                 *user.SignedInUser
+		PublicDashboardUid   string
+		ResponseErr          error
+		ExpectedHttpResponse int
+		ShouldCallService    bool
+	}{
+		{
+			Name:                 "User without dashboard access cannot read analytics",
+			User:                 userViewerAnotherDashboard,
+			PublicDashboardUid:   publicDashboardUid,
+			ExpectedHttpResponse: http.StatusForbidden,
+		},
+		{
+			Name:                 "User with dashboard access can read analytics",
+			User:                 userViewerDashboard,
+			PublicDashboardUid:   publicDashboardUid,
+			ExpectedHttpResponse: http.StatusOK,
+			ShouldCallService:    true,
+		},
+		{
+			Name:                 "Invalid publicDashboardUid returns an error",
+			User:                 userViewerDashboard,
+			PublicDashboardUid:   "inv@lid-publicd@shboard-uid!",
+			ExpectedHttpResponse: http.StatusBadRequest,
+		},
+		{
+			Name:                 "Public dashboard not found",
+			User:                 userViewerDashboard,
+			PublicDashboardUid:   publicDashboardUid,
+			ResponseErr:          ErrPublicDashboardNotFound.Errorf(""),
+			ExpectedHttpResponse: http.StatusNotFound,
+			ShouldCallService:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			service := publicdashboards.NewFakePublicDashboardService(t)
+
+			if test.ShouldCallService {
+				var res *AccessTokenStats
+				if test.ResponseErr == nil {
+					res = stats
+				}
+				service.On("FindAccessStats", mock.Anything, test.PublicDashboardUid, dashboardUid).
+					Return(res, test.ResponseErr)
+			}
+
+			testServer := setupTestServer(t, nil, service, test.User)
+
+			response := callAPI(testServer, http.MethodGet, fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards/%s/analytics", dashboardUid, test.PublicDashboardUid), nil, t)
+			assert.Equal(t, test.ExpectedHttpResponse, response.Code)
+
+			if test.ExpectedHttpResponse == http.StatusOK {
+				var got AccessTokenStats
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &got))
+				assert.Equal(t, stats.Queries, got.Queries)
+				assert.Equal(t, stats.CachedQueries, got.CachedQueries)
+				assert.Equal(t, stats.RateLimitedQueries, got.RateLimitedQueries)
+			}
+
+			if !test.ShouldCallService {
+				service.AssertNotCalled(t, "FindAccessStats")
+			}
+		})
+	}
+}
@@ -23,6 +23,10 @@ var (
 	ErrDashboardIsPublic                   = errutil.BadRequest("publicdashboards.dashboardIsPublic", errutil.WithPublicMessage("Dashboard is already public"))
 	ErrPublicDashboardUidExists            = errutil.BadRequest("publicdashboards.uidExists", errutil.WithPublicMessage("Dashboard Uid already exists"))
 	ErrPublicDashboardAccessTokenExists    = errutil.BadRequest("publicdashboards.accessTokenExists", errutil.WithPublicMessage("Dashboard Access Token already exists"))
+	ErrInvalidRateLimit                    = errutil.BadRequest("publicdashboards.invalidRateLimit", errutil.WithPublicMessage("rateLimitPerMinute should not be negative"))
+	ErrInvalidQueryCacheTTL                = errutil.BadRequest("publicdashboards.invalidQueryCacheTtl", errutil.WithPublicMessage("queryCacheTtlSeconds should not be negative"))
+
+	ErrRateLimited = errutil.TooManyRequests("publicdashboards.rateLimited", errutil.WithPublicMessage("Too many requests, try again later"))
 
 	ErrPublicDashboardNotEnabled = errutil.Forbidden("publicdashboards.notEnabled", errutil.WithPublicMessage("Dashboard paused"))
 )
@@ -54,6 +54,10 @@ type PublicDashboard struct {
 	AnnotationsEnabled   bool          `json:"annotationsEnabled" xorm:"annotations_enabled"`
 	Share                ShareType     `json:"share" xorm:"share"`
 	Recipients           []EmailDTO    `json:"recipients,omitempty" xorm:"-"`
+	// RateLimitPerMinute caps the number of uncached queries per minute, 0 means unlimited
+	RateLimitPerMinute int64 `json:"rateLimitPerMinute" xorm:"rate_limit_per_minute"`
+	// QueryCacheTTLSeconds is how long query results are served from cache, 0 disables caching
+	QueryCacheTTLSeconds int64 `json:"queryCacheTtlSeconds" xorm:"query_cache_ttl_seconds"`
 }
 
 type PublicDashboardDTO struct {
@@ -63,6 +67,24 @@ type PublicDashboardDTO struct {
 	IsEnabled            *bool     `json:"isEnabled"`
 	AnnotationsEnabled   *bool     `json:"annotationsEnabled"`
 	Share                ShareType `json:"share"`
+	RateLimitPerMinute   *int64    `json:"rateLimitPerMinute"`
+	QueryCacheTTLSeconds *int64    `json:"queryCacheTtlSeconds"`
+}
+
+// AccessTokenStats are the request counters of a public dashboard access token since the server started
+type AccessTokenStats struct {
+	AccessToken        string    `json:"accessToken"`
+	Views              int64     `json:"views"`
+	Queries            int64     `json:"queries"`
+	CachedQueries      int64     `json:"cachedQueries"`
+	RateLimitedQueries int64     `json:"rateLimitedQueries"`
+	FailedQueries      int64     `json:"failedQueries"`
+	AnnotationRequests int64     `json:"annotationRequests"`
+	QueryDurationMs    int64     `json:"queryDurationMs"`
+	ResponseFrames     int64     `json:"responseFrames"`
+	ResponseRows       int64     `json:"responseRows"`
+	LastAccessedAt     time.Time `json:"lastAccessedAt,omitempty"`
+	Since              time.Time `json:"since"`
 }
 
 type EmailDTO struct {
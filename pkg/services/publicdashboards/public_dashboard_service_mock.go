@@ -157,6 +157,36 @@ func (_m *FakePublicDashboardService) Find(ctx context.Context, uid string) (*mo
 	return r0, r1
 }
 
+// FindAccessStats provides a mock function with given fields: ctx, uid, dashboardUid
+func (_m *FakePublicDashboardService) FindAccessStats(ctx context.Context, uid string, dashboardUid string) (*models.AccessTokenStats, error) {
+	ret := _m.Called(ctx, uid, dashboardUid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAccessStats")
+	}
+
+	var r0 *models.AccessTokenStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AccessTokenStats, error)); ok {
+		return rf(ctx, uid, dashboardUid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AccessTokenStats); ok {
+		r0 = rf(ctx, uid, dashboardUid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessTokenStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, dashboardUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindAllWithPagination provides a mock function with given fields: ctx, query
 func (_m *FakePublicDashboardService) FindAllWithPagination(ctx context.Context, query *models.PublicDashboardListQuery) (*models.PublicDashboardListResponseWithPagination, error) {
 	ret := _m.Called(ctx, query)
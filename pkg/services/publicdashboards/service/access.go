@@ -0,0 +1,269 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+// queryCacheSize is the maximum number of query results cached for all the public dashboards.
+// The least recently used results are evicted first.
+const queryCacheSize = 1000
+
+// accessTracker keeps per access token request counters, rate limiters and the query result cache.
+// Counters are kept in memory and are reset when the server restarts, the same requests are counted
+// by the Prometheus metrics of the tracker, which are aggregated across all access tokens.
+type accessTracker struct {
+	mu       sync.Mutex
+	started  time.Time
+	stats    map[string]*models.AccessTokenStats
+	limiters map[string]*tokenLimiter
+	cache    *lru.Cache[string, cachedQueryResponse]
+	metrics  *accessMetrics
+	now      func() time.Time
+}
+
+type cachedQueryResponse struct {
+	res     *backend.QueryDataResponse
+	expires time.Time
+}
+
+// accessMetrics are not labeled by access token, as the token is what grants access to the public dashboard
+type accessMetrics struct {
+	views              prometheus.Counter
+	annotationRequests prometheus.Counter
+	queries            *prometheus.CounterVec
+	queryDuration      prometheus.Histogram
+}
+
+func newAccessMetrics() *accessMetrics {
+	return &accessMetrics{
+		views: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "grafana",
+			Name:      "public_dashboards_views_total",
+			Help:      "Total number of public dashboard views",
+		}),
+		annotationRequests: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "grafana",
+			Name:      "public_dashboards_annotation_requests_total",
+			Help:      "Total number of public dashboard annotation requests",
+		}),
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "grafana",
+			Name:      "public_dashboards_queries_total",
+			Help:      "Total number of public dashboard queries by result",
+		}, []string{"result"}),
+		queryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "grafana",
+			Name:      "public_dashboards_query_duration_seconds",
+			Help:      "Duration of the public dashboard queries sent to data sources",
+			Buckets:   prometheus.DefBuckets,
+		}),
+	}
+}
+
+// register adds the metrics to the registerer, or uses the ones already registered by another tracker
+func (m *accessMetrics) register(reg prometheus.Registerer) {
+	m.views = registerOrExisting(reg, m.views)
+	m.annotationRequests = registerOrExisting(reg, m.annotationRequests)
+	m.queries = registerOrExisting(reg, m.queries)
+	m.queryDuration = registerOrExisting(reg, m.queryDuration)
+}
+
+func registerOrExisting[T prometheus.Collector](reg prometheus.Registerer, c T) T {
+	var alreadyRegisterErr prometheus.AlreadyRegisteredError
+	if err := reg.Register(c); errors.As(err, &alreadyRegisterErr) {
+		if existing, ok := alreadyRegisterErr.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	return c
+}
+
+type tokenLimiter struct {
+	perMinute int64
+	limiter   *rate.Limiter
+}
+
+func newAccessTracker() *accessTracker {
+	// the size is a valid constant, so the error can be ignored
+	cache, _ := lru.New[string, cachedQueryResponse](queryCacheSize)
+	return &accessTracker{
+		started:  time.Now(),
+		stats:    map[string]*models.AccessTokenStats{},
+		limiters: map[string]*tokenLimiter{},
+		cache:    cache,
+		metrics:  newAccessMetrics(),
+		now:      time.Now,
+	}
+}
+
+// stat returns the counters of the access token, it must be called with the lock held
+func (t *accessTracker) stat(accessToken string) *models.AccessTokenStats {
+	s, ok := t.stats[accessToken]
+	if !ok {
+		s = &models.AccessTokenStats{AccessToken: accessToken, Since: t.started}
+		t.stats[accessToken] = s
+	}
+	s.LastAccessedAt = t.now()
+	return s
+}
+
+func (t *accessTracker) recordView(accessToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stat(accessToken).Views++
+	t.metrics.views.Inc()
+}
+
+func (t *accessTracker) recordAnnotationRequest(accessToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stat(accessToken).AnnotationRequests++
+	t.metrics.annotationRequests.Inc()
+}
+
+func (t *accessTracker) recordCachedQuery(accessToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.stat(accessToken)
+	s.Queries++
+	s.CachedQueries++
+	t.metrics.queries.WithLabelValues("cached").Inc()
+}
+
+func (t *accessTracker) recordQuery(accessToken string, duration time.Duration, res *backend.QueryDataResponse, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.stat(accessToken)
+	s.Queries++
+	s.QueryDurationMs += duration.Milliseconds()
+	t.metrics.queryDuration.Observe(duration.Seconds())
+	if err != nil {
+		s.FailedQueries++
+		t.metrics.queries.WithLabelValues("failed").Inc()
+		return
+	}
+	t.metrics.queries.WithLabelValues("success").Inc()
+	if res == nil {
+		return
+	}
+	for _, r := range res.Responses {
+		if r.Error != nil {
+			s.FailedQueries++
+		}
+		for _, frame := range r.Frames {
+			s.ResponseFrames++
+			if frame == nil {
+				continue
+			}
+			rows, err := frame.RowLen()
+			if err == nil {
+				s.ResponseRows += int64(rows)
+			}
+		}
+	}
+}
+
+// allow reports whether a query for the access token is allowed by the rate limit of the public dashboard.
+// Limits are enforced per server instance.
+func (t *accessTracker) allow(pubdash *models.PublicDashboard) bool {
+	if pubdash.RateLimitPerMinute <= 0 {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limiters[pubdash.AccessToken]
+	if !ok || l.perMinute != pubdash.RateLimitPerMinute {
+		l = &tokenLimiter{
+			perMinute: pubdash.RateLimitPerMinute,
+			limiter:   rate.NewLimiter(rate.Limit(float64(pubdash.RateLimitPerMinute)/60), int(pubdash.RateLimitPerMinute)),
+		}
+		t.limiters[pubdash.AccessToken] = l
+	}
+
+	if l.limiter.AllowN(t.now(), 1) {
+		return true
+	}
+	t.stat(pubdash.AccessToken).RateLimitedQueries++
+	t.metrics.queries.WithLabelValues("rate_limited").Inc()
+	return false
+}
+
+// snapshot returns a copy of the counters of the access token
+func (t *accessTracker) snapshot(accessToken string) models.AccessTokenStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.stats[accessToken]; ok {
+		return *s
+	}
+	return models.AccessTokenStats{AccessToken: accessToken, Since: t.started}
+}
+
+func (t *accessTracker) getCachedResponse(key string) (*backend.QueryDataResponse, bool) {
+	v, ok := t.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if !t.now().Before(v.expires) {
+		t.cache.Remove(key)
+		return nil, false
+	}
+	return v.res, true
+}
+
+// cacheResponse caches the response unless a query failed, failures are not served from the cache
+func (t *accessTracker) cacheResponse(key string, res *backend.QueryDataResponse, ttl time.Duration) {
+	if !cacheableQueryResponse(res) {
+		return
+	}
+	t.cache.Add(key, cachedQueryResponse{res: res, expires: t.now().Add(ttl)})
+}
+
+func cacheableQueryResponse(res *backend.QueryDataResponse) bool {
+	for _, r := range res.Responses {
+		if r.Error != nil || r.Status >= backend.StatusBadRequest {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidate drops the cached results and the rate limiter of the access token
+func (t *accessTracker) invalidate(accessToken string) {
+	prefix := accessToken + ":"
+	for _, key := range t.cache.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			t.cache.Remove(key)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.limiters, accessToken)
+}
+
+// queryCacheKey identifies the result of a panel query for a given time range
+func queryCacheKey(accessToken string, panelId int64, metricReq dtos.MetricRequest) (string, error) {
+	b, err := json.Marshal(metricReq)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%s:%d:%s", accessToken, panelId, hex.EncodeToString(sum[:])), nil
+}
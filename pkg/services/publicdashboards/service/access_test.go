@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+func TestAccessTrackerRecordQuery(t *testing.T) {
+	tracker := newAccessTracker()
+
+	res := &backend.QueryDataResponse{Responses: backend.Responses{
+		"A": {Frames: data.Frames{data.NewFrame("a", data.NewField("v", nil, []int64{1, 2, 3}))}},
+		"B": {Error: errors.New("boom")},
+	}}
+	tracker.recordQuery("token", 20*time.Millisecond, res, nil)
+	tracker.recordQuery("token", 10*time.Millisecond, nil, errors.New("boom"))
+	tracker.recordView("token")
+	tracker.recordAnnotationRequest("token")
+
+	stats := tracker.snapshot("token")
+	assert.Equal(t, int64(2), stats.Queries)
+	assert.Equal(t, int64(2), stats.FailedQueries)
+	assert.Equal(t, int64(30), stats.QueryDurationMs)
+	assert.Equal(t, int64(1), stats.ResponseFrames)
+	assert.Equal(t, int64(3), stats.ResponseRows)
+	assert.Equal(t, int64(1), stats.Views)
+	assert.Equal(t, int64(1), stats.AnnotationRequests)
+	assert.False(t, stats.LastAccessedAt.IsZero())
+
+	assert.Equal(t, models.AccessTokenStats{AccessToken: "other", Since: tracker.started}, tracker.snapshot("other"))
+}
+
+func TestAccessTrackerAllow(t *testing.T) {
+	now := time.Now()
+	tracker := newAccessTracker()
+	tracker.now = func() time.Time { return now }
+
+	t.Run("allows everything without a limit", func(t *testing.T) {
+		pubdash := &models.PublicDashboard{AccessToken: "unlimited"}
+		for i := 0; i < 100; i++ {
+			require.True(t, tracker.allow(pubdash))
+		}
+	})
+
+	t.Run("refills the limit over time", func(t *testing.T) {
+		pubdash := &models.PublicDashboard{AccessToken: "limited", RateLimitPerMinute: 3}
+		for i := 0; i < 3; i++ {
+			require.True(t, tracker.allow(pubdash))
+		}
+		require.False(t, tracker.allow(pubdash))
+		assert.Equal(t, int64(1), tracker.snapshot("limited").RateLimitedQueries)
+
+		now = now.Add(20 * time.Second)
+		require.True(t, tracker.allow(pubdash))
+		require.False(t, tracker.allow(pubdash))
+	})
+
+	t.Run("recreates the limiter when the limit changes", func(t *testing.T) {
+		pubdash := &models.PublicDashboard{AccessToken: "changed", RateLimitPerMinute: 1}
+		require.True(t, tracker.allow(pubdash))
+		require.False(t, tracker.allow(pubdash))
+
+		pubdash.RateLimitPerMinute = 2
+		require.True(t, tracker.allow(pubdash))
+		require.True(t, tracker.allow(pubdash))
+		require.False(t, tracker.allow(pubdash))
+	})
+}
+
+func TestAccessTrackerCache(t *testing.T) {
+	tracker := newAccessTracker()
+	req := dtos.MetricRequest{From: "now-1h", To: "now", Queries: []*simplejson.Json{simplejson.NewFromAny(map[string]any{"refId": "A"})}}
+
+	key, err := queryCacheKey("token", 1, req)
+	require.NoError(t, err)
+	otherPanel, err := queryCacheKey("token", 2, req)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherPanel)
+
+	res := &backend.QueryDataResponse{}
+	tracker.cacheResponse(key, res, time.Minute)
+	got, ok := tracker.getCachedResponse(key)
+	require.True(t, ok)
+	assert.Same(t, res, got)
+
+	_, ok = tracker.getCachedResponse(otherPanel)
+	assert.False(t, ok)
+
+	tracker.invalidate("token")
+	_, ok = tracker.getCachedResponse(key)
+	assert.False(t, ok)
+}
+
+func TestAccessTrackerCacheFailures(t *testing.T) {
+	tracker := newAccessTracker()
+
+	for name, res := range map[string]backend.DataResponse{
+		"error":  {Error: errors.New("query failed")},
+		"status": {Status: backend.StatusBadRequest},
+	} {
+		key := "token:1:" + name
+		tracker.cacheResponse(key, &backend.QueryDataResponse{Responses: backend.Responses{"A": {}, "B": res}}, time.Minute)
+		_, ok := tracker.getCachedResponse(key)
+		assert.False(t, ok, name)
+	}
+
+	tracker.cacheResponse("token:1:ok", &backend.QueryDataResponse{Responses: backend.Responses{"A": {Status: backend.StatusOK}}}, time.Minute)
+	_, ok := tracker.getCachedResponse("token:1:ok")
+	assert.True(t, ok)
+}
+
+func TestAccessTrackerCacheBounds(t *testing.T) {
+	now := time.Now()
+	tracker := newAccessTracker()
+	tracker.now = func() time.Time { return now }
+
+	t.Run("expires results after the ttl", func(t *testing.T) {
+		tracker.cacheResponse("token:1:a", &backend.QueryDataResponse{}, time.Minute)
+		_, ok := tracker.getCachedResponse("token:1:a")
+		require.True(t, ok)
+
+		now = now.Add(time.Minute)
+		_, ok = tracker.getCachedResponse("token:1:a")
+		require.False(t, ok)
+	})
+
+	t.Run("evicts the least recently used results", func(t *testing.T) {
+		for i := 0; i <= queryCacheSize; i++ {
+			tracker.cacheResponse(fmt.Sprintf("token:%d:a", i), &backend.QueryDataResponse{}, time.Hour)
+		}
+		assert.Equal(t, queryCacheSize, tracker.cache.Len())
+		_, ok := tracker.getCachedResponse("token:0:a")
+		assert.False(t, ok)
+		_, ok = tracker.getCachedResponse(fmt.Sprintf("token:%d:a", queryCacheSize))
+		assert.True(t, ok)
+	})
+}
+
+func TestAccessTrackerMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	tracker := newAccessTracker()
+	tracker.metrics.register(reg)
+
+	tracker.recordView("token")
+	tracker.recordAnnotationRequest("token")
+	tracker.recordQuery("token", time.Millisecond, &backend.QueryDataResponse{}, nil)
+	tracker.recordQuery("token", time.Millisecond, nil, errors.New("boom"))
+	tracker.recordCachedQuery("token")
+	tracker.allow(&models.PublicDashboard{AccessToken: "token", RateLimitPerMinute: 1})
+	tracker.allow(&models.PublicDashboard{AccessToken: "token", RateLimitPerMinute: 1})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(tracker.metrics.views))
+	assert.Equal(t, 1.0, testutil.ToFloat64(tracker.metrics.annotationRequests))
+	for result, count := range map[string]float64{"success": 1, "failed": 1, "cached": 1, "rate_limited": 1} {
+		assert.Equal(t, count, testutil.ToFloat64(tracker.metrics.queries.WithLabelValues(result)), result)
+	}
+
+	// the metrics are shared with the trackers registered later
+	other := newAccessTracker()
+	other.metrics.register(reg)
+	other.recordView("token")
+	assert.Equal(t, 2.0, testutil.ToFloat64(tracker.metrics.views))
+}
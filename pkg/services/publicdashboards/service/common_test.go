@@ -57,5 +57,6 @@ func newPublicDashboardServiceImpl(
 		serviceWrapper:     serviceWrapper,
 		license:            license,
 		features:           featuremgmt.WithFeatures(),
+		access:             newAccessTracker(),
 	}, store, cfg
 }
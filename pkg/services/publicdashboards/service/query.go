@@ -25,6 +25,8 @@ func (pd *PublicDashboardServiceImpl) FindAnnotations(ctx context.Context, reqDT
 		return nil, err
 	}
 
+	pd.access.recordAnnotationRequest(pub.AccessToken)
+
 	if !pub.AnnotationsEnabled {
 		return []models.AnnotationEvent{}, nil
 	}
@@ -141,9 +143,28 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 		return nil, models.ErrPanelQueriesNotFound.Errorf("GetQueryDataResponse: failed to extract queries from panel")
 	}
 
+	// cached results are served without counting towards the rate limit
+	var cacheKey string
+	if publicDashboard.QueryCacheTTLSeconds > 0 {
+		cacheKey, err = queryCacheKey(accessToken, panelId, metricReq)
+		if err != nil {
+			return nil, models.ErrInternalServerError.Errorf("GetQueryDataResponse: failed to build query cache key: %w", err)
+		}
+		if res, ok := pd.access.getCachedResponse(cacheKey); ok {
+			pd.access.recordCachedQuery(accessToken)
+			return res, nil
+		}
+	}
+
+	if !pd.access.allow(publicDashboard) {
+		return nil, models.ErrRateLimited.Errorf("GetQueryDataResponse: rate limit of %d queries per minute exceeded", publicDashboard.RateLimitPerMinute)
+	}
+
 	// We don't have a signed in user for public dashboards. We are using Grafana's Identity to query the datasource.
 	svcCtx, svcIdent := identity.WithServiceIdentity(ctx, dashboard.OrgID)
+	start := time.Now()
 	res, err := pd.QueryDataService.QueryData(svcCtx, svcIdent, skipDSCache, metricReq)
+	pd.access.recordQuery(accessToken, time.Since(start), res, err)
 
 	reqDatasources := metricReq.GetUniqueDatasourceTypes()
 	if err != nil {
@@ -154,6 +175,10 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 
 	sanitizeMetadataFromQueryData(res)
 
+	if cacheKey != "" {
+		pd.access.cacheResponse(cacheKey, res, time.Duration(publicDashboard.QueryCacheTTLSeconds)*time.Second)
+	}
+
 	return res, nil
 }
 
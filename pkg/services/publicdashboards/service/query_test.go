@@ -1400,3 +1400,91 @@ func buildJsonDataWithTimeRange(from, to, timezone string) *simplejson.Json {
 		"timezone": timezone,
 	})
 }
+
+func TestIntegrationGetQueryDataResponseCacheAndRateLimit(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	fakeDashboardService := &dashboards.FakeDashboardService{}
+	service, sqlStore, _ := newPublicDashboardServiceImpl(t, nil, nil, nil, fakeDashboardService, nil)
+	fakeQueryService := &query.FakeQueryService{}
+	fakeQueryService.On("QueryData", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&backend.QueryDataResponse{}, nil)
+	service.QueryDataService = fakeQueryService
+
+	dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, service.cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore))
+	require.NoError(t, err)
+
+	customPanels := []interface{}{
+		map[string]interface{}{
+			"id": 1,
+			"datasource": map[string]interface{}{
+				"uid": "ds1",
+			},
+			"targets": []interface{}{map[string]interface{}{"refId": "A"}},
+		}}
+	dashboard := insertTestDashboard(t, dashboardStore, "testDashWithLimits", 1, 0, "", true, []map[string]interface{}{}, customPanels)
+	fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+
+	isEnabled := true
+	rateLimit := int64(2)
+	cacheTTL := int64(0)
+	pubdash, err := service.Create(context.Background(), SignedInUser, &SavePublicDashboardDTO{
+		DashboardUid: dashboard.UID,
+		UserId:       7,
+		OrgID:        dashboard.OrgID,
+		PublicDashboard: &PublicDashboardDTO{
+			IsEnabled:            &isEnabled,
+			RateLimitPerMinute:   &rateLimit,
+			QueryCacheTTLSeconds: &cacheTTL,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rateLimit, pubdash.RateLimitPerMinute)
+
+	reqDTO := PublicDashboardQueryDTO{IntervalMs: 1, MaxDataPoints: 1}
+
+	t.Run("rejects queries above the rate limit", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := service.GetQueryDataResponse(context.Background(), false, reqDTO, 1, pubdash.AccessToken)
+			require.NoError(t, err)
+		}
+		_, err := service.GetQueryDataResponse(context.Background(), false, reqDTO, 1, pubdash.AccessToken)
+		require.ErrorIs(t, err, ErrRateLimited)
+
+		stats, err := service.FindAccessStats(context.Background(), pubdash.Uid, dashboard.UID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), stats.Queries)
+		assert.Equal(t, int64(1), stats.RateLimitedQueries)
+	})
+
+	t.Run("serves cached results without hitting the rate limit", func(t *testing.T) {
+		cacheTTL := int64(60)
+		_, err := service.Update(context.Background(), SignedInUser, &SavePublicDashboardDTO{
+			Uid:          pubdash.Uid,
+			DashboardUid: dashboard.UID,
+			UserId:       7,
+			OrgID:        dashboard.OrgID,
+			PublicDashboard: &PublicDashboardDTO{
+				QueryCacheTTLSeconds: &cacheTTL,
+			},
+		})
+		require.NoError(t, err)
+
+		// updating the public dashboard resets its rate limiter
+		calls := len(fakeQueryService.Calls)
+		for i := 0; i < 5; i++ {
+			_, err := service.GetQueryDataResponse(context.Background(), false, reqDTO, 1, pubdash.AccessToken)
+			require.NoError(t, err)
+		}
+		assert.Len(t, fakeQueryService.Calls, calls+1)
+
+		stats, err := service.FindAccessStats(context.Background(), pubdash.Uid, dashboard.UID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), stats.Queries)
+		assert.Equal(t, int64(4), stats.CachedQueries)
+	})
+
+	t.Run("returns an error when the public dashboard belongs to another dashboard", func(t *testing.T) {
+		_, err := service.FindAccessStats(context.Background(), pubdash.Uid, "another-dashboard")
+		require.ErrorIs(t, err, ErrInvalidUid)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
@@ -47,6 +48,7 @@ type PublicDashboardServiceImpl struct {
 	serviceWrapper     publicdashboards.ServiceWrapper
 	dashboardService   dashboards.DashboardService
 	license            licensing.Licensing
+	access             *accessTracker
 }
 
 var LogPrefix = "publicdashboards.service"
@@ -68,7 +70,11 @@ func ProvideService(
 	serviceWrapper publicdashboards.ServiceWrapper,
 	dashboardService dashboards.DashboardService,
 	license licensing.Licensing,
+	registerer prometheus.Registerer,
 ) *PublicDashboardServiceImpl {
+	access := newAccessTracker()
+	access.metrics.register(registerer)
+
 	return &PublicDashboardServiceImpl{
 		log:                log.New(LogPrefix),
 		cfg:                cfg,
@@ -81,6 +87,7 @@ func ProvideService(
 		serviceWrapper:     serviceWrapper,
 		dashboardService:   dashboardService,
 		license:            license,
+		access:             access,
 	}
 }
 
@@ -93,6 +100,8 @@ func (pd *PublicDashboardServiceImpl) GetPublicDashboardForView(ctx context.Cont
 		return nil, err
 	}
 
+	pd.access.recordView(pubdash.AccessToken)
+
 	metrics.MFolderIDsServiceCount.WithLabelValues(metrics.PublicDashboards).Inc()
 	meta := dtos.DashboardMeta{
 		Slug:                   dash.Slug,
@@ -325,6 +334,9 @@ func (pd *PublicDashboardServiceImpl) Update(ctx context.Context, u *user.Signed
 		return nil, ErrInternalServerError.Errorf("Update: failed to find public dashboard by uid: %s: %w", existingPubdash.Uid, err)
 	}
 
+	// cached results may have been produced with the previous time settings
+	pd.access.invalidate(existingPubdash.AccessToken)
+
 	pd.logIsEnabledChanged(existingPubdash, newPubdash, u)
 
 	return newPubdash, nil
@@ -472,6 +484,28 @@ func (pd *PublicDashboardServiceImpl) Delete(ctx context.Context, uid string, da
 	return pd.serviceWrapper.Delete(ctx, uid)
 }
 
+// FindAccessStats returns the request counters of the access token of a public dashboard
+func (pd *PublicDashboardServiceImpl) FindAccessStats(ctx context.Context, uid string, dashboardUid string) (*AccessTokenStats, error) {
+	ctx, span := tracer.Start(ctx, "publicdashboards.FindAccessStats")
+	defer span.End()
+
+	pubdash, err := pd.store.Find(ctx, uid)
+	if err != nil {
+		return nil, ErrInternalServerError.Errorf("FindAccessStats: failed to find public dashboard by uid: %s: %w", uid, err)
+	}
+	if pubdash == nil {
+		return nil, ErrPublicDashboardNotFound.Errorf("FindAccessStats: public dashboard not found by uid: %s", uid)
+	}
+
+	// validate the public dashboard belongs to the dashboard
+	if pubdash.DashboardUid != dashboardUid {
+		return nil, ErrInvalidUid.Errorf("FindAccessStats: the public dashboard does not belong to the dashboard")
+	}
+
+	stats := pd.access.snapshot(pubdash.AccessToken)
+	return &stats, nil
+}
+
 // intervalMS and maxQueryData values are being calculated on the frontend for regular dashboards
 // we are doing the same for public dashboards but because this access would be public, we need a way to keep this
 // values inside reasonable bounds to avoid an attack that could hit data sources with a small interval and a big
@@ -566,6 +600,8 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 	isEnabled := returnValueOrDefault(dto.PublicDashboard.IsEnabled, false)
 	annotationsEnabled := returnValueOrDefault(dto.PublicDashboard.AnnotationsEnabled, false)
 	timeSelectionEnabled := returnValueOrDefault(dto.PublicDashboard.TimeSelectionEnabled, false)
+	rateLimitPerMinute := returnInt64OrDefault(dto.PublicDashboard.RateLimitPerMinute, 0)
+	queryCacheTTLSeconds := returnInt64OrDefault(dto.PublicDashboard.QueryCacheTTLSeconds, 0)
 
 	share := dto.PublicDashboard.Share
 	if dto.PublicDashboard.Share == "" {
@@ -583,6 +619,8 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         &TimeSettings{},
 		Share:                share,
+		RateLimitPerMinute:   rateLimitPerMinute,
+		QueryCacheTTLSeconds: queryCacheTTLSeconds,
 		CreatedBy:            dto.UserId,
 		CreatedAt:            now,
 		UpdatedBy:            dto.UserId,
@@ -596,6 +634,8 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 	timeSelectionEnabled := returnValueOrDefault(pubdashDTO.TimeSelectionEnabled, pd.TimeSelectionEnabled)
 	isEnabled := returnValueOrDefault(pubdashDTO.IsEnabled, pd.IsEnabled)
 	annotationsEnabled := returnValueOrDefault(pubdashDTO.AnnotationsEnabled, pd.AnnotationsEnabled)
+	rateLimitPerMinute := returnInt64OrDefault(pubdashDTO.RateLimitPerMinute, pd.RateLimitPerMinute)
+	queryCacheTTLSeconds := returnInt64OrDefault(pubdashDTO.QueryCacheTTLSeconds, pd.QueryCacheTTLSeconds)
 
 	share := pubdashDTO.Share
 	if pubdashDTO.Share == "" {
@@ -609,6 +649,8 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         pd.TimeSettings,
 		Share:                share,
+		RateLimitPerMinute:   rateLimitPerMinute,
+		QueryCacheTTLSeconds: queryCacheTTLSeconds,
 		UpdatedBy:            dto.UserId,
 		UpdatedAt:            time.Now(),
 	}
@@ -621,3 +663,11 @@ func returnValueOrDefault(value *bool, defaultValue bool) bool {
 
 	return defaultValue
 }
+
+func returnInt64OrDefault(value *int64, defaultValue int64) int64 {
+	if value != nil {
+		return *value
+	}
+
+	return defaultValue
+}
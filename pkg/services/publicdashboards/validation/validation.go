@@ -13,6 +13,14 @@ func ValidatePublicDashboard(dto *SavePublicDashboardDTO) error {
 		return ErrInvalidShareType.Errorf("ValidateSavePublicDashboard: invalid share type")
 	}
 
+	if dto.PublicDashboard.RateLimitPerMinute != nil && *dto.PublicDashboard.RateLimitPerMinute < 0 {
+		return ErrInvalidRateLimit.Errorf("ValidateSavePublicDashboard: rate limit should not be negative")
+	}
+
+	if dto.PublicDashboard.QueryCacheTTLSeconds != nil && *dto.PublicDashboard.QueryCacheTTLSeconds < 0 {
+		return ErrInvalidQueryCacheTTL.Errorf("ValidateSavePublicDashboard: query cache ttl should not be negative")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,68 @@
+package queryhistory
+
+import (
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+const (
+	ActionSavedQueriesRead   = "savedqueries:read"
+	ActionSavedQueriesCreate = "savedqueries:create"
+	ActionSavedQueriesWrite  = "savedqueries:write"
+	ActionSavedQueriesDelete = "savedqueries:delete"
+)
+
+var ScopeSavedQueriesProvider = ac.NewScopeProvider("savedqueries")
+
+var (
+	savedQueriesReaderRole = ac.RoleDTO{
+		Name:        "fixed:savedqueries:reader",
+		DisplayName: "Reader",
+		Description: "List and use the saved queries of the organization",
+		Group:       "Saved queries",
+		Permissions: []ac.Permission{
+			{Action: ActionSavedQueriesRead},
+		},
+	}
+
+	savedQueriesCreatorRole = ac.RoleDTO{
+		Name:        "fixed:savedqueries:creator",
+		DisplayName: "Creator",
+		Description: "List saved queries and save starred queries for the organization",
+		Group:       "Saved queries",
+		Permissions: []ac.Permission{
+			{Action: ActionSavedQueriesRead},
+			{Action: ActionSavedQueriesCreate},
+		},
+	}
+
+	savedQueriesWriterRole = ac.RoleDTO{
+		Name:        "fixed:savedqueries:writer",
+		DisplayName: "Writer",
+		Description: "Create, update and delete all the saved queries of the organization",
+		Group:       "Saved queries",
+		Permissions: []ac.Permission{
+			{Action: ActionSavedQueriesRead},
+			{Action: ActionSavedQueriesCreate},
+			{Action: ActionSavedQueriesWrite, Scope: ScopeSavedQueriesProvider.GetResourceAllScope()},
+			{Action: ActionSavedQueriesDelete, Scope: ScopeSavedQueriesProvider.GetResourceAllScope()},
+		},
+	}
+)
+
+func declareFixedRoles(service ac.Service) error {
+	return service.DeclareFixedRoles(
+		ac.RoleRegistration{
+			Role:   savedQueriesReaderRole,
+			Grants: []string{string(org.RoleViewer)},
+		},
+		ac.RoleRegistration{
+			Role:   savedQueriesCreatorRole,
+			Grants: []string{string(org.RoleEditor)},
+		},
+		ac.RoleRegistration{
+			Role:   savedQueriesWriterRole,
+			Grants: []string{string(org.RoleAdmin)},
+		},
+	)
+}
@@ -1,6 +1,7 @@
 package queryhistory
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
@@ -15,6 +16,8 @@ import (
 )
 
 func (s *QueryHistoryService) registerAPIEndpoints() {
+	authorize := ac.Middleware(s.accessControl)
+
 	s.RouteRegister.Group("/api/query-history", func(entities routing.RouteRegister) {
 		entities.Post("/", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.createHandler, "Failed to create query history")))
 		entities.Get("/", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.searchHandler, "Failed to get query history")))
@@ -22,6 +25,17 @@ func (s *QueryHistoryService) registerAPIEndpoints() {
 		entities.Post("/star/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.starHandler, "Failed to star query history")))
 		entities.Delete("/star/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.unstarHandler, "Failed to unstar query history")))
 		entities.Patch("/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.patchCommentHandler, "Failed to update comment of query in query history")))
+		entities.Get("/export", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.exportHandler, "Failed to export query history")))
+		entities.Post("/import", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.importHandler, "Failed to import query history")))
+		entities.Get("/retention", middleware.ReqOrgAdmin, routing.Wrap(s.getRetentionPolicyHandler))
+		entities.Put("/retention", middleware.ReqOrgAdmin, routing.Wrap(s.updateRetentionPolicyHandler))
+		entities.Group("/saved", func(saved routing.RouteRegister) {
+			saved.Get("/", authorize(ac.EvalPermission(ActionSavedQueriesRead)), routing.Wrap(s.permissionsMiddleware(s.searchSavedHandler, "Failed to get saved queries")))
+			saved.Post("/", authorize(ac.EvalPermission(ActionSavedQueriesCreate)), routing.Wrap(s.permissionsMiddleware(s.saveHandler, "Failed to save query")))
+			saved.Get("/:uid", authorize(ac.EvalPermission(ActionSavedQueriesRead)), routing.Wrap(s.permissionsMiddleware(s.getSavedHandler, "Failed to get saved query")))
+			saved.Patch("/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.patchSavedHandler, "Failed to update saved query")))
+			saved.Delete("/:uid", middleware.ReqSignedIn, routing.Wrap(s.permissionsMiddleware(s.deleteSavedHandler, "Failed to delete saved query")))
+		})
 	})
 }
 
@@ -201,6 +215,270 @@ func (s *QueryHistoryService) unstarHandler(c *contextmodel.ReqContext) response
 	return response.JSON(http.StatusOK, QueryHistoryResponse{Result: query})
 }
 
+// swagger:route GET /query-history/export query_history exportQueries
+//
+// Export queries from query history.
+//
+// Returns the most recent queries of the signed in user in query history as a JSON document, which can be imported again.
+// Use the `datasourceUid` parameter to only export the queries of some data sources. At most 10000 queries are exported.
+//
+// Responses:
+// 200: exportQueryHistoryResponse
+// 401: unauthorisedError
+// 500: internalServerError
+func (s *QueryHistoryService) exportHandler(c *contextmodel.ReqContext) response.Response {
+	query := ExportQueryHistoryQuery{
+		DatasourceUIDs: c.QueryStrings("datasourceUid"),
+		OnlyStarred:    c.QueryBoolWithDefault("onlyStarred", false),
+	}
+
+	export, err := s.ExportQueriesFromQueryHistory(c.Req.Context(), c.SignedInUser, query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to export query history", err)
+	}
+
+	return response.JSON(http.StatusOK, export)
+}
+
+// swagger:route POST /query-history/import query_history importQueries
+//
+// Import queries into query history.
+//
+// Adds the queries of an export into the query history of the signed in user.
+// Use the `datasourceUid` parameter to only import the queries of some data sources, the other queries are skipped.
+//
+// Responses:
+// 200: importQueryHistoryResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 500: internalServerError
+func (s *QueryHistoryService) importHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := ImportQueryHistoryCommand{
+		DatasourceUIDs: c.QueryStrings("datasourceUid"),
+	}
+	if err := web.Bind(c.Req, &cmd.Export); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	result, err := s.ImportQueriesIntoQueryHistory(c.Req.Context(), c.SignedInUser, cmd)
+	if err != nil {
+		if errors.Is(err, ErrInvalidImport) {
+			return response.Error(http.StatusBadRequest, err.Error(), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to import query history", err)
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /query-history/retention query_history getQueryHistoryRetention
+//
+// Get the query history retention policy of the organization.
+//
+// Responses:
+// 200: queryHistoryRetentionResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (s *QueryHistoryService) getRetentionPolicyHandler(c *contextmodel.ReqContext) response.Response {
+	policy, err := s.GetRetentionPolicy(c.Req.Context(), c.GetOrgID())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get query history retention policy", err)
+	}
+
+	return response.JSON(http.StatusOK, policy)
+}
+
+// swagger:route PUT /query-history/retention query_history updateQueryHistoryRetention
+//
+// Update the query history retention policy of the organization.
+//
+// Unstarred queries older than `maxAgeSeconds` are deleted instead of after the default of 14 days,
+// which `maxAgeSeconds` can not exceed, and only the `maxCount` most recent unstarred queries of each user are kept.
+// Zero values disable a limit.
+//
+// Responses:
+// 200: queryHistoryRetentionResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (s *QueryHistoryService) updateRetentionPolicyHandler(c *contextmodel.ReqContext) response.Response {
+	policy := RetentionPolicy{}
+	if err := web.Bind(c.Req, &policy); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	if err := s.SetRetentionPolicy(c.Req.Context(), c.GetOrgID(), policy); err != nil {
+		if errors.Is(err, ErrInvalidRetention) {
+			return response.Error(http.StatusBadRequest, err.Error(), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to update query history retention policy", err)
+	}
+
+	return response.JSON(http.StatusOK, policy)
+}
+
+// swagger:route GET /query-history/saved query_history searchSavedQueries
+//
+// Search saved queries.
+//
+// Returns the saved queries of the organization that match the search criteria, sorted by title.
+// Only the queries of the data sources that the user can query are returned.
+//
+// Responses:
+// 200: getSavedQuerySearchResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (s *QueryHistoryService) searchSavedHandler(c *contextmodel.ReqContext) response.Response {
+	query := SearchSavedQueriesQuery{
+		DatasourceUIDs: c.QueryStrings("datasourceUid"),
+		SearchString:   c.Query("searchString"),
+		Page:           c.QueryInt("page"),
+		Limit:          c.QueryInt("limit"),
+	}
+
+	result, err := s.SearchSavedQueries(c.Req.Context(), c.SignedInUser, query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get saved queries", err)
+	}
+
+	return response.JSON(http.StatusOK, SavedQuerySearchResponse{Result: result})
+}
+
+// swagger:route POST /query-history/saved query_history saveQuery
+//
+// Save a starred query for the organization.
+//
+// Copies a starred query of the signed in user into the saved queries of the organization.
+//
+// Responses:
+// 200: getSavedQueryResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) saveHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := SaveQueryCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	query, err := s.SaveQueryFromQueryHistory(c.Req.Context(), c.SignedInUser, cmd)
+	if err != nil {
+		return savedQueryErrorResponse(err, "Failed to save query")
+	}
+
+	return response.JSON(http.StatusOK, SavedQueryResponse{Result: query})
+}
+
+// swagger:route GET /query-history/saved/{saved_query_uid} query_history getSavedQuery
+//
+// Get saved query.
+//
+// Responses:
+// 200: getSavedQueryResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) getSavedHandler(c *contextmodel.ReqContext) response.Response {
+	query, err := s.GetSavedQuery(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"])
+	if err != nil {
+		return savedQueryErrorResponse(err, "Failed to get saved query")
+	}
+
+	return response.JSON(http.StatusOK, SavedQueryResponse{Result: query})
+}
+
+// swagger:route PATCH /query-history/saved/{saved_query_uid} query_history patchSavedQuery
+//
+// Update saved query.
+//
+// Updates the title and description of a saved query. Saved queries can be updated by their creator,
+// or with the savedqueries:write permission.
+//
+// Responses:
+// 200: getSavedQueryResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) patchSavedHandler(c *contextmodel.ReqContext) response.Response {
+	queryUID := web.Params(c.Req)[":uid"]
+	if resp := s.checkSavedQueryAccess(c, queryUID, ActionSavedQueriesWrite); resp != nil {
+		return resp
+	}
+
+	cmd := PatchSavedQueryCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	query, err := s.PatchSavedQuery(c.Req.Context(), c.SignedInUser, queryUID, cmd)
+	if err != nil {
+		return savedQueryErrorResponse(err, "Failed to update saved query")
+	}
+
+	return response.JSON(http.StatusOK, SavedQueryResponse{Result: query})
+}
+
+// swagger:route DELETE /query-history/saved/{saved_query_uid} query_history deleteSavedQuery
+//
+// Delete saved query.
+//
+// Deletes a saved query. Saved queries can be deleted by their creator, or with the savedqueries:delete permission.
+// The starred query it was saved from is kept.
+//
+// Responses:
+// 200: okResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *QueryHistoryService) deleteSavedHandler(c *contextmodel.ReqContext) response.Response {
+	queryUID := web.Params(c.Req)[":uid"]
+	if resp := s.checkSavedQueryAccess(c, queryUID, ActionSavedQueriesDelete); resp != nil {
+		return resp
+	}
+
+	if err := s.DeleteSavedQuery(c.Req.Context(), c.SignedInUser, queryUID); err != nil {
+		return savedQueryErrorResponse(err, "Failed to delete saved query")
+	}
+
+	return response.Success("Saved query deleted")
+}
+
+// checkSavedQueryAccess allows the creator of a saved query, or users with the action on its scope
+func (s *QueryHistoryService) checkSavedQueryAccess(c *contextmodel.ReqContext, queryUID string, action string) response.Response {
+	query, err := s.GetSavedQuery(c.Req.Context(), c.SignedInUser, queryUID)
+	if err != nil {
+		return savedQueryErrorResponse(err, "Failed to get saved query")
+	}
+	if query.CreatedBy == c.SignedInUser.UserID {
+		return nil
+	}
+
+	hasAccess := ac.HasAccess(s.accessControl, c)
+	if !hasAccess(ac.EvalPermission(action, ScopeSavedQueriesProvider.GetResourceScopeUID(queryUID))) {
+		return response.Error(http.StatusForbidden, "Only the creator of a saved query can change it", nil)
+	}
+	return nil
+}
+
+func savedQueryErrorResponse(err error, message string) response.Response {
+	switch {
+	case errors.Is(err, ErrQueryNotFound), errors.Is(err, ErrSavedQueryNotFound):
+		return response.Error(http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, ErrQueryNotStarred), errors.Is(err, ErrInvalidSavedQueryTitle):
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+	return response.Error(http.StatusInternalServerError, message, err)
+}
+
 // swagger:parameters starQuery patchQueryComment deleteQuery unstarQuery
 type QueryHistoryByUID struct {
 	// in:path
@@ -248,6 +526,81 @@ type SearchQueriesParams struct {
 	To int64 `json:"to"`
 }
 
+// swagger:parameters exportQueries importQueries
+type ExportQueriesParams struct {
+	// List of data source UIDs of the queries
+	// in:query
+	// required: false
+	// type: array
+	// collectionFormat: multi
+	DatasourceUid []string `json:"datasourceUid"`
+}
+
+// swagger:parameters exportQueries
+type ExportStarredQueriesParams struct {
+	// Flag indicating if only starred queries should be exported
+	// in:query
+	// required: false
+	OnlyStarred bool `json:"onlyStarred"`
+}
+
+// swagger:parameters importQueries
+type ImportQueriesParams struct {
+	// in:body
+	// required:true
+	Body QueryHistoryExport `json:"body"`
+}
+
+// swagger:parameters updateQueryHistoryRetention
+type UpdateQueryHistoryRetentionParams struct {
+	// in:body
+	// required:true
+	Body RetentionPolicy `json:"body"`
+}
+
+// swagger:parameters getSavedQuery patchSavedQuery deleteSavedQuery
+type SavedQueryByUID struct {
+	// in:path
+	// required:true
+	UID string `json:"saved_query_uid"`
+}
+
+// swagger:parameters searchSavedQueries
+type SearchSavedQueriesParams struct {
+	// List of data source UIDs to search for
+	// in:query
+	// required: false
+	// type: array
+	// collectionFormat: multi
+	DatasourceUid []string `json:"datasourceUid"`
+	// Text inside the title or description that is searched for
+	// in:query
+	// required: false
+	SearchString string `json:"searchString"`
+	// Use this parameter to access hits beyond limit. Numbering starts at 1. limit param acts as page size.
+	// in:query
+	// required: false
+	Page int `json:"page"`
+	// Limit the number of returned results
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters saveQuery
+type SaveQueryParams struct {
+	// in:body
+	// required:true
+	Body SaveQueryCommand `json:"body"`
+}
+
+// swagger:parameters patchSavedQuery
+type PatchSavedQueryParams struct {
+	// in:body
+	// required:true
+	Body PatchSavedQueryCommand `json:"body"`
+}
+
 // swagger:parameters createQuery
 type CreateQueryParams struct {
 	// in:body
@@ -279,3 +632,33 @@ type GetQueryHistoryDeleteQueryResponse struct {
 	// in: body
 	Body QueryHistoryDeleteQueryResponse `json:"body"`
 }
+
+// swagger:response exportQueryHistoryResponse
+type ExportQueryHistoryResponse struct {
+	// in: body
+	Body QueryHistoryExport `json:"body"`
+}
+
+// swagger:response importQueryHistoryResponse
+type ImportQueryHistoryResponse struct {
+	// in: body
+	Body QueryHistoryImportResult `json:"body"`
+}
+
+// swagger:response queryHistoryRetentionResponse
+type QueryHistoryRetentionResponse struct {
+	// in: body
+	Body RetentionPolicy `json:"body"`
+}
+
+// swagger:response getSavedQuerySearchResponse
+type GetSavedQuerySearchResponse struct {
+	// in: body
+	Body SavedQuerySearchResponse `json:"body"`
+}
+
+// swagger:response getSavedQueryResponse
+type GetSavedQueryResponse struct {
+	// in: body
+	Body SavedQueryResponse `json:"body"`
+}
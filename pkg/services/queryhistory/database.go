@@ -304,51 +304,10 @@ func (s QueryHistoryService) unstarQuery(ctx context.Context, user *user.SignedI
 	return dto, nil
 }
 
+// deleteStaleQueries removes the unstarred queries older than olderThan in every org, the shorter max age
+// of the org retention policies is enforced by enforceRetentionPolicies
 func (s QueryHistoryService) deleteStaleQueries(ctx context.Context, olderThan int64) (int, error) {
-	var rowsCount int64
-
-	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
-		uids_sql := `SELECT uid FROM (
-			SELECT uid FROM query_history
-			LEFT JOIN query_history_star
-			ON query_history_star.query_uid = query_history.uid
-			WHERE query_history_star.query_uid IS NULL
-			AND query_history.created_at <= ?
-			ORDER BY query_history.id ASC
-			LIMIT 10000
-		) AS q`
-
-		details_sql := `DELETE
-			FROM query_history_details
-			WHERE query_history_item_uid IN (` + uids_sql + `)`
-
-		sql := `DELETE
-			FROM query_history
-			WHERE uid IN (` + uids_sql + `)`
-
-		_, err := session.Exec(details_sql, olderThan)
-		if err != nil {
-			return err
-		}
-
-		res, err := session.Exec(sql, olderThan)
-		if err != nil {
-			return err
-		}
-
-		rowsCount, err = res.RowsAffected()
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		return 0, err
-	}
-
-	return int(rowsCount), nil
+	return s.deleteUnstarredQueries(ctx, "query_history.created_at <= ?", retentionBatchSize, olderThan)
 }
 
 // enforceQueryHistoryRowLimit is run in scheduled cleanup and it removes queries and stars that exceeded limit
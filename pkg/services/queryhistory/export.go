@@ -0,0 +1,129 @@
+package queryhistory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// exportLimit is the maximum number of queries that can be exported or imported at once
+const exportLimit = 10000
+
+// exportQueries returns the most recent queries of the user in query history, optionally only the ones of the given data sources
+func (s QueryHistoryService) exportQueries(ctx context.Context, user *user.SignedInUser, query ExportQueryHistoryQuery) (QueryHistoryExport, error) {
+	searchQuery := SearchInQueryHistoryQuery{
+		DatasourceUIDs: query.DatasourceUIDs,
+		OnlyStarred:    query.OnlyStarred,
+		Sort:           "time-desc",
+		Page:           1,
+		Limit:          exportLimit,
+		To:             s.now().Unix(),
+	}
+	items := []QueryHistoryExportItem{}
+
+	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
+		builder := db.SQLBuilder{}
+		builder.Write(`SELECT
+			query_history.datasource_uid,
+			query_history.created_at AS created_at,
+			query_history.comment,
+			query_history.queries,
+		`)
+		writeStarredSQL(searchQuery, s.store, &builder, false)
+		writeFiltersSQL(searchQuery, user, s.store, &builder)
+		writeSortSQL(searchQuery, s.store, &builder)
+		writeLimitSQL(searchQuery, s.store, &builder)
+
+		return session.SQL(builder.GetSQLString(), builder.GetParams()...).Find(&items)
+	})
+	if err != nil {
+		return QueryHistoryExport{}, err
+	}
+
+	return QueryHistoryExport{Queries: items}, nil
+}
+
+// importQueries adds the exported queries into the query history of the user, queries of other
+// data sources than the given ones are skipped
+func (s QueryHistoryService) importQueries(ctx context.Context, user *user.SignedInUser, cmd ImportQueryHistoryCommand) (QueryHistoryImportResult, error) {
+	result := QueryHistoryImportResult{}
+	if len(cmd.Export.Queries) > exportLimit {
+		return result, fmt.Errorf("%w: at most %d queries can be imported at once", ErrInvalidImport, exportLimit)
+	}
+
+	now := s.now().Unix()
+	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
+		for i, item := range cmd.Export.Queries {
+			if item.Queries == nil {
+				return fmt.Errorf("%w: query %d has no queries", ErrInvalidImport, i)
+			}
+			// queries of mixed data sources are stored with the data source of each query
+			dsUIDs, err := FindDataSourceUIDs(item.Queries)
+			if err != nil {
+				dsUIDs = nil
+			}
+			if !matchesDatasources(cmd.DatasourceUIDs, append([]string{item.DatasourceUID}, dsUIDs...)) {
+				result.Skipped++
+				continue
+			}
+
+			createdAt := item.CreatedAt
+			if createdAt <= 0 || createdAt > now {
+				createdAt = now
+			}
+			queryHistory := QueryHistory{
+				OrgID:         user.OrgID,
+				UID:           util.GenerateShortUID(),
+				Queries:       item.Queries,
+				DatasourceUID: item.DatasourceUID,
+				CreatedBy:     user.UserID,
+				CreatedAt:     createdAt,
+				Comment:       item.Comment,
+			}
+			if _, err := session.Insert(&queryHistory); err != nil {
+				return err
+			}
+
+			for _, uid := range dsUIDs {
+				if _, err := session.Insert(&QueryHistoryDetails{
+					QueryHistoryItemUID: queryHistory.UID,
+					DatasourceUID:       uid,
+				}); err != nil {
+					return err
+				}
+			}
+
+			if item.Starred {
+				if _, err := session.Insert(&QueryHistoryStar{
+					UserID:   user.UserID,
+					QueryUID: queryHistory.UID,
+				}); err != nil {
+					return err
+				}
+			}
+			result.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return QueryHistoryImportResult{}, err
+	}
+
+	return result, nil
+}
+
+func matchesDatasources(filter []string, dsUIDs []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, uid := range dsUIDs {
+		if uid != "" && slices.Contains(filter, uid) {
+			return true
+		}
+	}
+	return false
+}
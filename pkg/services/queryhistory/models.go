@@ -7,9 +7,14 @@ import (
 )
 
 var (
-	ErrQueryNotFound        = errors.New("query in query history not found")
-	ErrStarredQueryNotFound = errors.New("starred query not found")
-	ErrQueryAlreadyStarred  = errors.New("query was already starred")
+	ErrQueryNotFound          = errors.New("query in query history not found")
+	ErrStarredQueryNotFound   = errors.New("starred query not found")
+	ErrQueryAlreadyStarred    = errors.New("query was already starred")
+	ErrQueryNotStarred        = errors.New("only starred queries can be saved")
+	ErrSavedQueryNotFound     = errors.New("saved query not found")
+	ErrInvalidSavedQueryTitle = errors.New("saved query title must be between 1 and 190 characters")
+	ErrInvalidRetention       = errors.New("max age and max count of the retention policy can not be negative, and the max age can not exceed 14 days")
+	ErrInvalidImport          = errors.New("imported queries are invalid")
 )
 
 // QueryHistory is the model for query history definitions
@@ -91,3 +96,114 @@ type PatchQueryCommentInQueryHistoryCommand struct {
 	// Updated comment
 	Comment string `json:"comment"`
 }
+
+// RetentionPolicy is the query history retention policy of an org, zero values disable a limit.
+// Starred queries are always kept.
+// swagger:model
+type RetentionPolicy struct {
+	// Queries created longer ago than MaxAgeSeconds are deleted, instead of after the default of 14 days.
+	// It can not exceed the default.
+	MaxAgeSeconds int64 `json:"maxAgeSeconds"`
+	// Only the MaxCount most recent queries of each user are kept
+	MaxCount int64 `json:"maxCount"`
+}
+
+type ExportQueryHistoryQuery struct {
+	DatasourceUIDs []string `json:"datasourceUids"`
+	OnlyStarred    bool     `json:"onlyStarred"`
+}
+
+// QueryHistoryExport is the JSON document of exported queries, it can be imported again
+// swagger:model
+type QueryHistoryExport struct {
+	Queries []QueryHistoryExportItem `json:"queries"`
+}
+
+type QueryHistoryExportItem struct {
+	DatasourceUID string           `json:"datasourceUid" xorm:"datasource_uid"`
+	CreatedAt     int64            `json:"createdAt"`
+	Comment       string           `json:"comment"`
+	Queries       *simplejson.Json `json:"queries"`
+	Starred       bool             `json:"starred"`
+}
+
+type ImportQueryHistoryCommand struct {
+	// Only the queries of these data sources are imported, all queries are imported when empty
+	DatasourceUIDs []string           `json:"-"`
+	Export         QueryHistoryExport `json:"-"`
+}
+
+type QueryHistoryImportResult struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// SavedQuery is a query of the query history shared with the org
+type SavedQuery struct {
+	ID            int64  `xorm:"pk autoincr 'id'"`
+	UID           string `xorm:"uid"`
+	OrgID         int64  `xorm:"org_id"`
+	DatasourceUID string `xorm:"datasource_uid"`
+	Title         string
+	Description   string
+	Queries       *simplejson.Json
+	CreatedBy     int64
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+func (SavedQuery) TableName() string {
+	return "query_history_saved"
+}
+
+type SavedQueryDTO struct {
+	UID           string           `json:"uid"`
+	DatasourceUID string           `json:"datasourceUid"`
+	Title         string           `json:"title"`
+	Description   string           `json:"description"`
+	Queries       *simplejson.Json `json:"queries"`
+	CreatedBy     int64            `json:"createdBy"`
+	CreatedAt     int64            `json:"createdAt"`
+	UpdatedAt     int64            `json:"updatedAt"`
+}
+
+// SavedQueryResponse is a response struct for SavedQueryDTO
+type SavedQueryResponse struct {
+	Result SavedQueryDTO `json:"result"`
+}
+
+type SearchSavedQueriesQuery struct {
+	DatasourceUIDs []string `json:"datasourceUids"`
+	SearchString   string   `json:"searchString"`
+	Page           int      `json:"page"`
+	Limit          int      `json:"limit"`
+}
+
+type SavedQuerySearchResult struct {
+	TotalCount   int             `json:"totalCount"`
+	SavedQueries []SavedQueryDTO `json:"savedQueries"`
+	Page         int             `json:"page"`
+	PerPage      int             `json:"perPage"`
+}
+
+type SavedQuerySearchResponse struct {
+	Result SavedQuerySearchResult `json:"result"`
+}
+
+// SaveQueryCommand is the command for promoting a starred query to the saved queries of the org
+// swagger:model
+type SaveQueryCommand struct {
+	// UID of the starred query in query history
+	// required: true
+	QueryUID string `json:"queryUid"`
+	// required: true
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// PatchSavedQueryCommand is the command for updating the title and description of a saved query
+// swagger:model
+type PatchSavedQueryCommand struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+}
@@ -6,15 +6,18 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
-func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, accessControl ac.AccessControl) *QueryHistoryService {
+func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, accessControl ac.AccessControl,
+	accesscontrolService ac.Service, kvStore kvstore.KVStore) (*QueryHistoryService, error) {
 	s := &QueryHistoryService{
 		store:         sqlStore,
+		kvStore:       kvStore,
 		Cfg:           cfg,
 		RouteRegister: routeRegister,
 		log:           log.New("query-history"),
@@ -24,10 +27,13 @@ func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.Rout
 
 	// Register routes only when query history is enabled
 	if s.Cfg.QueryHistoryEnabled {
+		if err := declareFixedRoles(accesscontrolService); err != nil {
+			return nil, err
+		}
 		s.registerAPIEndpoints()
 	}
 
-	return s
+	return s, nil
 }
 
 type Service interface {
@@ -39,10 +45,21 @@ type Service interface {
 	UnstarQueryInQueryHistory(ctx context.Context, user *user.SignedInUser, UID string) (QueryHistoryDTO, error)
 	DeleteStaleQueriesInQueryHistory(ctx context.Context, olderThan int64) (int, error)
 	EnforceRowLimitInQueryHistory(ctx context.Context, limit int, starredQueries bool) (int, error)
+	EnforceRetentionPoliciesInQueryHistory(ctx context.Context) (int, error)
+	GetRetentionPolicy(ctx context.Context, orgID int64) (RetentionPolicy, error)
+	SetRetentionPolicy(ctx context.Context, orgID int64, policy RetentionPolicy) error
+	ExportQueriesFromQueryHistory(ctx context.Context, user *user.SignedInUser, query ExportQueryHistoryQuery) (QueryHistoryExport, error)
+	ImportQueriesIntoQueryHistory(ctx context.Context, user *user.SignedInUser, cmd ImportQueryHistoryCommand) (QueryHistoryImportResult, error)
+	SaveQueryFromQueryHistory(ctx context.Context, user *user.SignedInUser, cmd SaveQueryCommand) (SavedQueryDTO, error)
+	SearchSavedQueries(ctx context.Context, user *user.SignedInUser, query SearchSavedQueriesQuery) (SavedQuerySearchResult, error)
+	GetSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) (SavedQueryDTO, error)
+	PatchSavedQuery(ctx context.Context, user *user.SignedInUser, UID string, cmd PatchSavedQueryCommand) (SavedQueryDTO, error)
+	DeleteSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) error
 }
 
 type QueryHistoryService struct {
 	store         db.DB
+	kvStore       kvstore.KVStore
 	Cfg           *setting.Cfg
 	RouteRegister routing.RouteRegister
 	log           log.Logger
@@ -81,3 +98,43 @@ func (s QueryHistoryService) DeleteStaleQueriesInQueryHistory(ctx context.Contex
 func (s QueryHistoryService) EnforceRowLimitInQueryHistory(ctx context.Context, limit int, starredQueries bool) (int, error) {
 	return s.enforceQueryHistoryRowLimit(ctx, limit, starredQueries)
 }
+
+func (s QueryHistoryService) EnforceRetentionPoliciesInQueryHistory(ctx context.Context) (int, error) {
+	return s.enforceRetentionPolicies(ctx)
+}
+
+func (s QueryHistoryService) GetRetentionPolicy(ctx context.Context, orgID int64) (RetentionPolicy, error) {
+	return s.getRetentionPolicy(ctx, orgID)
+}
+
+func (s QueryHistoryService) SetRetentionPolicy(ctx context.Context, orgID int64, policy RetentionPolicy) error {
+	return s.setRetentionPolicy(ctx, orgID, policy)
+}
+
+func (s QueryHistoryService) ExportQueriesFromQueryHistory(ctx context.Context, user *user.SignedInUser, query ExportQueryHistoryQuery) (QueryHistoryExport, error) {
+	return s.exportQueries(ctx, user, query)
+}
+
+func (s QueryHistoryService) ImportQueriesIntoQueryHistory(ctx context.Context, user *user.SignedInUser, cmd ImportQueryHistoryCommand) (QueryHistoryImportResult, error) {
+	return s.importQueries(ctx, user, cmd)
+}
+
+func (s QueryHistoryService) SaveQueryFromQueryHistory(ctx context.Context, user *user.SignedInUser, cmd SaveQueryCommand) (SavedQueryDTO, error) {
+	return s.saveQuery(ctx, user, cmd)
+}
+
+func (s QueryHistoryService) SearchSavedQueries(ctx context.Context, user *user.SignedInUser, query SearchSavedQueriesQuery) (SavedQuerySearchResult, error) {
+	return s.searchSavedQueries(ctx, user, query)
+}
+
+func (s QueryHistoryService) GetSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) (SavedQueryDTO, error) {
+	return s.getSavedQuery(ctx, user, UID)
+}
+
+func (s QueryHistoryService) PatchSavedQuery(ctx context.Context, user *user.SignedInUser, UID string, cmd PatchSavedQueryCommand) (SavedQueryDTO, error) {
+	return s.patchSavedQuery(ctx, user, UID, cmd)
+}
+
+func (s QueryHistoryService) DeleteSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) error {
+	return s.deleteSavedQuery(ctx, user, UID)
+}
@@ -0,0 +1,96 @@
+package queryhistory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestIntegrationExportQueriesFromQueryHistory(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	// In this scenario we have 2 starred queries and 1 not starred query
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When users export their query history, it should return all queries",
+		func(t *testing.T, sc scenarioContext) {
+			resp := sc.service.exportHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var export QueryHistoryExport
+			require.NoError(t, json.Unmarshal(resp.Body(), &export))
+			require.Len(t, export.Queries, 3)
+			require.Equal(t, testDsUID2, export.Queries[0].DatasourceUID)
+			require.True(t, export.Queries[0].Starred)
+			require.Equal(t, "test comment 2", export.Queries[2].Comment)
+			require.False(t, export.Queries[2].Starred)
+		})
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When users export the queries of a data source, it should only return these queries",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Form.Add("datasourceUid", testDsUID2)
+			resp := sc.service.exportHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var export QueryHistoryExport
+			require.NoError(t, json.Unmarshal(resp.Body(), &export))
+			require.Len(t, export.Queries, 1)
+			require.Equal(t, testDsUID2, export.Queries[0].DatasourceUID)
+		})
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When users export starred queries, it should only return these queries",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Form.Add("onlyStarred", "true")
+			resp := sc.service.exportHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var export QueryHistoryExport
+			require.NoError(t, json.Unmarshal(resp.Body(), &export))
+			require.Len(t, export.Queries, 2)
+		})
+}
+
+func TestIntegrationImportQueriesIntoQueryHistory(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When users import an export, it should add the queries",
+		func(t *testing.T, sc scenarioContext) {
+			export, err := sc.service.ExportQueriesFromQueryHistory(context.Background(), sc.reqContext.SignedInUser, ExportQueryHistoryQuery{})
+			require.NoError(t, err)
+
+			sc.reqContext.Req.Body = mockRequestBody(export)
+			resp := sc.service.importHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result QueryHistoryImportResult
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, QueryHistoryImportResult{Imported: 3}, result)
+
+			search, err := sc.service.SearchInQueryHistory(context.Background(), sc.reqContext.SignedInUser, SearchInQueryHistoryQuery{OnlyStarred: true})
+			require.NoError(t, err)
+			require.Equal(t, 4, search.TotalCount)
+		})
+
+	testScenarioWithMultipleQueriesInQueryHistory(t, "When users import the queries of a data source, it should skip the other queries",
+		func(t *testing.T, sc scenarioContext) {
+			export, err := sc.service.ExportQueriesFromQueryHistory(context.Background(), sc.reqContext.SignedInUser, ExportQueryHistoryQuery{})
+			require.NoError(t, err)
+
+			sc.reqContext.Req.Form.Add("datasourceUid", testDsUID2)
+			sc.reqContext.Req.Body = mockRequestBody(export)
+			resp := sc.service.importHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result QueryHistoryImportResult
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, QueryHistoryImportResult{Imported: 1, Skipped: 2}, result)
+
+			search, err := sc.service.SearchInQueryHistory(context.Background(), sc.reqContext.SignedInUser, SearchInQueryHistoryQuery{DatasourceUIDs: []string{testDsUID2}})
+			require.NoError(t, err)
+			require.Equal(t, 2, search.TotalCount)
+		})
+
+	testScenario(t, "When users import queries without queries, it should fail", false, false,
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Body = mockRequestBody(QueryHistoryExport{Queries: []QueryHistoryExportItem{{DatasourceUID: testDsUID1}}})
+			resp := sc.service.importHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+}
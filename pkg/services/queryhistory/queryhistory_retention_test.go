@@ -0,0 +1,100 @@
+package queryhistory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestIntegrationQueryHistoryRetentionPolicy(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	testScenario(t, "When admin updates the retention policy, it should be returned", false, false,
+		func(t *testing.T, sc scenarioContext) {
+			resp := sc.service.getRetentionPolicyHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			require.JSONEq(t, `{"maxAgeSeconds": 0, "maxCount": 0}`, string(resp.Body()))
+
+			sc.reqContext.Req.Body = mockRequestBody(RetentionPolicy{MaxAgeSeconds: 3600, MaxCount: 10})
+			resp = sc.service.updateRetentionPolicyHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+
+			resp = sc.service.getRetentionPolicyHandler(sc.reqContext)
+			var policy RetentionPolicy
+			require.NoError(t, json.Unmarshal(resp.Body(), &policy))
+			require.Equal(t, RetentionPolicy{MaxAgeSeconds: 3600, MaxCount: 10}, policy)
+		})
+
+	testScenario(t, "When admin updates the retention policy with negative values, it should fail", false, false,
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Body = mockRequestBody(RetentionPolicy{MaxAgeSeconds: -1})
+			resp := sc.service.updateRetentionPolicyHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	testScenario(t, "When admin updates the retention policy with a max age above the default, it should fail", false, false,
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Body = mockRequestBody(RetentionPolicy{MaxAgeSeconds: int64(MaxLifetime.Seconds()) + 1})
+			resp := sc.service.updateRetentionPolicyHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	// In this scenario we have 2 starred queries and 1 not starred query
+	testScenarioWithMultipleQueriesInQueryHistory(t, "Queries older than the max age of the org are deleted",
+		func(t *testing.T, sc scenarioContext) {
+			err := sc.service.SetRetentionPolicy(context.Background(), testOrgID, RetentionPolicy{MaxAgeSeconds: 3600})
+			require.NoError(t, err)
+
+			rowsDeleted, err := sc.service.EnforceRetentionPoliciesInQueryHistory(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 0, rowsDeleted)
+
+			now := sc.service.now()
+			sc.service.now = func() time.Time { return now.Add(2 * time.Hour) }
+			rowsDeleted, err = sc.service.EnforceRetentionPoliciesInQueryHistory(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 1, rowsDeleted)
+		})
+
+	// In this scenario we have 2 starred queries and 1 not starred query
+	testScenarioWithMultipleQueriesInQueryHistory(t, "The default stale deletion applies to orgs with a max age",
+		func(t *testing.T, sc scenarioContext) {
+			err := sc.service.SetRetentionPolicy(context.Background(), testOrgID, RetentionPolicy{MaxAgeSeconds: int64(MaxLifetime.Seconds())})
+			require.NoError(t, err)
+
+			rowsDeleted, err := sc.service.DeleteStaleQueriesInQueryHistory(context.Background(), sc.service.now().Unix()+60)
+			require.NoError(t, err)
+			require.Equal(t, 1, rowsDeleted)
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "Only the most recent queries of a user are kept",
+		func(t *testing.T, sc scenarioContext) {
+			start := sc.service.now()
+			for i := 1; i <= 2; i++ {
+				sc.service.now = func() time.Time { return start.Add(time.Duration(i) * time.Minute) }
+				_, err := sc.service.CreateQueryInQueryHistory(context.Background(), sc.reqContext.SignedInUser, CreateQueryInQueryHistoryCommand{
+					DatasourceUID: testDsUID1,
+					Queries:       simplejson.NewFromAny([]any{map[string]any{"expr": "recent"}}),
+				})
+				require.NoError(t, err)
+			}
+
+			err := sc.service.SetRetentionPolicy(context.Background(), testOrgID, RetentionPolicy{MaxCount: 1})
+			require.NoError(t, err)
+
+			rowsDeleted, err := sc.service.EnforceRetentionPoliciesInQueryHistory(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 2, rowsDeleted)
+
+			result, err := sc.service.SearchInQueryHistory(context.Background(), sc.reqContext.SignedInUser, SearchInQueryHistoryQuery{})
+			require.NoError(t, err)
+			require.Len(t, result.QueryHistory, 1)
+			require.Equal(t, start.Add(2*time.Minute).Unix(), result.QueryHistory[0].CreatedAt)
+		})
+}
@@ -0,0 +1,170 @@
+package queryhistory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/util/testutil"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// setPermissions replaces the permissions of the signed in user, it can query all data sources unless overridden
+func setPermissions(sc scenarioContext, permissions map[string][]string) {
+	orgPermissions := map[string][]string{datasources.ActionQuery: {datasources.ScopeAll}}
+	for action, scopes := range permissions {
+		orgPermissions[action] = scopes
+	}
+	sc.reqContext.SignedInUser.Permissions = map[int64]map[string][]string{testOrgID: orgPermissions}
+}
+
+func saveStarredQuery(t *testing.T, sc scenarioContext, title string) SavedQueryDTO {
+	t.Helper()
+	setPermissions(sc, nil)
+
+	sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+	resp := sc.service.starHandler(sc.reqContext)
+	require.Equal(t, 200, resp.Status())
+
+	sc.reqContext.Req.Body = mockRequestBody(SaveQueryCommand{QueryUID: sc.initialResult.Result.UID, Title: title})
+	resp = sc.service.saveHandler(sc.reqContext)
+	require.Equal(t, 200, resp.Status())
+
+	var result SavedQueryResponse
+	require.NoError(t, json.Unmarshal(resp.Body(), &result))
+	return result.Result
+}
+
+func TestIntegrationSavedQueries(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	testScenarioWithQueryInQueryHistory(t, "When users save a query that is not starred, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Body = mockRequestBody(SaveQueryCommand{QueryUID: sc.initialResult.Result.UID, Title: "Errors"})
+			resp := sc.service.saveHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When users save a query that does not exist, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.Req.Body = mockRequestBody(SaveQueryCommand{QueryUID: "unknown", Title: "Errors"})
+			resp := sc.service.saveHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When users save a starred query without title, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.service.starHandler(sc.reqContext)
+
+			sc.reqContext.Req.Body = mockRequestBody(SaveQueryCommand{QueryUID: sc.initialResult.Result.UID, Title: "  "})
+			resp := sc.service.saveHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When users save a starred query, it should be shared with the org",
+		func(t *testing.T, sc scenarioContext) {
+			saved := saveStarredQuery(t, sc, "Errors")
+			require.Equal(t, "Errors", saved.Title)
+			require.Equal(t, testDsUID1, saved.DatasourceUID)
+			require.Equal(t, sc.initialResult.Result.Queries, saved.Queries)
+
+			// another user of the org can find it
+			sc.reqContext.UserID = testUserID + 1
+			sc.reqContext.Req.Form.Add("searchString", "err")
+			resp := sc.service.searchSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var search SavedQuerySearchResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &search))
+			require.Equal(t, 1, search.Result.TotalCount)
+			require.Equal(t, saved.UID, search.Result.SavedQueries[0].UID)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": saved.UID})
+			resp = sc.service.getSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+
+			// but not in another org
+			sc.reqContext.OrgID = testOrgID + 1
+			resp = sc.service.getSavedHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When the creator updates a saved query, it should succeed",
+		func(t *testing.T, sc scenarioContext) {
+			saved := saveStarredQuery(t, sc, "Errors")
+
+			title := "All errors"
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": saved.UID})
+			sc.reqContext.Req.Body = mockRequestBody(PatchSavedQueryCommand{Title: &title})
+			resp := sc.service.patchSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result SavedQueryResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, "All errors", result.Result.Title)
+
+			resp = sc.service.deleteSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			resp = sc.service.getSavedHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When other users update a saved query, it should require permissions",
+		func(t *testing.T, sc scenarioContext) {
+			saved := saveStarredQuery(t, sc, "Errors")
+
+			description := "Errors of all services"
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": saved.UID})
+			sc.reqContext.UserID = testUserID + 1
+			sc.reqContext.Req.Body = mockRequestBody(PatchSavedQueryCommand{Description: &description})
+			resp := sc.service.patchSavedHandler(sc.reqContext)
+			require.Equal(t, 403, resp.Status())
+			resp = sc.service.deleteSavedHandler(sc.reqContext)
+			require.Equal(t, 403, resp.Status())
+
+			setPermissions(sc, map[string][]string{
+				ActionSavedQueriesWrite: {ScopeSavedQueriesProvider.GetResourceScopeUID(saved.UID)},
+			})
+			sc.reqContext.Req.Body = mockRequestBody(PatchSavedQueryCommand{Description: &description})
+			resp = sc.service.patchSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result SavedQueryResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, "Errors", result.Result.Title)
+			require.Equal(t, description, result.Result.Description)
+
+			// the write permission does not allow to delete it
+			resp = sc.service.deleteSavedHandler(sc.reqContext)
+			require.Equal(t, 403, resp.Status())
+		})
+
+	testScenarioWithQueryInQueryHistory(t, "When users cannot query the data source of a saved query, it should be hidden",
+		func(t *testing.T, sc scenarioContext) {
+			saved := saveStarredQuery(t, sc, "Errors")
+
+			for name, permissions := range map[string]map[string][]string{
+				"without data source access":         {datasources.ActionQuery: {}},
+				"with access to another data source": {datasources.ActionQuery: {datasources.ScopeProvider.GetResourceScopeUID(testDsUID2)}},
+			} {
+				t.Run(name, func(t *testing.T) {
+					setPermissions(sc, permissions)
+
+					resp := sc.service.searchSavedHandler(sc.reqContext)
+					require.Equal(t, 200, resp.Status())
+					var search SavedQuerySearchResponse
+					require.NoError(t, json.Unmarshal(resp.Body(), &search))
+					require.Equal(t, 0, search.Result.TotalCount)
+					require.Empty(t, search.Result.SavedQueries)
+
+					sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": saved.UID})
+					resp = sc.service.getSavedHandler(sc.reqContext)
+					require.Equal(t, 404, resp.Status())
+				})
+			}
+
+			setPermissions(sc, map[string][]string{datasources.ActionQuery: {datasources.ScopeProvider.GetResourceScopeUID(testDsUID1)}})
+			resp := sc.service.getSavedHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+		})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	accesscontrolmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
@@ -62,6 +63,7 @@ func testScenario(t *testing.T, desc string, isViewer bool, hasDatasourceExplore
 		service := QueryHistoryService{
 			Cfg:           setting.NewCfg(),
 			store:         sqlStore,
+			kvStore:       kvstore.ProvideService(sqlStore),
 			now:           time.Now,
 			accessControl: accesscontrolmock.New(),
 		}
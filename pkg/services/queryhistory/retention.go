@@ -0,0 +1,172 @@
+package queryhistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+)
+
+const (
+	retentionPolicyNamespace = "queryhistory"
+	retentionPolicyKey       = "retention"
+
+	// retentionBatchSize limits how many queries are deleted by a single statement
+	retentionBatchSize = 10000
+
+	// MaxLifetime is how long unstarred queries are kept in every org, retention policies can only shorten it
+	MaxLifetime = 14 * 24 * time.Hour
+)
+
+// getRetentionPolicy returns the retention policy of an org, orgs without a policy get an empty one
+func (s QueryHistoryService) getRetentionPolicy(ctx context.Context, orgID int64) (RetentionPolicy, error) {
+	policy := RetentionPolicy{}
+	value, ok, err := s.kvStore.Get(ctx, orgID, retentionPolicyNamespace, retentionPolicyKey)
+	if err != nil || !ok {
+		return policy, err
+	}
+
+	if err := json.Unmarshal([]byte(value), &policy); err != nil {
+		return RetentionPolicy{}, fmt.Errorf("failed to decode query history retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (s QueryHistoryService) setRetentionPolicy(ctx context.Context, orgID int64, policy RetentionPolicy) error {
+	if policy.MaxAgeSeconds < 0 || policy.MaxCount < 0 || policy.MaxAgeSeconds > int64(MaxLifetime.Seconds()) {
+		return ErrInvalidRetention
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return s.kvStore.Set(ctx, orgID, retentionPolicyNamespace, retentionPolicyKey, string(value))
+}
+
+// getRetentionPolicies returns the retention policies of all the orgs that have one
+func (s QueryHistoryService) getRetentionPolicies(ctx context.Context) (map[int64]RetentionPolicy, error) {
+	items, err := s.kvStore.GetAll(ctx, kvstore.AllOrganizations, retentionPolicyNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[int64]RetentionPolicy, len(items))
+	for orgID, values := range items {
+		value, ok := values[retentionPolicyKey]
+		if !ok {
+			continue
+		}
+		policy := RetentionPolicy{}
+		if err := json.Unmarshal([]byte(value), &policy); err != nil {
+			s.log.Warn("Failed to decode query history retention policy", "orgId", orgID, "error", err)
+			continue
+		}
+		policies[orgID] = policy
+	}
+	return policies, nil
+}
+
+// enforceRetentionPolicies is run in scheduled cleanup and it removes the unstarred queries exceeding
+// the max age or the max count per user of their org retention policy
+func (s QueryHistoryService) enforceRetentionPolicies(ctx context.Context) (int, error) {
+	policies, err := s.getRetentionPolicies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for orgID, policy := range policies {
+		if policy.MaxAgeSeconds > 0 {
+			olderThan := s.now().Unix() - policy.MaxAgeSeconds
+			count, err := s.deleteUnstarredQueries(ctx, "query_history.org_id = ? AND query_history.created_at <= ?", retentionBatchSize, orgID, olderThan)
+			if err != nil {
+				return deleted, err
+			}
+			deleted += count
+		}
+
+		if policy.MaxCount > 0 {
+			count, err := s.enforceUserQueryLimit(ctx, orgID, policy.MaxCount)
+			if err != nil {
+				return deleted, err
+			}
+			deleted += count
+		}
+	}
+	return deleted, nil
+}
+
+// enforceUserQueryLimit removes the oldest unstarred queries of the users of an org that have more than limit of them
+func (s QueryHistoryService) enforceUserQueryLimit(ctx context.Context, orgID int64, limit int64) (int, error) {
+	var users []struct {
+		CreatedBy int64
+		Count     int64
+	}
+	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
+		return session.SQL(`SELECT query_history.created_by, COUNT(*) AS count
+			FROM query_history
+			LEFT JOIN query_history_star
+			ON query_history_star.query_uid = query_history.uid
+			WHERE query_history_star.query_uid IS NULL
+			AND query_history.org_id = ?
+			GROUP BY query_history.created_by
+			HAVING COUNT(*) > ?`, orgID, limit).Find(&users)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, u := range users {
+		count, err := s.deleteUnstarredQueries(ctx, "query_history.org_id = ? AND query_history.created_by = ?", min(u.Count-limit, retentionBatchSize), orgID, u.CreatedBy)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// deleteUnstarredQueries removes the oldest unstarred queries matching the filter, together with their details
+func (s QueryHistoryService) deleteUnstarredQueries(ctx context.Context, filter string, limit int64, params ...any) (int, error) {
+	var rowsCount int64
+
+	err := s.store.WithTransactionalDbSession(ctx, func(session *db.Session) error {
+		uidsSQL := `SELECT uid FROM (
+			SELECT uid FROM query_history
+			LEFT JOIN query_history_star
+			ON query_history_star.query_uid = query_history.uid
+			WHERE query_history_star.query_uid IS NULL
+			AND ` + filter + `
+			ORDER BY query_history.created_at ASC, query_history.id ASC
+			LIMIT ?
+		) AS q`
+		args := append(append([]any{}, params...), limit)
+
+		_, err := session.Exec(append([]any{`DELETE
+			FROM query_history_details
+			WHERE query_history_item_uid IN (` + uidsSQL + `)`}, args...)...)
+		if err != nil {
+			return err
+		}
+
+		res, err := session.Exec(append([]any{`DELETE
+			FROM query_history
+			WHERE uid IN (` + uidsSQL + `)`}, args...)...)
+		if err != nil {
+			return err
+		}
+
+		rowsCount, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsCount), nil
+}
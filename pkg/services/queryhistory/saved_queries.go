@@ -0,0 +1,213 @@
+package queryhistory
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// saveQuery copies a starred query of the user into the saved queries of the org
+func (s QueryHistoryService) saveQuery(ctx context.Context, user *user.SignedInUser, cmd SaveQueryCommand) (SavedQueryDTO, error) {
+	title, err := validateSavedQueryTitle(cmd.Title)
+	if err != nil {
+		return SavedQueryDTO{}, err
+	}
+
+	var savedQuery SavedQuery
+	err = s.store.WithDbSession(ctx, func(session *db.Session) error {
+		var queryHistory QueryHistory
+		exists, err := session.Where("org_id = ? AND created_by = ? AND uid = ?", user.OrgID, user.UserID, cmd.QueryUID).Get(&queryHistory)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrQueryNotFound
+		}
+
+		starred, err := session.Table("query_history_star").Where("user_id = ? AND query_uid = ?", user.UserID, cmd.QueryUID).Exist()
+		if err != nil {
+			return err
+		}
+		if !starred {
+			return ErrQueryNotStarred
+		}
+
+		now := s.now().Unix()
+		savedQuery = SavedQuery{
+			UID:           util.GenerateShortUID(),
+			OrgID:         user.OrgID,
+			DatasourceUID: queryHistory.DatasourceUID,
+			Title:         title,
+			Description:   cmd.Description,
+			Queries:       queryHistory.Queries,
+			CreatedBy:     user.UserID,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		_, err = session.Insert(&savedQuery)
+		return err
+	})
+	if err != nil {
+		return SavedQueryDTO{}, err
+	}
+
+	return savedQuery.toDTO(), nil
+}
+
+// searchSavedQueries searches for saved queries of the org, sorted by title.
+// Only the queries of the data sources that the user can query are returned.
+func (s QueryHistoryService) searchSavedQueries(ctx context.Context, user *user.SignedInUser, query SearchSavedQueriesQuery) (SavedQuerySearchResult, error) {
+	dsFilter, err := datasourceQueryFilter(user)
+	if err != nil {
+		return SavedQuerySearchResult{}, err
+	}
+
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 100
+	}
+
+	savedQueries := []SavedQuery{}
+	var totalCount int64
+
+	err = s.store.WithDbSession(ctx, func(session *db.Session) error {
+		titleSQL, titleParam := s.store.GetDialect().LikeOperator("title", true, query.SearchString, true)
+		descriptionSQL, descriptionParam := s.store.GetDialect().LikeOperator("description", true, query.SearchString, true)
+
+		where := "org_id = ? AND (" + titleSQL + " OR " + descriptionSQL + ") AND" + dsFilter.Where
+		params := append([]any{user.OrgID, titleParam, descriptionParam}, dsFilter.Args...)
+		if len(query.DatasourceUIDs) > 0 {
+			where += " AND datasource_uid IN (?" + strings.Repeat(",?", len(query.DatasourceUIDs)-1) + ")"
+			for _, uid := range query.DatasourceUIDs {
+				params = append(params, uid)
+			}
+		}
+
+		var err error
+		totalCount, err = session.Where(where, params...).Count(&SavedQuery{})
+		if err != nil {
+			return err
+		}
+		return session.Where(where, params...).Asc("title").Limit(query.Limit, query.Limit*(query.Page-1)).Find(&savedQueries)
+	})
+	if err != nil {
+		return SavedQuerySearchResult{}, err
+	}
+
+	dtos := make([]SavedQueryDTO, 0, len(savedQueries))
+	for _, savedQuery := range savedQueries {
+		dtos = append(dtos, savedQuery.toDTO())
+	}
+
+	return SavedQuerySearchResult{
+		TotalCount:   int(totalCount),
+		SavedQueries: dtos,
+		Page:         query.Page,
+		PerPage:      query.Limit,
+	}, nil
+}
+
+// getSavedQuery returns a saved query of the org, it is not found when the user cannot query its data source
+func (s QueryHistoryService) getSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) (SavedQueryDTO, error) {
+	dsFilter, err := datasourceQueryFilter(user)
+	if err != nil {
+		return SavedQueryDTO{}, err
+	}
+
+	var savedQuery SavedQuery
+	err = s.store.WithDbSession(ctx, func(session *db.Session) error {
+		params := append([]any{user.OrgID, UID}, dsFilter.Args...)
+		exists, err := session.Where("org_id = ? AND uid = ? AND"+dsFilter.Where, params...).Get(&savedQuery)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrSavedQueryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return SavedQueryDTO{}, err
+	}
+
+	return savedQuery.toDTO(), nil
+}
+
+// patchSavedQuery updates the title and description of a saved query
+func (s QueryHistoryService) patchSavedQuery(ctx context.Context, user *user.SignedInUser, UID string, cmd PatchSavedQueryCommand) (SavedQueryDTO, error) {
+	var savedQuery SavedQuery
+	err := s.store.WithDbSession(ctx, func(session *db.Session) error {
+		exists, err := session.Where("org_id = ? AND uid = ?", user.OrgID, UID).Get(&savedQuery)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrSavedQueryNotFound
+		}
+
+		if cmd.Title != nil {
+			if savedQuery.Title, err = validateSavedQueryTitle(*cmd.Title); err != nil {
+				return err
+			}
+		}
+		if cmd.Description != nil {
+			savedQuery.Description = *cmd.Description
+		}
+		savedQuery.UpdatedAt = s.now().Unix()
+
+		_, err = session.ID(savedQuery.ID).Cols("title", "description", "updated_at").Update(&savedQuery)
+		return err
+	})
+	if err != nil {
+		return SavedQueryDTO{}, err
+	}
+
+	return savedQuery.toDTO(), nil
+}
+
+func (s QueryHistoryService) deleteSavedQuery(ctx context.Context, user *user.SignedInUser, UID string) error {
+	return s.store.WithDbSession(ctx, func(session *db.Session) error {
+		count, err := session.Where("org_id = ? AND uid = ?", user.OrgID, UID).Delete(&SavedQuery{})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrSavedQueryNotFound
+		}
+		return nil
+	})
+}
+
+// datasourceQueryFilter restricts the saved queries to the data sources that the user can query
+func datasourceQueryFilter(user *user.SignedInUser) (ac.SQLFilter, error) {
+	return ac.Filter(user, "query_history_saved.datasource_uid", datasources.ScopePrefix, datasources.ActionQuery)
+}
+
+func validateSavedQueryTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > 190 {
+		return "", ErrInvalidSavedQueryTitle
+	}
+	return title, nil
+}
+
+func (q SavedQuery) toDTO() SavedQueryDTO {
+	return SavedQueryDTO{
+		UID:           q.UID,
+		DatasourceUID: q.DatasourceUID,
+		Title:         q.Title,
+		Description:   q.Description,
+		Queries:       q.Queries,
+		CreatedBy:     q.CreatedBy,
+		CreatedAt:     q.CreatedAt,
+		UpdatedAt:     q.UpdatedAt,
+	}
+}
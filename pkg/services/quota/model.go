@@ -21,6 +21,10 @@ var ErrInvalidTagFormat = errutil.Internal("quota.invalid-invalid-tag-format")
 type ScopeParameters struct {
 	OrgID  int64
 	UserID int64
+	// TeamID selects the team scope, usage is reported for the resources created by the team members
+	TeamID int64
+	// FolderUID selects the folder scope, usage is reported for the resources stored directly in the folder
+	FolderUID string
 }
 
 type Scope string
@@ -29,11 +33,13 @@ const (
 	GlobalScope Scope = "global"
 	OrgScope    Scope = "org"
 	UserScope   Scope = "user"
+	TeamScope   Scope = "team"
+	FolderScope Scope = "folder"
 )
 
 func (s Scope) Validate() error {
 	switch s {
-	case GlobalScope, OrgScope, UserScope:
+	case GlobalScope, OrgScope, UserScope, TeamScope, FolderScope:
 		return nil
 	default:
 		return ErrInvalidScope.Errorf("bad scope: %s", s)
@@ -181,23 +187,27 @@ func (m *Map) Targets() (map[Target]struct{}, error) {
 }
 
 type Quota struct {
-	Id      int64
-	OrgId   int64
-	UserId  int64
-	Target  string
-	Limit   int64
-	Created time.Time
-	Updated time.Time
+	Id        int64
+	OrgId     int64
+	UserId    int64
+	TeamId    int64
+	FolderUid string
+	Target    string
+	Limit     int64
+	Created   time.Time
+	Updated   time.Time
 }
 
 type QuotaDTO struct {
-	OrgId   int64  `json:"org_id,omitempty"`
-	UserId  int64  `json:"user_id,omitempty"`
-	Target  string `json:"target"`
-	Limit   int64  `json:"limit"`
-	Used    int64  `json:"used"`
-	Service string `json:"-"`
-	Scope   string `json:"-"`
+	OrgId     int64  `json:"org_id,omitempty"`
+	UserId    int64  `json:"user_id,omitempty"`
+	TeamId    int64  `json:"team_id,omitempty"`
+	FolderUid string `json:"folder_uid,omitempty"`
+	Target    string `json:"target"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Service   string `json:"-"`
+	Scope     string `json:"-"`
 }
 
 func (dto QuotaDTO) Tag() (Tag, error) {
@@ -205,10 +215,26 @@ func (dto QuotaDTO) Tag() (Tag, error) {
 }
 
 type UpdateQuotaCmd struct {
-	Target string `json:"target"`
-	Limit  int64  `json:"limit"`
-	OrgID  int64  `json:"-"`
-	UserID int64  `json:"-"`
+	Target    string `json:"target"`
+	Limit     int64  `json:"limit"`
+	OrgID     int64  `json:"-"`
+	UserID    int64  `json:"-"`
+	TeamID    int64  `json:"-"`
+	FolderUID string `json:"-"`
+}
+
+// Scope returns the scope of the quota updated by the command
+func (cmd *UpdateQuotaCmd) Scope() Scope {
+	switch {
+	case cmd.TeamID != 0:
+		return TeamScope
+	case cmd.FolderUID != "":
+		return FolderScope
+	case cmd.UserID != 0:
+		return UserScope
+	default:
+		return OrgScope
+	}
 }
 
 type NewUsageReporter struct {
@@ -11,14 +11,23 @@ type Service interface {
 	// If the scope is organization, the ID is expected to be the organisation ID.
 	// If the scope is user, the id is expected to be the user ID.
 	GetQuotasByScope(ctx context.Context, scope Scope, ID int64) ([]QuotaDTO, error)
-	// Update overrides the quota for a specific scope (global, organization, user).
+	// GetTeamQuotas returns the quota of a team of the organization
+	GetTeamQuotas(ctx context.Context, orgID int64, teamID int64) ([]QuotaDTO, error)
+	// GetFolderQuotas returns the quota of a folder of the organization
+	GetFolderQuotas(ctx context.Context, orgID int64, folderUID string) ([]QuotaDTO, error)
+	// Update overrides the quota for a specific scope (global, organization, user, team, folder).
 	// If the cmd.OrgID is set, then the organization quota are updated.
 	// If the cmd.UseID is set, then the user quota are updated.
+	// If the cmd.TeamID or cmd.FolderUID is set, then the team or folder quota of the cmd.OrgID are updated.
 	Update(ctx context.Context, cmd *UpdateQuotaCmd) error
 	// QuotaReached is called by the quota middleware for applying quota enforcement to API handlers
 	QuotaReached(c *contextmodel.ReqContext, targetSrv TargetSrv) (bool, error)
-	// CheckQuotaReached checks if the quota limitations have been reached for a specific service
+	// CheckQuotaReached checks if the quota limitations have been reached for a specific service.
+	// Team quotas of every team the scopeParams.UserID is a member of are checked as well.
 	CheckQuotaReached(ctx context.Context, targetSrv TargetSrv, scopeParams *ScopeParameters) (bool, error)
+	// CheckFolderQuotaReached checks if the folder quota of a specific service has been reached.
+	// Moving a resource into a folder only changes the folder usage, so the limits of the other scopes are not checked.
+	CheckFolderQuotaReached(ctx context.Context, targetSrv TargetSrv, orgID int64, folderUID string) (bool, error)
 	// DeleteQuotaForUser deletes custom quota limitations for the user
 	DeleteQuotaForUser(ctx context.Context, userID int64) error
 	// DeleteByOrg(ctx context.Context, orgID int64) error
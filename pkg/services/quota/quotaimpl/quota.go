@@ -28,6 +28,14 @@ func (s *serviceDisabled) GetQuotasByScope(ctx context.Context, scope quota.Scop
 	return nil, quota.ErrDisabled
 }
 
+func (s *serviceDisabled) GetTeamQuotas(ctx context.Context, orgID int64, teamID int64) ([]quota.QuotaDTO, error) {
+	return nil, quota.ErrDisabled
+}
+
+func (s *serviceDisabled) GetFolderQuotas(ctx context.Context, orgID int64, folderUID string) ([]quota.QuotaDTO, error) {
+	return nil, quota.ErrDisabled
+}
+
 func (s *serviceDisabled) Update(ctx context.Context, cmd *quota.UpdateQuotaCmd) error {
 	return quota.ErrDisabled
 }
@@ -36,6 +44,10 @@ func (s *serviceDisabled) CheckQuotaReached(ctx context.Context, targetSrv quota
 	return false, nil
 }
 
+func (s *serviceDisabled) CheckFolderQuotaReached(ctx context.Context, targetSrv quota.TargetSrv, orgID int64, folderUID string) (bool, error) {
+	return false, nil
+}
+
 func (s *serviceDisabled) DeleteQuotaForUser(ctx context.Context, userID int64) error {
 	return nil
 }
@@ -104,8 +116,6 @@ func (s *service) GetQuotasByScope(ctx context.Context, scope quota.Scope, id in
 		return nil, err
 	}
 
-	q := make([]quota.QuotaDTO, 0)
-
 	scopeParams := quota.ScopeParameters{}
 	switch scope {
 	case quota.GlobalScope:
@@ -114,15 +124,35 @@ func (s *service) GetQuotasByScope(ctx context.Context, scope quota.Scope, id in
 		scopeParams.OrgID = id
 	case quota.UserScope:
 		scopeParams.UserID = id
+	default:
+		return nil, quota.ErrInvalidScope.Errorf("scope %s requires an organization", scope)
 	}
 
+	return s.getQuotas(ctx, scope, &scopeParams)
+}
+
+func (s *service) GetTeamQuotas(ctx context.Context, orgID int64, teamID int64) ([]quota.QuotaDTO, error) {
+	ctx, span := tracer.Start(ctx, "quota-service.GetTeamQuotas")
+	defer span.End()
+	return s.getQuotas(ctx, quota.TeamScope, &quota.ScopeParameters{OrgID: orgID, TeamID: teamID})
+}
+
+func (s *service) GetFolderQuotas(ctx context.Context, orgID int64, folderUID string) ([]quota.QuotaDTO, error) {
+	ctx, span := tracer.Start(ctx, "quota-service.GetFolderQuotas")
+	defer span.End()
+	return s.getQuotas(ctx, quota.FolderScope, &quota.ScopeParameters{OrgID: orgID, FolderUID: folderUID})
+}
+
+func (s *service) getQuotas(ctx context.Context, scope quota.Scope, scopeParams *quota.ScopeParameters) ([]quota.QuotaDTO, error) {
+	q := make([]quota.QuotaDTO, 0)
+
 	c := quota.FromContext(ctx, s.targetToSrv)
-	customLimits, err := s.store.Get(c, &scopeParams)
+	customLimits, err := s.store.Get(c, scopeParams)
 	if err != nil {
 		return nil, err
 	}
 
-	u, err := s.getUsage(ctx, &scopeParams)
+	u, err := s.getUsage(ctx, scopeParams)
 	if err != nil {
 		return nil, err
 	}
@@ -155,13 +185,15 @@ func (s *service) GetQuotasByScope(ctx context.Context, scope quota.Scope, id in
 
 		used, _ := u.Get(item.Tag)
 		q = append(q, quota.QuotaDTO{
-			Target:  string(target),
-			Limit:   limit,
-			OrgId:   scopeParams.OrgID,
-			UserId:  scopeParams.UserID,
-			Used:    used,
-			Service: string(srv),
-			Scope:   string(scope),
+			Target:    string(target),
+			Limit:     limit,
+			OrgId:     scopeParams.OrgID,
+			UserId:    scopeParams.UserID,
+			TeamId:    scopeParams.TeamID,
+			FolderUid: scopeParams.FolderUID,
+			Used:      used,
+			Service:   string(srv),
+			Scope:     string(scope),
 		})
 	}
 
@@ -186,6 +218,18 @@ func (s *service) Update(ctx context.Context, cmd *quota.UpdateQuotaCmd) error {
 		return quota.ErrInvalidTarget.Errorf("unknown quota target: %s", cmd.Target)
 	}
 
+	// team and folder quotas are only supported by the targets reporting their usage
+	if scope := cmd.Scope(); scope == quota.TeamScope || scope == quota.FolderScope {
+		srv, _ := s.targetToSrv.Get(quota.Target(cmd.Target))
+		tag, err := quota.NewTag(srv, quota.Target(cmd.Target), scope)
+		if err != nil {
+			return err
+		}
+		if _, ok := s.defaultLimits.Get(tag); !ok {
+			return quota.ErrInvalidScope.Errorf("quota target %s does not support the %s scope", cmd.Target, scope)
+		}
+	}
+
 	c := quota.FromContext(ctx, s.targetToSrv)
 	return s.store.Update(c, cmd)
 }
@@ -194,6 +238,37 @@ func (s *service) Update(ctx context.Context, cmd *quota.UpdateQuotaCmd) error {
 func (s *service) CheckQuotaReached(ctx context.Context, targetSrv quota.TargetSrv, scopeParams *quota.ScopeParameters) (bool, error) {
 	ctx, span := tracer.Start(ctx, "quota-service.CheckQuotaReached")
 	defer span.End()
+	reached, err := s.checkQuotaReached(ctx, targetSrv, scopeParams, "")
+	if err != nil || reached {
+		return reached, err
+	}
+
+	// do not check team quota if the user information is not available (eg no user is signed in)
+	if scopeParams == nil || scopeParams.OrgID == 0 || scopeParams.UserID == 0 {
+		return false, nil
+	}
+
+	teamIDs, err := s.store.GetLimitedTeams(quota.FromContext(ctx, s.targetToSrv), scopeParams.OrgID, scopeParams.UserID)
+	if err != nil {
+		return false, err
+	}
+	for _, teamID := range teamIDs {
+		reached, err := s.checkQuotaReached(ctx, targetSrv, &quota.ScopeParameters{OrgID: scopeParams.OrgID, TeamID: teamID}, quota.TeamScope)
+		if err != nil || reached {
+			return reached, err
+		}
+	}
+	return false, nil
+}
+
+func (s *service) CheckFolderQuotaReached(ctx context.Context, targetSrv quota.TargetSrv, orgID int64, folderUID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "quota-service.CheckFolderQuotaReached")
+	defer span.End()
+	return s.checkQuotaReached(ctx, targetSrv, &quota.ScopeParameters{OrgID: orgID, FolderUID: folderUID}, quota.FolderScope)
+}
+
+// checkQuotaReached checks the limits of a single set of scope parameters. If onlyScope is set, the limits of the other scopes are ignored
+func (s *service) checkQuotaReached(ctx context.Context, targetSrv quota.TargetSrv, scopeParams *quota.ScopeParameters, onlyScope quota.Scope) (bool, error) {
 	targetSrvLimits, err := s.getOverriddenLimits(ctx, targetSrv, scopeParams)
 	if err != nil {
 		return false, err
@@ -209,17 +284,27 @@ func (s *service) CheckQuotaReached(ctx context.Context, targetSrv quota.TargetS
 	}
 
 	for t, limit := range targetSrvLimits {
+		scope, err := t.GetScope()
+		if err != nil {
+			return false, quota.ErrFailedToGetScope.Errorf("failed to get the scope for target: %s", t)
+		}
+
+		if onlyScope != "" && scope != onlyScope {
+			continue
+		}
+
+		// team and folder quota only apply when the team or folder is known
+		if (scope == quota.TeamScope && (scopeParams == nil || scopeParams.TeamID == 0)) ||
+			(scope == quota.FolderScope && (scopeParams == nil || scopeParams.FolderUID == "")) {
+			continue
+		}
+
 		switch {
 		case limit < 0:
 			continue
 		case limit == 0:
 			return true, nil
 		default:
-			scope, err := t.GetScope()
-			if err != nil {
-				return false, quota.ErrFailedToGetScope.Errorf("failed to get the scope for target: %s", t)
-			}
-
 			// do not check user quota if the user information is not available (eg no user is signed in)
 			if scope == quota.UserScope && (scopeParams == nil || scopeParams.UserID == 0) {
 				continue
@@ -13,6 +13,8 @@ type store interface {
 	Get(ctx quota.Context, scopeParams *quota.ScopeParameters) (*quota.Map, error)
 	Update(ctx quota.Context, cmd *quota.UpdateQuotaCmd) error
 	DeleteByUser(quota.Context, int64) error
+	// GetLimitedTeams returns the teams of the user that have custom quota
+	GetLimitedTeams(ctx quota.Context, orgID int64, userID int64) ([]int64, error)
 }
 
 type sqlStore struct {
@@ -50,18 +52,48 @@ func (ss *sqlStore) Get(ctx quota.Context, scopeParams *quota.ScopeParameters) (
 		limits.Merge(userLimits)
 	}
 
+	if scopeParams.TeamID != 0 {
+		teamLimits, err := ss.getScopeQuota(ctx, quota.TeamScope, "org_id=? AND team_id=?", scopeParams.OrgID, scopeParams.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		limits.Merge(teamLimits)
+	}
+
+	if scopeParams.FolderUID != "" {
+		folderLimits, err := ss.getScopeQuota(ctx, quota.FolderScope, "org_id=? AND folder_uid=?", scopeParams.OrgID, scopeParams.FolderUID)
+		if err != nil {
+			return nil, err
+		}
+		limits.Merge(folderLimits)
+	}
+
 	return &limits, nil
 }
 
+func (ss *sqlStore) GetLimitedTeams(ctx quota.Context, orgID int64, userID int64) ([]int64, error) {
+	teamIDs := make([]int64, 0)
+	err := ss.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.SQL(`SELECT DISTINCT quota.team_id FROM quota
+			INNER JOIN team_member ON team_member.team_id = quota.team_id
+			WHERE quota.org_id = ? AND quota.team_id <> 0 AND team_member.user_id = ?`, orgID, userID).Find(&teamIDs)
+	})
+	return teamIDs, err
+}
+
 func (ss *sqlStore) Update(ctx quota.Context, cmd *quota.UpdateQuotaCmd) error {
 	return ss.db.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		// Check if quota is already defined in the DB
 		quota := quota.Quota{
-			Target: cmd.Target,
-			UserId: cmd.UserID,
-			OrgId:  cmd.OrgID,
+			Target:    cmd.Target,
+			UserId:    cmd.UserID,
+			OrgId:     cmd.OrgID,
+			TeamId:    cmd.TeamID,
+			FolderUid: cmd.FolderUID,
 		}
-		has, err := sess.Get(&quota)
+		// zero values are not used as conditions by sess.Get so the scope columns are matched explicitly
+		has, err := sess.Where("org_id=? AND user_id=? AND team_id=? AND folder_uid=? AND target=?",
+			cmd.OrgID, cmd.UserID, cmd.TeamID, cmd.FolderUID, cmd.Target).Get(&quota)
 		if err != nil {
 			return err
 		}
@@ -86,34 +118,18 @@ func (ss *sqlStore) Update(ctx quota.Context, cmd *quota.UpdateQuotaCmd) error {
 }
 
 func (ss *sqlStore) getUserScopeQuota(ctx quota.Context, userID int64) (*quota.Map, error) {
-	r := quota.Map{}
-	err := ss.db.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
-		quotas := make([]*quota.Quota, 0)
-		if err := sess.Table("quota").Where("user_id=? AND org_id=0", userID).Find(&quotas); err != nil {
-			return err
-		}
-
-		for _, q := range quotas {
-			srv, ok := ctx.TargetToSrv.Get(quota.Target(q.Target))
-			if !ok {
-				ss.logger.Info("failed to get service for target", "target", q.Target)
-			}
-			tag, err := quota.NewTag(srv, quota.Target(q.Target), quota.UserScope)
-			if err != nil {
-				return err
-			}
-			r.Set(tag, q.Limit)
-		}
-		return nil
-	})
-	return &r, err
+	return ss.getScopeQuota(ctx, quota.UserScope, "user_id=? AND org_id=0", userID)
 }
 
 func (ss *sqlStore) getOrgScopeQuota(ctx quota.Context, OrgID int64) (*quota.Map, error) {
+	return ss.getScopeQuota(ctx, quota.OrgScope, "user_id=0 AND org_id=? AND team_id=0 AND folder_uid=''", OrgID)
+}
+
+func (ss *sqlStore) getScopeQuota(ctx quota.Context, scope quota.Scope, where string, args ...any) (*quota.Map, error) {
 	r := quota.Map{}
 	err := ss.db.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
 		quotas := make([]*quota.Quota, 0)
-		if err := sess.Table("quota").Where("user_id=0 AND org_id=?", OrgID).Find(&quotas); err != nil {
+		if err := sess.Table("quota").Where(where, args...).Find(&quotas); err != nil {
 			return err
 		}
 
@@ -122,7 +138,7 @@ func (ss *sqlStore) getOrgScopeQuota(ctx quota.Context, OrgID int64) (*quota.Map
 			if !ok {
 				ss.logger.Info("failed to get service for target", "target", q.Target)
 			}
-			tag, err := quota.NewTag(srv, quota.Target(q.Target), quota.OrgScope)
+			tag, err := quota.NewTag(srv, quota.Target(q.Target), scope)
 			if err != nil {
 				return err
 			}
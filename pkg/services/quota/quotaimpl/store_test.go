@@ -3,10 +3,12 @@ package quotaimpl
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
 	"github.com/grafana/grafana/pkg/util/testutil"
@@ -30,3 +32,133 @@ func TestIntegrationQuotaDataAccess(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestIntegrationTeamAndFolderQuota(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	ss := db.InitTestDB(t)
+	quotaService := &service{
+		store:         &sqlStore{db: ss, logger: log.NewNopLogger()},
+		logger:        log.NewNopLogger(),
+		reporters:     make(map[quota.TargetSrv]quota.UsageReporterFunc),
+		defaultLimits: &quota.Map{},
+		targetToSrv:   quota.NewTargetToSrv(),
+	}
+
+	const (
+		srv    quota.TargetSrv = "widget"
+		target quota.Target    = "widget"
+	)
+	teamTag, err := quota.NewTag(srv, target, quota.TeamScope)
+	require.NoError(t, err)
+	folderTag, err := quota.NewTag(srv, target, quota.FolderScope)
+	require.NoError(t, err)
+
+	defaultLimits := &quota.Map{}
+	defaultLimits.Set(teamTag, -1)
+	defaultLimits.Set(folderTag, -1)
+	err = quotaService.RegisterQuotaReporter(&quota.NewUsageReporter{
+		TargetSrv:     srv,
+		DefaultLimits: defaultLimits,
+		Reporter: func(ctx context.Context, scopeParams *quota.ScopeParameters) (*quota.Map, error) {
+			u := &quota.Map{}
+			if scopeParams.TeamID != 0 {
+				u.Set(teamTag, 2)
+			}
+			if scopeParams.FolderUID != "" {
+				u.Set(folderTag, 1)
+			}
+			return u, nil
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = ss.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("INSERT INTO team_member (org_id, team_id, user_id, created, updated) VALUES (?, ?, ?, ?, ?)", 1, 10, 100, time.Now(), time.Now())
+		return err
+	})
+	require.NoError(t, err)
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		reached, err := quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, UserID: 100, FolderUID: "folder"})
+		require.NoError(t, err)
+		require.False(t, reached)
+	})
+
+	t.Run("team quota applies to the members of the team", func(t *testing.T) {
+		err := quotaService.Update(ctx, &quota.UpdateQuotaCmd{Target: string(target), Limit: 2, OrgID: 1, TeamID: 10})
+		require.NoError(t, err)
+
+		q, err := quotaService.GetTeamQuotas(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, q, 1)
+		require.Equal(t, int64(2), q[0].Limit)
+		require.Equal(t, int64(2), q[0].Used)
+		require.Equal(t, int64(10), q[0].TeamId)
+
+		reached, err := quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, UserID: 100})
+		require.NoError(t, err)
+		require.True(t, reached)
+
+		reached, err = quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, UserID: 200})
+		require.NoError(t, err)
+		require.False(t, reached)
+
+		err = quotaService.Update(ctx, &quota.UpdateQuotaCmd{Target: string(target), Limit: 3, OrgID: 1, TeamID: 10})
+		require.NoError(t, err)
+		reached, err = quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, UserID: 100})
+		require.NoError(t, err)
+		require.False(t, reached)
+	})
+
+	t.Run("folder quota applies to the folder only", func(t *testing.T) {
+		err := quotaService.Update(ctx, &quota.UpdateQuotaCmd{Target: string(target), Limit: 1, OrgID: 1, FolderUID: "folder"})
+		require.NoError(t, err)
+
+		q, err := quotaService.GetFolderQuotas(ctx, 1, "folder")
+		require.NoError(t, err)
+		require.Len(t, q, 1)
+		require.Equal(t, int64(1), q[0].Limit)
+		require.Equal(t, "folder", q[0].FolderUid)
+
+		reached, err := quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, FolderUID: "folder"})
+		require.NoError(t, err)
+		require.True(t, reached)
+
+		reached, err = quotaService.CheckQuotaReached(ctx, srv, &quota.ScopeParameters{OrgID: 1, FolderUID: "other"})
+		require.NoError(t, err)
+		require.False(t, reached)
+	})
+
+	t.Run("moving into a folder only checks the folder quota", func(t *testing.T) {
+		err := quotaService.Update(ctx, &quota.UpdateQuotaCmd{Target: string(target), Limit: 2, OrgID: 1, TeamID: 10})
+		require.NoError(t, err)
+
+		reached, err := quotaService.CheckFolderQuotaReached(ctx, srv, 1, "folder")
+		require.NoError(t, err)
+		require.True(t, reached)
+
+		reached, err = quotaService.CheckFolderQuotaReached(ctx, srv, 1, "other")
+		require.NoError(t, err)
+		require.False(t, reached)
+	})
+
+	t.Run("targets without team or folder usage reject the scope", func(t *testing.T) {
+		orgTag, err := quota.NewTag("gadget", "gadget", quota.OrgScope)
+		require.NoError(t, err)
+		limits := &quota.Map{}
+		limits.Set(orgTag, 10)
+		err = quotaService.RegisterQuotaReporter(&quota.NewUsageReporter{
+			TargetSrv:     "gadget",
+			DefaultLimits: limits,
+			Reporter: func(ctx context.Context, scopeParams *quota.ScopeParameters) (*quota.Map, error) {
+				return &quota.Map{}, nil
+			},
+		})
+		require.NoError(t, err)
+
+		err = quotaService.Update(ctx, &quota.UpdateQuotaCmd{Target: "gadget", Limit: 1, OrgID: 1, TeamID: 10})
+		require.ErrorIs(t, err, quota.ErrInvalidScope)
+	})
+}
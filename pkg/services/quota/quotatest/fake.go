@@ -20,6 +20,14 @@ func (f *FakeQuotaService) GetQuotasByScope(ctx context.Context, scope quota.Sco
 	return []quota.QuotaDTO{}, nil
 }
 
+func (f *FakeQuotaService) GetTeamQuotas(ctx context.Context, orgID int64, teamID int64) ([]quota.QuotaDTO, error) {
+	return []quota.QuotaDTO{}, nil
+}
+
+func (f *FakeQuotaService) GetFolderQuotas(ctx context.Context, orgID int64, folderUID string) ([]quota.QuotaDTO, error) {
+	return []quota.QuotaDTO{}, nil
+}
+
 func (f *FakeQuotaService) Update(ctx context.Context, cmd *quota.UpdateQuotaCmd) error {
 	return nil
 }
@@ -32,6 +40,10 @@ func (f *FakeQuotaService) CheckQuotaReached(c context.Context, target quota.Tar
 	return f.reached, f.err
 }
 
+func (f *FakeQuotaService) CheckFolderQuotaReached(c context.Context, target quota.TargetSrv, orgID int64, folderUID string) (bool, error) {
+	return f.reached, f.err
+}
+
 func (f *FakeQuotaService) DeleteQuotaForUser(c context.Context, userID int64) error {
 	return f.err
 }
@@ -55,3 +67,7 @@ func (f *FakeQuotaStore) Get(ctx quota.Context, scopeParams *quota.ScopeParamete
 func (f *FakeQuotaStore) Update(ctx quota.Context, cmd *quota.UpdateQuotaCmd) error {
 	return f.ExpectedError
 }
+
+func (f *FakeQuotaStore) GetLimitedTeams(ctx quota.Context, orgID int64, userID int64) ([]int64, error) {
+	return nil, f.ExpectedError
+}
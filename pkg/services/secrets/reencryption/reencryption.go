@@ -0,0 +1,95 @@
+// Package reencryption re-encrypts all data keys with the current key provider on startup,
+// so data keys can be moved onto a new provider, e.g. an external KMS, without manual steps.
+package reencryption
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/serverlock"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	actionName = "re-encrypt data keys"
+	// lockTimeout bounds how long another instance waits for a crashed re-encryption before running it again
+	lockTimeout = 30 * time.Minute
+)
+
+type Service struct {
+	enabled            bool
+	secretsService     secrets.Service
+	globalDataKeyStore contracts.GlobalDataKeyStorage
+	providerConfig     encryption.ProviderConfig
+	lock               *serverlock.ServerLockService
+	log                log.Logger
+}
+
+func ProvideService(
+	cfg *setting.Cfg,
+	secretsService secrets.Service,
+	globalDataKeyStore contracts.GlobalDataKeyStorage,
+	providerConfig encryption.ProviderConfig,
+	lock *serverlock.ServerLockService,
+) *Service {
+	return &Service{
+		enabled:            cfg.SectionWithEnvOverrides("security.encryption").Key("re_encrypt_data_keys_on_startup").MustBool(false),
+		secretsService:     secretsService,
+		globalDataKeyStore: globalDataKeyStore,
+		providerConfig:     providerConfig,
+		lock:               lock,
+		log:                log.New("secrets.reencryption"),
+	}
+}
+
+func (s *Service) IsDisabled() bool {
+	return !s.enabled
+}
+
+// Run re-encrypts the data keys once. Only one instance of a high availability setup
+// does the work, the others skip it while the lock is held.
+// Failures are logged rather than returned so they don't stop the server.
+func (s *Service) Run(ctx context.Context) error {
+	err := s.lock.LockExecuteAndRelease(ctx, actionName, lockTimeout, func(ctx context.Context) {
+		if err := s.ReEncryptDataKeys(ctx); err != nil {
+			s.log.Error("Failed to re-encrypt data keys", "error", err)
+		}
+	})
+
+	var lockExistsErr *serverlock.ServerLockExistsError
+	if errors.As(err, &lockExistsErr) {
+		s.log.Info("Skipping data keys re-encryption, it is running on another instance")
+	} else if err != nil {
+		s.log.Error("Failed to acquire lock to re-encrypt data keys", "error", err)
+	}
+	return nil
+}
+
+// ReEncryptDataKeys re-encrypts the data keys of the secrets service and of secrets management
+// with their current providers.
+func (s *Service) ReEncryptDataKeys(ctx context.Context) error {
+	start := time.Now()
+	s.log.Info("Re-encrypting data keys of the secrets service")
+	if err := s.secretsService.ReEncryptDataKeys(ctx); err != nil {
+		return err
+	}
+
+	currentProvider := s.providerConfig.CurrentProvider
+	if _, ok := s.providerConfig.AvailableProviders[currentProvider]; !ok {
+		s.log.Warn("Skipping re-encryption of secrets management data keys, the current provider is not configured", "provider", currentProvider)
+		return nil
+	}
+
+	s.log.Info("Re-encrypting data keys of secrets management", "provider", currentProvider)
+	if err := s.globalDataKeyStore.ReEncryptDataKeys(ctx, s.providerConfig.AvailableProviders, currentProvider); err != nil {
+		return err
+	}
+
+	s.log.Info("Data keys re-encrypted", "duration", time.Since(start))
+	return nil
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -11,7 +12,12 @@ import (
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 )
 
-const maxRetrievedTokens = 300
+const (
+	maxRetrievedTokens = 300
+
+	tokenPolicyNamespace = "serviceaccounts"
+	tokenPolicyKey       = "token-policy"
+)
 
 func (s *ServiceAccountsStoreImpl) ListTokens(
 	ctx context.Context, query *serviceaccounts.GetSATokensQuery,
@@ -57,6 +63,9 @@ func (s *ServiceAccountsStoreImpl) AddServiceAccountToken(ctx context.Context, s
 			SecondsToLive:    cmd.SecondsToLive,
 			ServiceAccountID: &serviceAccountId,
 		}
+		if len(cmd.Permissions) > 0 {
+			addKeyCmd.Permissions = &cmd.Permissions
+		}
 
 		key, err := s.apiKeyService.AddAPIKey(ctx, addKeyCmd)
 		if err != nil {
@@ -131,3 +140,25 @@ func (s *ServiceAccountsStoreImpl) assignApiKeyToServiceAccount(ctx context.Cont
 		return nil
 	})
 }
+
+// GetTokenPolicy returns the token policy of an org, orgs without a policy get an empty one
+func (s *ServiceAccountsStoreImpl) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	policy := &serviceaccounts.TokenPolicy{}
+	value, ok, err := s.kvStore.Get(ctx, orgID, tokenPolicyNamespace, tokenPolicyKey)
+	if err != nil || !ok {
+		return policy, err
+	}
+
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return nil, fmt.Errorf("failed to decode token policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (s *ServiceAccountsStoreImpl) SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return s.kvStore.Set(ctx, orgID, tokenPolicyNamespace, tokenPolicyKey, string(value))
+}
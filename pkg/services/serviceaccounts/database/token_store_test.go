@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/components/satokengen"
+	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts/tests"
 	"github.com/grafana/grafana/pkg/util/testutil"
@@ -98,6 +99,56 @@ func TestIntegration_Store_AddServiceAccountToken_WrongServiceAccount(t *testing
 	require.Error(t, err, "It should not be possible to add token to non-existing service account")
 }
 
+func TestIntegration_Store_AddServiceAccountToken_Permissions(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	saToCreate := tests.TestUser{Login: "servicetestwithTeam@admin", IsServiceAccount: true}
+	db, store := setupTestDatabase(t)
+	sa := tests.SetupUserServiceAccount(t, db, store.cfg, saToCreate)
+
+	permissions := apikey.Permissions{{Action: "dashboards:read", Scope: "folders:uid:ci"}, {Action: "annotations:create"}}
+	for _, name := range []string{"scoped", "unscoped"} {
+		key, err := satokengen.New(name)
+		require.NoError(t, err)
+
+		cmd := serviceaccounts.AddServiceAccountTokenCommand{Name: name, OrgId: sa.OrgID, Key: key.HashedKey}
+		if name == "scoped" {
+			cmd.Permissions = permissions
+		}
+		_, err = store.AddServiceAccountToken(context.Background(), sa.ID, &cmd)
+		require.NoError(t, err)
+	}
+
+	keys, err := store.ListTokens(context.Background(), &serviceaccounts.GetSATokensQuery{OrgID: &sa.OrgID, ServiceAccountID: &sa.ID})
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	require.Equal(t, "scoped", keys[0].Name)
+	require.True(t, keys[0].IsRestricted())
+	require.Equal(t, permissions, *keys[0].Permissions)
+	require.False(t, keys[1].IsRestricted())
+}
+
+func TestIntegration_Store_TokenPolicy(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	_, store := setupTestDatabase(t)
+
+	policy, err := store.GetTokenPolicy(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, &serviceaccounts.TokenPolicy{}, policy)
+
+	require.NoError(t, store.SetTokenPolicy(context.Background(), 1, &serviceaccounts.TokenPolicy{MaxSecondsToLive: 3600}))
+
+	policy, err = store.GetTokenPolicy(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3600), policy.MaxSecondsToLive)
+
+	policy, err = store.GetTokenPolicy(context.Background(), 2)
+	require.NoError(t, err)
+	require.Zero(t, policy.MaxSecondsToLive)
+}
+
 func TestIntegration_Store_RevokeServiceAccountToken(t *testing.T) {
 	testutil.SkipIntegrationTestInShortMode(t)
 
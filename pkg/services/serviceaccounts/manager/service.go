@@ -285,9 +285,38 @@ func (sa *ServiceAccountsService) AddServiceAccountToken(ctx context.Context, se
 	if err := validServiceAccountID(serviceAccountID); err != nil {
 		return nil, err
 	}
+	if err := validTokenPermissions(query.Permissions); err != nil {
+		return nil, err
+	}
+
+	policy, err := sa.store.GetTokenPolicy(ctx, query.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if policy.MaxSecondsToLive > 0 && (query.SecondsToLive <= 0 || query.SecondsToLive > policy.MaxSecondsToLive) {
+		return nil, serviceaccounts.ErrTokenLifetimeExceeded.Errorf("token lifetime must be between 1 and %d seconds", policy.MaxSecondsToLive)
+	}
+
 	return sa.store.AddServiceAccountToken(ctx, serviceAccountID, query)
 }
 
+func (sa *ServiceAccountsService) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	if err := validOrgID(orgID); err != nil {
+		return nil, err
+	}
+	return sa.store.GetTokenPolicy(ctx, orgID)
+}
+
+func (sa *ServiceAccountsService) SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	if err := validOrgID(orgID); err != nil {
+		return err
+	}
+	if policy.MaxSecondsToLive < 0 {
+		return serviceaccounts.ErrInvalidTokenPolicy.Errorf("max seconds to live can not be negative")
+	}
+	return sa.store.SetTokenPolicy(ctx, orgID, policy)
+}
+
 func (sa *ServiceAccountsService) DeleteServiceAccountToken(ctx context.Context, orgID, serviceAccountID int64, tokenID int64) error {
 	if err := validOrgID(orgID); err != nil {
 		return err
@@ -374,6 +403,18 @@ func validServiceAccountUID(saUID string) error {
 	return nil
 }
 
+func validTokenPermissions(permissions apikey.Permissions) error {
+	for _, p := range permissions {
+		if p.Action == "" {
+			return serviceaccounts.ErrInvalidTokenPermissions.Errorf("permission action can not be empty")
+		}
+		if p.Scope != "" && !accesscontrol.ValidateScope(p.Scope) {
+			return serviceaccounts.ErrInvalidTokenPermissions.Errorf("invalid scope %q for action %s", p.Scope, p.Action)
+		}
+	}
+	return nil
+}
+
 func validServiceAccountTokenID(tokenID int64) error {
 	if tokenID == 0 {
 		return serviceaccounts.ErrServiceAccountInvalidTokenID.Errorf("invalid service account token ID 0 has been specified")
@@ -26,6 +26,7 @@ type FakeServiceAccountStore struct {
 	ExpectedAPIKeys                         []apikey.APIKey
 	ExpectedAPIKey                          *apikey.APIKey
 	ExpectedBoolean                         bool
+	ExpectedTokenPolicy                     *serviceaccounts.TokenPolicy
 	ExpectedError                           error
 }
 
@@ -101,6 +102,20 @@ func (f *FakeServiceAccountStore) DeleteServiceAccountToken(ctx context.Context,
 	return f.ExpectedError
 }
 
+// GetTokenPolicy is a fake getting the token policy of an org.
+func (f *FakeServiceAccountStore) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	if f.ExpectedTokenPolicy == nil {
+		return &serviceaccounts.TokenPolicy{}, f.ExpectedError
+	}
+	return f.ExpectedTokenPolicy, f.ExpectedError
+}
+
+// SetTokenPolicy is a fake setting the token policy of an org.
+func (f *FakeServiceAccountStore) SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	f.ExpectedTokenPolicy = policy
+	return f.ExpectedError
+}
+
 // GetUsageMetrics is a fake getting usage metrics.
 func (f *FakeServiceAccountStore) GetUsageMetrics(ctx context.Context) (*serviceaccounts.Stats, error) {
 	return f.ExpectedStats, f.ExpectedError
@@ -158,3 +173,44 @@ func TestIntegrationProvideServiceAccount_DeleteServiceAccount(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestProvideServiceAccount_AddServiceAccountToken(t *testing.T) {
+	storeMock := newServiceAccountStoreFake()
+	storeMock.ExpectedAPIKey = &apikey.APIKey{ID: 1}
+	svc := ServiceAccountsService{store: storeMock, log: log.NewNopLogger()}
+
+	t.Run("should create token without a policy", func(t *testing.T) {
+		_, err := svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{Name: "ci", OrgId: 1})
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject invalid permissions", func(t *testing.T) {
+		_, err := svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{
+			Name: "ci", OrgId: 1, Permissions: apikey.Permissions{{Action: "dashboards:read", Scope: "folders:*:uid"}},
+		})
+		require.ErrorIs(t, err, serviceaccounts.ErrInvalidTokenPermissions)
+
+		_, err = svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{
+			Name: "ci", OrgId: 1, Permissions: apikey.Permissions{{Scope: "folders:uid:abc"}},
+		})
+		require.ErrorIs(t, err, serviceaccounts.ErrInvalidTokenPermissions)
+	})
+
+	t.Run("should enforce the max lifetime of the org", func(t *testing.T) {
+		require.NoError(t, svc.SetTokenPolicy(context.Background(), 1, &serviceaccounts.TokenPolicy{MaxSecondsToLive: 3600}))
+
+		_, err := svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{Name: "ci", OrgId: 1})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenLifetimeExceeded)
+
+		_, err = svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{Name: "ci", OrgId: 1, SecondsToLive: 7200})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenLifetimeExceeded)
+
+		_, err = svc.AddServiceAccountToken(context.Background(), 1, &serviceaccounts.AddServiceAccountTokenCommand{Name: "ci", OrgId: 1, SecondsToLive: 600})
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject negative max lifetime", func(t *testing.T) {
+		err := svc.SetTokenPolicy(context.Background(), 1, &serviceaccounts.TokenPolicy{MaxSecondsToLive: -1})
+		require.ErrorIs(t, err, serviceaccounts.ErrInvalidTokenPolicy)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/org"
 )
 
@@ -40,6 +41,9 @@ var (
 	ErrServiceAccountTokenNotFound       = errutil.NotFound("serviceaccounts.ErrTokenNotFound", errutil.WithPublicMessage("service account token not found"))
 	ErrInvalidTokenExpiration            = errutil.ValidationFailed("serviceaccounts.ErrInvalidInput", errutil.WithPublicMessage("invalid SecondsToLive value"))
 	ErrDuplicateToken                    = errutil.BadRequest("serviceaccounts.ErrTokenAlreadyExists", errutil.WithPublicMessage("service account token with given name already exists in the organization"))
+	ErrTokenLifetimeExceeded             = errutil.BadRequest("serviceaccounts.ErrTokenLifetimeExceeded", errutil.WithPublicMessage("token lifetime exceeds the maximum allowed by the organization"))
+	ErrInvalidTokenPermissions           = errutil.BadRequest("serviceaccounts.ErrInvalidTokenPermissions", errutil.WithPublicMessage("invalid token permissions"))
+	ErrInvalidTokenPolicy                = errutil.BadRequest("serviceaccounts.ErrInvalidTokenPolicy", errutil.WithPublicMessage("invalid token policy"))
 )
 
 type MigrationResult struct {
@@ -109,6 +113,17 @@ type AddServiceAccountTokenCommand struct {
 	OrgId         int64  `json:"-"`
 	Key           string `json:"-"`
 	SecondsToLive int64  `json:"secondsToLive"`
+	// Permissions restricts the token to these actions and scopes, the token
+	// has all permissions of the service account when empty
+	Permissions apikey.Permissions `json:"permissions,omitempty"`
+}
+
+// TokenPolicy is enforced when creating service account tokens in an org
+// swagger:model
+type TokenPolicy struct {
+	// MaxSecondsToLive is the maximum lifetime of new tokens, tokens without expiry are rejected when set
+	// example: 2592000
+	MaxSecondsToLive int64 `json:"maxSecondsToLive"`
 }
 
 type SearchOrgServiceAccountsQuery struct {
@@ -40,6 +40,9 @@ type Service interface {
 		cmd *AddServiceAccountTokenCommand) (*apikey.APIKey, error)
 	DeleteServiceAccountToken(ctx context.Context, orgID, serviceAccountID, tokenID int64) error
 	ListTokens(ctx context.Context, query *GetSATokensQuery) ([]apikey.APIKey, error)
+	// GetTokenPolicy returns the policy enforced when creating tokens in an org
+	GetTokenPolicy(ctx context.Context, orgID int64) (*TokenPolicy, error)
+	SetTokenPolicy(ctx context.Context, orgID int64, policy *TokenPolicy) error
 
 	MigrateApiKeysToServiceAccounts(ctx context.Context, orgID int64) (*MigrationResult, error)
 }
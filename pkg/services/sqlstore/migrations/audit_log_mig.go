@@ -0,0 +1,41 @@
+package migrations
+
+import . "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+func addAuditLogMigrations(mg *Migrator) {
+	auditLogV1 := Table{
+		Name: "audit_log",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "actor_uid", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "actor_login", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "actor_type", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "source", Type: DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "action", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "resource", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "resource_id", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "method", Type: DB_NVarchar, Length: 10, Nullable: false},
+			{Name: "path", Type: DB_Text, Nullable: false},
+			{Name: "before_hash", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "after_hash", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "outcome", Type: DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "status_code", Type: DB_Int, Nullable: false, Default: "0"},
+			{Name: "remote_addr", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "created"}},
+			{Cols: []string{"org_id", "actor_uid"}},
+			{Cols: []string{"org_id", "resource", "resource_id"}},
+			{Cols: []string{"created"}},
+		},
+	}
+
+	mg.AddMigration("create audit_log table", NewAddTableMigration(auditLogV1))
+	addTableIndicesMigrations(mg, "v1", auditLogV1)
+
+	mg.AddMigration("add state_tracked column to audit_log", NewAddColumnMigration(auditLogV1, &Column{
+		Name: "state_tracked", Type: DB_Bool, Nullable: false, Default: "0",
+	}))
+}
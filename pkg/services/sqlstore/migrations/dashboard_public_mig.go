@@ -96,4 +96,18 @@ func addPublicDashboardMigration(mg *Migrator) {
 	mg.AddMigration("backfill empty share column fields with default of public", NewRawSQLMigration(
 		"UPDATE dashboard_public SET share='public' WHERE share=''",
 	))
+
+	mg.AddMigration("add rate_limit_per_minute column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "rate_limit_per_minute",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add query_cache_ttl_seconds column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "query_cache_ttl_seconds",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
 }
@@ -71,4 +71,33 @@ func addDashboardSnapshotMigrations(mg *Migrator) {
 
 	mg.AddMigration("Change dashboard_encrypted column to MEDIUMBLOB", NewRawSQLMigration("").
 		Mysql("ALTER TABLE dashboard_snapshot MODIFY dashboard_encrypted MEDIUMBLOB;"))
+
+	mg.AddMigration("Add dashboard_uid column to dashboard_snapshot table", NewAddColumnMigration(snapshotV5, &Column{
+		Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: true,
+	}))
+
+	mg.AddMigration("Add index for org_id and dashboard_uid in dashboard_snapshot table", NewAddIndexMigration(snapshotV5, &Index{
+		Cols: []string{"org_id", "dashboard_uid"},
+	}))
+
+	snapshotView := Table{
+		Name: "dashboard_snapshot_view",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "snapshot_key", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "user_login", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "view_count", Type: DB_BigInt, Nullable: false},
+			{Name: "first_viewed", Type: DB_DateTime, Nullable: false},
+			{Name: "last_viewed", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"snapshot_key", "user_id", "user_login"}, Type: UniqueIndex},
+			{Cols: []string{"org_id", "snapshot_key"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard_snapshot_view table", NewAddTableMigration(snapshotView))
+	addTableIndicesMigrations(mg, "v1", snapshotView)
 }
@@ -155,4 +155,8 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	ualert.DropTitleUniqueIndexMigration(mg)
 
 	ualert.AddStateFiredAtColumn(mg)
+
+	addWebAuthnMigrations(mg)
+
+	addAuditLogMigrations(mg)
 }
@@ -39,4 +39,27 @@ func addQueryHistoryMigrations(mg *Migrator) {
 		},
 	}
 	mg.AddMigration("create query_history_details table v1", NewAddTableMigration(queryHistoryDetailsV1))
+
+	queryHistorySavedV1 := Table{
+		Name: "query_history_saved",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "datasource_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "title", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "description", Type: DB_Text, Nullable: false},
+			{Name: "queries", Type: DB_Text, Nullable: false},
+			{Name: "created_by", Type: DB_BigInt, Nullable: false},
+			{Name: "created_at", Type: DB_BigInt, Nullable: false},
+			{Name: "updated_at", Type: DB_BigInt, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "uid"}, Type: UniqueIndex},
+			{Cols: []string{"org_id", "datasource_uid"}},
+		},
+	}
+
+	mg.AddMigration("create query_history_saved table v1", NewAddTableMigration(queryHistorySavedV1))
+	addTableIndicesMigrations(mg, "v1", queryHistorySavedV1)
 }
@@ -28,4 +28,21 @@ func addQuotaMigration(mg *Migrator) {
 	mg.AddMigration("Update quota table charset", NewTableCharsetMigration("quota", []*Column{
 		{Name: "target", Type: DB_NVarchar, Length: 190, Nullable: false},
 	}))
+
+	mg.AddMigration("Add team_id column to quota table", NewAddColumnMigration(quotaV1, &Column{
+		Name: "team_id", Type: DB_BigInt, Nullable: false, Default: "0",
+	}))
+
+	mg.AddMigration("Add folder_uid column to quota table", NewAddColumnMigration(quotaV1, &Column{
+		Name: "folder_uid", Type: DB_NVarchar, Length: 40, Nullable: false, Default: "''",
+	}))
+
+	// team and folder quotas share the org_id of their org quota
+	mg.AddMigration("Remove unique index org_id_user_id_target from quota table", NewDropIndexMigration(quotaV1, &Index{
+		Cols: []string{"org_id", "user_id", "target"}, Type: UniqueIndex,
+	}))
+
+	mg.AddMigration("Add unique index org_id_user_id_team_id_folder_uid_target to quota table", NewAddIndexMigration(quotaV1, &Index{
+		Cols: []string{"org_id", "user_id", "team_id", "folder_uid", "target"}, Type: UniqueIndex,
+	}))
 }
@@ -0,0 +1,28 @@
+package migrations
+
+import . "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+func addWebAuthnMigrations(mg *Migrator) {
+	webAuthnCredentialV1 := Table{
+		Name: "webauthn_credential",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "name", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "credential_id", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "public_key", Type: DB_Blob, Nullable: false},
+			{Name: "sign_count", Type: DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "aaguid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "transports", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "last_used", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"credential_id"}, Type: UniqueIndex},
+			{Cols: []string{"user_id"}},
+		},
+	}
+
+	mg.AddMigration("create webauthn_credential table", NewAddTableMigration(webAuthnCredentialV1))
+	addTableIndicesMigrations(mg, "v1", webAuthnCredentialV1)
+}
@@ -126,6 +126,20 @@ func (f DashboardFilter) Where() (string, []any) {
 	return sqlUIDin("dashboard.uid", f.UIDs)
 }
 
+// CreatedByFilter matches the dashboards created by the users with the given uids
+type CreatedByFilter struct {
+	Dialect  migrator.Dialect
+	UserUIDs []string
+}
+
+func (f CreatedByFilter) Where() (string, []any) {
+	if len(f.UserUIDs) < 1 {
+		return "", nil
+	}
+	sql, params := sqlUIDin("uid", f.UserUIDs)
+	return fmt.Sprintf("dashboard.created_by IN (SELECT id FROM %s WHERE %s)", f.Dialect.Quote("user"), sql), params
+}
+
 type K6FolderFilter struct{}
 
 func (f K6FolderFilter) Where() (string, []any) {
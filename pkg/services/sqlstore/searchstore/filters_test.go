@@ -101,3 +101,17 @@ func TestTitleFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatedByFilter(t *testing.T) {
+	store := setupTestEnvironment(t)
+
+	f := searchstore.CreatedByFilter{
+		Dialect:  store.GetDialect(),
+		UserUIDs: []string{"user-1", "user-2"},
+	}
+
+	sql, params := f.Where()
+
+	assert.Equal(t, `dashboard.created_by IN (SELECT id FROM `+store.GetDialect().Quote("user")+` WHERE uid IN (?,?))`, sql)
+	assert.Equal(t, []any{"user-1", "user-2"}, params)
+}
@@ -1,12 +1,14 @@
 package store
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/secrets/kvstore"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -22,6 +24,10 @@ type GlobalStorageConfig struct {
 
 	// Paths under 'root' (NOTE: this is applied to all orgs)
 	Roots []RootStorageConfig `json:"roots"`
+
+	// Object storage (s3, gcs or azure) for the uploaded resources of all orgs, instead of the database.
+	// Each org uses the <orgId>/resources/ folder of the bucket.
+	Resources *RootStorageConfig `json:"resources,omitempty"`
 }
 
 func LoadStorageConfig(cfg *setting.Cfg, features featuremgmt.FeatureToggles) (*GlobalStorageConfig, error) {
@@ -58,6 +64,32 @@ func LoadStorageConfig(cfg *setting.Cfg, features featuremgmt.FeatureToggles) (*
 	return g, nil
 }
 
+// moveSecrets moves the credentials of object storage roots written in storage.json to the secrets store,
+// and saves the config without them.
+func (c *GlobalStorageConfig) moveSecrets(ctx context.Context, secretsStore kvstore.SecretsKVStore) error {
+	changed := false
+	for i := range c.Roots {
+		moved, err := moveRootStorageSecrets(ctx, secretsStore, &c.Roots[i])
+		if err != nil {
+			return err
+		}
+		changed = changed || moved
+	}
+	if c.Resources != nil {
+		c.Resources.Prefix = RootResources
+		moved, err := moveRootStorageSecrets(ctx, secretsStore, c.Resources)
+		if err != nil {
+			return err
+		}
+		changed = changed || moved
+	}
+
+	if changed {
+		return c.save()
+	}
+	return nil
+}
+
 func (c *GlobalStorageConfig) save() error {
 	out, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
@@ -79,11 +111,12 @@ type RootStorageConfig struct {
 	Disabled         bool   `json:"disabled,omitempty"`
 
 	// Depending on type, these will be configured
-	Disk *StorageLocalDiskConfig `json:"disk,omitempty"`
-	Git  *StorageGitConfig       `json:"git,omitempty"`
-	SQL  *StorageSQLConfig       `json:"sql,omitempty"`
-	S3   *StorageS3Config        `json:"s3,omitempty"`
-	GCS  *StorageGCSConfig       `json:"gcs,omitempty"`
+	Disk  *StorageLocalDiskConfig `json:"disk,omitempty"`
+	Git   *StorageGitConfig       `json:"git,omitempty"`
+	SQL   *StorageSQLConfig       `json:"sql,omitempty"`
+	S3    *StorageS3Config        `json:"s3,omitempty"`
+	GCS   *StorageGCSConfig       `json:"gcs,omitempty"`
+	Azure *StorageAzureConfig     `json:"azure,omitempty"`
 }
 
 type StorageLocalDiskConfig struct {
@@ -112,24 +145,56 @@ type StorageSQLConfig struct {
 type StorageS3Config struct {
 	Bucket string `json:"bucket"`
 	Folder string `json:"folder"`
+	Region string `json:"region"`
+
+	// Endpoint of S3 compatible services, e.g. MinIO
+	Endpoint  string `json:"endpoint,omitempty"`
+	PathStyle bool   `json:"pathStyle,omitempty"`
 
-	// SECURE!!!
-	AccessKey string `json:"accessKey"`
-	SecretKey string `json:"secretKey"`
-	Region    string `json:"region"`
+	// Server side encryption: AES256, aws:kms or aws:kms:dsse
+	SSE      string `json:"sse,omitempty"`
+	KMSKeyID string `json:"kmsKeyId,omitempty"`
+
+	// Without access key, credentials come from the environment, e.g. an instance role
+	AccessKey string `json:"accessKey,omitempty"`
+	// Moved to the secrets store when the config is loaded
+	SecretKey string `json:"secretKey,omitempty"`
 }
 
 type StorageGCSConfig struct {
 	Bucket string `json:"bucket"`
 	Folder string `json:"folder"`
 
-	CredentialsFile string `json:"credentialsFile"`
+	// Without credentials, the application default credentials are used
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+	// Service account key in JSON. Moved to the secrets store when the config is loaded
+	Credentials string `json:"credentials,omitempty"`
+}
+
+type StorageAzureConfig struct {
+	AccountName string `json:"accountName"`
+	Container   string `json:"container"`
+	Folder      string `json:"folder"`
+
+	// Defaults to https://<accountName>.blob.core.windows.net
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Without account key, the default Azure credentials are used, e.g. a managed identity.
+	// Moved to the secrets store when the config is loaded
+	AccountKey string `json:"accountKey,omitempty"`
 }
 
-func newStorage(cfg RootStorageConfig, _ string) (storageRuntime, error) {
+func newStorage(ctx context.Context, cfg RootStorageConfig, secretsStore kvstore.SecretsKVStore) (storageRuntime, error) {
 	switch cfg.Type {
 	case rootStorageTypeDisk:
 		return newDiskStorage(RootStorageMeta{}, cfg), nil
+	case rootStorageTypeS3, rootStorageTypeGCS, rootStorageTypeAzure:
+		secrets, err := getRootStorageSecrets(ctx, secretsStore, cfg.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		bucket, err := openObjectStorageBucket(ctx, cfg, secrets)
+		return newObjectStorage(RootStorageMeta{}, cfg, bucket, err), nil
 	}
 
 	return nil, fmt.Errorf("unsupported store: %s", cfg.Type)
@@ -360,6 +360,17 @@ func replaceDatasourceVariables(dash *DashboardSummaryInfo, datasourceVariablesL
 
 		variables := findDatasourceRefsForVariables(dsVariableRefs, datasourceVariablesLookup)
 		dash.Panels[i].Datasource = append(dsRefs, variables...)
+
+		// targets can only reference a single datasource
+		for j, target := range panel.Targets {
+			if target.Datasource == nil || !isVariableRef(target.Datasource.UID) {
+				continue
+			}
+			refs := datasourceVariablesLookup.getDatasourceRefs(getDataSourceVariableName(*target.Datasource))
+			if len(refs) == 1 {
+				dash.Panels[i].Targets[j].Datasource = &DataSourceRef{UID: refs[0].UID, Type: refs[0].Type}
+			}
+		}
 	}
 }
 
@@ -394,11 +405,12 @@ func readpanelInfo(iter *jsoniter.Iterator, lookup DatasourceLookup) PanelSummar
 	panel := PanelSummaryInfo{}
 
 	targets := newTargetInfo(lookup)
+	var panelDatasource *DataSourceRef
 
 	for l1Field := iter.ReadObject(); l1Field != ""; l1Field = iter.ReadObject() {
 		if iter.WhatIsNext() == jsoniter.NilValue {
 			if l1Field == "datasource" {
-				targets.addDatasource(iter)
+				panelDatasource = targets.addDatasource(iter)
 				continue
 			}
 
@@ -433,17 +445,17 @@ func readpanelInfo(iter *jsoniter.Iterator, lookup DatasourceLookup) PanelSummar
 			}
 
 		case "datasource":
-			targets.addDatasource(iter)
+			panelDatasource = targets.addDatasource(iter)
 
 		case "targets":
 			switch iter.WhatIsNext() {
 			case jsoniter.ArrayValue:
 				for iter.ReadArray() {
-					targets.addTarget(iter)
+					panel.addTarget(targets.addTarget(iter))
 				}
 			case jsoniter.ObjectValue:
 				for f := iter.ReadObject(); f != ""; f = iter.ReadObject() {
-					targets.addTarget(iter)
+					panel.addTarget(targets.addTarget(iter))
 				}
 			default:
 				iter.Skip()
@@ -483,5 +495,14 @@ func readpanelInfo(iter *jsoniter.Iterator, lookup DatasourceLookup) PanelSummar
 
 	panel.Datasource = targets.GetDatasourceInfo()
 
+	// Targets without an explicit datasource use the panel datasource
+	if panelDatasource != nil && !isSpecialDatasource(panelDatasource.UID) {
+		for i := range panel.Targets {
+			if panel.Targets[i].Datasource == nil {
+				panel.Targets[i].Datasource = &DataSourceRef{UID: panelDatasource.UID, Type: panelDatasource.Type}
+			}
+		}
+	}
+
 	return panel
 }
@@ -27,59 +27,92 @@ func (s *targetInfo) GetDatasourceInfo() []DataSourceRef {
 }
 
 // the node will either be string (name|uid) OR ref
-func (s *targetInfo) addDatasource(iter *jsoniter.Iterator) {
+// returns the reference that was found, or the raw reference when the lookup does not know it
+func (s *targetInfo) addDatasource(iter *jsoniter.Iterator) *DataSourceRef {
 	switch iter.WhatIsNext() {
 	case jsoniter.StringValue:
 		key := iter.ReadString()
 
 		dsRef := &DataSourceRef{UID: key}
 		if !isVariableRef(dsRef.UID) && !isSpecialDatasource(dsRef.UID) {
-			ds := s.lookup.ByRef(dsRef)
-			s.addRef(ds)
-		} else {
-			s.addRef(dsRef)
+			return s.addLookupRef(dsRef)
 		}
+		return s.addRef(dsRef)
 
 	case jsoniter.NilValue:
-		s.addRef(s.lookup.ByRef(nil))
 		iter.Skip()
+		return s.addRef(s.lookup.ByRef(nil))
 
 	case jsoniter.ObjectValue:
 		ref := &DataSourceRef{}
 		iter.ReadVal(ref)
 
 		if !isVariableRef(ref.UID) && !isSpecialDatasource(ref.UID) {
-			s.addRef(s.lookup.ByRef(ref))
-		} else {
-			s.addRef(ref)
+			return s.addLookupRef(ref)
 		}
+		return s.addRef(ref)
 
 	default:
 		v := iter.Read()
 		logf("[Panel.datasource.unknown] %v\n", v)
 	}
+	return nil
 }
 
-func (s *targetInfo) addRef(ref *DataSourceRef) {
+func (s *targetInfo) addLookupRef(ref *DataSourceRef) *DataSourceRef {
+	if ds := s.addRef(s.lookup.ByRef(ref)); ds != nil {
+		return ds
+	}
+	if ref.UID != "" {
+		return ref
+	}
+	return nil
+}
+
+func (s *targetInfo) addRef(ref *DataSourceRef) *DataSourceRef {
 	if ref != nil && ref.UID != "" {
 		s.uids[ref.UID] = ref
+		return ref
 	}
+	return nil
 }
 
-func (s *targetInfo) addTarget(iter *jsoniter.Iterator) {
+func (s *targetInfo) addTarget(iter *jsoniter.Iterator) TargetSummaryInfo {
+	target := TargetSummaryInfo{}
 	for l1Field := iter.ReadObject(); l1Field != ""; l1Field = iter.ReadObject() {
 		switch l1Field {
 		case "datasource":
-			s.addDatasource(iter)
+			if ref := s.addDatasource(iter); ref != nil {
+				target.Datasource = &DataSourceRef{UID: ref.UID, Type: ref.Type}
+			}
 
 		case "refId":
-			iter.Skip()
+			target.RefID = readTargetString(iter)
+
+		case "expr":
+			target.Expr = readTargetString(iter)
+
+		case "rawSql":
+			target.RawSQL = readTargetString(iter)
+
+		case "query":
+			target.Query = readTargetString(iter)
 
 		default:
 			v := iter.Read()
 			logf("[Panel.TARGET] %s=%v\n", l1Field, v)
 		}
 	}
+	return target
+}
+
+// Some query fields are objects in some datasources, only string values are kept
+func readTargetString(iter *jsoniter.Iterator) string {
+	if iter.WhatIsNext() == jsoniter.StringValue {
+		return iter.ReadString()
+	}
+	iter.Skip()
+	return ""
 }
 
 func (s *targetInfo) addPanel(panel PanelSummaryInfo) {
@@ -1,18 +1,34 @@
 package dashboard
 
 type PanelSummaryInfo struct {
-	ID            int64           `json:"id"`
-	Title         string          `json:"title"`
-	Description   string          `json:"description,omitempty"`
-	Type          string          `json:"type,omitempty"` // PluginID
-	PluginVersion string          `json:"pluginVersion,omitempty"`
-	LibraryPanel  string          `json:"libraryPanel,omitempty"` // UID of referenced library panel
-	Datasource    []DataSourceRef `json:"datasource,omitempty"`   // UIDs
-	Transformer   []string        `json:"transformer,omitempty"`  // ids of the transformation steps
+	ID            int64               `json:"id"`
+	Title         string              `json:"title"`
+	Description   string              `json:"description,omitempty"`
+	Type          string              `json:"type,omitempty"` // PluginID
+	PluginVersion string              `json:"pluginVersion,omitempty"`
+	LibraryPanel  string              `json:"libraryPanel,omitempty"` // UID of referenced library panel
+	Datasource    []DataSourceRef     `json:"datasource,omitempty"`   // UIDs
+	Transformer   []string            `json:"transformer,omitempty"`  // ids of the transformation steps
+	Targets       []TargetSummaryInfo `json:"targets,omitempty"`      // the queries
 	// Rows define panels as sub objects
 	Collapsed []PanelSummaryInfo `json:"collapsed,omitempty"`
 }
 
+type TargetSummaryInfo struct {
+	RefID      string         `json:"refId,omitempty"`
+	Datasource *DataSourceRef `json:"datasource,omitempty"`
+	Expr       string         `json:"expr,omitempty"`   // prometheus, loki
+	RawSQL     string         `json:"rawSql,omitempty"` // sql datasources
+	Query      string         `json:"query,omitempty"`  // other text based queries
+}
+
+// Only keep targets that include a datasource or a query
+func (p *PanelSummaryInfo) addTarget(target TargetSummaryInfo) {
+	if target.Datasource != nil || target.Expr != "" || target.RawSQL != "" || target.Query != "" {
+		p.Targets = append(p.Targets, target)
+	}
+}
+
 type DashboardSummaryInfo struct {
 	UID           string             `json:"uid,omitempty"`
 	ID            int64              `json:"id,omitempty"` // internal ID
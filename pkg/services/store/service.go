@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"gocloud.dev/blob"
+
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/filestorage"
@@ -18,6 +20,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/quota"
+	"github.com/grafana/grafana/pkg/services/secrets/kvstore"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
@@ -97,11 +100,20 @@ func ProvideService(
 	cfg *setting.Cfg,
 	quotaService quota.Service,
 	systemUsersService SystemUsers,
+	secretsStore kvstore.SecretsKVStore,
 ) (StorageService, error) {
+	ctx := context.Background()
 	settings, err := LoadStorageConfig(cfg, features)
 	if err != nil {
 		grafanaStorageLogger.Warn("Error loading storage config", "error", err)
 	}
+	if settings.Resources != nil && !isObjectStorageType(settings.Resources.Type) {
+		grafanaStorageLogger.Warn("Ignoring resources storage config, only s3, gcs and azure are supported", "type", settings.Resources.Type)
+		settings.Resources = nil
+	}
+	if err := settings.moveSecrets(ctx, secretsStore); err != nil {
+		grafanaStorageLogger.Warn("Error moving storage credentials to the secrets store", "error", err)
+	}
 
 	// always exists
 	globalRoots := []storageRuntime{
@@ -154,8 +166,7 @@ func ProvideService(
 		// all externally-defined storages lie under the "content" root
 		root.UnderContentRoot = true
 
-		// TODO: remove unused second argument
-		s, err := newStorage(root, filepath.Join(cfg.DataPath, "storage", "cache", root.Prefix))
+		s, err := newStorage(ctx, root, secretsStore)
 		if err != nil {
 			grafanaStorageLogger.Warn("Error loading storage config", "error", err)
 		}
@@ -164,6 +175,17 @@ func ProvideService(
 		}
 	}
 
+	// Uploaded resources are stored in the database unless an object storage is configured
+	var resourcesBucket *blob.Bucket
+	var resourcesErr error
+	if settings.Resources != nil {
+		var secrets rootStorageSecrets
+		secrets, resourcesErr = getRootStorageSecrets(ctx, secretsStore, RootResources)
+		if resourcesErr == nil {
+			resourcesBucket, resourcesErr = openObjectStorageBucket(ctx, *settings.Resources, secrets)
+		}
+	}
+
 	initializeOrgStorages := func(orgId int64) []storageRuntime {
 		storages := make([]storageRuntime, 0)
 
@@ -173,10 +195,25 @@ func ProvideService(
 			}, RootContent, "Content", "Content root", &StorageSQLConfig{}, sql, orgId, false))
 
 		// Custom upload files
-		storages = append(storages,
-			newSQLStorage(RootStorageMeta{
-				Builtin: true,
-			}, RootResources, "Resources", "Upload custom resource files", &StorageSQLConfig{}, sql, orgId, false))
+		if settings.Resources != nil {
+			resources := *settings.Resources
+			resources.Prefix = RootResources
+			resources.Name = "Resources"
+			resources.Description = "Upload custom resource files"
+			var bucket *blob.Bucket
+			if resourcesBucket != nil {
+				bucket = prefixedBucket(resourcesBucket, fmt.Sprintf("%d/%s", orgId, RootResources))
+			}
+			storages = append(storages,
+				newObjectStorage(RootStorageMeta{
+					Builtin: true,
+				}, resources, bucket, resourcesErr))
+		} else {
+			storages = append(storages,
+				newSQLStorage(RootStorageMeta{
+					Builtin: true,
+				}, RootResources, "Resources", "Upload custom resource files", &StorageSQLConfig{}, sql, orgId, false))
+		}
 
 		// System settings
 		storages = append(storages,
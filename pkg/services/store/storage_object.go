@@ -0,0 +1,339 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/azureblob"
+	"gocloud.dev/blob/gcsblob"
+	"gocloud.dev/blob/s3blob"
+	"gocloud.dev/gcp"
+	"golang.org/x/oauth2/google"
+
+	"github.com/grafana/grafana/pkg/infra/filestorage"
+	"github.com/grafana/grafana/pkg/services/secrets/kvstore"
+)
+
+const (
+	rootStorageTypeS3    = "s3"
+	rootStorageTypeGCS   = "gcs"
+	rootStorageTypeAzure = "azure"
+
+	// storageSecretsNamespace is the namespace of the root credentials in the secrets store, the type is the root prefix
+	storageSecretsNamespace = "storage"
+	// storageSecretsOrgID stores the credentials globally, roots are shared by all orgs
+	storageSecretsOrgID = 0
+
+	gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+var _ storageRuntime = &rootStorageObject{}
+
+func isObjectStorageType(storageType string) bool {
+	return storageType == rootStorageTypeS3 || storageType == rootStorageTypeGCS || storageType == rootStorageTypeAzure
+}
+
+// rootStorageObject is a root backed by a bucket of an object storage service,
+// so that all the instances of a high availability setup share its files.
+type rootStorageObject struct {
+	meta  RootStorageMeta
+	store filestorage.FileStorage
+}
+
+// newObjectStorage creates the root on top of the bucket. When opening the bucket failed,
+// the error is reported as a notice of the root.
+func newObjectStorage(meta RootStorageMeta, scfg RootStorageConfig, bucket *blob.Bucket, err error) *rootStorageObject {
+	meta.Config = scfg
+	if scfg.Prefix == "" {
+		meta.Notice = append(meta.Notice, data.Notice{
+			Severity: data.NoticeSeverityError,
+			Text:     "Missing prefix",
+		})
+	}
+	if err != nil {
+		grafanaStorageLogger.Warn("Error loading storage", "prefix", scfg.Prefix, "type", scfg.Type, "err", err)
+		meta.Notice = append(meta.Notice, data.Notice{
+			Severity: data.NoticeSeverityError,
+			Text:     "Failed to initialize storage",
+		})
+	}
+
+	s := &rootStorageObject{}
+	if meta.Notice == nil {
+		s.store = filestorage.NewCdkBlobStorage(grafanaStorageLogger, bucket, "", nil)
+		meta.Ready = true
+	}
+
+	s.meta = meta
+	return s
+}
+
+func (s *rootStorageObject) Meta() RootStorageMeta {
+	return s.meta
+}
+
+func (s *rootStorageObject) Store() filestorage.FileStorage {
+	return s.store
+}
+
+func (s *rootStorageObject) Sync() error {
+	return nil // always in sync
+}
+
+func (s *rootStorageObject) Write(ctx context.Context, cmd *WriteValueRequest) (*WriteValueResponse, error) {
+	path := cmd.Path
+	if !strings.HasPrefix(path, filestorage.Delimiter) {
+		path = filestorage.Delimiter + path
+	}
+	err := s.store.Upsert(ctx, &filestorage.UpsertFileCommand{
+		Path:     path,
+		Contents: []byte(cmd.Body),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WriteValueResponse{Code: 200}, nil
+}
+
+// rootStorageSecrets are the credentials of a root. They are kept in the secrets store rather than in storage.json.
+type rootStorageSecrets struct {
+	// S3 secret access key
+	SecretKey string `json:"secretKey,omitempty"`
+	// GCS service account key, in JSON
+	Credentials string `json:"credentials,omitempty"`
+	// Azure storage account key
+	AccountKey string `json:"accountKey,omitempty"`
+}
+
+func (s rootStorageSecrets) isEmpty() bool {
+	return s == rootStorageSecrets{}
+}
+
+func getRootStorageSecrets(ctx context.Context, secretsStore kvstore.SecretsKVStore, prefix string) (rootStorageSecrets, error) {
+	secrets := rootStorageSecrets{}
+	if secretsStore == nil {
+		return secrets, nil
+	}
+	value, ok, err := secretsStore.Get(ctx, storageSecretsOrgID, storageSecretsNamespace, prefix)
+	if err != nil || !ok {
+		return secrets, err
+	}
+	err = json.Unmarshal([]byte(value), &secrets)
+	return secrets, err
+}
+
+// moveRootStorageSecrets moves the credentials written in storage.json to the secrets store,
+// and removes them from the config. It returns true when the config changed.
+func moveRootStorageSecrets(ctx context.Context, secretsStore kvstore.SecretsKVStore, root *RootStorageConfig) (bool, error) {
+	plain := rootStorageSecrets{}
+	switch {
+	case root.S3 != nil:
+		plain.SecretKey = root.S3.SecretKey
+	case root.GCS != nil:
+		plain.Credentials = root.GCS.Credentials
+	case root.Azure != nil:
+		plain.AccountKey = root.Azure.AccountKey
+	}
+	if plain.isEmpty() {
+		return false, nil
+	}
+	if secretsStore == nil {
+		return false, errors.New("secrets store is not available")
+	}
+
+	secrets, err := getRootStorageSecrets(ctx, secretsStore, root.Prefix)
+	if err != nil {
+		return false, err
+	}
+	if plain.SecretKey != "" {
+		secrets.SecretKey = plain.SecretKey
+	}
+	if plain.Credentials != "" {
+		secrets.Credentials = plain.Credentials
+	}
+	if plain.AccountKey != "" {
+		secrets.AccountKey = plain.AccountKey
+	}
+	value, err := json.Marshal(secrets)
+	if err != nil {
+		return false, err
+	}
+	if err := secretsStore.Set(ctx, storageSecretsOrgID, storageSecretsNamespace, root.Prefix, string(value)); err != nil {
+		return false, err
+	}
+
+	switch {
+	case root.S3 != nil:
+		root.S3.SecretKey = ""
+	case root.GCS != nil:
+		root.GCS.Credentials = ""
+	case root.Azure != nil:
+		root.Azure.AccountKey = ""
+	}
+	return true, nil
+}
+
+// openObjectStorageBucket opens the bucket of the root, limited to its folder.
+func openObjectStorageBucket(ctx context.Context, cfg RootStorageConfig, secrets rootStorageSecrets) (*blob.Bucket, error) {
+	var bucket *blob.Bucket
+	var folder string
+	var err error
+
+	switch cfg.Type {
+	case rootStorageTypeS3:
+		if cfg.S3 == nil {
+			return nil, errors.New("missing s3 configuration")
+		}
+		bucket, err = openS3Bucket(ctx, cfg.S3, secrets)
+		folder = cfg.S3.Folder
+	case rootStorageTypeGCS:
+		if cfg.GCS == nil {
+			return nil, errors.New("missing gcs configuration")
+		}
+		bucket, err = openGCSBucket(ctx, cfg.GCS, secrets)
+		folder = cfg.GCS.Folder
+	case rootStorageTypeAzure:
+		if cfg.Azure == nil {
+			return nil, errors.New("missing azure configuration")
+		}
+		bucket, err = openAzureBucket(ctx, cfg.Azure, secrets)
+		folder = cfg.Azure.Folder
+	default:
+		return nil, fmt.Errorf("unsupported object storage: %s", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return prefixedBucket(bucket, folder), nil
+}
+
+// prefixedBucket limits the bucket to the folder, if any.
+func prefixedBucket(bucket *blob.Bucket, folder string) *blob.Bucket {
+	folder = strings.Trim(folder, filestorage.Delimiter)
+	if folder == "" {
+		return bucket
+	}
+	return blob.PrefixedBucket(bucket, folder+filestorage.Delimiter)
+}
+
+func openS3Bucket(ctx context.Context, cfg *StorageS3Config, secrets rootStorageSecrets) (*blob.Bucket, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("missing bucket")
+	}
+	sse := s3types.ServerSideEncryption(cfg.SSE)
+	if sse != "" && !slices.Contains(sse.Values(), sse) {
+		return nil, fmt.Errorf("unsupported server side encryption: %s", cfg.SSE)
+	}
+	if cfg.KMSKeyID != "" && sse == s3types.ServerSideEncryptionAes256 {
+		return nil, errors.New("kmsKeyId requires aws:kms server side encryption")
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(cfg.Region)}
+	// without an access key the credentials come from the environment, e.g. an instance role
+	if cfg.AccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKey, secrets.SecretKey, "")))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			// many S3 compatible services don't support the default checksums of the SDK
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+		o.UsePathStyle = cfg.PathStyle
+	})
+
+	return s3blob.OpenBucket(ctx, client, cfg.Bucket, &s3blob.Options{
+		EncryptionType:  sse,
+		KMSEncryptionID: cfg.KMSKeyID,
+	})
+}
+
+func openGCSBucket(ctx context.Context, cfg *StorageGCSConfig, secrets rootStorageSecrets) (*blob.Bucket, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("missing bucket")
+	}
+
+	var creds *google.Credentials
+	var err error
+	switch {
+	case secrets.Credentials != "":
+		creds, err = google.CredentialsFromJSON(ctx, []byte(secrets.Credentials), gcsScope)
+	case cfg.CredentialsFile != "":
+		// nolint:gosec
+		// The path comes from the storage configuration of the server
+		keyData, readErr := os.ReadFile(cfg.CredentialsFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", readErr)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, keyData, gcsScope)
+	default:
+		creds, err = gcp.DefaultCredentials(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gcp.NewHTTPClient(gcp.DefaultTransport(), gcp.CredentialsTokenSource(creds))
+	if err != nil {
+		return nil, err
+	}
+	return gcsblob.OpenBucket(ctx, client, cfg.Bucket, nil)
+}
+
+func openAzureBucket(ctx context.Context, cfg *StorageAzureConfig, secrets rootStorageSecrets) (*blob.Bucket, error) {
+	if cfg.AccountName == "" || cfg.Container == "" {
+		return nil, errors.New("missing account name or container")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", cfg.AccountName)
+	}
+	containerURL := strings.TrimSuffix(endpoint, "/") + "/" + cfg.Container
+
+	var client *container.Client
+	if secrets.AccountKey != "" {
+		cred, err := azblob.NewSharedKeyCredential(cfg.AccountName, secrets.AccountKey)
+		if err != nil {
+			return nil, err
+		}
+		client, err = container.NewClientWithSharedKeyCredential(containerURL, cred, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// managed identity, workload identity or environment credentials
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, err
+		}
+		client, err = container.NewClient(containerURL, cred, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return azureblob.OpenBucket(ctx, client, nil)
+}
@@ -0,0 +1,274 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/fileblob"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/grafana/grafana/pkg/infra/filestorage"
+	"github.com/grafana/grafana/pkg/services/secrets/kvstore"
+)
+
+func TestObjectStorage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the files under the folder of the bucket", func(t *testing.T) {
+		dir := t.TempDir()
+		bucket, err := fileblob.OpenBucket(dir, nil)
+		require.NoError(t, err)
+		root := newObjectStorage(RootStorageMeta{}, RootStorageConfig{Type: rootStorageTypeS3, Prefix: "uploads"}, prefixedBucket(bucket, "/grafana/"), nil)
+		require.True(t, root.Meta().Ready)
+
+		_, err = root.Write(ctx, &WriteValueRequest{Path: "img/logo.svg", Body: json.RawMessage(`"logo"`)})
+		require.NoError(t, err)
+
+		file, found, err := root.Store().Get(ctx, "/img/logo.svg", nil)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, `"logo"`, string(file.Contents))
+
+		require.FileExists(t, filepath.Join(dir, "grafana", "img", "logo.svg"))
+	})
+
+	t.Run("lists the files of the folder only", func(t *testing.T) {
+		bucket := memblob.OpenBucket(nil)
+		require.NoError(t, bucket.WriteAll(ctx, "other/file.txt", []byte("other"), nil))
+		root := newObjectStorage(RootStorageMeta{}, RootStorageConfig{Type: rootStorageTypeAzure, Prefix: "uploads"}, prefixedBucket(bucket, "grafana"), nil)
+
+		_, err := root.Write(ctx, &WriteValueRequest{Path: "file.txt", Body: json.RawMessage(`"logo"`)})
+		require.NoError(t, err)
+
+		files, err := root.Store().List(ctx, "/", nil, &filestorage.ListOptions{Recursive: true, WithFiles: true})
+		require.NoError(t, err)
+		require.Len(t, files.Files, 1)
+		require.Equal(t, "/file.txt", files.Files[0].FullPath)
+	})
+
+	t.Run("reports the errors as notices", func(t *testing.T) {
+		root := newObjectStorage(RootStorageMeta{}, RootStorageConfig{Type: rootStorageTypeGCS}, nil, io.EOF)
+		require.False(t, root.Meta().Ready)
+		require.Len(t, root.Meta().Notice, 2)
+	})
+
+	t.Run("validates the configuration", func(t *testing.T) {
+		for name, cfg := range map[string]RootStorageConfig{
+			"missing s3 config":     {Type: rootStorageTypeS3},
+			"missing bucket":        {Type: rootStorageTypeS3, S3: &StorageS3Config{Region: "us-east-1"}},
+			"unknown encryption":    {Type: rootStorageTypeS3, S3: &StorageS3Config{Bucket: "b", SSE: "rot13"}},
+			"kms key without kms":   {Type: rootStorageTypeS3, S3: &StorageS3Config{Bucket: "b", SSE: "AES256", KMSKeyID: "key"}},
+			"missing gcs bucket":    {Type: rootStorageTypeGCS, GCS: &StorageGCSConfig{}},
+			"missing azure account": {Type: rootStorageTypeAzure, Azure: &StorageAzureConfig{Container: "c"}},
+		} {
+			_, err := openObjectStorageBucket(ctx, cfg, rootStorageSecrets{})
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestS3ObjectStorage(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeS3Server(t)
+
+	bucket, err := openObjectStorageBucket(ctx, RootStorageConfig{
+		Type:   rootStorageTypeS3,
+		Prefix: "uploads",
+		S3: &StorageS3Config{
+			Bucket:    "grafana",
+			Folder:    "uploads",
+			Region:    "us-east-1",
+			Endpoint:  server.URL,
+			PathStyle: true,
+			SSE:       "aws:kms",
+			KMSKeyID:  "alias/grafana",
+			AccessKey: "access",
+		},
+	}, rootStorageSecrets{SecretKey: "secret"})
+	require.NoError(t, err)
+
+	root := newObjectStorage(RootStorageMeta{}, RootStorageConfig{Type: rootStorageTypeS3, Prefix: "uploads"}, bucket, nil)
+	_, err = root.Write(ctx, &WriteValueRequest{Path: "/img/logo.svg", Body: json.RawMessage(`"logo"`)})
+	require.NoError(t, err)
+
+	file, found, err := root.Store().Get(ctx, "/img/logo.svg", nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, `"logo"`, string(file.Contents))
+
+	files, err := root.Store().List(ctx, "/img", nil, &filestorage.ListOptions{Recursive: true, WithFiles: true})
+	require.NoError(t, err)
+	require.Len(t, files.Files, 1)
+
+	put := server.lastPut()
+	require.Equal(t, "/grafana/uploads/img/logo.svg", put.URL.Path)
+	require.Equal(t, "aws:kms", put.Header.Get("X-Amz-Server-Side-Encryption"))
+	require.Equal(t, "alias/grafana", put.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+	require.Contains(t, put.Header.Get("Authorization"), "Credential=access/")
+}
+
+func TestMoveRootStorageSecrets(t *testing.T) {
+	ctx := context.Background()
+	secretsStore := kvstore.NewFakeSecretsKVStore()
+
+	root := RootStorageConfig{Type: rootStorageTypeS3, Prefix: "uploads", S3: &StorageS3Config{Bucket: "b", AccessKey: "access", SecretKey: "secret"}}
+	moved, err := moveRootStorageSecrets(ctx, secretsStore, &root)
+	require.NoError(t, err)
+	require.True(t, moved)
+	require.Empty(t, root.S3.SecretKey)
+	require.Equal(t, "access", root.S3.AccessKey)
+
+	secrets, err := getRootStorageSecrets(ctx, secretsStore, "uploads")
+	require.NoError(t, err)
+	require.Equal(t, rootStorageSecrets{SecretKey: "secret"}, secrets)
+
+	t.Run("does nothing without plain text credentials", func(t *testing.T) {
+		moved, err := moveRootStorageSecrets(ctx, secretsStore, &root)
+		require.NoError(t, err)
+		require.False(t, moved)
+
+		secrets, err := getRootStorageSecrets(ctx, secretsStore, "uploads")
+		require.NoError(t, err)
+		require.Equal(t, "secret", secrets.SecretKey)
+	})
+}
+
+type fakeS3Object struct {
+	body     []byte
+	metadata http.Header
+	modified time.Time
+}
+
+// fakeS3Server implements the subset of the S3 API used by the blob storage, with path style addressing.
+type fakeS3Server struct {
+	*httptest.Server
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+	puts    []*http.Request
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	s := &fakeS3Server{objects: map[string]fakeS3Object{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeS3Server) lastPut() *http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.puts[len(s.puts)-1]
+}
+
+func (s *fakeS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// /bucket/key
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	key := ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter"))
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		metadata := http.Header{}
+		for k, v := range r.Header {
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+				metadata[k] = v
+			}
+		}
+		s.objects[key] = fakeS3Object{body: body, metadata: metadata, modified: time.Now().UTC()}
+		s.puts = append(s.puts, r)
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+			}
+			return
+		}
+		for k, v := range obj.metadata {
+			w.Header()[k] = v
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", obj.modified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.body)
+		}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (s *fakeS3Server) list(w http.ResponseWriter, prefix string, delimiter string) {
+	type content struct {
+		Key          string
+		Size         int
+		LastModified string
+		ETag         string
+	}
+	type commonPrefix struct {
+		Prefix string
+	}
+	result := struct {
+		XMLName        xml.Name `xml:"ListBucketResult"`
+		Prefix         string
+		KeyCount       int
+		IsTruncated    bool
+		Contents       []content
+		CommonPrefixes []commonPrefix
+	}{Prefix: prefix}
+
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(k, prefix)
+		if delimiter != "" {
+			if i := strings.Index(rest, delimiter); i >= 0 {
+				p := prefix + rest[:i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: p})
+				}
+				continue
+			}
+		}
+		obj := s.objects[k]
+		result.Contents = append(result.Contents, content{Key: k, Size: len(obj.body), LastModified: obj.modified.Format(time.RFC3339), ETag: `"etag"`})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,145 @@
+package supportbundlesimpl
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/plugincontext"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
+	"github.com/grafana/grafana/pkg/services/supportbundles"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+const (
+	datasourceHealthCheckTimeout     = 10 * time.Second
+	datasourceHealthCheckConcurrency = 5
+)
+
+type datasourceHealthInfo struct {
+	UID      string `json:"uid"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	OrgID    int64  `json:"orgId"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+func datasourceHealthCollector(
+	dataSourceService datasources.DataSourceService,
+	pluginStore pluginstore.Store,
+	pluginClient plugins.Client,
+	pluginContextProvider *plugincontext.Provider,
+	logger log.Logger,
+) supportbundles.Collector {
+	return supportbundles.Collector{
+		UID:               "datasource-health",
+		DisplayName:       "Data source health",
+		Description:       "Health check results of the data sources of all organizations",
+		IncludedByDefault: false,
+		Default:           false,
+		Fn: func(ctx context.Context) (*supportbundles.SupportItem, error) {
+			dataSources, err := dataSourceService.GetAllDataSources(ctx, &datasources.GetAllDataSourcesQuery{})
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]datasourceHealthInfo, len(dataSources))
+			sem := make(chan struct{}, datasourceHealthCheckConcurrency)
+			var wg sync.WaitGroup
+			for i, ds := range dataSources {
+				results[i] = datasourceHealthInfo{
+					UID:   ds.UID,
+					Name:  ds.Name,
+					Type:  ds.Type,
+					OrgID: ds.OrgID,
+				}
+
+				plugin, exists := pluginStore.Plugin(ctx, ds.Type)
+				if !exists {
+					results[i].Status = "PLUGIN_NOT_FOUND"
+					continue
+				}
+				// frontend only data sources have no health check
+				if !plugin.Backend {
+					results[i].Status = "SKIPPED"
+					continue
+				}
+
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(info *datasourceHealthInfo, ds *datasources.DataSource) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					checkDatasourceHealth(ctx, pluginClient, pluginContextProvider, ds, info, logger)
+				}(&results[i], ds)
+			}
+			wg.Wait()
+
+			data, err := json.MarshalIndent(results, "", " ")
+			if err != nil {
+				return nil, err
+			}
+
+			return &supportbundles.SupportItem{
+				Filename:  "datasource_health.json",
+				FileBytes: data,
+			}, nil
+		},
+	}
+}
+
+func checkDatasourceHealth(ctx context.Context, pluginClient plugins.Client, pluginContextProvider *plugincontext.Provider,
+	ds *datasources.DataSource, info *datasourceHealthInfo, logger log.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, datasourceHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		info.Duration = time.Since(start).String()
+	}()
+
+	pCtx, err := pluginContextProvider.GetWithDataSource(ctx, ds.Type, supportBundleUser(ds.OrgID), ds)
+	if err != nil {
+		info.Status = backend.HealthStatusUnknown.String()
+		info.Error = err.Error()
+		return
+	}
+
+	resp, err := pluginClient.CheckHealth(ctx, &backend.CheckHealthRequest{
+		PluginContext: pCtx,
+		Headers:       map[string]string{},
+	})
+	if err != nil {
+		logger.Debug("Failed to check data source health", "uid", ds.UID, "orgId", ds.OrgID, "error", err)
+		info.Status = backend.HealthStatusError.String()
+		info.Error = err.Error()
+		return
+	}
+
+	info.Status = resp.Status.String()
+	info.Message = resp.Message
+}
+
+func supportBundleUser(orgID int64) *user.SignedInUser {
+	return &user.SignedInUser{
+		Login:            "sa-supportbundle",
+		OrgID:            orgID,
+		OrgRole:          "Admin",
+		IsGrafanaAdmin:   true,
+		IsServiceAccount: true,
+		Permissions: map[int64]map[string][]string{
+			orgID: {datasources.ActionQuery: {datasources.ScopeAll}},
+		},
+	}
+}
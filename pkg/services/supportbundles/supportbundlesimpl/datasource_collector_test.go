@@ -0,0 +1,87 @@
+package supportbundlesimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db/dbtest"
+	"github.com/grafana/grafana/pkg/infra/localcache"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginconfig"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/plugincontext"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginsettings"
+	pluginSettings "github.com/grafana/grafana/pkg/services/pluginsintegration/pluginsettings/service"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
+	secretstest "github.com/grafana/grafana/pkg/services/secrets/fakes"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestDatasourceHealthCollector(t *testing.T) {
+	dsService := &fakeDatasources.FakeDataSourceService{
+		DataSources: []*datasources.DataSource{
+			{UID: "prom", Name: "Prometheus", Type: "prometheus", OrgID: 1, JsonData: simplejson.New()},
+			{UID: "loki", Name: "Loki", Type: "loki", OrgID: 2, JsonData: simplejson.New()},
+			{UID: "frontend", Name: "Frontend", Type: "frontend-datasource", OrgID: 1, JsonData: simplejson.New()},
+			{UID: "missing", Name: "Missing", Type: "not-installed", OrgID: 1, JsonData: simplejson.New()},
+		},
+	}
+	pluginStore := pluginstore.NewFakePluginStore(
+		pluginstore.Plugin{JSONData: plugins.JSONData{ID: "prometheus", Backend: true}},
+		pluginstore.Plugin{JSONData: plugins.JSONData{ID: "loki", Backend: true}},
+		pluginstore.Plugin{JSONData: plugins.JSONData{ID: "frontend-datasource"}},
+	)
+	pluginContextProvider := plugincontext.ProvideService(setting.NewCfg(), localcache.ProvideService(), pluginStore,
+		&fakeDatasources.FakeCacheService{}, dsService,
+		pluginSettings.ProvideService(&dbtest.FakeDB{ExpectedError: pluginsettings.ErrPluginSettingNotFound}, secretstest.NewFakeSecretsService()),
+		pluginconfig.NewFakePluginRequestConfigProvider(),
+	)
+	client := &fakeHealthPluginClient{}
+
+	item, err := datasourceHealthCollector(dsService, pluginStore, client, pluginContextProvider, log.NewNopLogger()).Fn(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "datasource_health.json", item.Filename)
+
+	var results []datasourceHealthInfo
+	require.NoError(t, json.Unmarshal(item.FileBytes, &results))
+	require.Len(t, results, 4)
+	// the duration depends on the plugin, only check it is set for the checked data sources
+	for i := range results[:2] {
+		assert.NotEmpty(t, results[i].Duration)
+		results[i].Duration = ""
+	}
+	assert.Equal(t, []datasourceHealthInfo{
+		{UID: "prom", Name: "Prometheus", Type: "prometheus", OrgID: 1, Status: "OK", Message: "Data source is working"},
+		{UID: "loki", Name: "Loki", Type: "loki", OrgID: 2, Status: "ERROR", Error: "connection refused"},
+		{UID: "frontend", Name: "Frontend", Type: "frontend-datasource", OrgID: 1, Status: "SKIPPED"},
+		{UID: "missing", Name: "Missing", Type: "not-installed", OrgID: 1, Status: "PLUGIN_NOT_FOUND"},
+	}, results)
+	assert.ElementsMatch(t, []int64{1, 2}, client.orgIDs)
+}
+
+type fakeHealthPluginClient struct {
+	plugins.Client
+	// the health checks run concurrently
+	mu     sync.Mutex
+	orgIDs []int64
+}
+
+func (c *fakeHealthPluginClient) CheckHealth(_ context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	c.mu.Lock()
+	c.orgIDs = append(c.orgIDs, req.PluginContext.OrgID)
+	c.mu.Unlock()
+	if req.PluginContext.PluginID == "loki" {
+		return nil, errors.New("connection refused")
+	}
+	return &backend.CheckHealthResult{Status: backend.HealthStatusOk, Message: "Data source is working"}, nil
+}
@@ -13,13 +13,17 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
+	"github.com/grafana/grafana/pkg/plugins"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/plugincontext"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginsettings"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
 	"github.com/grafana/grafana/pkg/services/supportbundles"
 	"github.com/grafana/grafana/pkg/services/supportbundles/bundleregistry"
 	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
 )
 
 const (
@@ -58,7 +62,11 @@ func ProvideService(
 	settings setting.Provider,
 	sql db.DB,
 	usageStats usagestats.Service,
-	tracer tracing.Tracer) (*Service, error) {
+	tracer tracing.Tracer,
+	dataSourceService datasources.DataSourceService,
+	pluginClient plugins.Client,
+	pluginContextProvider *plugincontext.Provider,
+	dualWriter dualwrite.Service) (*Service, error) {
 	section := cfg.SectionWithEnvOverrides("support_bundles")
 	s := &Service{
 		accessControl:        accessControl,
@@ -92,6 +100,8 @@ func ProvideService(
 	s.bundleRegistry.RegisterSupportItemCollector(settingsCollector(settings))
 	s.bundleRegistry.RegisterSupportItemCollector(dbCollector(sql))
 	s.bundleRegistry.RegisterSupportItemCollector(pluginInfoCollector(pluginStore, pluginSettings, s.log))
+	s.bundleRegistry.RegisterSupportItemCollector(datasourceHealthCollector(dataSourceService, pluginStore, pluginClient, pluginContextProvider, s.log))
+	s.bundleRegistry.RegisterSupportItemCollector(unifiedStorageCollector(cfg, dualWriter))
 
 	return s, nil
 }
@@ -0,0 +1,141 @@
+package supportbundlesimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/grafana/grafana/pkg/services/supportbundles"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
+)
+
+type unifiedStorageInfo struct {
+	// DualWriterModes are the modes configured in [unified_storage.<resource>.<group>]
+	DualWriterModes map[string]int                     `json:"dual_writer_modes"`
+	SyncStatus      map[string]dualwrite.StorageStatus `json:"sync_status"`
+	IndexPath       string                             `json:"index_path"`
+	IndexSizes      []indexSizeInfo                    `json:"index_sizes,omitempty"`
+	Errors          []string                           `json:"errors,omitempty"`
+}
+
+type indexSizeInfo struct {
+	Namespace string `json:"namespace"`
+	Resource  string `json:"resource"`
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// dualWriteManagedResources are the resources whose dual writer status is stored in the database
+var dualWriteManagedResources = []string{
+	"folders.folder.grafana.app",
+	"dashboards.dashboard.grafana.app",
+}
+
+func unifiedStorageCollector(cfg *setting.Cfg, dual dualwrite.Service) supportbundles.Collector {
+	return supportbundles.Collector{
+		UID:               "unified-storage",
+		DisplayName:       "Unified storage information",
+		Description:       "Dual writer modes, migration status and search index sizes of unified storage",
+		IncludedByDefault: false,
+		Default:           true,
+		Fn: func(ctx context.Context) (*supportbundles.SupportItem, error) {
+			info := unifiedStorageInfo{
+				DualWriterModes: map[string]int{},
+				SyncStatus:      map[string]dualwrite.StorageStatus{},
+				IndexPath:       indexRoot(cfg),
+			}
+
+			for resource, config := range cfg.UnifiedStorage {
+				info.DualWriterModes[resource] = int(config.DualWriterMode)
+			}
+
+			for _, resource := range dualWriteManagedResources {
+				gr := schema.ParseGroupResource(resource)
+				if !dual.ShouldManage(gr) {
+					continue
+				}
+				status, err := dual.Status(ctx, gr)
+				if err != nil {
+					info.Errors = append(info.Errors, err.Error())
+					continue
+				}
+				info.SyncStatus[resource] = status
+			}
+
+			sizes, err := indexSizes(info.IndexPath)
+			if err != nil {
+				info.Errors = append(info.Errors, err.Error())
+			}
+			info.IndexSizes = sizes
+
+			data, err := json.MarshalIndent(info, "", " ")
+			if err != nil {
+				return nil, err
+			}
+
+			return &supportbundles.SupportItem{
+				Filename:  "unified_storage.json",
+				FileBytes: data,
+			}, nil
+		},
+	}
+}
+
+// indexRoot mirrors the default used by the unified storage search backend
+func indexRoot(cfg *setting.Cfg) string {
+	if cfg.IndexPath != "" {
+		return cfg.IndexPath
+	}
+	return filepath.Join(cfg.DataPath, "unified-search", "bleve")
+}
+
+// indexSizes sums the files of every <root>/<namespace>/<resource> index directory
+func indexSizes(root string) ([]indexSizeInfo, error) {
+	namespaces, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sizes []indexSizeInfo
+	for _, ns := range namespaces {
+		if !ns.IsDir() {
+			continue
+		}
+		resources, err := os.ReadDir(filepath.Join(root, ns.Name()))
+		if err != nil {
+			return sizes, err
+		}
+		for _, res := range resources {
+			if !res.IsDir() {
+				continue
+			}
+			size := indexSizeInfo{Namespace: ns.Name(), Resource: res.Name()}
+			err := filepath.WalkDir(filepath.Join(root, ns.Name(), res.Name()), func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				fi, err := d.Info()
+				if err != nil {
+					return err
+				}
+				size.Files++
+				size.Bytes += fi.Size()
+				return nil
+			})
+			if err != nil {
+				return sizes, err
+			}
+			sizes = append(sizes, size)
+		}
+	}
+	return sizes, nil
+}
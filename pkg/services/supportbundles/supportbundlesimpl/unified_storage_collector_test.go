@@ -0,0 +1,64 @@
+package supportbundlesimpl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apiserver/rest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
+)
+
+func TestIndexSizes(t *testing.T) {
+	t.Run("should return nothing when the index root does not exist", func(t *testing.T) {
+		sizes, err := indexSizes(filepath.Join(t.TempDir(), "missing"))
+		require.NoError(t, err)
+		assert.Empty(t, sizes)
+	})
+
+	t.Run("should sum the files of every namespace and resource", func(t *testing.T) {
+		root := t.TempDir()
+		writeIndexFile(t, filepath.Join(root, "default", "dashboards.dashboard.grafana.app", "20250101-000000", "store", "root.bolt"), 100)
+		writeIndexFile(t, filepath.Join(root, "default", "dashboards.dashboard.grafana.app", "20250101-000000", "index_meta.json"), 20)
+		writeIndexFile(t, filepath.Join(root, "stacks-1", "folders.folder.grafana.app", "20250101-000000", "store", "root.bolt"), 50)
+		// files next to the namespaces are not indexes
+		writeIndexFile(t, filepath.Join(root, "lock"), 1)
+
+		sizes, err := indexSizes(root)
+		require.NoError(t, err)
+		assert.Equal(t, []indexSizeInfo{
+			{Namespace: "default", Resource: "dashboards.dashboard.grafana.app", Files: 2, Bytes: 120},
+			{Namespace: "stacks-1", Resource: "folders.folder.grafana.app", Files: 1, Bytes: 50},
+		}, sizes)
+	})
+}
+
+func TestUnifiedStorageCollector(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.DataPath = t.TempDir()
+	cfg.UnifiedStorage = map[string]setting.UnifiedStorageConfig{
+		"dashboards.dashboard.grafana.app": {DualWriterMode: rest.Mode2},
+	}
+
+	item, err := unifiedStorageCollector(cfg, dualwrite.ProvideStaticServiceForTests(cfg)).Fn(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "unified_storage.json", item.Filename)
+
+	var info unifiedStorageInfo
+	require.NoError(t, json.Unmarshal(item.FileBytes, &info))
+	assert.Equal(t, map[string]int{"dashboards.dashboard.grafana.app": 2}, info.DualWriterModes)
+	assert.Equal(t, filepath.Join(cfg.DataPath, "unified-search", "bleve"), info.IndexPath)
+	assert.Empty(t, info.Errors)
+}
+
+func writeIndexFile(t *testing.T, path string, size int) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0600))
+}
@@ -43,6 +43,9 @@ type SignedInUser struct {
 	Teams            []int64
 	// Permissions grouped by orgID and actions
 	Permissions map[int64]map[string][]string `json:"-"`
+	// PermissionsRestricted is set when the permissions were restricted for the request, like for
+	// down-scoped service account tokens, and must be enforced on top of the stored permissions.
+	PermissionsRestricted bool `json:"-" xorm:"-"`
 
 	// IDToken is a signed token representing the identity that can be forwarded to plugins and external services.
 	IDToken           string                                       `json:"-" xorm:"-"`
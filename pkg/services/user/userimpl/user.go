@@ -216,6 +216,9 @@ func (s *Service) Delete(ctx context.Context, cmd *user.DeleteUserCommand) error
 		return err
 	}
 
+	// the orgs of the user are needed to find the cache keys, invalidate before they are deleted
+	s.invalidateSignedInUser(ctx, cmd.UserID)
+
 	return s.store.Delete(ctx, cmd.UserID)
 }
 
@@ -316,7 +319,12 @@ func (s *Service) Update(ctx context.Context, cmd *user.UpdateUserCommand) error
 		}
 	}
 
-	return s.store.Update(ctx, cmd)
+	if err := s.store.Update(ctx, cmd); err != nil {
+		return err
+	}
+
+	s.invalidateSignedInUser(ctx, cmd.UserID)
+	return nil
 }
 
 func (s *Service) UpdateLastSeenAt(ctx context.Context, cmd *user.UpdateUserLastSeenAtCommand) error {
@@ -379,6 +387,25 @@ func (s *Service) GetSignedInUser(ctx context.Context, query *user.GetSignedInUs
 	return result, nil
 }
 
+// invalidateSignedInUser removes the signed in user from the cache of every org of the user, on all instances.
+func (s *Service) invalidateSignedInUser(ctx context.Context, userID int64) {
+	if s.cacheService == nil {
+		return
+	}
+
+	orgs, err := s.orgService.GetUserOrgList(ctx, &org.GetUserOrgListQuery{UserID: userID})
+	if err != nil {
+		// the cached user expires within seconds anyway
+		return
+	}
+
+	keys := make([]string, 0, len(orgs))
+	for _, o := range orgs {
+		keys = append(keys, newSignedInUserCacheKey(o.OrgID, userID))
+	}
+	s.cacheService.Invalidate(keys...)
+}
+
 func newSignedInUserCacheKey(orgID, userID int64) string {
 	return fmt.Sprintf("signed-in-user-%d-%d", userID, orgID)
 }
@@ -421,7 +448,14 @@ func (s *Service) BatchDisableUsers(ctx context.Context, cmd *user.BatchDisableU
 	))
 	defer span.End()
 
-	return s.store.BatchDisableUsers(ctx, cmd)
+	if err := s.store.BatchDisableUsers(ctx, cmd); err != nil {
+		return err
+	}
+
+	for _, userID := range cmd.UserIDs {
+		s.invalidateSignedInUser(ctx, userID)
+	}
+	return nil
 }
 
 func (s *Service) GetProfile(ctx context.Context, query *user.GetUserProfileQuery) (*user.UserProfileDTO, error) {
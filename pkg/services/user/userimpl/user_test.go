@@ -213,6 +213,26 @@ func TestService_Update(t *testing.T) {
 		err := service.Update(context.Background(), &user.UpdateUserCommand{UserID: 2, OrgID: &orgID})
 		require.Error(t, err)
 	})
+
+	t.Run("removes the user from the signed in user cache of its orgs", func(t *testing.T) {
+		service := setup(func(svc *Service) {
+			svc.orgService = &orgtest.FakeOrgService{ExpectedUserOrgDTO: []*org.UserOrgDTO{{OrgID: 1}, {OrgID: 2}}}
+			svc.cacheService = localcache.ProvideService()
+		})
+		for _, orgID := range []int64{1, 2, 3} {
+			service.cacheService.Set(newSignedInUserCacheKey(orgID, 2), user.SignedInUser{UserID: 2, OrgID: orgID}, time.Minute)
+		}
+
+		err := service.Update(context.Background(), &user.UpdateUserCommand{UserID: 2, Name: "new name"})
+		require.NoError(t, err)
+
+		_, found := service.cacheService.Get(newSignedInUserCacheKey(1, 2))
+		require.False(t, found)
+		_, found = service.cacheService.Get(newSignedInUserCacheKey(2, 2))
+		require.False(t, found)
+		_, found = service.cacheService.Get(newSignedInUserCacheKey(3, 2))
+		require.True(t, found)
+	})
 }
 
 func TestUpdateLastSeenAt(t *testing.T) {
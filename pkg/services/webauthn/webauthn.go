@@ -0,0 +1,81 @@
+package webauthn
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+)
+
+var (
+	ErrCredentialNotFound   = errutil.NotFound("webauthn.credential-not-found", errutil.WithPublicMessage("Credential not found"))
+	ErrCredentialExists     = errutil.Conflict("webauthn.credential-exists", errutil.WithPublicMessage("Credential is already registered"))
+	ErrSessionNotFound      = errutil.Unauthorized("webauthn.session-not-found", errutil.WithPublicMessage("Security key request expired, please try again"))
+	ErrInvalidResponse      = errutil.BadRequest("webauthn.invalid-response", errutil.WithPublicMessage("Invalid security key response"))
+	ErrVerificationFailed   = errutil.Unauthorized("webauthn.verification-failed", errutil.WithPublicMessage("Security key verification failed"))
+	ErrPasskeyLoginDisabled = errutil.Forbidden("webauthn.passkey-login-disabled", errutil.WithPublicMessage("Passkey login is disabled"))
+)
+
+type Service interface {
+	// BeginRegistration starts registering a new credential for the signed in user
+	BeginRegistration(ctx context.Context, usr identity.Requester) (*CredentialCreation, error)
+	// FinishRegistration verifies the authenticator response and stores the new credential
+	FinishRegistration(ctx context.Context, usr identity.Requester, cmd *FinishRegistrationCommand) (*Credential, error)
+	// CreateLoginToken returns a short lived token that allows the user to begin a login,
+	// used when a credential is required after a password was verified
+	CreateLoginToken(ctx context.Context, userID int64) (string, error)
+	// BeginLogin starts a login. Without a login token any discoverable credential (passkey) can be used
+	BeginLogin(ctx context.Context, loginToken string) (*CredentialAssertion, error)
+	// FinishLogin verifies the assertion and returns the credential that was used
+	FinishLogin(ctx context.Context, cmd *FinishLoginCommand) (*Credential, error)
+	// GetCredentials returns the credentials registered for a user
+	GetCredentials(ctx context.Context, userID int64) ([]*Credential, error)
+	// DeleteCredential removes a credential registered for a user
+	DeleteCredential(ctx context.Context, userID, id int64) error
+}
+
+// Credential is a public key credential registered for a user
+type Credential struct {
+	ID           int64     `xorm:"pk autoincr 'id'" json:"id"`
+	UserID       int64     `xorm:"user_id" json:"-"`
+	Name         string    `xorm:"name" json:"name"`
+	CredentialID string    `xorm:"credential_id" json:"credentialId"` // base64url encoded
+	PublicKey    []byte    `xorm:"public_key" json:"-"`               // COSE encoded
+	SignCount    int64     `xorm:"sign_count" json:"-"`
+	AAGUID       string    `xorm:"aaguid" json:"aaguid"`
+	Transports   string    `xorm:"transports" json:"-"`
+	Created      time.Time `xorm:"created" json:"created"`
+	LastUsed     time.Time `xorm:"last_used" json:"lastUsed"`
+}
+
+func (c Credential) TableName() string {
+	return "webauthn_credential"
+}
+
+type FinishRegistrationCommand struct {
+	SessionID string `json:"sessionId" binding:"Required"`
+	Name      string `json:"name"`
+	// Credential is the JSON encoded result of navigator.credentials.create()
+	Credential *protocol.CredentialCreationResponse `json:"credential" binding:"Required"`
+}
+
+type FinishLoginCommand struct {
+	SessionID string `json:"sessionId" binding:"Required"`
+	// Credential is the JSON encoded result of navigator.credentials.get()
+	Credential *protocol.CredentialAssertionResponse `json:"credential" binding:"Required"`
+}
+
+// CredentialCreation is passed to navigator.credentials.create() by the browser
+type CredentialCreation struct {
+	SessionID string                                      `json:"sessionId"`
+	PublicKey protocol.PublicKeyCredentialCreationOptions `json:"publicKey"`
+}
+
+// CredentialAssertion is passed to navigator.credentials.get() by the browser
+type CredentialAssertion struct {
+	SessionID string                                     `json:"sessionId"`
+	PublicKey protocol.PublicKeyCredentialRequestOptions `json:"publicKey"`
+}
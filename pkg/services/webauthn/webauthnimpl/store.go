@@ -0,0 +1,88 @@
+package webauthnimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+)
+
+type store interface {
+	Insert(ctx context.Context, cred *webauthn.Credential) error
+	Get(ctx context.Context, credentialID string) (*webauthn.Credential, error)
+	List(ctx context.Context, userID int64) ([]*webauthn.Credential, error)
+	UpdateUsage(ctx context.Context, id int64, signCount int64, lastUsed time.Time) error
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+type xormStore struct {
+	db db.DB
+}
+
+func (s *xormStore) Insert(ctx context.Context, cred *webauthn.Credential) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Exist(&webauthn.Credential{CredentialID: cred.CredentialID})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return webauthn.ErrCredentialExists.Errorf("credential already registered")
+		}
+		_, err = sess.Insert(cred)
+		return err
+	})
+}
+
+func (s *xormStore) Get(ctx context.Context, credentialID string) (*webauthn.Credential, error) {
+	cred := &webauthn.Credential{}
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		has, err := sess.Where("credential_id = ?", credentialID).Get(cred)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return webauthn.ErrCredentialNotFound.Errorf("credential not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (s *xormStore) List(ctx context.Context, userID int64) ([]*webauthn.Credential, error) {
+	creds := make([]*webauthn.Credential, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("user_id = ?", userID).Asc("id").Find(&creds)
+	})
+	return creds, err
+}
+
+func (s *xormStore) UpdateUsage(ctx context.Context, id int64, signCount int64, lastUsed time.Time) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.ID(id).Cols("sign_count", "last_used").Update(&webauthn.Credential{
+			SignCount: signCount,
+			LastUsed:  lastUsed,
+		})
+		return err
+	})
+}
+
+func (s *xormStore) Delete(ctx context.Context, userID, id int64) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("DELETE FROM webauthn_credential WHERE user_id = ? AND id = ?", userID, id)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return webauthn.ErrCredentialNotFound.Errorf("credential not found")
+		}
+		return nil
+	})
+}
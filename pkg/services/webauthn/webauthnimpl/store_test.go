@@ -0,0 +1,70 @@
+package webauthnimpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationWebAuthnStore(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	ctx := context.Background()
+	s := &xormStore{db: db.InitTestDB(t)}
+	now := time.Now().Truncate(time.Second)
+
+	newCredential := func(userID int64, credentialID string) *webauthn.Credential {
+		return &webauthn.Credential{
+			UserID:       userID,
+			Name:         "key",
+			CredentialID: credentialID,
+			PublicKey:    []byte("key"),
+			Created:      now,
+			LastUsed:     now,
+		}
+	}
+
+	require.NoError(t, s.Insert(ctx, newCredential(1, "a")))
+	require.NoError(t, s.Insert(ctx, newCredential(1, "b")))
+	require.NoError(t, s.Insert(ctx, newCredential(2, "c")))
+	require.ErrorIs(t, s.Insert(ctx, newCredential(2, "a")), webauthn.ErrCredentialExists)
+
+	creds, err := s.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, creds, 2)
+	require.Equal(t, "a", creds[0].CredentialID)
+	require.Equal(t, "b", creds[1].CredentialID)
+
+	cred, err := s.Get(ctx, "c")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), cred.UserID)
+	require.Equal(t, []byte("key"), cred.PublicKey)
+
+	_, err = s.Get(ctx, "missing")
+	require.ErrorIs(t, err, webauthn.ErrCredentialNotFound)
+
+	later := now.Add(time.Hour)
+	require.NoError(t, s.UpdateUsage(ctx, cred.ID, 10, later))
+	cred, err = s.Get(ctx, "c")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), cred.SignCount)
+	require.True(t, later.Equal(cred.LastUsed))
+
+	// Credentials can only be removed by their user
+	require.ErrorIs(t, s.Delete(ctx, 1, cred.ID), webauthn.ErrCredentialNotFound)
+	require.NoError(t, s.Delete(ctx, 2, cred.ID))
+	creds, err = s.List(ctx, 2)
+	require.NoError(t, err)
+	require.Empty(t, creds)
+}
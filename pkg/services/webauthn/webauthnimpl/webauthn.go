@@ -0,0 +1,389 @@
+package webauthnimpl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	sessionKeyPrefix    = "webauthn-session-%s"
+	loginTokenKeyPrefix = "webauthn-login-token-%s"
+
+	// credential ids are stored base64url encoded in an indexed column
+	maxCredentialIDLength = 190
+)
+
+var _ webauthn.Service = new(Service)
+
+func ProvideService(db db.DB, cfg *setting.Cfg, cache remotecache.CacheStorage) *Service {
+	return &Service{
+		store: &xormStore{db: db},
+		cfg:   cfg,
+		cache: cache,
+		now:   time.Now,
+		log:   log.New("webauthn"),
+	}
+}
+
+type Service struct {
+	store store
+	cfg   *setting.Cfg
+	cache remotecache.CacheStorage
+	now   func() time.Time
+	log   log.Logger
+}
+
+// session is stored in the remote cache between the begin and finish requests of a ceremony
+type session struct {
+	Data         gowebauthn.SessionData `json:"data"`
+	UserID       int64                  `json:"userId"`
+	Registration bool                   `json:"registration"`
+}
+
+// webAuthnUser is a Grafana user as seen by the WebAuthn library. The user handle is the id of the user,
+// so that it can be checked against the credentials, that only know the id of their user
+type webAuthnUser struct {
+	id          int64
+	name        string
+	displayName string
+	creds       []*webauthn.Credential
+}
+
+var _ gowebauthn.User = new(webAuthnUser)
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(strconv.FormatInt(u.id, 10))
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.name
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.displayName
+}
+
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []gowebauthn.Credential {
+	res := make([]gowebauthn.Credential, 0, len(u.creds))
+	for _, cred := range u.creds {
+		id, err := base64.RawURLEncoding.DecodeString(cred.CredentialID)
+		if err != nil {
+			continue
+		}
+		c := gowebauthn.Credential{
+			ID:            id,
+			PublicKey:     cred.PublicKey,
+			Authenticator: gowebauthn.Authenticator{SignCount: uint32(cred.SignCount)},
+		}
+		if cred.Transports != "" {
+			for _, transport := range strings.Split(cred.Transports, ",") {
+				c.Transport = append(c.Transport, protocol.AuthenticatorTransport(transport))
+			}
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+func (s *Service) webAuthn() (*gowebauthn.WebAuthn, error) {
+	timeout := gowebauthn.TimeoutConfig{Enforce: true, Timeout: s.cfg.WebAuthnAuth.Timeout, TimeoutUVD: s.cfg.WebAuthnAuth.Timeout}
+	return gowebauthn.New(&gowebauthn.Config{
+		RPID:                  s.cfg.WebAuthnAuth.RPID,
+		RPDisplayName:         s.cfg.WebAuthnAuth.RPDisplayName,
+		RPOrigins:             s.cfg.WebAuthnAuth.RPOrigins,
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementPreferred,
+			UserVerification: protocol.VerificationPreferred,
+		},
+		Timeouts: gowebauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+}
+
+func (s *Service) BeginRegistration(ctx context.Context, usr identity.Requester) (*webauthn.CredentialCreation, error) {
+	userID, err := usr.GetInternalID()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := s.store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	wa, err := s.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	u := &webAuthnUser{id: userID, name: usr.GetLogin(), displayName: usr.GetName(), creds: creds}
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(creds))
+	for _, cred := range u.WebAuthnCredentials() {
+		exclusions = append(exclusions, cred.Descriptor())
+	}
+	creation, data, err := wa.BeginRegistration(u, gowebauthn.WithExclusions(exclusions))
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := s.createSession(ctx, session{Data: *data, UserID: userID, Registration: true})
+	if err != nil {
+		return nil, err
+	}
+	return &webauthn.CredentialCreation{SessionID: sessionID, PublicKey: creation.Response}, nil
+}
+
+func (s *Service) FinishRegistration(ctx context.Context, usr identity.Requester, cmd *webauthn.FinishRegistrationCommand) (*webauthn.Credential, error) {
+	userID, err := usr.GetInternalID()
+	if err != nil {
+		return nil, err
+	}
+	sess, err := s.getSession(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sess.Registration || sess.UserID != userID {
+		return nil, webauthn.ErrSessionNotFound.Errorf("session does not belong to the user")
+	}
+	if cmd.Credential == nil {
+		return nil, webauthn.ErrInvalidResponse.Errorf("missing credential")
+	}
+
+	parsed, err := cmd.Credential.Parse()
+	if err != nil {
+		return nil, webauthn.ErrInvalidResponse.Errorf("failed to parse credential: %w", err)
+	}
+	wa, err := s.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+	attested, err := wa.CreateCredential(&webAuthnUser{id: userID}, sess.Data, parsed)
+	if err != nil {
+		return nil, webauthn.ErrInvalidResponse.Errorf("failed to verify credential: %w", err)
+	}
+
+	credentialID := base64.RawURLEncoding.EncodeToString(attested.ID)
+	if len(credentialID) > maxCredentialIDLength {
+		return nil, webauthn.ErrInvalidResponse.Errorf("credential id is too long")
+	}
+
+	transports := make([]string, 0, len(attested.Transport))
+	for _, transport := range attested.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	now := s.now()
+	cred := &webauthn.Credential{
+		UserID:       userID,
+		Name:         cmd.Name,
+		CredentialID: credentialID,
+		PublicKey:    attested.PublicKey,
+		SignCount:    int64(attested.Authenticator.SignCount),
+		AAGUID:       fmt.Sprintf("%x", attested.Authenticator.AAGUID),
+		Transports:   strings.Join(transports, ","),
+		Created:      now,
+		LastUsed:     now,
+	}
+	if cred.Name == "" {
+		cred.Name = "Security key"
+	}
+	if err := s.store.Insert(ctx, cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (s *Service) CreateLoginToken(ctx context.Context, userID int64) (string, error) {
+	token, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	err = s.cache.Set(ctx, fmt.Sprintf(loginTokenKeyPrefix, token), []byte(strconv.FormatInt(userID, 10)), s.cfg.WebAuthnAuth.Timeout)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *Service) BeginLogin(ctx context.Context, loginToken string) (*webauthn.CredentialAssertion, error) {
+	wa, err := s.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+
+	var userID int64
+	var assertion *protocol.CredentialAssertion
+	var data *gowebauthn.SessionData
+	if loginToken == "" {
+		if !s.cfg.WebAuthnAuth.AllowPasskeyLogin {
+			return nil, webauthn.ErrPasskeyLoginDisabled.Errorf("passkey login is disabled")
+		}
+		// Passkey logins replace the password, so the user must be verified by the authenticator
+		assertion, data, err = wa.BeginDiscoverableLogin(gowebauthn.WithUserVerification(protocol.VerificationRequired))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// The login token can only be used once
+		key := fmt.Sprintf(loginTokenKeyPrefix, loginToken)
+		value, err := s.cache.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, remotecache.ErrCacheItemNotFound) {
+				return nil, webauthn.ErrSessionNotFound.Errorf("login token not found")
+			}
+			return nil, err
+		}
+		if err := s.cache.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+		if userID, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+			return nil, err
+		}
+		creds, err := s.store.List(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		assertion, data, err = wa.BeginLogin(&webAuthnUser{id: userID, creds: creds})
+		if err != nil {
+			return nil, webauthn.ErrCredentialNotFound.Errorf("failed to begin login: %w", err)
+		}
+	}
+
+	sessionID, err := s.createSession(ctx, session{Data: *data, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &webauthn.CredentialAssertion{SessionID: sessionID, PublicKey: assertion.Response}, nil
+}
+
+func (s *Service) FinishLogin(ctx context.Context, cmd *webauthn.FinishLoginCommand) (*webauthn.Credential, error) {
+	sess, err := s.getSession(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess.Registration {
+		return nil, webauthn.ErrSessionNotFound.Errorf("expected a login session")
+	}
+	if cmd.Credential == nil {
+		return nil, webauthn.ErrInvalidResponse.Errorf("missing credential")
+	}
+
+	parsed, err := cmd.Credential.Parse()
+	if err != nil {
+		return nil, webauthn.ErrInvalidResponse.Errorf("failed to parse assertion: %w", err)
+	}
+
+	cred, err := s.store.Get(ctx, parsed.ID)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrCredentialNotFound) {
+			return nil, webauthn.ErrVerificationFailed.Errorf("unknown credential: %w", err)
+		}
+		return nil, err
+	}
+	if sess.UserID != 0 && sess.UserID != cred.UserID {
+		return nil, webauthn.ErrVerificationFailed.Errorf("credential does not belong to the user")
+	}
+	creds, err := s.store.List(ctx, cred.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	wa, err := s.webAuthn()
+	if err != nil {
+		return nil, err
+	}
+	u := &webAuthnUser{id: cred.UserID, creds: creds}
+	var validated *gowebauthn.Credential
+	if sess.UserID == 0 {
+		validated, err = wa.ValidateDiscoverableLogin(func(rawID, userHandle []byte) (gowebauthn.User, error) {
+			return u, nil
+		}, sess.Data, parsed)
+	} else {
+		validated, err = wa.ValidateLogin(u, sess.Data, parsed)
+	}
+	if err != nil {
+		return nil, webauthn.ErrVerificationFailed.Errorf("failed to verify assertion: %w", err)
+	}
+	// A counter that does not increase is a sign the authenticator was cloned.
+	// Authenticators that do not implement a counter always return 0
+	if validated.Authenticator.CloneWarning {
+		return nil, webauthn.ErrVerificationFailed.Errorf("signature counter did not increase")
+	}
+
+	cred.SignCount = int64(validated.Authenticator.SignCount)
+	cred.LastUsed = s.now()
+	if err := s.store.UpdateUsage(ctx, cred.ID, cred.SignCount, cred.LastUsed); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (s *Service) GetCredentials(ctx context.Context, userID int64) ([]*webauthn.Credential, error) {
+	return s.store.List(ctx, userID)
+}
+
+func (s *Service) DeleteCredential(ctx context.Context, userID, id int64) error {
+	return s.store.Delete(ctx, userID, id)
+}
+
+func (s *Service) createSession(ctx context.Context, sess session) (string, error) {
+	sessionID, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	value, err := json.Marshal(sess)
+	if err != nil {
+		return "", err
+	}
+	if err := s.cache.Set(ctx, fmt.Sprintf(sessionKeyPrefix, sessionID), value, s.cfg.WebAuthnAuth.Timeout); err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+// getSession returns and removes a session, so each challenge can only be answered once
+func (s *Service) getSession(ctx context.Context, sessionID string) (*session, error) {
+	key := fmt.Sprintf(sessionKeyPrefix, sessionID)
+	value, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, remotecache.ErrCacheItemNotFound) {
+			return nil, webauthn.ErrSessionNotFound.Errorf("session not found")
+		}
+		return nil, err
+	}
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	sess := &session{}
+	if err := json.Unmarshal(value, sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
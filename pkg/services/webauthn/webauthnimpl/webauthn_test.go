@@ -0,0 +1,334 @@
+package webauthnimpl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+type fakeStore struct {
+	store
+	creds []*webauthn.Credential
+}
+
+func (f *fakeStore) Insert(ctx context.Context, cred *webauthn.Credential) error {
+	cred.ID = int64(len(f.creds) + 1)
+	f.creds = append(f.creds, cred)
+	return nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, credentialID string) (*webauthn.Credential, error) {
+	for _, cred := range f.creds {
+		if cred.CredentialID == credentialID {
+			c := *cred
+			return &c, nil
+		}
+	}
+	return nil, webauthn.ErrCredentialNotFound.Errorf("credential not found")
+}
+
+func (f *fakeStore) List(ctx context.Context, userID int64) ([]*webauthn.Credential, error) {
+	return f.creds, nil
+}
+
+func (f *fakeStore) UpdateUsage(ctx context.Context, id int64, signCount int64, lastUsed time.Time) error {
+	for _, cred := range f.creds {
+		if cred.ID == id {
+			cred.SignCount = signCount
+			cred.LastUsed = lastUsed
+		}
+	}
+	return nil
+}
+
+func newTestService(t *testing.T, creds ...*webauthn.Credential) *Service {
+	t.Helper()
+	cfg := setting.NewCfg()
+	cfg.WebAuthnAuth = setting.AuthWebAuthnSettings{
+		Enabled:           true,
+		RPID:              "grafana.example.com",
+		RPDisplayName:     "Grafana",
+		RPOrigins:         []string{"https://grafana.example.com"},
+		Timeout:           time.Minute,
+		AllowPasskeyLogin: true,
+	}
+	return &Service{
+		store: &fakeStore{creds: creds},
+		cfg:   cfg,
+		cache: remotecache.NewFakeCacheStorage(),
+		now:   time.Now,
+		log:   log.NewNopLogger(),
+	}
+}
+
+func TestService_BeginLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("login token can only be used once", func(t *testing.T) {
+		s := newTestService(t, &webauthn.Credential{UserID: 1, CredentialID: "Y3JlZA", Transports: "usb,nfc"})
+		token, err := s.CreateLoginToken(ctx, 1)
+		require.NoError(t, err)
+
+		assertion, err := s.BeginLogin(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, "grafana.example.com", assertion.PublicKey.RelyingPartyID)
+		require.Equal(t, protocol.VerificationPreferred, assertion.PublicKey.UserVerification)
+		require.Len(t, assertion.PublicKey.AllowedCredentials, 1)
+		require.Equal(t, []byte("cred"), []byte(assertion.PublicKey.AllowedCredentials[0].CredentialID))
+		require.Equal(t, []protocol.AuthenticatorTransport{protocol.USB, protocol.NFC}, assertion.PublicKey.AllowedCredentials[0].Transport)
+
+		_, err = s.BeginLogin(ctx, token)
+		require.ErrorIs(t, err, webauthn.ErrSessionNotFound)
+	})
+
+	t.Run("passkey login requires user verification", func(t *testing.T) {
+		s := newTestService(t)
+		assertion, err := s.BeginLogin(ctx, "")
+		require.NoError(t, err)
+		require.Empty(t, assertion.PublicKey.AllowedCredentials)
+		require.Equal(t, protocol.VerificationRequired, assertion.PublicKey.UserVerification)
+
+		s.cfg.WebAuthnAuth.AllowPasskeyLogin = false
+		_, err = s.BeginLogin(ctx, "")
+		require.ErrorIs(t, err, webauthn.ErrPasskeyLoginDisabled)
+	})
+
+	t.Run("sessions can only be used once", func(t *testing.T) {
+		s := newTestService(t)
+		assertion, err := s.BeginLogin(ctx, "")
+		require.NoError(t, err)
+
+		_, err = s.getSession(ctx, assertion.SessionID)
+		require.NoError(t, err)
+		_, err = s.FinishLogin(ctx, &webauthn.FinishLoginCommand{SessionID: assertion.SessionID})
+		require.ErrorIs(t, err, webauthn.ErrSessionNotFound)
+	})
+
+	t.Run("registration sessions belong to the user", func(t *testing.T) {
+		s := newTestService(t)
+		creation, err := s.BeginRegistration(ctx, &user.SignedInUser{UserID: 1, UserUID: "uid", Login: "admin"})
+		require.NoError(t, err)
+		require.Equal(t, protocol.URLEncodedBase64("1"), creation.PublicKey.User.ID)
+		require.Equal(t, "admin", creation.PublicKey.User.Name)
+
+		_, err = s.FinishRegistration(ctx, &user.SignedInUser{UserID: 2}, &webauthn.FinishRegistrationCommand{SessionID: creation.SessionID})
+		require.ErrorIs(t, err, webauthn.ErrSessionNotFound)
+	})
+}
+
+func TestService_Ceremonies(t *testing.T) {
+	ctx := context.Background()
+	usr := &user.SignedInUser{UserID: 1, Login: "admin"}
+	origin := "https://grafana.example.com"
+
+	register := func(t *testing.T, s *Service, authenticator *testAuthenticator) *webauthn.Credential {
+		t.Helper()
+		creation, err := s.BeginRegistration(ctx, usr)
+		require.NoError(t, err)
+		cred, err := s.FinishRegistration(ctx, usr, &webauthn.FinishRegistrationCommand{
+			SessionID:  creation.SessionID,
+			Credential: authenticator.create(t, creation.PublicKey.Challenge, origin),
+		})
+		require.NoError(t, err)
+		return cred
+	}
+
+	secondFactor := func(t *testing.T, s *Service, authenticator *testAuthenticator, origin string, signCount uint32) (*webauthn.Credential, error) {
+		t.Helper()
+		token, err := s.CreateLoginToken(ctx, 1)
+		require.NoError(t, err)
+		assertion, err := s.BeginLogin(ctx, token)
+		require.NoError(t, err)
+		return s.FinishLogin(ctx, &webauthn.FinishLoginCommand{
+			SessionID:  assertion.SessionID,
+			Credential: authenticator.get(t, assertion.PublicKey.Challenge, origin, signCount, flagUserPresent, nil),
+		})
+	}
+
+	t.Run("registered credentials can be used to log in", func(t *testing.T) {
+		s := newTestService(t)
+		authenticator := newTestAuthenticator(t)
+		registered := register(t, s, authenticator)
+		require.Equal(t, base64.RawURLEncoding.EncodeToString(authenticator.id), registered.CredentialID)
+		require.Equal(t, "Security key", registered.Name)
+
+		cred, err := secondFactor(t, s, authenticator, origin, 5)
+		require.NoError(t, err)
+		require.Equal(t, registered.ID, cred.ID)
+		require.Equal(t, int64(5), cred.SignCount)
+
+		t.Run("counter must increase", func(t *testing.T) {
+			_, err := secondFactor(t, s, authenticator, origin, 5)
+			require.ErrorIs(t, err, webauthn.ErrVerificationFailed)
+		})
+
+		t.Run("origin must be allowed", func(t *testing.T) {
+			_, err := secondFactor(t, s, authenticator, "https://evil.example.com", 6)
+			require.ErrorIs(t, err, webauthn.ErrVerificationFailed)
+		})
+
+		t.Run("signature must be valid", func(t *testing.T) {
+			token, err := s.CreateLoginToken(ctx, 1)
+			require.NoError(t, err)
+			assertion, err := s.BeginLogin(ctx, token)
+			require.NoError(t, err)
+			rsp := authenticator.get(t, assertion.PublicKey.Challenge, origin, 6, flagUserPresent, nil)
+			rsp.AssertionResponse.Signature[len(rsp.AssertionResponse.Signature)-1] ^= 0xff
+			_, err = s.FinishLogin(ctx, &webauthn.FinishLoginCommand{SessionID: assertion.SessionID, Credential: rsp})
+			require.ErrorIs(t, err, webauthn.ErrVerificationFailed)
+		})
+
+		t.Run("credentials of another user are rejected", func(t *testing.T) {
+			token, err := s.CreateLoginToken(ctx, 2)
+			require.NoError(t, err)
+			assertion, err := s.BeginLogin(ctx, token)
+			require.NoError(t, err)
+			_, err = s.FinishLogin(ctx, &webauthn.FinishLoginCommand{
+				SessionID:  assertion.SessionID,
+				Credential: authenticator.get(t, assertion.PublicKey.Challenge, origin, 7, flagUserPresent, nil),
+			})
+			require.ErrorIs(t, err, webauthn.ErrVerificationFailed)
+		})
+	})
+
+	t.Run("passkey login", func(t *testing.T) {
+		s := newTestService(t)
+		authenticator := newTestAuthenticator(t)
+		register(t, s, authenticator)
+
+		passkey := func(t *testing.T, flags byte, userHandle []byte) (*webauthn.Credential, error) {
+			t.Helper()
+			assertion, err := s.BeginLogin(ctx, "")
+			require.NoError(t, err)
+			return s.FinishLogin(ctx, &webauthn.FinishLoginCommand{
+				SessionID:  assertion.SessionID,
+				Credential: authenticator.get(t, assertion.PublicKey.Challenge, origin, 0, flags, userHandle),
+			})
+		}
+
+		_, err := passkey(t, flagUserPresent, []byte("1"))
+		require.ErrorIs(t, err, webauthn.ErrVerificationFailed, "the user must be verified")
+
+		_, err = passkey(t, flagUserPresent|flagUserVerified, []byte("2"))
+		require.ErrorIs(t, err, webauthn.ErrVerificationFailed, "the user handle must match the credential")
+
+		cred, err := passkey(t, flagUserPresent|flagUserVerified, []byte("1"))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), cred.UserID)
+	})
+}
+
+// testAuthenticator is a software authenticator with an ES256 key, that does not provide an attestation
+type testAuthenticator struct {
+	id        []byte
+	key       *ecdsa.PrivateKey
+	publicKey []byte
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := cbor.Marshal(map[int]any{
+		1:  2,  // EC2
+		3:  -7, // ES256
+		-1: 1,  // P-256
+		-2: key.X.FillBytes(make([]byte, 32)),
+		-3: key.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+	return &testAuthenticator{id: []byte("es256-credential"), key: key, publicKey: publicKey}
+}
+
+func (a *testAuthenticator) authData(signCount uint32, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("grafana.example.com"))
+	data := slices.Concat(rpIDHash[:], []byte{flags}, binary.BigEndian.AppendUint32(nil, signCount))
+	if flags&flagAttestedCredentialData != 0 {
+		data = slices.Concat(data, make([]byte, 16), binary.BigEndian.AppendUint16(nil, uint16(len(a.id))), a.id, a.publicKey)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, typ string, challenge protocol.URLEncodedBase64, origin string) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+// create returns the JSON encoded result of navigator.credentials.create(), as sent by the browser
+func (a *testAuthenticator) create(t *testing.T, challenge protocol.URLEncodedBase64, origin string) *protocol.CredentialCreationResponse {
+	attestation, err := cbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(0, flagUserPresent|flagAttestedCredentialData),
+	})
+	require.NoError(t, err)
+
+	return unmarshalResponse[protocol.CredentialCreationResponse](t, map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.id),
+		"rawId": protocol.URLEncodedBase64(a.id),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    protocol.URLEncodedBase64(clientDataJSON(t, "webauthn.create", challenge, origin)),
+			"attestationObject": protocol.URLEncodedBase64(attestation),
+			"transports":        []string{"usb"},
+		},
+	})
+}
+
+// get returns the JSON encoded result of navigator.credentials.get(), as sent by the browser
+func (a *testAuthenticator) get(t *testing.T, challenge protocol.URLEncodedBase64, origin string, signCount uint32, flags byte, userHandle []byte) *protocol.CredentialAssertionResponse {
+	clientData := clientDataJSON(t, "webauthn.get", challenge, origin)
+	authData := a.authData(signCount, flags)
+	clientDataHash := sha256.Sum256(clientData)
+	hash := sha256.Sum256(slices.Concat(authData, clientDataHash[:]))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, hash[:])
+	require.NoError(t, err)
+
+	return unmarshalResponse[protocol.CredentialAssertionResponse](t, map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.id),
+		"rawId": protocol.URLEncodedBase64(a.id),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    protocol.URLEncodedBase64(clientData),
+			"authenticatorData": protocol.URLEncodedBase64(authData),
+			"signature":         protocol.URLEncodedBase64(signature),
+			"userHandle":        protocol.URLEncodedBase64(userHandle),
+		},
+	})
+}
+
+func unmarshalResponse[T any](t *testing.T, v map[string]any) *T {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var rsp T
+	require.NoError(t, json.Unmarshal(data, &rsp))
+	return &rsp
+}
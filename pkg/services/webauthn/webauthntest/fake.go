@@ -0,0 +1,47 @@
+package webauthntest
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+)
+
+var _ webauthn.Service = new(FakeService)
+
+type FakeService struct {
+	ExpectedCredentials []*webauthn.Credential
+	ExpectedCredential  *webauthn.Credential
+	ExpectedCreation    *webauthn.CredentialCreation
+	ExpectedAssertion   *webauthn.CredentialAssertion
+	ExpectedLoginToken  string
+	ExpectedErr         error
+}
+
+func (f *FakeService) BeginRegistration(ctx context.Context, usr identity.Requester) (*webauthn.CredentialCreation, error) {
+	return f.ExpectedCreation, f.ExpectedErr
+}
+
+func (f *FakeService) FinishRegistration(ctx context.Context, usr identity.Requester, cmd *webauthn.FinishRegistrationCommand) (*webauthn.Credential, error) {
+	return f.ExpectedCredential, f.ExpectedErr
+}
+
+func (f *FakeService) CreateLoginToken(ctx context.Context, userID int64) (string, error) {
+	return f.ExpectedLoginToken, f.ExpectedErr
+}
+
+func (f *FakeService) BeginLogin(ctx context.Context, loginToken string) (*webauthn.CredentialAssertion, error) {
+	return f.ExpectedAssertion, f.ExpectedErr
+}
+
+func (f *FakeService) FinishLogin(ctx context.Context, cmd *webauthn.FinishLoginCommand) (*webauthn.Credential, error) {
+	return f.ExpectedCredential, f.ExpectedErr
+}
+
+func (f *FakeService) GetCredentials(ctx context.Context, userID int64) ([]*webauthn.Credential, error) {
+	return f.ExpectedCredentials, f.ExpectedErr
+}
+
+func (f *FakeService) DeleteCredential(ctx context.Context, userID, id int64) error {
+	return f.ExpectedErr
+}
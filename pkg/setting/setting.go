@@ -291,6 +291,12 @@ type Cfg struct {
 
 	PasswordlessMagicLinkAuth AuthPasswordlessMagicLinkSettings
 
+	WebAuthnAuth AuthWebAuthnSettings
+
+	MTLSAuth AuthMTLSSettings
+
+	AuditLog AuditLogSettings
+
 	// SSO Settings Auth
 	SSOSettingsReloadInterval        time.Duration
 	SSOSettingsConfigurableProviders map[string]bool
@@ -313,6 +319,9 @@ type Cfg struct {
 	// DistributedCache
 	RemoteCacheOptions *RemoteCacheSettings
 
+	// Query result caching
+	QueryCaching QueryCachingSettings
+
 	// Deprecated: no longer used
 	ViewersCanEdit bool
 
@@ -343,6 +352,7 @@ type Cfg struct {
 	AlertingAnnotationCleanupSetting   AnnotationCleanupSettings
 	DashboardAnnotationCleanupSettings AnnotationCleanupSettings
 	APIAnnotationCleanupSettings       AnnotationCleanupSettings
+	AnnotationWebhooks                 AnnotationWebhookSettings
 
 	// GrafanaJavascriptAgent config
 	GrafanaJavascriptAgent GrafanaJavascriptAgent
@@ -832,6 +842,14 @@ func (cfg *Cfg) readAnnotationSettings() error {
 	cfg.DashboardAnnotationCleanupSettings = newAnnotationCleanupSettings(dashboardAnnotation, "max_age")
 	cfg.APIAnnotationCleanupSettings = newAnnotationCleanupSettings(apiIAnnotation, "max_age")
 
+	webhooks := cfg.Raw.Section("annotations.webhooks")
+	cfg.AnnotationWebhooks = AnnotationWebhookSettings{
+		Enabled:             webhooks.Key("enabled").MustBool(false),
+		GenericTextTemplate: webhooks.Key("generic_text_template").MustString("{{ .text }}"),
+		GenericTagsTemplate: webhooks.Key("generic_tags_template").MustString(""),
+		GenericTimeTemplate: webhooks.Key("generic_time_template").MustString(""),
+	}
+
 	return nil
 }
 
@@ -849,6 +867,15 @@ type AnnotationCleanupSettings struct {
 	MaxCount int64
 }
 
+type AnnotationWebhookSettings struct {
+	Enabled bool
+	// Go templates executed against the payload of generic webhooks. The tags template renders
+	// a comma separated list and the time template an RFC 3339 date or epoch milliseconds.
+	GenericTextTemplate string
+	GenericTagsTemplate string
+	GenericTimeTemplate string
+}
+
 func EnvKey(sectionName string, keyName string) string {
 	sN := strings.ToUpper(strings.ReplaceAll(sectionName, ".", "_"))
 	sN = strings.ReplaceAll(sN, "-", "_")
@@ -1346,6 +1373,9 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.readAuthProxySettings()
 	cfg.readSessionConfig()
 	cfg.readPasswordlessMagicLinkSettings()
+	cfg.readWebAuthnSettings()
+	cfg.readAuthMTLSSettings()
+	cfg.readAuditLogSettings()
 	if err := cfg.readSmtpSettings(); err != nil {
 		return err
 	}
@@ -1414,6 +1444,7 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.GeomapEnableCustomBaseLayers = geomapSection.Key("enable_custom_baselayers").MustBool(true)
 
 	cfg.readRemoteCacheSettings()
+	cfg.readQueryCachingSettings()
 	cfg.readDateFormats()
 	cfg.readGrafanaJavascriptAgentConfig()
 
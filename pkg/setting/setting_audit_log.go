@@ -0,0 +1,59 @@
+package setting
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	AuditLogExportFile = "file"
+	AuditLogExportLoki = "loki"
+)
+
+type AuditLogSettings struct {
+	// Enabled records write requests to the HTTP API and the Kubernetes style APIs
+	Enabled bool
+	// Retention is how long entries are kept, zero keeps them forever
+	Retention time.Duration
+	// LogReads also records GET requests
+	LogReads bool
+	// ExcludePaths are path prefixes of requests that are never recorded
+	ExcludePaths []string
+	// Export is where entries are sent in addition to the database, one of file or loki
+	Export             string
+	ExportFilePath     string
+	ExportLokiURL      string
+	ExportLokiTenantID string
+}
+
+func (cfg *Cfg) readAuditLogSettings() {
+	section := cfg.SectionWithEnvOverrides("audit_log")
+	settings := AuditLogSettings{}
+	settings.Enabled = section.Key("enabled").MustBool(false)
+	settings.Retention = time.Duration(section.Key("retention_days").MustInt64(90)) * 24 * time.Hour
+	settings.LogReads = section.Key("log_reads").MustBool(false)
+	settings.ExcludePaths = strings.Fields(section.Key("exclude_paths").MustString("/api/ds/query /api/frontend-metrics /api/login /api/live /api/search"))
+	settings.Export = section.Key("export").MustString("")
+	settings.ExportFilePath = section.Key("export_file_path").MustString(filepath.Join("audit", "audit.log"))
+	settings.ExportLokiURL = section.Key("export_loki_url").MustString("")
+	settings.ExportLokiTenantID = section.Key("export_loki_tenant_id").MustString("")
+
+	if !filepath.IsAbs(settings.ExportFilePath) {
+		settings.ExportFilePath = filepath.Join(cfg.DataPath, settings.ExportFilePath)
+	}
+
+	switch settings.Export {
+	case "", AuditLogExportFile:
+	case AuditLogExportLoki:
+		if settings.ExportLokiURL == "" {
+			cfg.Logger.Error("audit_log export is loki but export_loki_url is not set, entries will not be exported")
+			settings.Export = ""
+		}
+	default:
+		cfg.Logger.Error("Unknown audit_log export, entries will not be exported", "export", settings.Export)
+		settings.Export = ""
+	}
+
+	cfg.AuditLog = settings
+}
@@ -0,0 +1,53 @@
+package setting
+
+import (
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type AuthMTLSSettings struct {
+	// Mutual TLS client certificate auth
+	Enabled bool
+	// ClientCAFile is the PEM encoded bundle of certificate authorities client certificates are verified against
+	ClientCAFile string
+	// LoginAttribute, EmailAttribute, NameAttribute and OrgAttribute select the certificate fields
+	// used for the login, email, name and external orgs, one of CN, O, OU, SAN_EMAIL, SAN_DNS or SAN_URI
+	LoginAttribute      string
+	EmailAttribute      string
+	NameAttribute       string
+	OrgAttribute        string
+	OrgMapping          []string
+	RoleAttributeStrict bool
+	SkipOrgRoleSync     bool
+	AutoSignUp          bool
+	// ServiceAccountURIPrefix makes certificates with a URI SAN starting with this prefix
+	// authenticate as the service account named after the rest of the URI
+	ServiceAccountURIPrefix string
+	// ServiceAccountOrgID is the org service accounts are looked up and created in
+	ServiceAccountOrgID int64
+}
+
+func (cfg *Cfg) readAuthMTLSSettings() {
+	section := cfg.SectionWithEnvOverrides("auth.mtls")
+	settings := AuthMTLSSettings{}
+	settings.Enabled = section.Key("enabled").MustBool(false)
+	settings.ClientCAFile = section.Key("client_ca_file").MustString("")
+	settings.LoginAttribute = section.Key("login_attribute").MustString("CN")
+	settings.EmailAttribute = section.Key("email_attribute").MustString("SAN_EMAIL")
+	settings.NameAttribute = section.Key("name_attribute").MustString("CN")
+	settings.OrgAttribute = section.Key("org_attribute").MustString("OU")
+	settings.OrgMapping = util.SplitString(section.Key("org_mapping").MustString(""))
+	settings.RoleAttributeStrict = section.Key("role_attribute_strict").MustBool(false)
+	settings.SkipOrgRoleSync = section.Key("skip_org_role_sync").MustBool(false)
+	settings.AutoSignUp = section.Key("auto_sign_up").MustBool(false)
+	settings.ServiceAccountURIPrefix = section.Key("service_account_uri_prefix").MustString("")
+	settings.ServiceAccountOrgID = section.Key("service_account_org_id").MustInt64(1)
+
+	if settings.Enabled && settings.ClientCAFile == "" {
+		cfg.Logger.Error("auth.mtls is enabled but client_ca_file is not set, client certificates will not be verified")
+	}
+	if settings.Enabled && cfg.Protocol != HTTPSScheme && cfg.Protocol != HTTP2Scheme {
+		cfg.Logger.Warn("auth.mtls requires the server protocol to be https or h2")
+	}
+
+	cfg.MTLSAuth = settings
+}
@@ -0,0 +1,27 @@
+package setting
+
+import "time"
+
+type QueryCachingSettings struct {
+	// Enabled caches data source query results in the remote cache
+	Enabled bool
+	// TTL is how long query results are cached unless the data source sets its own TTL
+	TTL time.Duration
+	// ResourcesEnabled also caches GET resource requests
+	ResourcesEnabled bool
+	// ResourcesTTL is how long resource responses are cached
+	ResourcesTTL time.Duration
+	// MaxValueSize is the largest encoded response in bytes that is cached
+	MaxValueSize int
+}
+
+func (cfg *Cfg) readQueryCachingSettings() {
+	section := cfg.Raw.Section("query_caching")
+	cfg.QueryCaching = QueryCachingSettings{
+		Enabled:          section.Key("enabled").MustBool(false),
+		TTL:              section.Key("ttl").MustDuration(5 * time.Minute),
+		ResourcesEnabled: section.Key("resources_enabled").MustBool(false),
+		ResourcesTTL:     section.Key("resources_ttl").MustDuration(5 * time.Minute),
+		MaxValueSize:     section.Key("max_value_mb").MustInt(1) * 1024 * 1024,
+	}
+}
@@ -5,6 +5,8 @@ type RemoteCacheSettings struct {
 	ConnStr    string
 	Prefix     string
 	Encryption bool
+	// Invalidation fans out invalidations of in-memory caches to the other instances, either "redis" or "postgres"
+	Invalidation string
 }
 
 func (cfg *Cfg) readRemoteCacheSettings() {
@@ -13,11 +15,13 @@ func (cfg *Cfg) readRemoteCacheSettings() {
 	connStr := valueAsString(cacheServer, "connstr", "")
 	prefix := valueAsString(cacheServer, "prefix", "")
 	encryption := cacheServer.Key("encryption").MustBool(false)
+	invalidation := valueAsString(cacheServer, "invalidation", "")
 
 	cfg.RemoteCacheOptions = &RemoteCacheSettings{
-		Name:       dbName,
-		ConnStr:    connStr,
-		Prefix:     prefix,
-		Encryption: encryption,
+		Name:         dbName,
+		ConnStr:      connStr,
+		Prefix:       prefix,
+		Encryption:   encryption,
+		Invalidation: invalidation,
 	}
 }
@@ -14,7 +14,7 @@ type SecretsManagerSettings struct {
 	CurrentEncryptionProvider string
 
 	// ConfiguredKMSProviders is a map of KMS providers found in the config file. The keys are in the format of <provider>.<keyName>, and the values are a map of the properties in that section
-	// In OSS, the provider type can only be "secret_key". In Enterprise, it can additionally be one of: "aws_kms", "azure_keyvault", "google_kms", "hashicorp_vault"
+	// The provider type can be one of: "secret_key", "aws_kms", "azure_keyvault", "google_kms", "hashicorp_vault", "local_key", "pkcs11"
 	ConfiguredKMSProviders map[string]map[string]string
 
 	GrpcClientEnable        bool   // Whether to enable the gRPC client. If disabled, it will use the in-process services implementations.
@@ -0,0 +1,64 @@
+package setting
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type AuthWebAuthnSettings struct {
+	// WebAuthn (security keys and passkeys)
+	Enabled bool
+	// RPID is the relying party id, the domain credentials are scoped to
+	RPID string
+	// RPDisplayName is the name shown by the browser when registering a credential
+	RPDisplayName string
+	// RPOrigins are the origins allowed to perform WebAuthn ceremonies
+	RPOrigins []string
+	Timeout   time.Duration
+	// AllowPasskeyLogin allows logging in with a discoverable credential only
+	AllowPasskeyLogin bool
+	// RequireSecondFactor requires all users to use a credential after their password
+	RequireSecondFactor bool
+	// RequireSecondFactorOrgIDs requires members of these orgs to use a credential after their password
+	RequireSecondFactorOrgIDs []int64
+	// AllowEnrollment lets users without a credential log in with their password only, so they can register one.
+	// It is disabled by default, as it lets a stolen password bypass the second factor of users who have not enrolled.
+	AllowEnrollment bool
+}
+
+func (cfg *Cfg) readWebAuthnSettings() {
+	section := cfg.SectionWithEnvOverrides("auth.webauthn")
+	settings := AuthWebAuthnSettings{}
+	settings.Enabled = section.Key("enabled").MustBool(false)
+	settings.RPID = section.Key("rp_id").MustString("")
+	settings.RPDisplayName = section.Key("rp_display_name").MustString("Grafana")
+	settings.RPOrigins = util.SplitString(section.Key("rp_origins").MustString(""))
+	settings.Timeout = section.Key("timeout").MustDuration(5 * time.Minute)
+	settings.AllowPasskeyLogin = section.Key("allow_passkey_login").MustBool(true)
+	settings.RequireSecondFactor = section.Key("require_second_factor").MustBool(false)
+	settings.AllowEnrollment = section.Key("allow_enrollment").MustBool(false)
+
+	for _, v := range util.SplitString(section.Key("require_second_factor_org_ids").MustString("")) {
+		orgID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			cfg.Logger.Error("Invalid org id in auth.webauthn require_second_factor_org_ids", "value", v)
+			continue
+		}
+		settings.RequireSecondFactorOrgIDs = append(settings.RequireSecondFactorOrgIDs, orgID)
+	}
+
+	// Default to the domain and origin Grafana is served from
+	if appURL, err := url.Parse(cfg.AppURL); err == nil {
+		if settings.RPID == "" {
+			settings.RPID = appURL.Hostname()
+		}
+		if len(settings.RPOrigins) == 0 {
+			settings.RPOrigins = []string{appURL.Scheme + "://" + appURL.Host}
+		}
+	}
+
+	cfg.WebAuthnAuth = settings
+}
@@ -0,0 +1,10 @@
+UPDATE
+  {{ .Ident "secret_data_key" }}
+SET
+  {{ .Ident "label" }} = {{ .Arg .Label }},
+  {{ .Ident "provider" }} = {{ .Arg .Provider }},
+  {{ .Ident "encrypted_data" }} = {{ .Arg .EncryptedData }},
+  {{ .Ident "updated" }} = {{ .Arg .Updated }}
+WHERE {{ .Ident "namespace" }} = {{ .Arg .Namespace }} AND
+  {{ .Ident "uid" }} = {{ .Arg .UID }}
+;
@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/grafana-app-sdk/logging"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption"
 	"github.com/grafana/grafana/pkg/storage/unified/sql/sqltemplate"
 )
 
@@ -406,3 +407,120 @@ func (ss *globalEncryptionStoreImpl) DisableAllDataKeys(ctx context.Context) err
 
 	return nil
 }
+
+// ReEncryptDataKeys decrypts all data keys with the provider they were encrypted with
+// and encrypts them again with the current provider.
+// Data keys that cannot be re-encrypted are logged and left untouched.
+func (ss *globalEncryptionStoreImpl) ReEncryptDataKeys(ctx context.Context, providers encryption.ProviderMap, currProvider encryption.ProviderID) error {
+	start := time.Now()
+	ctx, span := ss.tracer.Start(ctx, "GlobalDataKeyStorage.ReEncryptDataKeys", trace.WithAttributes(
+		attribute.String("provider", string(currProvider)),
+	))
+	defer func() {
+		span.End()
+		ss.metrics.ReEncryptDataKeysDuration.Observe(float64(time.Since(start)))
+	}()
+
+	currentProvider, ok := providers[currProvider]
+	if !ok {
+		return fmt.Errorf("current provider %s is not configured", currProvider)
+	}
+
+	dataKeys, err := ss.listAllDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.FromContext(ctx)
+	for _, k := range dataKeys {
+		provider, ok := providers[k.Provider]
+		if !ok {
+			logger.Warn("Could not find provider to re-encrypt data encryption key", "uid", k.UID, "namespace", k.Namespace, "provider", k.Provider)
+			continue
+		}
+
+		decrypted, err := provider.Decrypt(ctx, k.EncryptedData)
+		if err != nil {
+			logger.Warn("Error while decrypting data encryption key to re-encrypt it", "uid", k.UID, "namespace", k.Namespace, "provider", k.Provider, "error", err)
+			continue
+		}
+
+		encrypted, err := currentProvider.Encrypt(ctx, decrypted)
+		if err != nil {
+			logger.Warn("Error while re-encrypting data encryption key", "uid", k.UID, "namespace", k.Namespace, "provider", currProvider, "error", err)
+			continue
+		}
+
+		req := updateDataKeyProvider{
+			SQLTemplate:   sqltemplate.New(ss.dialect),
+			Namespace:     k.Namespace,
+			UID:           k.UID,
+			Label:         encryption.KeyLabel(currProvider),
+			Provider:      currProvider,
+			EncryptedData: encrypted,
+			Updated:       time.Now(),
+		}
+
+		query, err := sqltemplate.Execute(sqlDataKeyUpdateProvider, req)
+		if err != nil {
+			return fmt.Errorf("execute template %q: %w", sqlDataKeyUpdateProvider.Name(), err)
+		}
+
+		if _, err := ss.db.ExecContext(ctx, query, req.GetArgs()...); err != nil {
+			logger.Warn("Error while updating re-encrypted data encryption key", "uid", k.UID, "namespace", k.Namespace, "provider", currProvider, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (ss *globalEncryptionStoreImpl) listAllDataKeys(ctx context.Context) ([]*contracts.SecretDataKey, error) {
+	req := listAllDataKeys{
+		SQLTemplate: sqltemplate.New(ss.dialect),
+	}
+
+	query, err := sqltemplate.Execute(sqlDataKeyListAll, req)
+	if err != nil {
+		return nil, fmt.Errorf("execute template %q: %w", sqlDataKeyListAll.Name(), err)
+	}
+
+	rows, err := ss.db.QueryContext(ctx, query, req.GetArgs()...)
+	if err != nil {
+		return nil, fmt.Errorf("listing all data keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	dataKeys := make([]*contracts.SecretDataKey, 0)
+	for rows.Next() {
+		var row SecretDataKey
+		err = rows.Scan(
+			&row.UID,
+			&row.Namespace,
+			&row.Label,
+			&row.Provider,
+			&row.EncryptedData,
+			&row.Active,
+			&row.Created,
+			&row.Updated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error reading data key row: %w", err)
+		}
+
+		dataKeys = append(dataKeys, &contracts.SecretDataKey{
+			UID:           row.UID,
+			Namespace:     row.Namespace,
+			Label:         row.Label,
+			Provider:      row.Provider,
+			EncryptedData: row.EncryptedData,
+			Active:        row.Active,
+			Created:       row.Created,
+			Updated:       row.Updated,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read rows error: %w", err)
+	}
+
+	return dataKeys, nil
+}
@@ -114,6 +114,68 @@ func TestEncryptionStoreImpl_DataKeyLifecycle(t *testing.T) {
 	require.False(t, disabledKey.Active)
 }
 
+func TestEncryptionStoreImpl_ReEncryptDataKeys(t *testing.T) {
+	testDB := sqlstore.NewTestStore(t, sqlstore.WithMigrator(migrator.New()))
+	tracer := noop.NewTracerProvider().Tracer("test")
+	store, err := ProvideDataKeyStorage(database.ProvideDatabase(testDB, tracer), tracer, nil)
+	require.NoError(t, err)
+	globalStore, err := ProvideGlobalDataKeyStorage(database.ProvideDatabase(testDB, tracer), tracer, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	providers := encryption.ProviderMap{
+		passThroughProvider: &PassThroughEncryptionProvider{},
+		base64Provider:      &Base64EncryptionProvider{},
+	}
+
+	require.NoError(t, store.CreateDataKey(ctx, &contracts.SecretDataKey{
+		UID:           "uid-1",
+		Namespace:     "ns-1",
+		Label:         "label-1",
+		Active:        true,
+		EncryptedData: []byte("data-1"),
+		Provider:      passThroughProvider,
+	}))
+	require.NoError(t, store.CreateDataKey(ctx, &contracts.SecretDataKey{
+		UID:           "uid-2",
+		Namespace:     "ns-2",
+		Label:         "label-2",
+		Active:        true,
+		EncryptedData: []byte("data-2"),
+		Provider:      passThroughProvider,
+	}))
+	require.NoError(t, store.CreateDataKey(ctx, &contracts.SecretDataKey{
+		UID:           "uid-3",
+		Namespace:     "ns-2",
+		Label:         "label-3",
+		Active:        true,
+		EncryptedData: []byte("data-3"),
+		Provider:      "unknown.v1",
+	}))
+
+	require.NoError(t, globalStore.ReEncryptDataKeys(ctx, providers, base64Provider))
+
+	for uid, namespace := range map[string]string{"uid-1": "ns-1", "uid-2": "ns-2"} {
+		dataKey, err := store.GetDataKey(ctx, namespace, uid)
+		require.NoError(t, err)
+		require.Equal(t, base64Provider, dataKey.Provider)
+		require.Equal(t, encryption.KeyLabel(base64Provider), dataKey.Label)
+
+		decrypted, err := providers[base64Provider].Decrypt(ctx, dataKey.EncryptedData)
+		require.NoError(t, err)
+		require.Equal(t, "data-"+uid[len("uid-"):], string(decrypted))
+	}
+
+	// Data keys of unknown providers are left untouched
+	dataKey, err := store.GetDataKey(ctx, "ns-2", "uid-3")
+	require.NoError(t, err)
+	require.Equal(t, encryption.ProviderID("unknown.v1"), dataKey.Provider)
+	require.Equal(t, []byte("data-3"), dataKey.EncryptedData)
+
+	err = globalStore.ReEncryptDataKeys(ctx, providers, "missing.v1")
+	require.Error(t, err)
+}
+
 type PassThroughEncryptionProvider struct{}
 
 func (d *PassThroughEncryptionProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
@@ -87,6 +87,7 @@ func NewDataKeyMetrics(reg prometheus.Registerer) *DataKeyMetrics {
 
 type GlobalDataKeyMetrics struct {
 	DisableAllDataKeysDuration prometheus.Histogram
+	ReEncryptDataKeysDuration  prometheus.Histogram
 }
 
 func newGlobalDataKeyMetrics() *GlobalDataKeyMetrics {
@@ -99,6 +100,14 @@ func newGlobalDataKeyMetrics() *GlobalDataKeyMetrics {
 			Help:      "Duration of disable all data keys operations",
 			Buckets:   prometheus.DefBuckets,
 		}),
+
+		ReEncryptDataKeysDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "re_encrypt_data_keys_duration_seconds",
+			Help:      "Duration of re-encrypt data keys operations",
+			Buckets:   prometheus.DefBuckets,
+		}),
 	}
 }
 
@@ -110,6 +119,7 @@ func NewGlobalDataKeyMetrics(reg prometheus.Registerer) *GlobalDataKeyMetrics {
 	if reg != nil {
 		reg.MustRegister(
 			m.DisableAllDataKeysDuration,
+			m.ReEncryptDataKeysDuration,
 		)
 	}
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption"
 	"github.com/grafana/grafana/pkg/storage/unified/sql/sqltemplate"
 )
 
@@ -24,13 +25,15 @@ var (
 	sqlEncryptedValueListAll  = mustTemplate("encrypted_value_list_all.sql")
 	sqlEncryptedValueCountAll = mustTemplate("encrypted_value_count_all.sql")
 
-	sqlDataKeyCreate      = mustTemplate("data_key_create.sql")
-	sqlDataKeyRead        = mustTemplate("data_key_read.sql")
-	sqlDataKeyReadCurrent = mustTemplate("data_key_read_current.sql")
-	sqlDataKeyList        = mustTemplate("data_key_list.sql")
-	sqlDataKeyDisable     = mustTemplate("data_key_disable.sql")
-	sqlDataKeyDelete      = mustTemplate("data_key_delete.sql")
-	sqlDataKeyDisableAll  = mustTemplate("data_key_disable_all.sql")
+	sqlDataKeyCreate         = mustTemplate("data_key_create.sql")
+	sqlDataKeyRead           = mustTemplate("data_key_read.sql")
+	sqlDataKeyReadCurrent    = mustTemplate("data_key_read_current.sql")
+	sqlDataKeyList           = mustTemplate("data_key_list.sql")
+	sqlDataKeyDisable        = mustTemplate("data_key_disable.sql")
+	sqlDataKeyDelete         = mustTemplate("data_key_delete.sql")
+	sqlDataKeyDisableAll     = mustTemplate("data_key_disable_all.sql")
+	sqlDataKeyListAll        = mustTemplate("data_key_list_all.sql")
+	sqlDataKeyUpdateProvider = mustTemplate("data_key_update_provider.sql")
 )
 
 // TODO: Move this to a common place so that all stores can use
@@ -168,3 +171,21 @@ type disableAllDataKeys struct {
 }
 
 func (r disableAllDataKeys) Validate() error { return nil }
+
+type listAllDataKeys struct {
+	sqltemplate.SQLTemplate
+}
+
+func (r listAllDataKeys) Validate() error { return nil }
+
+type updateDataKeyProvider struct {
+	sqltemplate.SQLTemplate
+	Namespace     string
+	UID           string
+	Label         string
+	Provider      encryption.ProviderID
+	EncryptedData []byte
+	Updated       time.Time
+}
+
+func (r updateDataKeyProvider) Validate() error { return nil }
@@ -226,6 +226,28 @@ func TestDataKeyQueries(t *testing.T) {
 					},
 				},
 			},
+			sqlDataKeyListAll: {
+				{
+					Name: "list_all",
+					Data: &listAllDataKeys{
+						SQLTemplate: mocks.NewTestingSQLTemplate(),
+					},
+				},
+			},
+			sqlDataKeyUpdateProvider: {
+				{
+					Name: "update_provider",
+					Data: &updateDataKeyProvider{
+						SQLTemplate:   mocks.NewTestingSQLTemplate(),
+						Namespace:     "ns",
+						UID:           "abc123",
+						Label:         "label",
+						Provider:      "provider",
+						EncryptedData: []byte("secret"),
+						Updated:       time.Unix(1735689600, 0).UTC(),
+					},
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,10 @@
+UPDATE
+  `secret_data_key`
+SET
+  `label` = 'label',
+  `provider` = 'provider',
+  `encrypted_data` = '[115 101 99 114 101 116]',
+  `updated` = '2025-01-01 00:00:00 +0000 UTC'
+WHERE `namespace` = 'ns' AND
+  `uid` = 'abc123'
+;
@@ -0,0 +1,12 @@
+SELECT
+  "uid",
+  "namespace",
+  "label",
+  "provider",
+  "encrypted_data",
+  "active",
+  "created",
+  "updated"
+FROM
+  "secret_data_key"
+;
@@ -276,6 +276,10 @@ func extractSecureValues(kp *secretv1beta1.Keeper) map[string]struct{} {
 		if kp.Spec.HashiCorpVault.Token.SecureValueName != "" {
 			return map[string]struct{}{kp.Spec.HashiCorpVault.Token.SecureValueName: {}}
 		}
+
+		if appRole := kp.Spec.HashiCorpVault.AppRole; appRole != nil && appRole.SecretID.SecureValueName != "" {
+			return map[string]struct{}{appRole.SecretID.SecureValueName: {}}
+		}
 	}
 
 	return nil
@@ -162,6 +162,7 @@ func TestDeleteWithSuggestionAndConflict(t *testing.T) {
 type resourceClientMock struct {
 	resourcepb.ResourceStoreClient
 	resourcepb.ResourceIndexClient
+	resourcepb.SearchSubscriptionsClient
 	resourcepb.ManagedObjectIndexClient
 	resourcepb.BulkStoreClient
 	resourcepb.BlobStoreClient
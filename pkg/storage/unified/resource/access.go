@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -170,6 +171,13 @@ func (c authzLimitedClient) Compile(ctx context.Context, id claims.AuthInfo, req
 		attribute.Bool("fallback_used", fallbackUsed),
 	))
 	defer span.End()
+	if _, ok := id.(namespacedAuthInfo); ok && (fallbackUsed || !c.IsCompatibleWithRBAC(req.Group, req.Resource)) {
+		// the permissions in other namespaces can only be resolved by the authz service
+		span.SetAttributes(attribute.Bool("allowed", false))
+		span.SetStatus(codes.Error, "Cross namespace access")
+		span.RecordError(ErrCrossNamespaceAccess)
+		return nil, claims.NoopZookie{}, ErrCrossNamespaceAccess
+	}
 	if fallbackUsed {
 		if req.Namespace == "" {
 			// cross namespace queries are not allowed when fallback is used
@@ -218,6 +226,30 @@ func (c authzLimitedClient) IsCompatibleWithRBAC(group, resource string) bool {
 
 var _ claims.AccessClient = &authzLimitedClient{}
 
+// ErrCrossNamespaceAccess is returned when the access of an identity can't be resolved in another namespace
+var ErrCrossNamespaceAccess = errors.New("access can not be checked in another namespace")
+
+// namespacedAuthInfo is the identity of the caller in another namespace.
+// The authz service resolves the permissions of the subject in that namespace, while the
+// permissions of the identity itself only apply to its own namespace.
+type namespacedAuthInfo struct {
+	claims.AuthInfo
+	namespace string
+}
+
+func (a namespacedAuthInfo) GetNamespace() string {
+	return a.namespace
+}
+
+// AuthInfoInNamespace returns the identity used to check the access of the caller in another namespace.
+// Access clients that can't resolve the permissions of the caller in that namespace reject it.
+func AuthInfoInNamespace(id claims.AuthInfo, namespace string) claims.AuthInfo {
+	if claims.NamespaceMatches(id.GetNamespace(), namespace) {
+		return id
+	}
+	return namespacedAuthInfo{AuthInfo: id, namespace: namespace}
+}
+
 type contextFallbackKey struct{}
 
 func WithFallback(ctx context.Context) context.Context {
@@ -222,3 +222,60 @@ func TestNamespaceMatchingFallback(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthzLimitedClient_CompileInOtherNamespace(t *testing.T) {
+	user := &identity.StaticRequester{Namespace: "default", UserUID: "u1", Type: authlib.TypeUser}
+	req := authlib.ListRequest{
+		Group:     "dashboard.grafana.app",
+		Resource:  "dashboards",
+		Verb:      utils.VerbList,
+		Namespace: "org-2",
+	}
+
+	t.Run("resolves the permissions of the caller in the other namespace", func(t *testing.T) {
+		inner := &namespaceAccessClient{allowed: map[string]string{"default": "a", "org-2": "b"}}
+		client := NewAuthzLimitedClient(inner, AuthzOptions{})
+
+		_, _, err := client.Compile(context.Background(), user, req)
+		require.ErrorIs(t, err, authlib.ErrNamespaceMismatch)
+
+		check, _, err := client.Compile(context.Background(), AuthInfoInNamespace(user, "org-2"), req)
+		require.NoError(t, err)
+		require.Equal(t, "u1", inner.subject)
+		require.True(t, check("b", ""))
+		require.False(t, check("a", ""))
+	})
+
+	t.Run("rejects other namespaces when the permissions can't be resolved", func(t *testing.T) {
+		client := NewAuthzLimitedClient(authlib.FixedAccessClient(true), AuthzOptions{})
+
+		_, _, err := client.Compile(WithFallback(context.Background()), AuthInfoInNamespace(user, "org-2"), req)
+		require.ErrorIs(t, err, ErrCrossNamespaceAccess)
+
+		unchecked := req
+		unchecked.Group, unchecked.Resource = "unknown.group", "unknown.resource"
+		_, _, err = client.Compile(context.Background(), AuthInfoInNamespace(user, "org-2"), unchecked)
+		require.ErrorIs(t, err, ErrCrossNamespaceAccess)
+	})
+
+	t.Run("keeps the identity in its own namespace", func(t *testing.T) {
+		require.Same(t, user, AuthInfoInNamespace(user, "default"))
+	})
+}
+
+// namespaceAccessClient allows one item in each namespace, like the authz service resolving
+// the permissions of the subject in the namespace of the request
+type namespaceAccessClient struct {
+	authlib.AccessClient
+	allowed map[string]string
+	subject string
+}
+
+func (c *namespaceAccessClient) Compile(_ context.Context, id authlib.AuthInfo, req authlib.ListRequest) (authlib.ItemChecker, authlib.Zookie, error) {
+	if !authlib.NamespaceMatches(id.GetNamespace(), req.Namespace) {
+		return nil, authlib.NoopZookie{}, authlib.ErrNamespaceMismatch
+	}
+	c.subject = id.GetIdentifier()
+	allowed := c.allowed[req.Namespace]
+	return func(name, _ string) bool { return name == allowed }, authlib.NoopZookie{}, nil
+}
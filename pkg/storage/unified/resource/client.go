@@ -35,6 +35,7 @@ import (
 type ResourceClient interface {
 	resourcepb.ResourceStoreClient
 	resourcepb.ResourceIndexClient
+	resourcepb.SearchSubscriptionsClient
 	resourcepb.ManagedObjectIndexClient
 	resourcepb.BulkStoreClient
 	resourcepb.BlobStoreClient
@@ -45,6 +46,7 @@ type ResourceClient interface {
 type resourceClient struct {
 	resourcepb.ResourceStoreClient
 	resourcepb.ResourceIndexClient
+	resourcepb.SearchSubscriptionsClient
 	resourcepb.ManagedObjectIndexClient
 	resourcepb.BulkStoreClient
 	resourcepb.BlobStoreClient
@@ -69,12 +71,13 @@ func NewResourceClient(conn, indexConn grpc.ClientConnInterface, cfg *setting.Cf
 
 func newResourceClient(storageCc grpc.ClientConnInterface, indexCc grpc.ClientConnInterface) ResourceClient {
 	return &resourceClient{
-		ResourceStoreClient:      resourcepb.NewResourceStoreClient(storageCc),
-		ResourceIndexClient:      resourcepb.NewResourceIndexClient(indexCc),
-		ManagedObjectIndexClient: resourcepb.NewManagedObjectIndexClient(indexCc),
-		BulkStoreClient:          resourcepb.NewBulkStoreClient(storageCc),
-		BlobStoreClient:          resourcepb.NewBlobStoreClient(storageCc),
-		DiagnosticsClient:        resourcepb.NewDiagnosticsClient(storageCc),
+		ResourceStoreClient:       resourcepb.NewResourceStoreClient(storageCc),
+		ResourceIndexClient:       resourcepb.NewResourceIndexClient(indexCc),
+		SearchSubscriptionsClient: resourcepb.NewSearchSubscriptionsClient(indexCc),
+		ManagedObjectIndexClient:  resourcepb.NewManagedObjectIndexClient(indexCc),
+		BulkStoreClient:           resourcepb.NewBulkStoreClient(storageCc),
+		BlobStoreClient:           resourcepb.NewBlobStoreClient(storageCc),
+		DiagnosticsClient:         resourcepb.NewDiagnosticsClient(storageCc),
 	}
 }
 
@@ -97,6 +100,7 @@ func NewLocalResourceClient(server ResourceServer) ResourceClient {
 	for _, desc := range []*grpc.ServiceDesc{
 		&resourcepb.ResourceStore_ServiceDesc,
 		&resourcepb.ResourceIndex_ServiceDesc,
+		&resourcepb.SearchSubscriptions_ServiceDesc,
 		&resourcepb.ManagedObjectIndex_ServiceDesc,
 		&resourcepb.BlobStore_ServiceDesc,
 		&resourcepb.BulkStore_ServiceDesc,
@@ -1060,6 +1060,80 @@ func (_c *MockResourceClient_Watch_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// WatchSearch provides a mock function with given fields: ctx, in, opts
+func (_m *MockResourceClient) WatchSearch(ctx context.Context, in *resourcepb.WatchSearchRequest, opts ...grpc.CallOption) (resourcepb.SearchSubscriptions_WatchSearchClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchSearch")
+	}
+
+	var r0 resourcepb.SearchSubscriptions_WatchSearchClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *resourcepb.WatchSearchRequest, ...grpc.CallOption) (resourcepb.SearchSubscriptions_WatchSearchClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *resourcepb.WatchSearchRequest, ...grpc.CallOption) resourcepb.SearchSubscriptions_WatchSearchClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(resourcepb.SearchSubscriptions_WatchSearchClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *resourcepb.WatchSearchRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockResourceClient_WatchSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchSearch'
+type MockResourceClient_WatchSearch_Call struct {
+	*mock.Call
+}
+
+// WatchSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *resourcepb.WatchSearchRequest
+//   - opts ...grpc.CallOption
+func (_e *MockResourceClient_Expecter) WatchSearch(ctx interface{}, in interface{}, opts ...interface{}) *MockResourceClient_WatchSearch_Call {
+	return &MockResourceClient_WatchSearch_Call{Call: _e.mock.On("WatchSearch",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockResourceClient_WatchSearch_Call) Run(run func(ctx context.Context, in *resourcepb.WatchSearchRequest, opts ...grpc.CallOption)) *MockResourceClient_WatchSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*resourcepb.WatchSearchRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockResourceClient_WatchSearch_Call) Return(_a0 resourcepb.SearchSubscriptions_WatchSearchClient, _a1 error) *MockResourceClient_WatchSearch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockResourceClient_WatchSearch_Call) RunAndReturn(run func(context.Context, *resourcepb.WatchSearchRequest, ...grpc.CallOption) (resourcepb.SearchSubscriptions_WatchSearchClient, error)) *MockResourceClient_WatchSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockResourceClient creates a new instance of MockResourceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockResourceClient(t interface {
@@ -0,0 +1,366 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	folders "github.com/grafana/grafana/apps/folder/pkg/apis/folder/v1beta1"
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+	"github.com/grafana/grafana/pkg/storage/unified/resourcepb"
+)
+
+// RestoreClient is the subset of the resource client used to restore a collection
+type RestoreClient interface {
+	resourcepb.ResourceStoreClient
+}
+
+// RestoreRequest describes a collection that should be rolled back to a previous point in time
+type RestoreRequest struct {
+	// The namespace/group/resource to restore (name is ignored)
+	Key *resourcepb.ResourceKey
+
+	// The collection will look like it did at this resource version
+	ResourceVersion int64
+
+	// When set, only items inside this folder (or any of its subfolders) are restored.
+	// The folder tree is evaluated both now and at the target resource version
+	Folder string
+
+	// Calculate the changes, but do not apply them
+	DryRun bool
+}
+
+// RestoreChange is a single create/update/delete required to restore the collection
+type RestoreChange struct {
+	Action resourcepb.BulkRequest_Action `json:"action"`
+	Name   string                        `json:"name"`
+	Folder string                        `json:"folder,omitempty"`
+
+	// The resource version that exists now (zero when the item does not currently exist)
+	CurrentResourceVersion int64 `json:"currentResourceVersion,omitempty"`
+
+	// The resource version that will be restored (zero when the item will be deleted)
+	TargetResourceVersion int64 `json:"targetResourceVersion,omitempty"`
+
+	// The resource version written by the restore (zero for a dry run or when the change failed)
+	RestoredResourceVersion int64 `json:"restoredResourceVersion,omitempty"`
+
+	// Set when the change could not be applied
+	Error string `json:"error,omitempty"`
+
+	value             []byte
+	currentGeneration int64
+}
+
+type RestoreResponse struct {
+	// The resource version the collection was restored to
+	ResourceVersion int64 `json:"resourceVersion"`
+
+	// Everything that was (or would be in a dry run) changed
+	Changes []RestoreChange `json:"changes"`
+
+	// The number of changes that could not be applied
+	Failed int `json:"failed,omitempty"`
+}
+
+// ResourceVersionFromTime converts a timestamp into a resource version that can be used
+// in a RestoreRequest.  This matches the microsecond resource versions from the SQL backend
+func ResourceVersionFromTime(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// Restore calculates the changes required to bring a collection back to how it looked at
+// a previous resource version, and (unless this is a dry run) applies them one by one.
+// Changes are written like any other create, update or delete, so watchers are notified, and a
+// change fails when the item was modified after the changes were calculated. Failed changes do not
+// stop the others, they are reported in the response along with an error.
+func Restore(ctx context.Context, client RestoreClient, req RestoreRequest) (*RestoreResponse, error) {
+	if req.Key == nil || req.Key.Namespace == "" || req.Key.Group == "" || req.Key.Resource == "" {
+		return nil, fmt.Errorf("restore requires namespace, group and resource")
+	}
+	if req.ResourceVersion < 1 {
+		return nil, fmt.Errorf("restore requires a target resource version")
+	}
+	key := &resourcepb.ResourceKey{
+		Namespace: req.Key.Namespace,
+		Group:     req.Key.Group,
+		Resource:  req.Key.Resource,
+	}
+
+	current, err := restoreListItems(ctx, client, key, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list current items: %w", err)
+	}
+	target, err := restoreListItems(ctx, client, key, req.ResourceVersion)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list items at %d: %w", req.ResourceVersion, err)
+	}
+
+	var scope map[string]bool
+	if req.Folder != "" {
+		scope, err = restoreFolderScope(ctx, client, key.Namespace, req.Folder, req.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	isFolder := key.Group == folders.GROUP && key.Resource == folders.RESOURCE
+	inScope := func(item *restoreItem) bool {
+		if scope == nil || item == nil {
+			return scope == nil
+		}
+		if isFolder {
+			return scope[item.name]
+		}
+		return scope[item.folder]
+	}
+
+	rsp := &RestoreResponse{
+		ResourceVersion: req.ResourceVersion,
+		Changes:         restoreChanges(current, target, inScope),
+	}
+	if req.DryRun || len(rsp.Changes) == 0 {
+		return rsp, nil
+	}
+
+	restoreApply(ctx, client, key, rsp.Changes)
+	for _, change := range rsp.Changes {
+		if change.Error != "" {
+			rsp.Failed++
+		}
+	}
+	if rsp.Failed > 0 {
+		return rsp, fmt.Errorf("unable to restore %d of %d changes", rsp.Failed, len(rsp.Changes))
+	}
+	return rsp, nil
+}
+
+type restoreItem struct {
+	name            string
+	folder          string
+	resourceVersion int64
+	generation      int64
+	value           []byte
+}
+
+// restoreChanges compares the current items with the target items
+func restoreChanges(current, target map[string]*restoreItem, inScope func(*restoreItem) bool) []RestoreChange {
+	changes := []RestoreChange{}
+	for name, old := range target {
+		now := current[name]
+		if !inScope(old) && !inScope(now) {
+			continue
+		}
+		if now == nil {
+			changes = append(changes, RestoreChange{
+				Action:                resourcepb.BulkRequest_ADDED,
+				Name:                  name,
+				Folder:                old.folder,
+				TargetResourceVersion: old.resourceVersion,
+				value:                 old.value,
+			})
+			continue
+		}
+		if now.resourceVersion == old.resourceVersion || bytes.Equal(now.value, old.value) {
+			continue
+		}
+		changes = append(changes, RestoreChange{
+			Action:                 resourcepb.BulkRequest_MODIFIED,
+			Name:                   name,
+			Folder:                 old.folder,
+			CurrentResourceVersion: now.resourceVersion,
+			TargetResourceVersion:  old.resourceVersion,
+			value:                  old.value,
+			currentGeneration:      now.generation,
+		})
+	}
+	for name, now := range current {
+		if _, ok := target[name]; ok || !inScope(now) {
+			continue
+		}
+		changes = append(changes, RestoreChange{
+			Action:                 resourcepb.BulkRequest_DELETED,
+			Name:                   name,
+			Folder:                 now.folder,
+			CurrentResourceVersion: now.resourceVersion,
+			value:                  now.value,
+		})
+	}
+
+	// Stable output makes the dry run listing readable
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Action != changes[j].Action {
+			return changes[i].Action < changes[j].Action
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// restoreApply writes each change with the resource version it was calculated from
+func restoreApply(ctx context.Context, client RestoreClient, key *resourcepb.ResourceKey, changes []RestoreChange) {
+	now := time.Now()
+	for i := range changes {
+		change := &changes[i]
+		itemKey := &resourcepb.ResourceKey{
+			Namespace: key.Namespace,
+			Group:     key.Group,
+			Resource:  key.Resource,
+			Name:      change.Name,
+		}
+		rv, err := restoreWrite(ctx, client, itemKey, change, now)
+		if err != nil {
+			change.Error = err.Error()
+			continue
+		}
+		change.RestoredResourceVersion = rv
+	}
+}
+
+func restoreWrite(ctx context.Context, client RestoreClient, key *resourcepb.ResourceKey, change *RestoreChange, now time.Time) (int64, error) {
+	if change.Action == resourcepb.BulkRequest_DELETED {
+		rsp, err := client.Delete(ctx, &resourcepb.DeleteRequest{
+			Key:             key,
+			ResourceVersion: change.CurrentResourceVersion,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if rsp.Error != nil {
+			return 0, GetError(rsp.Error)
+		}
+		return rsp.ResourceVersion, nil
+	}
+
+	value, err := restoreValue(*change, now)
+	if err != nil {
+		return 0, err
+	}
+	if change.Action == resourcepb.BulkRequest_ADDED {
+		rsp, err := client.Create(ctx, &resourcepb.CreateRequest{
+			Key:   key,
+			Value: value,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if rsp.Error != nil {
+			return 0, GetError(rsp.Error)
+		}
+		return rsp.ResourceVersion, nil
+	}
+	rsp, err := client.Update(ctx, &resourcepb.UpdateRequest{
+		Key:             key,
+		ResourceVersion: change.CurrentResourceVersion,
+		Value:           value,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rsp.Error != nil {
+		return 0, GetError(rsp.Error)
+	}
+	return rsp.ResourceVersion, nil
+}
+
+// restoreValue prepares the saved value so it is written as a new change
+func restoreValue(change RestoreChange, now time.Time) ([]byte, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(change.value); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", change.Name, err)
+	}
+	meta, err := utils.MetaAccessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	meta.SetResourceVersion("")
+	meta.SetUpdatedTimestamp(&now)
+	if change.Action == resourcepb.BulkRequest_ADDED {
+		meta.SetGeneration(1)
+		meta.SetDeletionTimestamp(nil)
+	} else {
+		meta.SetGeneration(max(meta.GetGeneration(), change.currentGeneration, 1) + 1)
+	}
+	return obj.MarshalJSON()
+}
+
+// restoreListItems reads every item in a collection. When rv is zero the latest values are returned
+func restoreListItems(ctx context.Context, client resourcepb.ResourceStoreClient, key *resourcepb.ResourceKey, rv int64) (map[string]*restoreItem, error) {
+	items := make(map[string]*restoreItem)
+	req := &resourcepb.ListRequest{
+		ResourceVersion: rv,
+		Limit:           500,
+		Options: &resourcepb.ListOptions{
+			Key: key,
+		},
+	}
+	for {
+		rsp, err := client.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Error != nil {
+			return nil, GetError(rsp.Error)
+		}
+		for _, v := range rsp.Items {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(v.Value); err != nil {
+				return nil, err
+			}
+			meta, err := utils.MetaAccessor(obj)
+			if err != nil {
+				return nil, err
+			}
+			items[obj.GetName()] = &restoreItem{
+				name:            obj.GetName(),
+				folder:          meta.GetFolder(),
+				resourceVersion: v.ResourceVersion,
+				generation:      meta.GetGeneration(),
+				value:           v.Value,
+			}
+		}
+		if rsp.NextPageToken == "" {
+			return items, nil
+		}
+		req.NextPageToken = rsp.NextPageToken
+	}
+}
+
+// restoreFolderScope finds the folder and all its descendants, both now and at the target version
+func restoreFolderScope(ctx context.Context, client resourcepb.ResourceStoreClient, namespace string, root string, rv int64) (map[string]bool, error) {
+	scope := map[string]bool{root: true}
+	key := &resourcepb.ResourceKey{
+		Namespace: namespace,
+		Group:     folders.GROUP,
+		Resource:  folders.RESOURCE,
+	}
+	for _, v := range []int64{0, rv} {
+		items, err := restoreListItems(ctx, client, key, v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list folders: %w", err)
+		}
+		children := make(map[string][]string, len(items))
+		for _, f := range items {
+			children[f.folder] = append(children[f.folder], f.name)
+		}
+		visited := map[string]bool{root: true}
+		queue := []string{root}
+		for len(queue) > 0 {
+			parent := queue[0]
+			queue = queue[1:]
+			for _, child := range children[parent] {
+				if visited[child] {
+					continue
+				}
+				visited[child] = true
+				scope[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return scope, nil
+}
@@ -0,0 +1,231 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	folders "github.com/grafana/grafana/apps/folder/pkg/apis/folder/v1beta1"
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+	"github.com/grafana/grafana/pkg/storage/unified/resourcepb"
+)
+
+func TestRestore(t *testing.T) {
+	dashboards := &resourcepb.ResourceKey{Namespace: "default", Group: "dashboard.grafana.app", Resource: "dashboards"}
+	folderKey := &resourcepb.ResourceKey{Namespace: "default", Group: folders.GROUP, Resource: folders.RESOURCE}
+
+	newClient := func() *fakeRestoreClient {
+		return &fakeRestoreClient{
+			items: map[string]map[int64][]*resourcepb.ResourceWrapper{
+				NSGR(dashboards): {
+					// now
+					0: {
+						restoreTestItem(t, "a", "f1", 200, 3),
+						restoreTestItem(t, "c", "f2", 300, 1),
+						restoreTestItem(t, "d", "", 150, 1),
+					},
+					// at rv=100
+					100: {
+						restoreTestItem(t, "a", "f1", 50, 2),
+						restoreTestItem(t, "b", "f2", 60, 1),
+						restoreTestItem(t, "d", "", 150, 1),
+					},
+				},
+				NSGR(folderKey): {
+					0: {
+						restoreTestItem(t, "f1", "", 10, 1),
+						restoreTestItem(t, "f3", "", 10, 1),
+					},
+					100: {
+						restoreTestItem(t, "f1", "", 10, 1),
+						restoreTestItem(t, "f2", "f1", 10, 1),
+						restoreTestItem(t, "f3", "", 10, 1),
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("requires a resource version", func(t *testing.T) {
+		_, err := Restore(context.Background(), newClient(), RestoreRequest{Key: dashboards})
+		require.Error(t, err)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := newClient()
+		rsp, err := Restore(context.Background(), client, RestoreRequest{
+			Key:             dashboards,
+			ResourceVersion: 100,
+			DryRun:          true,
+		})
+		require.NoError(t, err)
+		require.Zero(t, rsp.Failed)
+		require.Nil(t, client.created)
+		require.Nil(t, client.updated)
+		require.Nil(t, client.deleted)
+		require.Equal(t, []RestoreChange{
+			{Action: resourcepb.BulkRequest_ADDED, Name: "b", Folder: "f2", TargetResourceVersion: 60},
+			{Action: resourcepb.BulkRequest_MODIFIED, Name: "a", Folder: "f1", CurrentResourceVersion: 200, TargetResourceVersion: 50},
+			{Action: resourcepb.BulkRequest_DELETED, Name: "c", Folder: "f2", CurrentResourceVersion: 300},
+		}, withoutValues(rsp.Changes))
+	})
+
+	t.Run("folder subtree", func(t *testing.T) {
+		client := newClient()
+		rsp, err := Restore(context.Background(), client, RestoreRequest{
+			Key:             dashboards,
+			ResourceVersion: 100,
+			Folder:          "f1",
+			DryRun:          true,
+		})
+		require.NoError(t, err)
+		// f2 was inside f1 at the target version, so b and c are included
+		require.Len(t, rsp.Changes, 3)
+
+		rsp, err = Restore(context.Background(), client, RestoreRequest{
+			Key:             dashboards,
+			ResourceVersion: 100,
+			Folder:          "f3",
+			DryRun:          true,
+		})
+		require.NoError(t, err)
+		require.Empty(t, rsp.Changes)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		client := newClient()
+		rsp, err := Restore(context.Background(), client, RestoreRequest{
+			Key:             dashboards,
+			ResourceVersion: 100,
+		})
+		require.NoError(t, err)
+		require.Zero(t, rsp.Failed)
+		require.Len(t, client.created, 1)
+		require.Len(t, client.updated, 1)
+		require.Len(t, client.deleted, 1)
+
+		// writes are checked against the current resource versions
+		require.Equal(t, int64(200), client.updated[0].ResourceVersion)
+		require.Equal(t, int64(300), client.deleted[0].ResourceVersion)
+		require.Equal(t, "c", client.deleted[0].Key.Name)
+
+		generations := make(map[string]int64)
+		for _, value := range map[string][]byte{"b": client.created[0].Value, "a": client.updated[0].Value} {
+			obj := map[string]any{}
+			require.NoError(t, json.Unmarshal(value, &obj))
+			meta := obj["metadata"].(map[string]any)
+			require.Empty(t, meta["resourceVersion"])
+			generations[meta["name"].(string)] = int64(meta["generation"].(float64))
+		}
+		require.Equal(t, map[string]int64{
+			"a": 4,
+			"b": 1,
+		}, generations)
+
+		for _, change := range rsp.Changes {
+			require.Empty(t, change.Error)
+			require.Equal(t, int64(1000), change.RestoredResourceVersion, change.Name)
+		}
+	})
+
+	t.Run("reports the changes that failed", func(t *testing.T) {
+		client := newClient()
+		client.fail = "a"
+		rsp, err := Restore(context.Background(), client, RestoreRequest{
+			Key:             dashboards,
+			ResourceVersion: 100,
+		})
+		require.Error(t, err)
+		require.NotNil(t, rsp)
+		require.Equal(t, 1, rsp.Failed)
+
+		// the other changes are still applied
+		require.Len(t, client.created, 1)
+		require.Len(t, client.deleted, 1)
+		for _, change := range rsp.Changes {
+			if change.Name == "a" {
+				require.NotEmpty(t, change.Error)
+				require.Zero(t, change.RestoredResourceVersion)
+			} else {
+				require.Empty(t, change.Error)
+			}
+		}
+	})
+}
+
+func withoutValues(changes []RestoreChange) []RestoreChange {
+	for i := range changes {
+		changes[i].value = nil
+		changes[i].currentGeneration = 0
+	}
+	return changes
+}
+
+func restoreTestItem(t *testing.T, name, folder string, rv int64, generation int64) *resourcepb.ResourceWrapper {
+	annotations := map[string]any{}
+	if folder != "" {
+		annotations[utils.AnnoKeyFolder] = folder
+	}
+	value, err := json.Marshal(map[string]any{
+		"apiVersion": "test.grafana.app/v1",
+		"kind":       "Test",
+		"metadata": map[string]any{
+			"name":            name,
+			"namespace":       "default",
+			"resourceVersion": fmt.Sprintf("%d", rv),
+			"generation":      generation,
+			"annotations":     annotations,
+		},
+		"spec": map[string]any{"rv": rv},
+	})
+	require.NoError(t, err)
+	return &resourcepb.ResourceWrapper{ResourceVersion: rv, Value: value}
+}
+
+type fakeRestoreClient struct {
+	resourcepb.ResourceStoreClient
+
+	// collection > rv > items
+	items map[string]map[int64][]*resourcepb.ResourceWrapper
+
+	// writes to this name fail with a conflict
+	fail string
+
+	created []*resourcepb.CreateRequest
+	updated []*resourcepb.UpdateRequest
+	deleted []*resourcepb.DeleteRequest
+}
+
+func (c *fakeRestoreClient) List(_ context.Context, req *resourcepb.ListRequest, _ ...grpc.CallOption) (*resourcepb.ListResponse, error) {
+	return &resourcepb.ListResponse{
+		Items: c.items[NSGR(req.Options.Key)][req.ResourceVersion],
+	}, nil
+}
+
+func (c *fakeRestoreClient) Create(_ context.Context, req *resourcepb.CreateRequest, _ ...grpc.CallOption) (*resourcepb.CreateResponse, error) {
+	if req.Key.Name == c.fail {
+		return &resourcepb.CreateResponse{Error: AsErrorResult(ErrOptimisticLockingFailed)}, nil
+	}
+	c.created = append(c.created, req)
+	return &resourcepb.CreateResponse{ResourceVersion: 1000}, nil
+}
+
+func (c *fakeRestoreClient) Update(_ context.Context, req *resourcepb.UpdateRequest, _ ...grpc.CallOption) (*resourcepb.UpdateResponse, error) {
+	if req.Key.Name == c.fail {
+		return &resourcepb.UpdateResponse{Error: AsErrorResult(ErrOptimisticLockingFailed)}, nil
+	}
+	c.updated = append(c.updated, req)
+	return &resourcepb.UpdateResponse{ResourceVersion: 1000}, nil
+}
+
+func (c *fakeRestoreClient) Delete(_ context.Context, req *resourcepb.DeleteRequest, _ ...grpc.CallOption) (*resourcepb.DeleteResponse, error) {
+	if req.Key.Name == c.fail {
+		return &resourcepb.DeleteResponse{Error: AsErrorResult(ErrOptimisticLockingFailed)}, nil
+	}
+	c.deleted = append(c.deleted, req)
+	return &resourcepb.DeleteResponse{ResourceVersion: 1000}, nil
+}
@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	searchv0alpha1 "github.com/grafana/grafana/pkg/apis/search/v0alpha1"
+	"github.com/grafana/grafana/pkg/storage/unified/resourcepb"
+)
+
+const (
+	SavedSearchGroup    = searchv0alpha1.GROUP
+	SavedSearchResource = "savedsearches"
+	SavedSearchKind     = "SavedSearch"
+)
+
+// ReadSavedSearch reads a saved search from the raw resource value
+func ReadSavedSearch(value []byte) (*searchv0alpha1.SavedSearch, error) {
+	obj := &searchv0alpha1.SavedSearch{}
+	if err := json.Unmarshal(value, obj); err != nil {
+		return nil, fmt.Errorf("unable to read saved search: %w", err)
+	}
+	if obj.Kind != "" && obj.Kind != SavedSearchKind {
+		return nil, fmt.Errorf("expected kind %s, found %s", SavedSearchKind, obj.Kind)
+	}
+	return obj, nil
+}
+
+// SavedSearchRequest converts the saved spec into a search request within the namespace
+func SavedSearchRequest(s *searchv0alpha1.SavedSearchSpec, namespace string) (*resourcepb.ResourceSearchRequest, error) {
+	if s.Group == "" || s.Resource == "" {
+		return nil, fmt.Errorf("saved search requires group and resource")
+	}
+	req := &resourcepb.ResourceSearchRequest{
+		Options: &resourcepb.ListOptions{
+			Key: &resourcepb.ResourceKey{
+				Namespace: namespace,
+				Group:     s.Group,
+				Resource:  s.Resource,
+			},
+			Fields: toRequirements(s.Filters),
+			Labels: toRequirements(s.Labels),
+		},
+		Query:  s.Query,
+		Fields: s.Fields,
+	}
+	for _, f := range s.Federated {
+		req.Federated = append(req.Federated, &resourcepb.ResourceKey{
+			Namespace: namespace,
+			Group:     f.Group,
+			Resource:  f.Resource,
+		})
+	}
+	for _, v := range s.Sort {
+		desc := strings.HasPrefix(v, "-")
+		req.SortBy = append(req.SortBy, &resourcepb.ResourceSearchRequest_Sort{
+			Field: strings.TrimPrefix(v, "-"),
+			Desc:  desc,
+		})
+	}
+	if len(s.Facets) > 0 {
+		req.Facet = make(map[string]*resourcepb.ResourceSearchRequest_Facet, len(s.Facets))
+		for _, f := range s.Facets {
+			req.Facet[f] = &resourcepb.ResourceSearchRequest_Facet{
+				Field: f,
+				Limit: 50,
+			}
+		}
+	}
+	return req, nil
+}
+
+func toRequirements(v []searchv0alpha1.SavedSearchRequirement) []*resourcepb.Requirement {
+	if len(v) == 0 {
+		return nil
+	}
+	reqs := make([]*resourcepb.Requirement, 0, len(v))
+	for _, r := range v {
+		reqs = append(reqs, &resourcepb.Requirement{
+			Key:      r.Key,
+			Operator: r.Operator,
+			Values:   r.Values,
+		})
+	}
+	return reqs
+}
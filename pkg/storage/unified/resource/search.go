@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -28,6 +30,9 @@ import (
 
 const maxBatchSize = 1000
 
+// The maximum number of namespaces included in a single cross-namespace search
+const maxSearchNamespaces = 250
+
 type NamespacedResource struct {
 	Namespace string
 	Group     string
@@ -304,9 +309,22 @@ func (s *searchSupport) Search(ctx context.Context, req *resourcepb.ResourceSear
 		}, nil
 	}
 
+	// Cross-namespace searches are federated across the same resources in each namespace
+	if len(req.Namespaces) > 0 {
+		federated, err := s.crossNamespaceKeys(ctx, req)
+		if err != nil {
+			return &resourcepb.ResourceSearchResponse{
+				Error: AsErrorResult(err),
+			}, nil
+		}
+		req = proto.Clone(req).(*resourcepb.ResourceSearchRequest)
+		req.Federated = federated
+	}
+
 	// Get the federated indexes
 	federate := make([]ResourceIndex, len(req.Federated))
 	for i, f := range req.Federated {
+		nsr.Namespace = cmp.Or(f.Namespace, req.Options.Key.Namespace)
 		nsr.Group = f.Group
 		nsr.Resource = f.Resource
 		federate[i], err = s.getOrCreateIndex(ctx, nsr, "federatedSearch")
@@ -320,6 +338,56 @@ func (s *searchSupport) Search(ctx context.Context, req *resourcepb.ResourceSear
 	return idx.Search(ctx, s.access, req, federate)
 }
 
+// crossNamespaceKeys returns the federated keys including the requested (and federated)
+// resources in each additional namespace
+func (s *searchSupport) crossNamespaceKeys(ctx context.Context, req *resourcepb.ResourceSearchRequest) ([]*resourcepb.ResourceKey, error) {
+	key := req.Options.Key
+	seen := map[string]bool{key.Namespace: true}
+	namespaces := []string{}
+	add := func(ns string) {
+		if ns != "" && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for _, ns := range req.Namespaces {
+		if ns != "*" {
+			add(ns)
+			continue
+		}
+		stats, err := s.storage.GetResourceStats(ctx, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, stat := range stats {
+			if stat.Group == key.Group && stat.Resource == key.Resource {
+				add(stat.Namespace)
+			}
+		}
+	}
+	if len(namespaces) > maxSearchNamespaces {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("too many namespaces (max %d)", maxSearchNamespaces))
+	}
+	slices.Sort(namespaces)
+
+	keys := slices.Clone(req.Federated)
+	for _, ns := range namespaces {
+		keys = append(keys, &resourcepb.ResourceKey{
+			Namespace: ns,
+			Group:     key.Group,
+			Resource:  key.Resource,
+		})
+		for _, f := range req.Federated {
+			keys = append(keys, &resourcepb.ResourceKey{
+				Namespace: ns,
+				Group:     f.Group,
+				Resource:  f.Resource,
+			})
+		}
+	}
+	return keys, nil
+}
+
 // GetStats implements ResourceServer.
 func (s *searchSupport) GetStats(ctx context.Context, req *resourcepb.ResourceStatsRequest) (*resourcepb.ResourceStatsResponse, error) {
 	if req.Namespace == "" {
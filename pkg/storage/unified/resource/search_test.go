@@ -381,3 +381,64 @@ func (m *slowSearchBackendWithCache) BuildIndex(ctx context.Context, key Namespa
 	}
 	return idx, nil
 }
+
+func TestSearchCrossNamespaceKeys(t *testing.T) {
+	support := &searchSupport{
+		storage: &mockStorageBackend{
+			resourceStats: []ResourceStats{
+				{NamespacedResource: NamespacedResource{Namespace: "org-2", Group: "dashboard.grafana.app", Resource: "dashboards"}, Count: 3},
+				{NamespacedResource: NamespacedResource{Namespace: "default", Group: "dashboard.grafana.app", Resource: "dashboards"}, Count: 5},
+				{NamespacedResource: NamespacedResource{Namespace: "org-3", Group: "folder.grafana.app", Resource: "folders"}, Count: 1},
+			},
+		},
+	}
+	req := &resourcepb.ResourceSearchRequest{
+		Options: &resourcepb.ListOptions{
+			Key: &resourcepb.ResourceKey{Namespace: "default", Group: "dashboard.grafana.app", Resource: "dashboards"},
+		},
+		Federated: []*resourcepb.ResourceKey{
+			{Namespace: "default", Group: "folder.grafana.app", Resource: "folders"},
+		},
+	}
+
+	t.Run("explicit namespaces", func(t *testing.T) {
+		req.Namespaces = []string{"org-5", "default", "org-4", "org-5"}
+		keys, err := support.crossNamespaceKeys(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"default/folder.grafana.app/folders",
+			"org-4/dashboard.grafana.app/dashboards",
+			"org-4/folder.grafana.app/folders",
+			"org-5/dashboard.grafana.app/dashboards",
+			"org-5/folder.grafana.app/folders",
+		}, keyStrings(keys))
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		req.Namespaces = []string{"*"}
+		keys, err := support.crossNamespaceKeys(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"default/folder.grafana.app/folders",
+			"org-2/dashboard.grafana.app/dashboards",
+			"org-2/folder.grafana.app/folders",
+		}, keyStrings(keys))
+	})
+
+	t.Run("too many namespaces", func(t *testing.T) {
+		req.Namespaces = nil
+		for i := 0; i <= maxSearchNamespaces; i++ {
+			req.Namespaces = append(req.Namespaces, fmt.Sprintf("org-%d", i+10))
+		}
+		_, err := support.crossNamespaceKeys(context.Background(), req)
+		require.Error(t, err)
+	})
+}
+
+func keyStrings(keys []*resourcepb.ResourceKey) []string {
+	res := make([]string, len(keys))
+	for i, k := range keys {
+		res[i] = k.Namespace + "/" + k.Group + "/" + k.Resource
+	}
+	return res
+}
@@ -0,0 +1,279 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/grafana/grafana/pkg/storage/unified/resourcepb"
+)
+
+// The initial results are loaded in pages of this size
+const watchSearchPageSize = 1000
+
+// WatchSearch implements SearchSubscriptionsServer.
+// Each write event that matches the search key is checked against the search index,
+// and an event is sent when the item enters, changes within, or leaves the result set
+func (s *server) WatchSearch(req *resourcepb.WatchSearchRequest, srv resourcepb.SearchSubscriptions_WatchSearchServer) error {
+	ctx := srv.Context()
+	if err := s.Init(ctx); err != nil {
+		return err
+	}
+	if s.search == nil {
+		return fmt.Errorf("search index not configured")
+	}
+
+	query, err := s.watchSearchQuery(ctx, req)
+	if err != nil {
+		return srv.Send(&resourcepb.WatchSearchEvent{
+			Error: AsErrorResult(err),
+		})
+	}
+
+	// Subscribe before loading the initial results so no changes are missed
+	stream, err := s.broadcaster.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.broadcaster.Unsubscribe(stream)
+
+	watcher := newSearchWatcher(query, s.search.Search)
+	initial, err := watcher.load(ctx)
+	if err != nil {
+		return srv.Send(&resourcepb.WatchSearchEvent{
+			Error: AsErrorResult(err),
+		})
+	}
+	if req.SendInitialResults {
+		for _, event := range initial {
+			if err := srv.Send(event); err != nil {
+				return err
+			}
+		}
+		if err := srv.Send(&resourcepb.WatchSearchEvent{
+			Type:            resourcepb.WatchSearchEvent_BOOKMARK,
+			ResourceVersion: s.mostRecentRV.Load(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-stream:
+			if !ok {
+				s.log.Debug("watch search events closed")
+				return nil
+			}
+			if !watcher.matchesKey(event.Key) {
+				continue
+			}
+			rsp, err := watcher.update(ctx, event)
+			if err != nil {
+				s.log.Warn("error checking search watch event", "key", event.Key, "err", err)
+				rsp = &resourcepb.WatchSearchEvent{Error: AsErrorResult(err)}
+			}
+			if rsp == nil {
+				continue
+			}
+			if err := srv.Send(rsp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchSearchQuery finds the search request, either inline or from a saved search resource
+func (s *server) watchSearchQuery(ctx context.Context, req *resourcepb.WatchSearchRequest) (*resourcepb.ResourceSearchRequest, error) {
+	query := req.Query
+	if req.SavedSearch != nil {
+		key := req.SavedSearch
+		if key.Group == "" {
+			key.Group = SavedSearchGroup
+		}
+		if key.Resource == "" {
+			key.Resource = SavedSearchResource
+		}
+		if key.Group != SavedSearchGroup || key.Resource != SavedSearchResource || key.Name == "" {
+			return nil, apierrors.NewBadRequest("invalid saved search key")
+		}
+		found, err := s.Read(ctx, &resourcepb.ReadRequest{Key: key})
+		if err != nil {
+			return nil, err
+		}
+		if found.Error != nil {
+			return nil, GetError(found.Error)
+		}
+		saved, err := ReadSavedSearch(found.Value)
+		if err != nil {
+			return nil, err
+		}
+		query, err = SavedSearchRequest(&saved.Spec, key.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if query == nil || query.Options == nil || query.Options.Key == nil {
+		return nil, apierrors.NewBadRequest("missing search query")
+	}
+	key := query.Options.Key
+	if key.Namespace == "" || key.Group == "" || key.Resource == "" {
+		return nil, apierrors.NewBadRequest("missing namespace, group or resource")
+	}
+	return query, nil
+}
+
+type searchFunc = func(ctx context.Context, req *resourcepb.ResourceSearchRequest) (*resourcepb.ResourceSearchResponse, error)
+
+// searchWatcher tracks the items that currently match a search
+type searchWatcher struct {
+	query  *resourcepb.ResourceSearchRequest
+	search searchFunc
+
+	// SearchID => resource version
+	matches map[string]int64
+}
+
+func newSearchWatcher(query *resourcepb.ResourceSearchRequest, search searchFunc) *searchWatcher {
+	query = proto.Clone(query).(*resourcepb.ResourceSearchRequest)
+	// The result set is tracked by key, so ordering and stats are not needed
+	query.SortBy = nil
+	query.Facet = nil
+	query.Explain = false
+	query.Page = 0
+	return &searchWatcher{
+		query:   query,
+		search:  search,
+		matches: make(map[string]int64),
+	}
+}
+
+// matchesKey checks if the event is for the searched (or federated) resource
+func (w *searchWatcher) matchesKey(key *resourcepb.ResourceKey) bool {
+	if matchesQueryKey(w.query.Options.Key, key) {
+		return true
+	}
+	for _, f := range w.query.Federated {
+		if f.Namespace == "" {
+			f = &resourcepb.ResourceKey{
+				Namespace: w.query.Options.Key.Namespace,
+				Group:     f.Group,
+				Resource:  f.Resource,
+			}
+		}
+		if matchesQueryKey(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads the current results and returns an ADDED event for each
+func (w *searchWatcher) load(ctx context.Context) ([]*resourcepb.WatchSearchEvent, error) {
+	var events []*resourcepb.WatchSearchEvent
+	req := proto.Clone(w.query).(*resourcepb.ResourceSearchRequest)
+	req.Limit = watchSearchPageSize
+	req.Offset = 0
+	for {
+		rsp, err := w.search(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Error != nil {
+			return nil, GetError(rsp.Error)
+		}
+		if rsp.Results == nil {
+			return events, nil
+		}
+		for _, row := range rsp.Results.Rows {
+			w.matches[SearchID(row.Key)] = row.ResourceVersion
+			events = append(events, &resourcepb.WatchSearchEvent{
+				Type:            resourcepb.WatchSearchEvent_ADDED,
+				ResourceVersion: row.ResourceVersion,
+				Row:             row,
+				Columns:         rsp.Results.Columns,
+			})
+		}
+		req.Offset += int64(len(rsp.Results.Rows))
+		if len(rsp.Results.Rows) < watchSearchPageSize || req.Offset >= rsp.TotalHits {
+			return events, nil
+		}
+	}
+}
+
+// update checks if the changed item matches the search, and returns the event to send (if any)
+func (w *searchWatcher) update(ctx context.Context, event *WrittenEvent) (*resourcepb.WatchSearchEvent, error) {
+	id := SearchID(event.Key)
+	previousRV, existed := w.matches[id]
+
+	var row *resourcepb.ResourceTableRow
+	var columns []*resourcepb.ResourceTableColumnDefinition
+	if event.Type != resourcepb.WatchEvent_DELETED {
+		// Run the same search, limited to the changed item
+		req := proto.Clone(w.query).(*resourcepb.ResourceSearchRequest)
+		req.Limit = int64(len(req.Federated) + 1)
+		req.Offset = 0
+		req.Options.Fields = append(req.Options.Fields, &resourcepb.Requirement{
+			Key:      SEARCH_FIELD_NAME,
+			Operator: string(selection.In),
+			Values:   []string{event.Key.Name},
+		})
+		rsp, err := w.search(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Error != nil {
+			return nil, GetError(rsp.Error)
+		}
+		if rsp.Results != nil {
+			columns = rsp.Results.Columns
+			for _, r := range rsp.Results.Rows {
+				if r.Key != nil && SearchID(r.Key) == id {
+					row = r
+					break
+				}
+			}
+		}
+	}
+
+	switch {
+	case row != nil && !existed:
+		w.matches[id] = row.ResourceVersion
+		return &resourcepb.WatchSearchEvent{
+			Type:            resourcepb.WatchSearchEvent_ADDED,
+			ResourceVersion: event.ResourceVersion,
+			Row:             row,
+			Columns:         columns,
+		}, nil
+
+	case row != nil:
+		if row.ResourceVersion == previousRV {
+			return nil, nil // already sent
+		}
+		w.matches[id] = row.ResourceVersion
+		return &resourcepb.WatchSearchEvent{
+			Type:            resourcepb.WatchSearchEvent_MODIFIED,
+			ResourceVersion: event.ResourceVersion,
+			Row:             row,
+			Columns:         columns,
+		}, nil
+
+	case existed:
+		delete(w.matches, id)
+		return &resourcepb.WatchSearchEvent{
+			Type:            resourcepb.WatchSearchEvent_REMOVED,
+			ResourceVersion: event.ResourceVersion,
+			Row: &resourcepb.ResourceTableRow{
+				Key:             event.Key,
+				ResourceVersion: event.ResourceVersion,
+			},
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	searchv0alpha1 "github.com/grafana/grafana/pkg/apis/search/v0alpha1"
+	"github.com/grafana/grafana/pkg/storage/unified/resourcepb"
+)
+
+func TestReadSavedSearch(t *testing.T) {
+	saved, err := ReadSavedSearch([]byte(`{
+		"apiVersion": "search.grafana.app/v0alpha1",
+		"kind": "SavedSearch",
+		"metadata": {"name": "payments"},
+		"spec": {
+			"title": "Payment dashboards",
+			"group": "dashboard.grafana.app",
+			"resource": "dashboards",
+			"query": "checkout",
+			"filters": [{"key": "tags", "operator": "=", "values": ["payments"]}],
+			"facets": ["tags"],
+			"sort": ["-title"]
+		}
+	}`))
+	require.NoError(t, err)
+
+	req, err := SavedSearchRequest(&saved.Spec, "default")
+	require.NoError(t, err)
+	require.Equal(t, "default", req.Options.Key.Namespace)
+	require.Equal(t, "dashboards", req.Options.Key.Resource)
+	require.Equal(t, "checkout", req.Query)
+	require.Equal(t, []*resourcepb.Requirement{{Key: "tags", Operator: "=", Values: []string{"payments"}}}, req.Options.Fields)
+	require.Equal(t, "tags", req.Facet["tags"].Field)
+	require.Equal(t, "title", req.SortBy[0].Field)
+	require.True(t, req.SortBy[0].Desc)
+
+	_, err = ReadSavedSearch([]byte(`{"kind": "Dashboard"}`))
+	require.Error(t, err)
+
+	_, err = SavedSearchRequest(&searchv0alpha1.SavedSearchSpec{}, "default")
+	require.Error(t, err)
+}
+
+func TestSearchWatcher(t *testing.T) {
+	key := &resourcepb.ResourceKey{Namespace: "default", Group: "dashboard.grafana.app", Resource: "dashboards"}
+	rowKey := func(name string) *resourcepb.ResourceKey {
+		return &resourcepb.ResourceKey{Namespace: key.Namespace, Group: key.Group, Resource: key.Resource, Name: name}
+	}
+
+	// name => rv for everything that currently matches the search
+	index := map[string]int64{"a": 1, "b": 2}
+	var requests []*resourcepb.ResourceSearchRequest
+	search := func(ctx context.Context, req *resourcepb.ResourceSearchRequest) (*resourcepb.ResourceSearchResponse, error) {
+		requests = append(requests, req)
+		names := []string{"a", "b", "c"}
+		for _, f := range req.Options.Fields {
+			if f.Key == SEARCH_FIELD_NAME {
+				names = f.Values
+			}
+		}
+		rsp := &resourcepb.ResourceSearchResponse{Results: &resourcepb.ResourceTable{}}
+		for _, name := range names {
+			if rv, ok := index[name]; ok {
+				rsp.Results.Rows = append(rsp.Results.Rows, &resourcepb.ResourceTableRow{Key: rowKey(name), ResourceVersion: rv})
+			}
+		}
+		rsp.TotalHits = int64(len(rsp.Results.Rows))
+		return rsp, nil
+	}
+
+	watcher := newSearchWatcher(&resourcepb.ResourceSearchRequest{
+		Options: &resourcepb.ListOptions{
+			Key:    key,
+			Fields: []*resourcepb.Requirement{{Key: "tags", Operator: "=", Values: []string{"payments"}}},
+		},
+		SortBy: []*resourcepb.ResourceSearchRequest_Sort{{Field: "title"}},
+	}, search)
+
+	require.True(t, watcher.matchesKey(rowKey("x")))
+	require.False(t, watcher.matchesKey(&resourcepb.ResourceKey{Namespace: "default", Group: "folder.grafana.app", Resource: "folders", Name: "x"}))
+
+	initial, err := watcher.load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, initial, 2)
+	require.Nil(t, requests[0].SortBy)
+
+	changed := func(name string, rv int64, action resourcepb.WatchEvent_Type) *resourcepb.WatchSearchEvent {
+		evt, err := watcher.update(context.Background(), &WrittenEvent{
+			Type:            action,
+			Key:             rowKey(name),
+			ResourceVersion: rv,
+		})
+		require.NoError(t, err)
+		return evt
+	}
+
+	// c is created and matches the search
+	index["c"] = 3
+	evt := changed("c", 3, resourcepb.WatchEvent_ADDED)
+	require.Equal(t, resourcepb.WatchSearchEvent_ADDED, evt.Type)
+	require.Equal(t, "c", evt.Row.Key.Name)
+
+	// the search for a single item keeps the original filters
+	last := requests[len(requests)-1]
+	require.Len(t, last.Options.Fields, 2)
+	require.Equal(t, "tags", last.Options.Fields[0].Key)
+
+	// a is changed and still matches
+	index["a"] = 4
+	evt = changed("a", 4, resourcepb.WatchEvent_MODIFIED)
+	require.Equal(t, resourcepb.WatchSearchEvent_MODIFIED, evt.Type)
+
+	// the same version is not sent twice
+	require.Nil(t, changed("a", 4, resourcepb.WatchEvent_MODIFIED))
+
+	// b is changed and no longer matches
+	delete(index, "b")
+	evt = changed("b", 5, resourcepb.WatchEvent_MODIFIED)
+	require.Equal(t, resourcepb.WatchSearchEvent_REMOVED, evt.Type)
+	require.Equal(t, "b", evt.Row.Key.Name)
+
+	// d never matched
+	require.Nil(t, changed("d", 6, resourcepb.WatchEvent_ADDED))
+
+	// c is deleted
+	delete(index, "c")
+	evt = changed("c", 7, resourcepb.WatchEvent_DELETED)
+	require.Equal(t, resourcepb.WatchSearchEvent_REMOVED, evt.Type)
+}
@@ -34,6 +34,7 @@ type ResourceServer interface {
 	resourcepb.ResourceStoreServer
 	resourcepb.BulkStoreServer
 	resourcepb.ResourceIndexServer
+	resourcepb.SearchSubscriptionsServer
 	resourcepb.ManagedObjectIndexServer
 	resourcepb.BlobStoreServer
 	resourcepb.DiagnosticsServer
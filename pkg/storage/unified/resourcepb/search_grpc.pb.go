@@ -152,3 +152,127 @@ var ResourceIndex_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "search.proto",
 }
+
+const (
+	SearchSubscriptions_WatchSearch_FullMethodName = "/resource.SearchSubscriptions/WatchSearch"
+)
+
+// SearchSubscriptionsClient is the client API for SearchSubscriptions service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Subscribe to changes in the results of a search
+// Like the ResourceIndex, this can be exposed to clients directly
+type SearchSubscriptionsClient interface {
+	// Stream changes to the search results as documents are indexed
+	WatchSearch(ctx context.Context, in *WatchSearchRequest, opts ...grpc.CallOption) (SearchSubscriptions_WatchSearchClient, error)
+}
+
+type searchSubscriptionsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSearchSubscriptionsClient(cc grpc.ClientConnInterface) SearchSubscriptionsClient {
+	return &searchSubscriptionsClient{cc}
+}
+
+func (c *searchSubscriptionsClient) WatchSearch(ctx context.Context, in *WatchSearchRequest, opts ...grpc.CallOption) (SearchSubscriptions_WatchSearchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SearchSubscriptions_ServiceDesc.Streams[0], SearchSubscriptions_WatchSearch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &searchSubscriptionsWatchSearchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SearchSubscriptions_WatchSearchClient interface {
+	Recv() (*WatchSearchEvent, error)
+	grpc.ClientStream
+}
+
+type searchSubscriptionsWatchSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *searchSubscriptionsWatchSearchClient) Recv() (*WatchSearchEvent, error) {
+	m := new(WatchSearchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SearchSubscriptionsServer is the server API for SearchSubscriptions service.
+// All implementations should embed UnimplementedSearchSubscriptionsServer
+// for forward compatibility
+//
+// Subscribe to changes in the results of a search
+// Like the ResourceIndex, this can be exposed to clients directly
+type SearchSubscriptionsServer interface {
+	// Stream changes to the search results as documents are indexed
+	WatchSearch(*WatchSearchRequest, SearchSubscriptions_WatchSearchServer) error
+}
+
+// UnimplementedSearchSubscriptionsServer should be embedded to have forward compatible implementations.
+type UnimplementedSearchSubscriptionsServer struct {
+}
+
+func (UnimplementedSearchSubscriptionsServer) WatchSearch(*WatchSearchRequest, SearchSubscriptions_WatchSearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSearch not implemented")
+}
+
+// UnsafeSearchSubscriptionsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SearchSubscriptionsServer will
+// result in compilation errors.
+type UnsafeSearchSubscriptionsServer interface {
+	mustEmbedUnimplementedSearchSubscriptionsServer()
+}
+
+func RegisterSearchSubscriptionsServer(s grpc.ServiceRegistrar, srv SearchSubscriptionsServer) {
+	s.RegisterService(&SearchSubscriptions_ServiceDesc, srv)
+}
+
+func _SearchSubscriptions_WatchSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SearchSubscriptionsServer).WatchSearch(m, &searchSubscriptionsWatchSearchServer{ServerStream: stream})
+}
+
+type SearchSubscriptions_WatchSearchServer interface {
+	Send(*WatchSearchEvent) error
+	grpc.ServerStream
+}
+
+type searchSubscriptionsWatchSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *searchSubscriptionsWatchSearchServer) Send(m *WatchSearchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SearchSubscriptions_ServiceDesc is the grpc.ServiceDesc for SearchSubscriptions service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SearchSubscriptions_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resource.SearchSubscriptions",
+	HandlerType: (*SearchSubscriptionsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSearch",
+			Handler:       _SearchSubscriptions_WatchSearch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "search.proto",
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"cmp"
 	"context"
 	"encoding/binary"
 	"encoding/json"
@@ -1100,9 +1101,61 @@ func (b *bleveIndex) Search(
 		}
 		response.Facet[k] = f
 	}
+
+	// Facets for each namespace in a cross-namespace search
+	if len(req.Namespaces) > 0 && len(searchrequest.Facets) > 0 {
+		response.NamespaceFacet, err = b.namespaceFacets(ctx, searchrequest, federate)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return response, nil
 }
 
+// namespaceFacets runs the same search (without hits) in each namespace to calculate facets
+func (b *bleveIndex) namespaceFacets(
+	ctx context.Context,
+	searchrequest *bleve.SearchRequest,
+	federate []resource.ResourceIndex,
+) (map[string]*resourcepb.ResourceSearchResponse_NamespaceFacet, error) {
+	indexes := map[string][]bleve.Index{
+		b.key.Namespace: {b.index},
+	}
+	for _, extra := range federate {
+		typedindex, ok := extra.(*bleveIndex)
+		if !ok {
+			return nil, fmt.Errorf("federated indexes must be the same type")
+		}
+		indexes[typedindex.key.Namespace] = append(indexes[typedindex.key.Namespace], typedindex.index)
+	}
+
+	facetrequest := *searchrequest
+	facetrequest.Size = 0
+	facetrequest.From = 0
+	facetrequest.Fields = nil
+	facetrequest.Explain = false
+
+	result := make(map[string]*resourcepb.ResourceSearchResponse_NamespaceFacet, len(indexes))
+	for ns, all := range indexes {
+		var index bleve.Index = bleve.NewIndexAlias(all...)
+		if len(all) == 1 {
+			index = all[0]
+		}
+		res, err := index.SearchInContext(ctx, &facetrequest)
+		if err != nil {
+			return nil, err
+		}
+		facets := &resourcepb.ResourceSearchResponse_NamespaceFacet{
+			Facet: make(map[string]*resourcepb.ResourceSearchResponse_Facet, len(res.Facets)),
+		}
+		for k, v := range res.Facets {
+			facets.Facet[k] = newResponseFacet(v)
+		}
+		result[ns] = facets
+	}
+	return result, nil
+}
+
 func (b *bleveIndex) DocCount(ctx context.Context, folder string) (int64, error) {
 	ctx, span := b.tracing.Start(ctx, tracingPrexfixBleve+"DocCount")
 	defer span.End()
@@ -1290,25 +1343,38 @@ func (b *bleveIndex) toBleveSearchRequest(ctx context.Context, req *resourcepb.R
 		if err != nil {
 			return nil, resource.AsErrorResult(err)
 		}
-		checkers := map[string]authlib.ItemChecker{
-			b.key.Resource: checker,
-		}
+		// federated keys can name other namespaces even when the request does not list them
+		namespaced := len(req.Namespaces) > 0 || slices.ContainsFunc(req.Federated, func(f *resourcepb.ResourceKey) bool {
+			return f.Namespace != "" && f.Namespace != b.key.Namespace
+		})
+		scoped := newPermissionScopedQuery(searchrequest.Query, map[string]authlib.ItemChecker{}, namespaced)
+		scoped.checkers[scoped.checkerKey(b.key.Namespace, b.key.Resource)] = checker
 
 		// handle federation
 		for _, federated := range req.Federated {
-			checker, _, err := access.Compile(ctx, auth, authlib.ListRequest{
+			federatedVerb := utils.VerbList
+			if federated.Group == b.key.Group && federated.Resource == b.key.Resource {
+				federatedVerb = verb // the same resource in another namespace
+			}
+			federatedAuth := auth
+			if federated.Namespace != "" {
+				// the permissions of the caller are resolved in the namespace of the federated index
+				federatedAuth = resource.AuthInfoInNamespace(auth, federated.Namespace)
+			}
+			checker, _, err := access.Compile(ctx, federatedAuth, authlib.ListRequest{
 				Namespace: federated.Namespace,
 				Group:     federated.Group,
 				Resource:  federated.Resource,
-				Verb:      utils.VerbList,
+				Verb:      federatedVerb,
 			})
 			if err != nil {
 				return nil, resource.AsErrorResult(err)
 			}
-			checkers[federated.Resource] = checker
+			// federated keys without a namespace are in the namespace of the request
+			scoped.checkers[scoped.checkerKey(cmp.Or(federated.Namespace, b.key.Namespace), federated.Resource)] = checker
 		}
 
-		searchrequest.Query = newPermissionScopedQuery(searchrequest.Query, checkers)
+		searchrequest.Query = scoped
 	}
 
 	for k, v := range req.Facet {
@@ -1809,18 +1875,27 @@ func newResponseFacet(v *search.FacetResult) *resourcepb.ResourceSearchResponse_
 
 type permissionScopedQuery struct {
 	query.Query
-	checkers map[string]authlib.ItemChecker // one checker per resource
-	log      log.Logger
+	checkers   map[string]authlib.ItemChecker // one checker per resource
+	namespaced bool                           // checkers are registered for each namespace and resource
+	log        log.Logger
 }
 
-func newPermissionScopedQuery(q query.Query, checkers map[string]authlib.ItemChecker) *permissionScopedQuery {
+func newPermissionScopedQuery(q query.Query, checkers map[string]authlib.ItemChecker, namespaced bool) *permissionScopedQuery {
 	return &permissionScopedQuery{
-		Query:    q,
-		checkers: checkers,
-		log:      log.New("search_permissions"),
+		Query:      q,
+		checkers:   checkers,
+		namespaced: namespaced,
+		log:        log.New("search_permissions"),
 	}
 }
 
+func (q *permissionScopedQuery) checkerKey(namespace, resource string) string {
+	if q.namespaced {
+		return namespace + "/" + resource
+	}
+	return resource
+}
+
 func (q *permissionScopedQuery) Searcher(ctx context.Context, i index.IndexReader, m mapping.IndexMapping, options search.SearcherOptions) (search.Searcher, error) {
 	// Get a new logger from context, to pass traceIDs etc.
 	logger := q.log.FromContext(ctx)
@@ -1862,11 +1937,12 @@ func (q *permissionScopedQuery) Searcher(ctx context.Context, i index.IndexReade
 			logger.Debug("Error reading doc values", "error", err)
 			return false
 		}
-		if _, ok := q.checkers[resource]; !ok {
-			logger.Debug("No resource checker found", "resource", resource)
+		checker, ok := q.checkers[q.checkerKey(ns, resource)]
+		if !ok {
+			logger.Debug("No resource checker found", "ns", ns, "resource", resource)
 			return false
 		}
-		allowed := q.checkers[resource](name, folder)
+		allowed := checker(name, folder)
 		if !allowed {
 			logger.Debug("Denying access", "ns", ns, "name", name, "folder", folder)
 		}
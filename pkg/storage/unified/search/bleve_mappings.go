@@ -137,6 +137,16 @@ func getBleveDocMappings(_ resource.SearchableDocumentFields) *mapping.DocumentM
 		IncludeInAll:       false,
 	})
 
+	mapper.AddFieldMappingsAt(resource.SEARCH_FIELD_CREATED_BY, &mapping.FieldMapping{
+		Name:               resource.SEARCH_FIELD_CREATED_BY,
+		Type:               "text",
+		Analyzer:           keyword.Name,
+		Index:              true, // used to filter by creator
+		Store:              false,
+		IncludeTermVectors: false,
+		IncludeInAll:       false,
+	})
+
 	referenceMapper := bleve.NewDocumentMapping()
 	referenceMapper.DefaultAnalyzer = keyword.Name
 	mapper.AddSubDocumentMapping("reference", referenceMapper)
@@ -147,5 +157,22 @@ func getBleveDocMappings(_ resource.SearchableDocumentFields) *mapping.DocumentM
 	fieldMapper := bleve.NewDocumentMapping()
 	mapper.AddSubDocumentMapping("fields", fieldMapper)
 
+	// Dashboard query references are matched exactly
+	for _, f := range []string{DASHBOARD_DS_UIDS, DASHBOARD_QUERY_METRICS, DASHBOARD_QUERY_LABELS} {
+		fieldMapper.AddFieldMappingsAt(f, &mapping.FieldMapping{
+			Name:               f,
+			Type:               "text",
+			Analyzer:           keyword.Name,
+			Store:              true,
+			Index:              true,
+			IncludeTermVectors: false,
+			IncludeInAll:       false,
+		})
+	}
+	queriesMapping := bleve.NewTextFieldMapping()
+	queriesMapping.Analyzer = standard.Name
+	queriesMapping.Store = false // the full query text can be large
+	fieldMapper.AddFieldMappingsAt(DASHBOARD_QUERIES, queriesMapping)
+
 	return mapper
 }
@@ -28,6 +28,8 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/utils"
 	"github.com/grafana/grafana/pkg/infra/log/logtest"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
 	authzextv1 "github.com/grafana/grafana/pkg/services/authz/proto/v1"
 	"github.com/grafana/grafana/pkg/services/store/kind/dashboard"
 	"github.com/grafana/grafana/pkg/services/user"
@@ -122,8 +124,9 @@ func testBleveBackend(t *testing.T, backend *bleveBackend) {
 								Group:     "dashboard.grafana.app",
 								Resource:  "dashboards",
 							},
-							Title:  "aaa (dash)",
-							Folder: "xxx",
+							Title:     "aaa (dash)",
+							Folder:    "xxx",
+							CreatedBy: "user:creator",
 							Fields: map[string]any{
 								DASHBOARD_PANEL_TYPES:       []string{"timeseries", "table"},
 								DASHBOARD_ERRORS_TODAY:      25,
@@ -283,6 +286,22 @@ func testBleveBackend(t *testing.T, backend *bleveBackend) {
 			rsp.Results.Rows[1].Key.Name,
 		})
 
+		// can filter by the creator
+		rsp, err = index.Search(ctx, NewStubAccessClient(map[string]bool{"dashboards": true}), &resourcepb.ResourceSearchRequest{
+			Options: &resourcepb.ListOptions{
+				Key: key,
+				Fields: []*resourcepb.Requirement{{
+					Key:      resource.SEARCH_FIELD_CREATED_BY,
+					Operator: "in",
+					Values:   []string{"user:creator", "user:other"},
+				}},
+			},
+			Limit: 100000,
+		}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rsp.TotalHits)
+		require.Equal(t, "aaa", rsp.Results.Rows[0].Key.Name)
+
 		// can get sprinkles fields and sort by them
 		rsp, err = index.Search(ctx, NewStubAccessClient(map[string]bool{"dashboards": true}), &resourcepb.ResourceSearchRequest{
 			Options: &resourcepb.ListOptions{
@@ -1566,3 +1585,95 @@ func docCount(t *testing.T, idx resource.ResourceIndex) int {
 	require.NoError(t, err)
 	return int(cnt)
 }
+
+func TestBleveCrossNamespaceSearchPermissions(t *testing.T) {
+	backend, _ := setupBleveBackend(t)
+	ctx := identity.WithRequester(context.Background(), &user.SignedInUser{UserUID: "u1", OrgID: 1, Namespace: "default", Permissions: map[int64]map[string][]string{
+		1: {"dashboards:read": {"dashboards:uid:*"}},
+	}})
+
+	buildIndex := func(namespace string, names ...string) resource.ResourceIndex {
+		key := resource.NamespacedResource{Namespace: namespace, Group: "dashboard.grafana.app", Resource: "dashboards"}
+		index, err := backend.BuildIndex(ctx, key, int64(len(names)), nil, "test", func(index resource.ResourceIndex) (int64, error) {
+			items := make([]*resource.BulkIndexItem, 0, len(names))
+			for _, name := range names {
+				items = append(items, &resource.BulkIndexItem{
+					Action: resource.ActionIndex,
+					Doc: &resource.IndexableDocument{
+						RV:    1,
+						Name:  name,
+						Title: name,
+						Key:   &resourcepb.ResourceKey{Name: name, Namespace: namespace, Group: key.Group, Resource: key.Resource},
+					},
+				})
+			}
+			return 1, index.BulkIndex(&resource.BulkIndexRequest{Items: items})
+		}, nil, false)
+		require.NoError(t, err)
+		return index
+	}
+	defaultIndex := buildIndex("default", "a", "b")
+	otherIndex := buildIndex("org-2", "c", "d")
+
+	searchIn := func(access authlib.AccessClient, namespaces []string) (*resourcepb.ResourceSearchResponse, error) {
+		return defaultIndex.Search(ctx, access, &resourcepb.ResourceSearchRequest{
+			Options:    &resourcepb.ListOptions{Key: &resourcepb.ResourceKey{Namespace: "default", Group: "dashboard.grafana.app", Resource: "dashboards"}},
+			Namespaces: namespaces,
+			Federated:  []*resourcepb.ResourceKey{{Namespace: "org-2", Group: "dashboard.grafana.app", Resource: "dashboards"}},
+			Limit:      100,
+		}, []resource.ResourceIndex{otherIndex})
+	}
+	search := func(access authlib.AccessClient) (*resourcepb.ResourceSearchResponse, error) {
+		return searchIn(access, []string{"org-2"})
+	}
+	hits := func(rsp *resourcepb.ResourceSearchResponse) []string {
+		hits := []string{}
+		for _, row := range rsp.Results.Rows {
+			hits = append(hits, row.Key.Namespace+"/"+row.Key.Name)
+		}
+		return hits
+	}
+
+	t.Run("applies the permissions of the caller in each namespace", func(t *testing.T) {
+		// like the authz service, the permissions are resolved in the namespace of the request
+		access := resource.NewAuthzLimitedClient(&namespacedStubAccessClient{allowed: map[string]string{"default": "a", "org-2": "c"}}, resource.AuthzOptions{})
+		rsp, err := search(access)
+		require.NoError(t, err)
+		require.Nil(t, rsp.Error)
+		require.ElementsMatch(t, []string{"default/a", "org-2/c"}, hits(rsp))
+	})
+
+	t.Run("applies the permissions of each namespace to federated keys in another namespace", func(t *testing.T) {
+		// the federated key names another namespace without listing it in the namespaces of the request
+		access := resource.NewAuthzLimitedClient(&namespacedStubAccessClient{allowed: map[string]string{"default": "a", "org-2": "c"}}, resource.AuthzOptions{})
+		rsp, err := searchIn(access, nil)
+		require.NoError(t, err)
+		require.Nil(t, rsp.Error)
+		require.ElementsMatch(t, []string{"default/a", "org-2/c"}, hits(rsp))
+	})
+
+	t.Run("rejects the search when the permissions in other namespaces can't be resolved", func(t *testing.T) {
+		legacy := accesscontrol.NewLegacyAccessClient(actest.FakeAccessControl{ExpectedEvaluate: true}, accesscontrol.ResourceAuthorizerOptions{
+			Resource: "dashboards",
+			Attr:     "uid",
+		})
+		rsp, err := search(resource.NewAuthzLimitedClient(legacy, resource.AuthzOptions{}))
+		require.NoError(t, err)
+		require.NotNil(t, rsp.Error)
+	})
+}
+
+type namespacedStubAccessClient struct {
+	StubAccessClient
+	allowed map[string]string // the allowed name in each namespace
+}
+
+func (nc *namespacedStubAccessClient) Compile(ctx context.Context, id authlib.AuthInfo, req authlib.ListRequest) (authlib.ItemChecker, authlib.Zookie, error) {
+	if !authlib.NamespaceMatches(id.GetNamespace(), req.Namespace) {
+		return nil, authlib.NoopZookie{}, authlib.ErrNamespaceMismatch
+	}
+	allowed := nc.allowed[req.Namespace]
+	return func(name, folder string) bool {
+		return name == allowed
+	}, authlib.NoopZookie{}, nil
+}
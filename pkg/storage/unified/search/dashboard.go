@@ -25,6 +25,10 @@ const DASHBOARD_PANEL_TYPES = "panel_types"
 const DASHBOARD_DS_TYPES = "ds_types"
 const DASHBOARD_TRANSFORMATIONS = "transformation"
 const DASHBOARD_LIBRARY_PANEL_REFERENCE = "reference.LibraryPanel"
+const DASHBOARD_DS_UIDS = "ds_uids"
+const DASHBOARD_QUERIES = "queries"
+const DASHBOARD_QUERY_METRICS = "query_metrics"
+const DASHBOARD_QUERY_LABELS = "query_labels"
 
 //------------------------------------------------------------
 // The following fields are added in enterprise
@@ -70,6 +74,39 @@ func DashboardBuilder(namespaced resource.NamespacedDocumentSupplier) (resource.
 				Filterable: true,
 			},
 		},
+		{
+			Name:        DASHBOARD_DS_UIDS,
+			Type:        resourcepb.ResourceTableColumnDefinition_STRING,
+			IsArray:     true,
+			Description: "Datasource UIDs used by the panel queries",
+			Properties: &resourcepb.ResourceTableColumnDefinition_Properties{
+				Filterable: true,
+			},
+		},
+		{
+			Name:        DASHBOARD_QUERIES,
+			Type:        resourcepb.ResourceTableColumnDefinition_STRING,
+			IsArray:     true,
+			Description: "The query text (expr, rawSql or query) from each panel target",
+		},
+		{
+			Name:        DASHBOARD_QUERY_METRICS,
+			Type:        resourcepb.ResourceTableColumnDefinition_STRING,
+			IsArray:     true,
+			Description: "Metric names (or SQL tables) referenced by the panel queries",
+			Properties: &resourcepb.ResourceTableColumnDefinition_Properties{
+				Filterable: true,
+			},
+		},
+		{
+			Name:        DASHBOARD_QUERY_LABELS,
+			Type:        resourcepb.ResourceTableColumnDefinition_STRING,
+			IsArray:     true,
+			Description: "Label matchers used by the panel queries, eg: job=\"api\"",
+			Properties: &resourcepb.ResourceTableColumnDefinition_Properties{
+				Filterable: true,
+			},
+		},
 		{
 			Name:        DASHBOARD_ERRORS_TODAY,
 			Type:        resourcepb.ResourceTableColumnDefinition_INT64,
@@ -278,8 +315,10 @@ func (s *DashboardDocumentBuilder) BuildDocument(ctx context.Context, key *resou
 	panelTypes := []string{}
 	transformations := []string{}
 	dsTypes := []string{}
+	queries := newQueryReferences()
 
 	for _, p := range summary.Panels {
+		queries.addPanel(p)
 		if p.Type != "" {
 			panelTypes = append(panelTypes, p.Type)
 		}
@@ -327,6 +366,18 @@ func (s *DashboardDocumentBuilder) BuildDocument(ctx context.Context, key *resou
 		sort.Strings(transformations)
 		doc.Fields[DASHBOARD_TRANSFORMATIONS] = transformations
 	}
+	if len(queries.datasources) > 0 {
+		doc.Fields[DASHBOARD_DS_UIDS] = sortedKeys(queries.datasources)
+	}
+	if len(queries.queries) > 0 {
+		doc.Fields[DASHBOARD_QUERIES] = sortedKeys(queries.queries)
+	}
+	if len(queries.metrics) > 0 {
+		doc.Fields[DASHBOARD_QUERY_METRICS] = sortedKeys(queries.metrics)
+	}
+	if len(queries.matchers) > 0 {
+		doc.Fields[DASHBOARD_QUERY_LABELS] = sortedKeys(queries.matchers)
+	}
 
 	// Add the stats fields
 	for k, v := range s.Stats[summary.UID] {
@@ -343,6 +394,10 @@ func DashboardFields() []string {
 		DASHBOARD_PANEL_TYPES,
 		DASHBOARD_DS_TYPES,
 		DASHBOARD_TRANSFORMATIONS,
+		DASHBOARD_DS_UIDS,
+		DASHBOARD_QUERIES,
+		DASHBOARD_QUERY_METRICS,
+		DASHBOARD_QUERY_LABELS,
 	}
 
 	return append(baseFields, UsageInsightsFields()...)
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/grafana/pkg/services/store/kind/dashboard"
+)
+
+// queryReferences collects the datasources, queries, metrics and label matchers used by dashboard targets
+type queryReferences struct {
+	datasources map[string]bool
+	queries     map[string]bool
+	metrics     map[string]bool
+	matchers    map[string]bool
+}
+
+func newQueryReferences() *queryReferences {
+	return &queryReferences{
+		datasources: make(map[string]bool),
+		queries:     make(map[string]bool),
+		metrics:     make(map[string]bool),
+		matchers:    make(map[string]bool),
+	}
+}
+
+// addPanel adds the targets from a panel (and any collapsed row panels)
+func (r *queryReferences) addPanel(p dashboard.PanelSummaryInfo) {
+	for _, t := range p.Targets {
+		r.addTarget(t)
+	}
+	for _, c := range p.Collapsed {
+		r.addPanel(c)
+	}
+}
+
+func (r *queryReferences) addTarget(t dashboard.TargetSummaryInfo) {
+	dsType := ""
+	if t.Datasource != nil {
+		dsType = t.Datasource.Type
+		if t.Datasource.UID != "" && !strings.HasPrefix(t.Datasource.UID, "$") && !strings.HasPrefix(t.Datasource.UID, "-- ") {
+			r.datasources[t.Datasource.UID] = true
+		}
+	}
+
+	switch {
+	case t.Expr != "":
+		r.queries[t.Expr] = true
+		if strings.Contains(dsType, "loki") {
+			r.addSelectors(t.Expr, false)
+		} else {
+			r.addPromQL(t.Expr)
+		}
+	case t.RawSQL != "":
+		r.queries[t.RawSQL] = true
+		r.addSQL(t.RawSQL)
+	case t.Query != "":
+		r.queries[t.Query] = true
+	}
+}
+
+// Template variables used as a range are replaced so the query can be parsed
+var rangeVariableRegex = regexp.MustCompile(`([\[:])\s*(\$\{[^}]+\}|\$\w+|\[\[[^\]]+\]\])`)
+
+// addPromQL finds all metric names and label matchers in a prometheus query
+func (r *queryReferences) addPromQL(expr string) {
+	node, err := parser.ParseExpr(rangeVariableRegex.ReplaceAllString(expr, "${1}5m"))
+	if err != nil {
+		// Template variables may make the query invalid, so only look at the selectors
+		r.addSelectors(expr, true)
+		return
+	}
+	parser.Inspect(node, func(n parser.Node, _ []parser.Node) error {
+		if vs, ok := n.(*parser.VectorSelector); ok {
+			r.addMatchers(vs.LabelMatchers)
+		}
+		return nil
+	})
+}
+
+// addSelectors finds all `{...}` selectors outside of quoted strings.
+// When withNames is true, the identifier before the selector is used as the metric name
+func (r *queryReferences) addSelectors(expr string, withNames bool) {
+	var quote rune
+	start := -1
+	for i, c := range expr {
+		if quote != 0 {
+			if c == quote && (i == 0 || expr[i-1] != '\\') {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '{':
+			start = i
+		case '}':
+			if start < 0 {
+				continue
+			}
+			if withNames {
+				name := metricNameBefore(expr, start)
+				if name != "" {
+					r.metrics[name] = true
+				}
+			}
+			matchers, err := parser.ParseMetricSelector(expr[start : i+1])
+			if err == nil {
+				r.addMatchers(matchers)
+			}
+			start = -1
+		}
+	}
+}
+
+func (r *queryReferences) addMatchers(matchers []*labels.Matcher) {
+	for _, m := range matchers {
+		if m.Name == labels.MetricName {
+			if m.Type == labels.MatchEqual {
+				r.metrics[m.Value] = true
+			}
+			continue
+		}
+		r.matchers[m.String()] = true
+	}
+}
+
+func metricNameBefore(expr string, idx int) string {
+	i := idx
+	for i > 0 {
+		c := expr[i-1]
+		if c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			i--
+			continue
+		}
+		break
+	}
+	return expr[i:idx]
+}
+
+var (
+	sqlTableRegex     = regexp.MustCompile(`(?i)\b(?:from|join)\s+([\w."` + "`" + `\[\]]+)`)
+	sqlConditionRegex = regexp.MustCompile(`(\w+)\s*(=|!=|<>)\s*'([^']*)'`)
+)
+
+// addSQL adds the table names as metrics, and simple equality conditions as matchers
+func (r *queryReferences) addSQL(sql string) {
+	for _, m := range sqlTableRegex.FindAllStringSubmatch(sql, -1) {
+		table := strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(m[1])
+		if table != "" {
+			r.metrics[table] = true
+		}
+	}
+	for _, m := range sqlConditionRegex.FindAllStringSubmatch(sql, -1) {
+		op := m[2]
+		if op == "<>" {
+			op = "!="
+		}
+		r.matchers[m[1]+op+`"`+m[3]+`"`] = true
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
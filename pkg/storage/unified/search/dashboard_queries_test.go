@@ -0,0 +1,68 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/store/kind/dashboard"
+)
+
+func TestQueryReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   dashboard.TargetSummaryInfo
+		metrics  []string
+		matchers []string
+	}{
+		{
+			name: "promql",
+			target: dashboard.TargetSummaryInfo{
+				Datasource: &dashboard.DataSourceRef{Type: "prometheus", UID: "prom"},
+				Expr:       `histogram_quantile(0.9, sum(rate(http_request_duration_seconds_bucket{job="api"}[5m])) by (le))`,
+			},
+			metrics:  []string{"http_request_duration_seconds_bucket"},
+			matchers: []string{`job="api"`},
+		},
+		{
+			name: "promql with template variables",
+			target: dashboard.TargetSummaryInfo{
+				Expr: `rate(node_cpu_seconds_total{instance=~"$instance", mode!="idle"}[$__rate_interval]) * $scale`,
+			},
+			metrics:  []string{"node_cpu_seconds_total"},
+			matchers: []string{`instance=~"$instance"`, `mode!="idle"`},
+		},
+		{
+			name: "loki",
+			target: dashboard.TargetSummaryInfo{
+				Datasource: &dashboard.DataSourceRef{Type: "loki", UID: "logs"},
+				Expr:       `sum(count_over_time({app="api"} |= "{not a selector}" [5m]))`,
+			},
+			matchers: []string{`app="api"`},
+		},
+		{
+			name: "sql",
+			target: dashboard.TargetSummaryInfo{
+				RawSQL: "SELECT * FROM \"public\".\"events\" WHERE kind <> 'debug'",
+			},
+			metrics:  []string{"public.events"},
+			matchers: []string{`kind!="debug"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := newQueryReferences()
+			refs.addTarget(tt.target)
+			require.Equal(t, tt.metrics, nilIfEmpty(sortedKeys(refs.metrics)))
+			require.Equal(t, tt.matchers, nilIfEmpty(sortedKeys(refs.matchers)))
+		})
+	}
+}
+
+func nilIfEmpty(v []string) []string {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,22 @@
+{{/* keys are compared byte-wise, regardless of the database collation */}}
+{{ define "kvKey" }}{{ .Ident "key" }}{{ if eq .DialectName "postgres" }} COLLATE "C"{{ end }}{{ end }}
+SELECT
+    {{ .Ident "key" | .Into .Response.Key }}
+    FROM {{ .Ident "resource_kv" }}
+    WHERE 1 = 1
+        AND {{ .Ident "section" }} = {{ .Arg .Section }}
+        {{ if .Options.StartKey }}
+        AND {{ template "kvKey" . }} >= {{ .Arg .Options.StartKey }}
+        {{ end }}
+        {{ if .Options.EndKey }}
+        AND {{ template "kvKey" . }} < {{ .Arg .Options.EndKey }}
+        {{ end }}
+    {{ if .SortDesc }}
+    ORDER BY {{ template "kvKey" . }} DESC
+    {{ else }}
+    ORDER BY {{ template "kvKey" . }} ASC
+    {{ end }}
+    {{ if (gt .Options.Limit 0) }}
+    LIMIT {{ .Arg .Options.Limit }}
+    {{ end }}
+;
@@ -0,0 +1,17 @@
+INSERT INTO {{ .Ident "resource_kv" }}
+    (
+        {{ .Ident "section" }},
+        {{ .Ident "key" }},
+        {{ .Ident "value" }}
+    )
+    VALUES (
+        {{ .Arg .Section }},
+        {{ .Arg .Key }},
+        {{ .Arg .Value }}
+    )
+{{ if eq .DialectName "mysql" }}
+    ON DUPLICATE KEY UPDATE {{ .Ident "value" }} = VALUES({{ .Ident "value" }})
+{{ else }}
+    ON CONFLICT ({{ .Ident "section" }}, {{ .Ident "key" }}) DO UPDATE SET {{ .Ident "value" }} = excluded.{{ .Ident "value" }}
+{{ end }}
+;
@@ -167,5 +167,22 @@ func initResourceTables(mg *migrator.Migrator) string {
 		Name: "IDX_resource_history_namespace_group_resource_name_generation",
 	}))
 
+	// Generic key/value store, used by the KV based storage backend
+	resource_kv_table := migrator.Table{
+		Name: "resource_kv",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			// Keys are compared byte-wise (latin1_bin in MySQL)
+			{Name: "section", Type: migrator.DB_NVarchar, Length: 64, Nullable: false, IsLatin: true},
+			{Name: "key", Type: migrator.DB_NVarchar, Length: 1024, Nullable: false, IsLatin: true},
+			{Name: "value", Type: migrator.DB_LongBlob, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"section", "key"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create table resource_kv", migrator.NewAddTableMigration(resource_kv_table))
+	mg.AddMigration("create table resource_kv, index: 0", migrator.NewAddIndexMigration(resource_kv_table, resource_kv_table.Indices[0]))
+
 	return marker
 }
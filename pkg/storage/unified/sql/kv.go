@@ -0,0 +1,192 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/grafana/grafana/pkg/storage/unified/resource"
+	"github.com/grafana/grafana/pkg/storage/unified/sql/db"
+	"github.com/grafana/grafana/pkg/storage/unified/sql/dbutil"
+	"github.com/grafana/grafana/pkg/storage/unified/sql/sqltemplate"
+)
+
+var _ resource.KV = &sqlKV{}
+
+// sqlKV implements the KV interface on top of the resource database, so the KV
+// based storage can share state between multiple instances
+type sqlKV struct {
+	db      db.DB
+	dialect sqltemplate.Dialect
+}
+
+// NewKV creates a KV store using the `resource_kv` table in an initialized resource database
+func NewKV(dbConn db.DB) (resource.KV, error) {
+	if dbConn == nil {
+		return nil, errors.New("no db")
+	}
+	dialect := sqltemplate.DialectForDriver(dbConn.DriverName())
+	if dialect == nil {
+		return nil, fmt.Errorf("no dialect for driver %q", dbConn.DriverName())
+	}
+	return &sqlKV{
+		db:      dbConn,
+		dialect: dialect,
+	}, nil
+}
+
+func (k *sqlKV) Get(ctx context.Context, section string, key string) (io.ReadCloser, error) {
+	if section == "" {
+		return nil, fmt.Errorf("section is required")
+	}
+	if key == "" {
+		return nil, resource.ErrNotFound
+	}
+
+	res, err := dbutil.QueryRow(ctx, k.db, sqlResourceKVGet, sqlKVGetRequest{
+		sqlKVRequest: sqlKVRequest{
+			SQLTemplate: sqltemplate.New(k.dialect),
+			Section:     section,
+			Key:         key,
+		},
+		Response: new(sqlKVValue),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, resource.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(res.Value)), nil
+}
+
+// sqlKVWriteCloser buffers the value and writes it when closed
+type sqlKVWriteCloser struct {
+	kv      *sqlKV
+	ctx     context.Context
+	section string
+	key     string
+	buf     *bytes.Buffer
+	closed  bool
+}
+
+// Write implements io.Writer
+func (w *sqlKVWriteCloser) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write to closed writer")
+	}
+	return w.buf.Write(p)
+}
+
+// Close implements io.Closer - replaces any existing value with the buffered data
+func (w *sqlKVWriteCloser) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	value := w.buf.Bytes()
+	if value == nil {
+		value = []byte{} // the column is not nullable
+	}
+	// A single upsert, so that concurrent writers of the same key do not conflict on the unique index
+	if _, err := dbutil.Exec(w.ctx, w.kv.db, sqlResourceKVUpsert, sqlKVUpsertRequest{
+		sqlKVRequest: w.request(),
+		Value:        value,
+	}); err != nil {
+		return fmt.Errorf("save value: %w", err)
+	}
+	return nil
+}
+
+func (w *sqlKVWriteCloser) request() sqlKVRequest {
+	return sqlKVRequest{
+		SQLTemplate: sqltemplate.New(w.kv.dialect),
+		Section:     w.section,
+		Key:         w.key,
+	}
+}
+
+func (k *sqlKV) Save(ctx context.Context, section string, key string) (io.WriteCloser, error) {
+	if section == "" {
+		return nil, fmt.Errorf("section is required")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	return &sqlKVWriteCloser{
+		kv:      k,
+		ctx:     ctx,
+		section: section,
+		key:     key,
+		buf:     &bytes.Buffer{},
+	}, nil
+}
+
+func (k *sqlKV) Delete(ctx context.Context, section string, key string) error {
+	if section == "" {
+		return fmt.Errorf("section is required")
+	}
+	if key == "" {
+		return resource.ErrNotFound
+	}
+
+	res, err := dbutil.Exec(ctx, k.db, sqlResourceKVDelete, sqlKVRequest{
+		SQLTemplate: sqltemplate.New(k.dialect),
+		Section:     section,
+		Key:         key,
+	})
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return resource.ErrNotFound
+	}
+	return nil
+}
+
+func (k *sqlKV) Keys(ctx context.Context, section string, opt resource.ListOptions) iter.Seq2[string, error] {
+	if section == "" {
+		return func(yield func(string, error) bool) {
+			yield("", fmt.Errorf("section is required"))
+		}
+	}
+
+	return func(yield func(string, error) bool) {
+		keys, err := dbutil.Query(ctx, k.db, sqlResourceKVKeys, sqlKVKeysRequest{
+			SQLTemplate: sqltemplate.New(k.dialect),
+			Section:     section,
+			Options:     opt,
+			Response:    new(sqlKVKey),
+		})
+		if err != nil {
+			yield("", err)
+			return
+		}
+		for _, key := range keys {
+			if !yield(key, nil) {
+				return
+			}
+		}
+	}
+}
+
+// UnixTimestamp returns the database time, so all instances use the same clock
+func (k *sqlKV) UnixTimestamp(ctx context.Context) (int64, error) {
+	now, err := dbutil.QueryRow(ctx, k.db, sqlResourceKVNow, sqlKVNowRequest{
+		SQLTemplate: sqltemplate.New(k.dialect),
+		Response:    new(resourceVersionResponse),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return now / 1e6, nil
+}
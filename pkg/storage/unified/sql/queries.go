@@ -58,6 +58,12 @@ var (
 
 	sqlResourceBlobInsert = mustTemplate("resource_blob_insert.sql")
 	sqlResourceBlobQuery  = mustTemplate("resource_blob_query.sql")
+
+	sqlResourceKVGet    = mustTemplate("resource_kv_get.sql")
+	sqlResourceKVUpsert = mustTemplate("resource_kv_upsert.sql")
+	sqlResourceKVDelete = mustTemplate("resource_kv_delete.sql")
+	sqlResourceKVKeys   = mustTemplate("resource_kv_keys.sql")
+	sqlResourceKVNow    = mustTemplate("resource_kv_now.sql")
 )
 
 // TxOptions.
@@ -360,6 +366,81 @@ func (r sqlResourceBlobQueryRequest) Validate() error {
 	return nil
 }
 
+// KV
+
+type sqlKVRequest struct {
+	sqltemplate.SQLTemplate
+	Section string
+	Key     string
+}
+
+func (r sqlKVRequest) Validate() error {
+	if r.Section == "" {
+		return fmt.Errorf("missing section")
+	}
+	if r.Key == "" {
+		return fmt.Errorf("missing key")
+	}
+	return nil
+}
+
+type sqlKVGetRequest struct {
+	sqlKVRequest
+	Response *sqlKVValue
+}
+
+type sqlKVValue struct {
+	Value []byte
+}
+
+func (r sqlKVGetRequest) Results() (*sqlKVValue, error) {
+	return &sqlKVValue{Value: r.Response.Value}, nil
+}
+
+type sqlKVUpsertRequest struct {
+	sqlKVRequest
+	Value []byte
+}
+
+type sqlKVKeysRequest struct {
+	sqltemplate.SQLTemplate
+	Section  string
+	Options  resource.ListOptions
+	Response *sqlKVKey
+}
+
+type sqlKVKey struct {
+	Key string
+}
+
+func (r sqlKVKeysRequest) SortDesc() bool {
+	return r.Options.Sort == resource.SortOrderDesc
+}
+
+func (r sqlKVKeysRequest) Validate() error {
+	if r.Section == "" {
+		return fmt.Errorf("missing section")
+	}
+	return nil
+}
+
+func (r sqlKVKeysRequest) Results() (string, error) {
+	return r.Response.Key, nil
+}
+
+type sqlKVNowRequest struct {
+	sqltemplate.SQLTemplate
+	Response *resourceVersionResponse
+}
+
+func (r sqlKVNowRequest) Validate() error {
+	return nil
+}
+
+func (r sqlKVNowRequest) Results() (int64, error) {
+	return r.Response.CurrentEpoch, nil
+}
+
 // update RV
 
 type sqlResourceUpdateRVRequest struct {
@@ -479,5 +479,75 @@ func TestUnifiedStorageQueries(t *testing.T) {
 					},
 				},
 			},
+
+			sqlResourceKVGet: {
+				{
+					Name: "simple",
+					Data: &sqlKVGetRequest{
+						sqlKVRequest: sqlKVRequest{
+							SQLTemplate: mocks.NewTestingSQLTemplate(),
+							Section:     "unified/data",
+							Key:         "group/resource/ns/name",
+						},
+						Response: new(sqlKVValue),
+					},
+				},
+			},
+			sqlResourceKVUpsert: {
+				{
+					Name: "simple",
+					Data: &sqlKVUpsertRequest{
+						sqlKVRequest: sqlKVRequest{
+							SQLTemplate: mocks.NewTestingSQLTemplate(),
+							Section:     "unified/data",
+							Key:         "group/resource/ns/name",
+						},
+						Value: []byte("value"),
+					},
+				},
+			},
+			sqlResourceKVDelete: {
+				{
+					Name: "simple",
+					Data: &sqlKVRequest{
+						SQLTemplate: mocks.NewTestingSQLTemplate(),
+						Section:     "unified/data",
+						Key:         "group/resource/ns/name",
+					},
+				},
+			},
+			sqlResourceKVKeys: {
+				{
+					Name: "all",
+					Data: &sqlKVKeysRequest{
+						SQLTemplate: mocks.NewTestingSQLTemplate(),
+						Section:     "unified/data",
+						Response:    new(sqlKVKey),
+					},
+				},
+				{
+					Name: "range",
+					Data: &sqlKVKeysRequest{
+						SQLTemplate: mocks.NewTestingSQLTemplate(),
+						Section:     "unified/data",
+						Options: resource.ListOptions{
+							Sort:     resource.SortOrderDesc,
+							StartKey: "group/resource/",
+							EndKey:   "group/resource0",
+							Limit:    10,
+						},
+						Response: new(sqlKVKey),
+					},
+				},
+			},
+			sqlResourceKVNow: {
+				{
+					Name: "simple",
+					Data: &sqlKVNowRequest{
+						SQLTemplate: mocks.NewTestingSQLTemplate(),
+						Response:    new(resourceVersionResponse),
+					},
+				},
+			},
 		}})
 }
@@ -271,6 +271,7 @@ func (s *service) starting(ctx context.Context) error {
 	resourcepb.RegisterResourceStoreServer(srv, server)
 	resourcepb.RegisterBulkStoreServer(srv, server)
 	resourcepb.RegisterResourceIndexServer(srv, server)
+	resourcepb.RegisterSearchSubscriptionsServer(srv, server)
 	resourcepb.RegisterManagedObjectIndexServer(srv, server)
 	resourcepb.RegisterBlobStoreServer(srv, server)
 	resourcepb.RegisterDiagnosticsServer(srv, server)
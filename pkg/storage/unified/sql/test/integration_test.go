@@ -92,6 +92,28 @@ func TestIntegrationSQLStorageBackend(t *testing.T) {
 	})
 }
 
+func TestIntegrationSQLKV(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	unitest.RunKVTest(t, func(ctx context.Context) resource.KV {
+		initMutex.Lock()
+		defer initMutex.Unlock()
+
+		dbstore := db.InitTestDB(t)
+		eDB, err := dbimpl.ProvideResourceDB(dbstore, setting.NewCfg(), nil)
+		require.NoError(t, err)
+
+		dbConn, err := eDB.Init(testutil.NewTestContext(t, time.Now().Add(1*time.Minute)))
+		require.NoError(t, err)
+
+		kv, err := sql.NewKV(dbConn)
+		require.NoError(t, err)
+		return kv
+	}, &unitest.KVTestOptions{
+		NSPrefix: "sql-kv-test",
+	})
+}
+
 func TestIntegrationSearchAndStorage(t *testing.T) {
 	testutil.SkipIntegrationTestInShortMode(t)
 
@@ -0,0 +1,3 @@
+SELECT
+    CAST(FLOOR(UNIX_TIMESTAMP(NOW(6)) * 1000000) AS SIGNED)
+;
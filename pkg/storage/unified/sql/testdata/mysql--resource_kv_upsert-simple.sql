@@ -0,0 +1,13 @@
+INSERT INTO `resource_kv`
+    (
+        `section`,
+        `key`,
+        `value`
+    )
+    VALUES (
+        'unified/data',
+        'group/resource/ns/name',
+        '[118 97 108 117 101]'
+    )
+    ON DUPLICATE KEY UPDATE `value` = VALUES(`value`)
+;
@@ -0,0 +1,10 @@
+SELECT
+    "key"
+    FROM "resource_kv"
+    WHERE 1 = 1
+        AND "section" = 'unified/data'
+        AND "key" COLLATE "C" >= 'group/resource/'
+        AND "key" COLLATE "C" < 'group/resource0'
+    ORDER BY "key" COLLATE "C" DESC
+    LIMIT 10
+;
@@ -0,0 +1,10 @@
+SELECT
+    "key"
+    FROM "resource_kv"
+    WHERE 1 = 1
+        AND "section" = 'unified/data'
+        AND "key" >= 'group/resource/'
+        AND "key" < 'group/resource0'
+    ORDER BY "key" DESC
+    LIMIT 10
+;
@@ -436,6 +436,38 @@ func runTestKVConcurrent(t *testing.T, kv resource.KV, nsPrefix string) {
 		}
 	})
 
+	t.Run("concurrent saves of the same key", func(t *testing.T) {
+		const numGoroutines = 10
+		const key = "concurrent-same-key"
+
+		done := make(chan error, numGoroutines)
+		for i := 0; i < numGoroutines; i++ {
+			go func(goroutineID int) {
+				writer, err := kv.Save(ctx, section, key)
+				if err != nil {
+					done <- err
+					return
+				}
+				if _, err := io.Copy(writer, strings.NewReader(fmt.Sprintf("value-%d", goroutineID))); err != nil {
+					done <- err
+					return
+				}
+				done <- writer.Close()
+			}(i)
+		}
+		for i := 0; i < numGoroutines; i++ {
+			require.NoError(t, <-done)
+		}
+
+		// the last writer wins
+		reader, err := kv.Get(ctx, section, key)
+		require.NoError(t, err)
+		value, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		require.True(t, strings.HasPrefix(string(value), "value-"))
+	})
+
 	t.Run("concurrent save, delete, and list operations", func(t *testing.T) {
 		const numGoroutines = 5
 		done := make(chan error, numGoroutines)
@@ -0,0 +1,152 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	searchv0alpha1 "github.com/grafana/grafana/pkg/apis/search/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/apiserver/options"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/tests/apis"
+	"github.com/grafana/grafana/pkg/tests/testinfra"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSavedSearches(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	helper := apis.NewK8sTestHelper(t, testinfra.GrafanaOpts{
+		AppModeProduction:    false, // required for experimental apis
+		DisableAnonymous:     true,
+		APIServerStorageType: options.StorageTypeUnified,
+		EnableFeatureToggles: []string{
+			featuremgmt.FlagGrafanaAPIServerWithExperimentalAPIs, // required to register search.grafana.app
+		},
+	})
+
+	t.Run("Check discovery client", func(t *testing.T) {
+		disco := helper.GetGroupVersionInfoJSON(searchv0alpha1.GROUP)
+
+		require.JSONEq(t, `[
+			{
+				"freshness": "Current",
+				"resources": [
+					{
+						"resource": "savedsearches",
+						"responseKind": {
+							"group": "",
+							"kind": "SavedSearch",
+							"version": ""
+						},
+						"scope": "Namespaced",
+						"singularResource": "savedsearch",
+						"verbs": [
+							"create",
+							"delete",
+							"deletecollection",
+							"get",
+							"list",
+							"patch",
+							"update",
+							"watch"
+						]
+					}
+				],
+				"version": "v0alpha1"
+			}
+		]`, disco)
+	})
+
+	newSavedSearch := func(name string, spec map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": searchv0alpha1.APIVERSION,
+				"kind":       "SavedSearch",
+				"metadata": map[string]any{
+					"name": name,
+				},
+				"spec": spec,
+			},
+		}
+	}
+
+	t.Run("CRUD", func(t *testing.T) {
+		ctx := context.Background()
+		editor := helper.GetResourceClient(apis.ResourceClientArgs{
+			User: helper.Org1.Editor,
+			GVR:  searchv0alpha1.SavedSearchResourceInfo.GroupVersionResource(),
+		})
+
+		created, err := editor.Resource.Create(ctx, newSavedSearch("payments", map[string]any{
+			"title":    "Payment dashboards",
+			"group":    "dashboard.grafana.app",
+			"resource": "dashboards",
+			"filters": []any{
+				map[string]any{"key": "tags", "operator": "=", "values": []any{"payments"}},
+			},
+		}), metav1.CreateOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "payments", created.GetName())
+
+		found, err := editor.Resource.Get(ctx, "payments", metav1.GetOptions{})
+		require.NoError(t, err)
+		title, _, _ := unstructured.NestedString(found.Object, "spec", "title")
+		require.Equal(t, "Payment dashboards", title)
+
+		err = unstructured.SetNestedField(found.Object, "checkout", "spec", "query")
+		require.NoError(t, err)
+		updated, err := editor.Resource.Update(ctx, found, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		query, _, _ := unstructured.NestedString(updated.Object, "spec", "query")
+		require.Equal(t, "checkout", query)
+
+		list, err := editor.Resource.List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+
+		err = editor.Resource.Delete(ctx, "payments", metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		_, err = editor.Resource.Get(ctx, "payments", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err), "expected not found, got %v", err)
+	})
+
+	t.Run("rejects saved searches without a resource", func(t *testing.T) {
+		editor := helper.GetResourceClient(apis.ResourceClientArgs{
+			User: helper.Org1.Editor,
+			GVR:  searchv0alpha1.SavedSearchResourceInfo.GroupVersionResource(),
+		})
+
+		_, err := editor.Resource.Create(context.Background(), newSavedSearch("invalid", map[string]any{
+			"title": "Missing resource",
+		}), metav1.CreateOptions{})
+		require.True(t, apierrors.IsBadRequest(err), "expected bad request, got %v", err)
+	})
+
+	t.Run("viewers can read but not write", func(t *testing.T) {
+		ctx := context.Background()
+		viewer := helper.GetResourceClient(apis.ResourceClientArgs{
+			User: helper.Org1.Viewer,
+			GVR:  searchv0alpha1.SavedSearchResourceInfo.GroupVersionResource(),
+		})
+
+		_, err := viewer.Resource.List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+
+		_, err = viewer.Resource.Create(ctx, newSavedSearch("viewer", map[string]any{
+			"group":    "dashboard.grafana.app",
+			"resource": "dashboards",
+		}), metav1.CreateOptions{})
+		require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+	})
+}
@@ -65,6 +65,8 @@ var (
 type datasourceInfo struct {
 	HTTPClient *http.Client
 	URL        string
+	// TeamTenants is true when Grafana sets the tenants from the teams of the users
+	TeamTenants bool
 
 	// open streams
 	streams   map[string]data.FrameJSONCache
@@ -104,10 +106,20 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider) datasource.Ins
 			return nil, backend.DownstreamError(fmt.Errorf("error creating http client: %w", err))
 		}
 
+		var jsonData struct {
+			TeamTenants json.RawMessage `json:"teamTenants"`
+		}
+		if len(settings.JSONData) > 0 {
+			if err := json.Unmarshal(settings.JSONData, &jsonData); err != nil {
+				return nil, backend.DownstreamError(fmt.Errorf("error reading settings: %w", err))
+			}
+		}
+
 		model := &datasourceInfo{
-			HTTPClient: client,
-			URL:        settings.URL,
-			streams:    make(map[string]data.FrameJSONCache),
+			HTTPClient:  client,
+			URL:         settings.URL,
+			TeamTenants: len(jsonData.TeamTenants) > 0 && string(jsonData.TeamTenants) != "null",
+			streams:     make(map[string]data.FrameJSONCache),
 		}
 		return model, nil
 	}
@@ -29,6 +29,13 @@ func (s *Service) SubscribeStream(ctx context.Context, req *backend.SubscribeStr
 		}, err
 	}
 
+	// The streams are shared by the users running the same query, whatever their tenants
+	if dsInfo.TeamTenants {
+		return &backend.SubscribeStreamResponse{
+			Status: backend.SubscribeStreamStatusPermissionDenied,
+		}, fmt.Errorf("streaming is not supported with team tenants")
+	}
+
 	// Expect tail/${key}
 	if !strings.HasPrefix(req.Path, "tail/") {
 		return &backend.SubscribeStreamResponse{
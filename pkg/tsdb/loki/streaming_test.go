@@ -57,4 +57,25 @@ func TestSubscribeStream(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, backend.SubscribeStreamStatusOK, resp.Status)
 	})
+
+	t.Run("when the data source has team tenants", func(t *testing.T) {
+		cfg := backend.NewGrafanaCfg(map[string]string{
+			featuretoggles.EnabledFeatures: flagLokiExperimentalStreaming,
+		})
+		ctx := backend.WithGrafanaConfig(context.Background(), cfg)
+
+		tenantReq := *req
+		tenantReq.PluginContext.DataSourceInstanceSettings = &backend.DataSourceInstanceSettings{
+			ID:       2,
+			UID:      "tenants",
+			Type:     "loki",
+			URL:      "http://localhost:3100",
+			JSONData: []byte(`{"teamTenants": {"tenants": {"1": ["team-a"]}}}`),
+		}
+
+		resp, err := service.SubscribeStream(ctx, &tenantReq)
+
+		require.Error(t, err)
+		require.Equal(t, backend.SubscribeStreamStatusPermissionDenied, resp.Status)
+	})
 }
@@ -3,6 +3,7 @@ package tempo
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
@@ -20,6 +21,8 @@ import (
 
 var logger = backend.NewLoggerWith("logger", "tsdb.tempo")
 
+const defaultTenantHeader = "X-Scope-OrgID"
+
 // newGrpcClient creates a new gRPC client to connect to a streaming query service.
 // This uses the default google.golang.org/grpc library. One caveat to that is that it does not allow passing the
 // default httpClient to the gRPC client. This means that we cannot use the same middleware that we use for
@@ -154,6 +157,40 @@ func CustomHeadersStreamInterceptor(httpOpts httpclient.Options) grpc.StreamClie
 	}
 }
 
+// getTenantHeader returns the tenant header when the data source maps the teams of the users to tenants.
+// The tenants are set by Grafana on the requests, the HTTP client forwards them but the gRPC client has to.
+func getTenantHeader(settings backend.DataSourceInstanceSettings) (string, error) {
+	if len(settings.JSONData) == 0 {
+		return "", nil
+	}
+	var jsonData struct {
+		TeamTenants *struct {
+			Header string `json:"header"`
+		} `json:"teamTenants"`
+	}
+	if err := json.Unmarshal(settings.JSONData, &jsonData); err != nil {
+		return "", err
+	}
+	if jsonData.TeamTenants == nil {
+		return "", nil
+	}
+	if jsonData.TeamTenants.Header == "" {
+		return defaultTenantHeader, nil
+	}
+	return jsonData.TeamTenants.Header, nil
+}
+
+// appendTenantToOutgoingContext adds the tenant of the request to the outgoing context of the RPC calls.
+func appendTenantToOutgoingContext(ctx context.Context, req *backend.RunStreamRequest, datasource *DatasourceInfo) context.Context {
+	if datasource.TenantHeader == "" {
+		return ctx
+	}
+	if tenant := req.GetHTTPHeader(datasource.TenantHeader); tenant != "" {
+		return metadata.AppendToOutgoingContext(ctx, datasource.TenantHeader, tenant)
+	}
+	return ctx
+}
+
 type basicAuth struct {
 	Header string
 }
@@ -0,0 +1,38 @@
+package tempo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTenantHeader(t *testing.T) {
+	header, err := getTenantHeader(backend.DataSourceInstanceSettings{JSONData: []byte(`{"httpMethod": "GET"}`)})
+	require.NoError(t, err)
+	require.Empty(t, header)
+
+	header, err = getTenantHeader(backend.DataSourceInstanceSettings{JSONData: []byte(`{"teamTenants": {"tenants": {"1": ["team-a"]}}}`)})
+	require.NoError(t, err)
+	require.Equal(t, "X-Scope-OrgID", header)
+
+	header, err = getTenantHeader(backend.DataSourceInstanceSettings{JSONData: []byte(`{"teamTenants": {"header": "X-Tenant"}}`)})
+	require.NoError(t, err)
+	require.Equal(t, "X-Tenant", header)
+
+	t.Run("forwards the tenant to the RPC calls", func(t *testing.T) {
+		req := &backend.RunStreamRequest{}
+		req.SetHTTPHeader("X-Scope-OrgID", "team-a|team-b")
+
+		ctx := appendTenantToOutgoingContext(context.Background(), req, &DatasourceInfo{TenantHeader: "X-Scope-OrgID"})
+		md, ok := metadata.FromOutgoingContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, []string{"team-a|team-b"}, md.Get("X-Scope-OrgID"))
+
+		ctx = appendTenantToOutgoingContext(context.Background(), req, &DatasourceInfo{})
+		_, ok = metadata.FromOutgoingContext(ctx)
+		require.False(t, ok)
+	})
+}
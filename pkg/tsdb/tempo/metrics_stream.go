@@ -68,6 +68,7 @@ func (s *Service) runMetricsStream(ctx context.Context, req *backend.RunStreamRe
 	// changes or updates, so we have to get it from context.
 	// Ideally this would be pushed higher, so it's set once for all rpc calls, but we have only one now.
 	ctx = metadata.AppendToOutgoingContext(ctx, "User-Agent", backend.UserAgentFromContext(ctx).String())
+	ctx = appendTenantToOutgoingContext(ctx, req, datasource)
 
 	if isInstantQuery(tempoQuery.MetricsQueryType) {
 		instantQuery := &tempopb.QueryInstantRequest{
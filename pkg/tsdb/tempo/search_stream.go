@@ -66,6 +66,7 @@ func (s *Service) runSearchStream(ctx context.Context, req *backend.RunStreamReq
 	// changes or updates, so we have to get it from context.
 	// Ideally this would be pushed higher, so it's set once for all rpc calls, but we have only one now.
 	ctx = metadata.AppendToOutgoingContext(ctx, "User-Agent", backend.UserAgentFromContext(ctx).String())
+	ctx = appendTenantToOutgoingContext(ctx, req, datasource)
 
 	stream, err := datasource.StreamingClient.Search(ctx, sr)
 	if err != nil {
@@ -41,6 +41,8 @@ type DatasourceInfo struct {
 	HTTPClient      *http.Client
 	StreamingClient tempopb.StreamingQuerierClient
 	URL             string
+	// TenantHeader is the header of the tenants set by Grafana from the teams of the user, if any
+	TenantHeader string
 }
 
 func ProvideService(httpClientProvider *httpclient.Provider, tracer trace.Tracer) *Service {
@@ -82,10 +84,17 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider) datasource.Ins
 			return nil, err
 		}
 
+		tenantHeader, err := getTenantHeader(settings)
+		if err != nil {
+			ctxLogger.Error("Failed to get team tenants", "error", err, "function", logEntrypoint())
+			return nil, err
+		}
+
 		model := &DatasourceInfo{
 			HTTPClient:      client,
 			StreamingClient: streamingClient,
 			URL:             settings.URL,
+			TenantHeader:    tenantHeader,
 		}
 		return model, nil
 	}
@@ -147,8 +147,8 @@ const TransformationEditorRow = (props: Props) => {
                 <div>
                   <p>
                     <Trans i18nKey="correlations.transform-row.expression-tooltip">
-                      Required for regular expression. The expression the transformation will use. Logfmt does not use
-                      further specifications.
+                      Required for regular expression, JSONPath and template. The expression the transformation will
+                      use. Logfmt does not use further specifications.
                     </Trans>
                   </p>
                 </div>
@@ -185,8 +185,8 @@ const TransformationEditorRow = (props: Props) => {
                 <div>
                   <p>
                     <Trans i18nKey="correlations.transform-row.map-value-tooltip">
-                      Optional. Defines the name of the variable. This is currently only valid for regular expressions
-                      with a single, unnamed capture group.
+                      Defines the name of the variable. Optional for regular expressions with a single, unnamed capture
+                      group and for JSONPath, required for template.
                     </Trans>
                   </p>
                 </div>
@@ -93,6 +93,53 @@ export function getSupportedTransTypeDetails(
           ),
         },
       };
+    case SupportedTransformationType.JSONPath:
+      return {
+        label: t('correlations.trans-details.jsonpath-label', 'JSONPath'),
+        value: SupportedTransformationType.JSONPath,
+        description: t(
+          'correlations.trans-details.jsonpath-description',
+          'Field will be parsed as JSON and the value at the JSONPath expression is returned as a variable.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.jsonpath-expression',
+            'JSONPath expression starting at the root object, for example $.resource.service.'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: false,
+          helpText: t(
+            'correlations.trans-details.jsonpath-map-values',
+            'Defines the name of the variable. Defaults to the name of the field.'
+          ),
+        },
+      };
+    case SupportedTransformationType.Template:
+      return {
+        label: t('correlations.trans-details.template-label', 'Template'),
+        value: SupportedTransformationType.Template,
+        description: t(
+          'correlations.trans-details.template-description',
+          'Combines the variables extracted by previous transformations into a new variable.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.template-expression',
+            'Reference variables with the ${name} syntax, for example ${namespace}/${service}.'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.template-map-values', 'Defines the name of the variable.'),
+        },
+      };
     default:
       return {
         label: transType,
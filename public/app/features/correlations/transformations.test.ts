@@ -0,0 +1,80 @@
+import { SupportedTransformationType } from '@grafana/data';
+
+import { evaluateJSONPath, getTransformationVars } from './transformations';
+
+describe('correlations transformations', () => {
+  describe('evaluateJSONPath', () => {
+    const value = {
+      resource: { service: 'api', 'k8s.namespace': 'prod' },
+      spans: [{ id: 'a' }, { id: 'b' }],
+    };
+
+    it.each([
+      ['$', [value]],
+      ['$.resource.service', ['api']],
+      ["$.resource['k8s.namespace']", ['prod']],
+      ['$.resource["k8s.namespace"]', ['prod']],
+      ['$.spans[1].id', ['b']],
+      ['$.spans[-1].id', ['b']],
+      ['$.spans[*].id', ['a', 'b']],
+      ['$..id', ['a', 'b']],
+      ['$.resource.*', ['api', 'prod']],
+      ['$.missing.id', []],
+    ])('evaluates %s', (expression, expected) => {
+      expect(evaluateJSONPath(value, expression)).toEqual(expected);
+    });
+
+    it.each(['resource.service', '$.spans[?(@.id)]', '$resource'])('does not support %s', (expression) => {
+      expect(evaluateJSONPath(value, expression)).toBeUndefined();
+    });
+  });
+
+  describe('jsonpath', () => {
+    const fieldValue = JSON.stringify({ trace: { id: 'abc', spans: 3 } });
+
+    it('extracts the value named after mapValue', () => {
+      const vars = getTransformationVars(
+        { type: SupportedTransformationType.JSONPath, expression: '$.trace.id', mapValue: 'traceId' },
+        fieldValue,
+        'body'
+      );
+      expect(vars).toEqual({ traceId: { value: 'abc' } });
+    });
+
+    it('names the value after the field without mapValue', () => {
+      const vars = getTransformationVars(
+        { type: SupportedTransformationType.JSONPath, expression: '$.trace.spans' },
+        fieldValue,
+        'body'
+      );
+      expect(vars).toEqual({ body: { value: '3' } });
+    });
+
+    it('does not extract anything from invalid JSON or missing values', () => {
+      const transformation = { type: SupportedTransformationType.JSONPath, expression: '$.trace.id' };
+      expect(getTransformationVars(transformation, 'not json', 'body')).toEqual({});
+      expect(getTransformationVars(transformation, '{}', 'body')).toEqual({});
+    });
+  });
+
+  describe('template', () => {
+    const transformation = {
+      type: SupportedTransformationType.Template,
+      expression: '${namespace}/${service}',
+      mapValue: 'job',
+    };
+
+    it('combines the variables extracted before', () => {
+      const vars = getTransformationVars(transformation, '', 'body', {
+        namespace: { value: 'prod' },
+        service: { value: 'api' },
+      });
+      expect(vars).toEqual({ job: { value: 'prod/api' } });
+    });
+
+    it('does not extract anything when a variable is missing', () => {
+      const vars = getTransformationVars(transformation, '', 'body', { namespace: { value: 'prod' } });
+      expect(vars).toEqual({});
+    });
+  });
+});
@@ -3,10 +3,67 @@ import logfmt from 'logfmt';
 import { ScopedVars, DataLinkTransformationConfig, SupportedTransformationType } from '@grafana/data';
 import { safeStringifyValue } from 'app/core/utils/explore';
 
+// matches one step of a JSONPath: .name, ..name, .*, [n], [*], ['name'] or ["name"]
+const jsonPathStepRegex = /^(\.\.?)([A-Za-z_$@][\w$@-]*|\*)|^\[(-?\d+|\*|'[^']*'|"[^"]*")\]/;
+// matches the ${name} references of a template expression
+const templateVariableRegex = /\$\{([^}]*)\}/g;
+
+const childValues = (value: unknown, key: string): unknown[] => {
+  if (value === null || typeof value !== 'object') {
+    return [];
+  }
+  if (key === '*') {
+    return Object.values(value);
+  }
+  if (Array.isArray(value) && /^-?\d+$/.test(key)) {
+    const index = Number(key);
+    const item = value[index < 0 ? value.length + index : index];
+    return item === undefined ? [] : [item];
+  }
+  const entry = Object.entries(value).find(([name]) => name === key);
+  return entry ? [entry[1]] : [];
+};
+
+const descendantValues = (value: unknown): unknown[] => {
+  if (value === null || typeof value !== 'object') {
+    return [value];
+  }
+  return [value, ...Object.values(value).flatMap(descendantValues)];
+};
+
+/**
+ * Returns the values at a JSONPath expression, in the dot and bracket notations accepted by the backend,
+ * or undefined when the expression is not supported.
+ */
+export const evaluateJSONPath = (value: unknown, expression: string): unknown[] | undefined => {
+  if (!expression.startsWith('$')) {
+    return undefined;
+  }
+  let rest = expression.slice(1);
+  let values = [value];
+  while (rest.length > 0) {
+    const step = jsonPathStepRegex.exec(rest);
+    if (!step) {
+      return undefined;
+    }
+    rest = rest.slice(step[0].length);
+
+    let key = step[2] ?? step[3];
+    if (key.startsWith("'") || key.startsWith('"')) {
+      key = key.slice(1, -1);
+    }
+    const searched = step[1] === '..' ? values.flatMap(descendantValues) : values;
+    values = searched.flatMap((v) => childValues(v, key));
+  }
+  return values;
+};
+
 export const getTransformationVars = (
   transformation: DataLinkTransformationConfig,
   fieldValue: string,
-  fieldName: string
+  fieldName: string,
+  // variables extracted by the previous transformations, used by template transformations
+  vars: ScopedVars = {}
 ): ScopedVars => {
   let transformationScopedVars: ScopedVars = {};
   let transformVal: { [key: string]: string | boolean | null | undefined } = {};
@@ -24,6 +81,36 @@ export const getTransformationVars = (
     }
   } else if (transformation.type === SupportedTransformationType.Logfmt && fieldValue !== undefined) {
     transformVal = logfmt.parse(fieldValue);
+  } else if (transformation.type === SupportedTransformationType.JSONPath && transformation.expression) {
+    let parsed: unknown;
+    try {
+      parsed = typeof fieldValue === 'string' ? JSON.parse(fieldValue) : fieldValue;
+    } catch (e) {
+      parsed = undefined;
+    }
+    const values = parsed === undefined ? undefined : evaluateJSONPath(parsed, transformation.expression);
+    if (values && values.length > 0) {
+      const value = values[0];
+      transformVal[transformation.mapValue || transformation.field || fieldName] =
+        typeof value === 'string' ? value : safeStringifyValue(value);
+    }
+  } else if (
+    transformation.type === SupportedTransformationType.Template &&
+    transformation.expression &&
+    transformation.mapValue
+  ) {
+    let defined = true;
+    const value = transformation.expression.replace(templateVariableRegex, (_, name: string) => {
+      if (vars[name] === undefined) {
+        defined = false;
+        return '';
+      }
+      const varValue = vars[name].value;
+      return typeof varValue === 'string' ? varValue : safeStringifyValue(varValue);
+    });
+    if (defined) {
+      transformVal[transformation.mapValue] = value;
+    }
   }
 
   Object.keys(transformVal).forEach((key) => {
@@ -111,7 +111,10 @@ export const CorrelationHelper = ({ exploreId, correlations }: Props) => {
           mapValue: transformation.mapValue,
         },
         correlations.vars[transformation.field!],
-        transformation.field!
+        transformation.field!,
+        Object.fromEntries(
+          Object.entries({ ...correlations.vars, ...transVarRecords }).map(([key, value]) => [key, { value }])
+        )
       );
 
       Object.keys(transformationVars).forEach((key) => {
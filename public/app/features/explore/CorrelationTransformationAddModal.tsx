@@ -3,7 +3,7 @@ import { useId, useState, useMemo, useEffect } from 'react';
 import Highlighter from 'react-highlight-words';
 import { useForm, Controller } from 'react-hook-form';
 
-import { DataLinkTransformationConfig, ScopedVars } from '@grafana/data';
+import { DataLinkTransformationConfig, ScopedVars, SupportedTransformationType } from '@grafana/data';
 import { Trans, t } from '@grafana/i18n';
 import { Button, Field, Icon, Input, Label, Modal, Select, Tooltip, Stack } from '@grafana/ui';
 
@@ -12,7 +12,7 @@ import {
   getTransformOptions,
   TransformationFieldDetails,
 } from '../correlations/Forms/types';
-import { getTransformationVars } from '../correlations/transformations';
+import { evaluateJSONPath, getTransformationVars } from '../correlations/transformations';
 
 interface CorrelationTransformationAddModalProps {
   onCancel: () => void;
@@ -93,10 +93,14 @@ export const CorrelationTransformationAddModal = ({
       let isExpressionValid = false;
       if (expression !== undefined) {
         isExpressionValid = true;
-        try {
-          new RegExp(expression);
-        } catch (e) {
-          isExpressionValid = false;
+        if (formValues.type === SupportedTransformationType.Regex) {
+          try {
+            new RegExp(expression);
+          } catch (e) {
+            isExpressionValid = false;
+          }
+        } else if (formValues.type === SupportedTransformationType.JSONPath) {
+          isExpressionValid = evaluateJSONPath({}, expression) !== undefined;
         }
       } else {
         isExpressionValid = !formFieldsVis.expressionDetails.show;
@@ -324,6 +324,42 @@ describe('explore links utils', () => {
       );
     });
 
+    it('returns internal links with jsonpath and template transformations', () => {
+      const transformationLink: DataLink = {
+        title: '',
+        url: '',
+        internal: {
+          query: { query: 'http_requests{job=${job}}' },
+          datasourceUid: 'uid_1',
+          datasourceName: 'test_ds',
+        },
+        meta: {
+          transformations: [
+            { type: SupportedTransformationType.JSONPath, expression: '$.resource.namespace', mapValue: 'namespace' },
+            { type: SupportedTransformationType.JSONPath, expression: '$.resource.service', mapValue: 'service' },
+            { type: SupportedTransformationType.Template, expression: '${namespace}-${service}', mapValue: 'job' },
+          ],
+        },
+      };
+
+      const { field, range, dataFrame } = setup(transformationLink, true, {
+        name: 'msg',
+        type: FieldType.string,
+        values: ['{"resource":{"namespace":"prod","service":"api"}}'],
+        config: {
+          links: [transformationLink],
+        },
+      });
+
+      const links = getFieldLinksForExplore({ field, rowIndex: 0, range, dataFrame });
+      expect(links).toHaveLength(1);
+      expect(links[0].href).toBe(
+        `/explore?left=${encodeURIComponent(
+          '{"range":{"from":"now-1h","to":"now"},"datasource":"uid_1","queries":[{"query":"http_requests{job=prod-api}"}]}'
+        )}`
+      );
+    });
+
     it('returns internal links with 2 unnamed regex transformations and use the last transformation', () => {
       const transformationLink: DataLink = {
         title: '',